@@ -1,6 +1,7 @@
 package client
 
 import (
+	"fmt"
 	"net/http"
 
 	"github.com/iotaledger/goshimmer/packages/jsonmodels"
@@ -10,6 +11,8 @@ const (
 	routeCollectiveBeacon = "drng/collectiveBeacon"
 	routeRandomness       = "drng/info/randomness"
 	routeCommittee        = "drng/info/committee"
+	routeInstances        = "drng/admin/instances"
+	routeHistory          = "drng/history"
 )
 
 // BroadcastCollectiveBeacon sends the given collective beacon (payload) by creating a message in the backend.
@@ -44,3 +47,26 @@ func (api *GoShimmerAPI) GetCommittee() (*jsonmodels.CommitteeResponse, error) {
 	}
 	return res, nil
 }
+
+// GetDRNGHistory gets the persisted beacons of the given instance between the rounds from and to (both inclusive).
+func (api *GoShimmerAPI) GetDRNGHistory(instanceID uint32, from, to uint64) (*jsonmodels.HistoryResponse, error) {
+	res := &jsonmodels.HistoryResponse{}
+	if err := api.do(http.MethodGet, fmt.Sprintf("%s?instance=%d&from=%d&to=%d", routeHistory, instanceID, from, to), nil, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// LoadDRNGInstance loads the dRNG instance of the given committee at runtime.
+func (api *GoShimmerAPI) LoadDRNGInstance(committee *jsonmodels.Committee) (*jsonmodels.Committee, error) {
+	res := &jsonmodels.LoadInstanceResponse{}
+	if err := api.do(http.MethodPost, routeInstances, committee, res); err != nil {
+		return nil, err
+	}
+	return res.Committee, nil
+}
+
+// UnloadDRNGInstance unloads the dRNG instance with the given instanceID.
+func (api *GoShimmerAPI) UnloadDRNGInstance(instanceID uint32) error {
+	return api.do(http.MethodDelete, fmt.Sprintf("%s/%d", routeInstances, instanceID), nil, nil)
+}
@@ -39,7 +39,7 @@ func (api *GoShimmerAPI) SendFaucetRequest(base58EncodedAddr string, powTarget i
 		}
 	}
 
-	address, err := ledgerstate.AddressFromBase58EncodedString(base58EncodedAddr)
+	address, err := ledgerstate.AddressFromString(base58EncodedAddr)
 	if err != nil {
 		return nil, errors.Errorf("could not decode address from string: %w", err)
 	}
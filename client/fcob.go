@@ -0,0 +1,51 @@
+package client
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/iotaledger/goshimmer/packages/jsonmodels"
+)
+
+const (
+	routeFCOBOpinions          = "fcob/opinions"
+	routeFCOBTimestampOpinions = "fcob/timestampOpinions"
+)
+
+// GetOpinionAudit gets the audit of the stored FCoB opinion of the given transaction.
+func (api *GoShimmerAPI) GetOpinionAudit(base58EncodedTransactionID string) (*jsonmodels.OpinionAudit, error) {
+	res := &jsonmodels.OpinionAudit{}
+	if err := api.do(http.MethodGet, fmt.Sprintf("%s/%s", routeFCOBOpinions, base58EncodedTransactionID), nil, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// GetOpinionAudits gets the audits of at most limit stored FCoB opinions of transactions. Only inconsistent opinions
+// are returned unless all is set.
+func (api *GoShimmerAPI) GetOpinionAudits(all bool, limit int) (*jsonmodels.OpinionAuditsResponse, error) {
+	res := &jsonmodels.OpinionAuditsResponse{}
+	if err := api.do(http.MethodGet, fmt.Sprintf("%s?all=%t&limit=%d", routeFCOBOpinions, all, limit), nil, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// GetTimestampOpinionAudit gets the audit of the stored FCoB timestamp opinion of the given message.
+func (api *GoShimmerAPI) GetTimestampOpinionAudit(base58EncodedMessageID string) (*jsonmodels.TimestampOpinionAudit, error) {
+	res := &jsonmodels.TimestampOpinionAudit{}
+	if err := api.do(http.MethodGet, fmt.Sprintf("%s/%s", routeFCOBTimestampOpinions, base58EncodedMessageID), nil, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// GetTimestampOpinionAudits gets the audits of at most limit stored FCoB timestamp opinions of messages. Only
+// inconsistent opinions are returned unless all is set.
+func (api *GoShimmerAPI) GetTimestampOpinionAudits(all bool, limit int) (*jsonmodels.TimestampOpinionAuditsResponse, error) {
+	res := &jsonmodels.TimestampOpinionAuditsResponse{}
+	if err := api.do(http.MethodGet, fmt.Sprintf("%s?all=%t&limit=%d", routeFCOBTimestampOpinions, all, limit), nil, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
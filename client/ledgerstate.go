@@ -2,6 +2,7 @@ package client
 
 import (
 	"net/http"
+	"strconv"
 	"strings"
 
 	"github.com/iotaledger/goshimmer/packages/jsonmodels"
@@ -10,7 +11,10 @@ import (
 const (
 	// basic routes
 	routeGetAddresses     = "ledgerstate/addresses/"
+	routeGetAliases       = "ledgerstate/aliases/"
 	routeGetBranches      = "ledgerstate/branches/"
+	routeGetColors        = "ledgerstate/colors/"
+	routeGetConflicts     = "ledgerstate/conflicts/"
 	routeGetOutputs       = "ledgerstate/outputs/"
 	routeGetTransactions  = "ledgerstate/transactions/"
 	routePostTransactions = "ledgerstate/transactions"
@@ -24,6 +28,10 @@ const (
 	pathInclusionState = "/inclusionState"
 	pathConsensus      = "/consensus"
 	pathAttachments    = "/attachments"
+	pathHistory        = "/history"
+	pathStates         = "/states/"
+	pathTransaction    = "/transaction"
+	pathTimeline       = "/timeline"
 )
 
 // GetAddressOutputs gets the spent and unspent outputs of an address.
@@ -59,6 +67,40 @@ func (api *GoShimmerAPI) PostAddressUnspentOutputs(base58EncodedAddresses []stri
 	return res, nil
 }
 
+// GetAliasHistory gets every indexed version of the alias chain with the given address.
+func (api *GoShimmerAPI) GetAliasHistory(base58EncodedAliasAddress string) (*jsonmodels.GetAliasHistoryResponse, error) {
+	res := &jsonmodels.GetAliasHistoryResponse{}
+	if err := api.do(http.MethodGet, func() string {
+		return strings.Join([]string{routeGetAliases, base58EncodedAliasAddress, pathHistory}, "")
+	}(), nil, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// GetAliasState gets the versions of the alias chain with the given address that have the given state index.
+func (api *GoShimmerAPI) GetAliasState(base58EncodedAliasAddress string, stateIndex uint32) (*jsonmodels.GetAliasStateResponse, error) {
+	res := &jsonmodels.GetAliasStateResponse{}
+	if err := api.do(http.MethodGet, func() string {
+		return strings.Join([]string{routeGetAliases, base58EncodedAliasAddress, pathStates, strconv.FormatUint(uint64(stateIndex), 10)}, "")
+	}(), nil, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// GetAliasStateTransaction gets the transaction that transitioned the alias chain with the given address into the
+// given state index.
+func (api *GoShimmerAPI) GetAliasStateTransaction(base58EncodedAliasAddress string, stateIndex uint32) (*jsonmodels.Transaction, error) {
+	res := &jsonmodels.Transaction{}
+	if err := api.do(http.MethodGet, func() string {
+		return strings.Join([]string{routeGetAliases, base58EncodedAliasAddress, pathStates, strconv.FormatUint(uint64(stateIndex), 10), pathTransaction}, "")
+	}(), nil, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
 // GetBranch gets the branch information.
 func (api *GoShimmerAPI) GetBranch(base58EncodedBranchID string) (*jsonmodels.Branch, error) {
 	res := &jsonmodels.Branch{}
@@ -92,6 +134,28 @@ func (api *GoShimmerAPI) GetBranchConflicts(base58EncodedBranchID string) (*json
 	return res, nil
 }
 
+// GetColorSupply gets the minted, burned and circulating supply of a color.
+func (api *GoShimmerAPI) GetColorSupply(base58EncodedColor string) (*jsonmodels.ColorSupply, error) {
+	res := &jsonmodels.ColorSupply{}
+	if err := api.do(http.MethodGet, func() string {
+		return strings.Join([]string{routeGetColors, base58EncodedColor}, "")
+	}(), nil, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// GetConflictTimeline gets the recorded steps of the resolution of a conflict.
+func (api *GoShimmerAPI) GetConflictTimeline(base58EncodedConflictID string) (*jsonmodels.GetConflictTimelineResponse, error) {
+	res := &jsonmodels.GetConflictTimelineResponse{}
+	if err := api.do(http.MethodGet, func() string {
+		return strings.Join([]string{routeGetConflicts, base58EncodedConflictID, pathTimeline}, "")
+	}(), nil, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
 // GetOutput gets the output corresponding to OutputID.
 func (api *GoShimmerAPI) GetOutput(base58EncodedOutputID string) (*jsonmodels.Output, error) {
 	res := &jsonmodels.Output{}
@@ -18,7 +18,10 @@ const (
 	routePending                  = "mana/pending"
 	routePastConsensusVector      = "mana/consensus/past"
 	routePastConsensusEventLogs   = "mana/consensus/logs"
+	routePastConsensusMetadata    = "mana/consensus/metadata"
 	routeAllowedPledgeNodeIDs     = "mana/allowedManaPledge"
+	routeManaHistory              = "mana/history"
+	routeDelegations              = "mana/delegations"
 )
 
 // GetOwnMana returns the access and consensus mana of the node this api client is communicating with.
@@ -152,7 +155,7 @@ func (api *GoShimmerAPI) GetPastConsensusManaVector(t int64) (*jsonmodels.PastCo
 // GetPastConsensusVectorMetadata returns the consensus base mana vector metadata of a time in the past.
 func (api *GoShimmerAPI) GetPastConsensusVectorMetadata() (*jsonmodels.PastConsensusVectorMetadataResponse, error) {
 	res := &jsonmodels.PastConsensusVectorMetadataResponse{}
-	if err := api.do(http.MethodGet, routePastConsensusVector, nil, res); err != nil {
+	if err := api.do(http.MethodGet, routePastConsensusMetadata, nil, res); err != nil {
 		return nil, err
 	}
 	return res, nil
@@ -177,3 +180,38 @@ func (api *GoShimmerAPI) GetAllowedManaPledgeNodeIDs() (*jsonmodels.AllowedManaP
 
 	return res, nil
 }
+
+// GetManaHistory returns the recorded access and consensus mana of the node specified, sampled between from and to
+// (unix timestamps in seconds).
+func (api *GoShimmerAPI) GetManaHistory(fullNodeID string, from, to int64) (*jsonmodels.GetManaHistoryResponse, error) {
+	res := &jsonmodels.GetManaHistoryResponse{}
+	if err := api.do(http.MethodGet, func() string {
+		return fmt.Sprintf("%s?nodeID=%s&from=%d&to=%d", routeManaHistory, fullNodeID, from, to)
+	}(), nil, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// GetDelegations returns the records of the delegations to the node. If delegator is not empty, only the delegations of
+// the given delegator address are returned.
+func (api *GoShimmerAPI) GetDelegations(delegator string) (*jsonmodels.GetDelegationsResponse, error) {
+	res := &jsonmodels.GetDelegationsResponse{}
+	route := routeDelegations
+	if delegator != "" {
+		route = fmt.Sprintf("%s?delegator=%s", routeDelegations, delegator)
+	}
+	if err := api.do(http.MethodGet, route, nil, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// GetDelegation returns the record of the delegation with the given delegation ID (base58 encoded alias address).
+func (api *GoShimmerAPI) GetDelegation(delegationID string) (*jsonmodels.GetDelegationResponse, error) {
+	res := &jsonmodels.GetDelegationResponse{}
+	if err := api.do(http.MethodGet, fmt.Sprintf("%s/%s", routeDelegations, delegationID), nil, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
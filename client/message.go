@@ -7,9 +7,10 @@ import (
 )
 
 const (
-	routeMessage         = "messages/"
-	routeMessageMetadata = "/metadata"
-	routeSendPayload     = "messages/payload"
+	routeMessage          = "messages/"
+	routeMessageMetadata  = "/metadata"
+	routeMessageConsensus = "/consensus"
+	routeSendPayload      = "messages/payload"
 )
 
 // GetMessage is the handler for the /messages/:messageID endpoint.
@@ -44,6 +45,22 @@ func (api *GoShimmerAPI) GetMessageMetadata(base58EncodedID string) (*jsonmodels
 	return res, nil
 }
 
+// GetMessageConsensusMetadata is the handler for the /messages/:messageID/consensus endpoint.
+func (api *GoShimmerAPI) GetMessageConsensusMetadata(base58EncodedID string) (*jsonmodels.MessageConsensusMetadata, error) {
+	res := &jsonmodels.MessageConsensusMetadata{}
+
+	if err := api.do(
+		http.MethodGet,
+		routeMessage+base58EncodedID+routeMessageConsensus,
+		nil,
+		res,
+	); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
 // SendPayload send a message with the given payload.
 func (api *GoShimmerAPI) SendPayload(payload []byte) (string, error) {
 	res := &jsonmodels.PostPayloadResponse{}
@@ -0,0 +1,41 @@
+package client
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/iotaledger/goshimmer/packages/jsonmodels"
+)
+
+const (
+	routeStatementsNodes      = "statements/nodes"
+	routeStatementsConflicts  = "statements/conflicts"
+	routeStatementsTimestamps = "statements/timestamps"
+)
+
+// GetNodeStatements gets the persisted FPC statements of the given node.
+func (api *GoShimmerAPI) GetNodeStatements(base58EncodedNodeID string) (*jsonmodels.NodeStatementsResponse, error) {
+	res := &jsonmodels.NodeStatementsResponse{}
+	if err := api.do(http.MethodGet, fmt.Sprintf("%s/%s", routeStatementsNodes, base58EncodedNodeID), nil, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// GetConflictStatementOpinions gets the opinions that the nodes stated about the given conflict.
+func (api *GoShimmerAPI) GetConflictStatementOpinions(base58EncodedTransactionID string) (*jsonmodels.NodesOpinionsResponse, error) {
+	res := &jsonmodels.NodesOpinionsResponse{}
+	if err := api.do(http.MethodGet, fmt.Sprintf("%s/%s", routeStatementsConflicts, base58EncodedTransactionID), nil, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// GetTimestampStatementOpinions gets the opinions that the nodes stated about the timestamp of the given message.
+func (api *GoShimmerAPI) GetTimestampStatementOpinions(base58EncodedMessageID string) (*jsonmodels.NodesOpinionsResponse, error) {
+	res := &jsonmodels.NodesOpinionsResponse{}
+	if err := api.do(http.MethodGet, fmt.Sprintf("%s/%s", routeStatementsTimestamps, base58EncodedMessageID), nil, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
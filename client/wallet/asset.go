@@ -28,6 +28,24 @@ type Asset struct {
 	TransactionID ledgerstate.TransactionID
 }
 
+// AssetSupply represents the supply of an asset that is tracked by the ledger.
+type AssetSupply struct {
+	// MintingTransactionID is the ID of the transaction that minted the asset
+	MintingTransactionID ledgerstate.TransactionID
+
+	// Minted is the total amount of tokens that were minted
+	Minted uint64
+
+	// Burned is the amount of tokens that were recolored back to IOTA
+	Burned uint64
+
+	// Circulating is the amount of tokens that currently exist in the ledger
+	Circulating uint64
+
+	// Holders is the amount of addresses that currently hold tokens of the asset
+	Holders uint64
+}
+
 // ToRegistry creates a ergistry asset from a wallet asset.
 func (a *Asset) ToRegistry() *registry.Asset {
 	return &registry.Asset{
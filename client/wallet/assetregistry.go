@@ -25,6 +25,8 @@ type AssetRegistry struct {
 	// client communicates with the central registry
 	client  *registryclient.HTTPClient
 	network string
+	// supplyProvider retrieves the supply of an asset as tracked by the ledger
+	supplyProvider func(color ledgerstate.Color) (*AssetSupply, error)
 }
 
 // NewAssetRegistry is the constructor for the AssetRegistry.
@@ -35,9 +37,9 @@ func NewAssetRegistry(network string, registryURL ...string) *AssetRegistry {
 	}
 	client := registryclient.NewHTTPClient(resty.New().SetHostURL(hostURL))
 	return &AssetRegistry{
-		make(map[ledgerstate.Color]Asset),
-		client,
-		network,
+		assets:  make(map[ledgerstate.Color]Asset),
+		client:  client,
+		network: network,
 	}
 }
 
@@ -150,6 +152,12 @@ func (a *AssetRegistry) SetRegistryURL(url string) {
 	a.client = registryclient.NewHTTPClient(resty.New().SetHostURL(url))
 }
 
+// SetSupplyProvider sets the function that is used to retrieve the supply of an asset from the ledger. If it is set,
+// the supply and the minting transaction reported by the ledger take precedence over the data of the registry.
+func (a *AssetRegistry) SetSupplyProvider(supplyProvider func(color ledgerstate.Color) (*AssetSupply, error)) {
+	a.supplyProvider = supplyProvider
+}
+
 // Network returns the current network the asset registry connects to.
 func (a *AssetRegistry) Network() string {
 	return a.network
@@ -212,8 +220,13 @@ func (a *AssetRegistry) Symbol(color ledgerstate.Color) string {
 	return "cI"
 }
 
-// Supply returns the initial supply of the token.
+// Supply returns the minted supply of the token as tracked by the ledger or the initial supply stored in the registry
+// if the ledger does not know the token (yet).
 func (a *AssetRegistry) Supply(color ledgerstate.Color) string {
+	if supply, err := a.ledgerSupply(color); err == nil {
+		return strconv.FormatUint(supply.Minted, 10)
+	}
+
 	if asset, assetExists := a.assets[color]; assetExists {
 		return strconv.FormatUint(asset.Supply, 10)
 	}
@@ -233,6 +246,10 @@ func (a *AssetRegistry) Supply(color ledgerstate.Color) string {
 
 // TransactionID returns the ID of the transaction that created the token.
 func (a *AssetRegistry) TransactionID(color ledgerstate.Color) string {
+	if supply, err := a.ledgerSupply(color); err == nil {
+		return supply.MintingTransactionID.Base58()
+	}
+
 	if asset, assetExists := a.assets[color]; assetExists {
 		return asset.TransactionID.Base58()
 	}
@@ -289,6 +306,15 @@ func (a *AssetRegistry) Bytes() []byte {
 	return marshalUtil.Bytes()
 }
 
+// ledgerSupply is an internal utility function that retrieves the supply of the given asset from the ledger.
+func (a *AssetRegistry) ledgerSupply(color ledgerstate.Color) (*AssetSupply, error) {
+	if a.supplyProvider == nil || color == ledgerstate.ColorIOTA {
+		return nil, errors.Errorf("no ledger supply available for assetID (color) %s", color.Base58())
+	}
+
+	return a.supplyProvider(color)
+}
+
 func (a *AssetRegistry) updateLocalFromCentral(color ledgerstate.Color) (success bool) {
 	loadedAsset, err := a.client.LoadAsset(context.TODO(), a.network, color.Base58())
 	if err == nil {
@@ -28,7 +28,7 @@ func Amount(amount map[ledgerstate.Color]uint64) DepositFundsToNFTOption {
 // Alias specifies which alias to transfer.
 func Alias(aliasID string) DepositFundsToNFTOption {
 	return func(options *DepositFundsToNFTOptions) error {
-		parsed, err := ledgerstate.AliasAddressFromBase58EncodedString(aliasID)
+		parsed, err := ledgerstate.AliasAddressFromString(aliasID)
 		if err != nil {
 			return err
 		}
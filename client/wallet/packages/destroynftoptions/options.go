@@ -36,7 +36,7 @@ func ConsensusManaPledgeID(nodeID string) DestroyNFTOption {
 // Alias specifies which alias to destroy.
 func Alias(aliasID string) DestroyNFTOption {
 	return func(options *DestroyNFTOptions) error {
-		parsed, err := ledgerstate.AliasAddressFromBase58EncodedString(aliasID)
+		parsed, err := ledgerstate.AliasAddressFromString(aliasID)
 		if err != nil {
 			return err
 		}
@@ -48,7 +48,7 @@ func Alias(aliasID string) DestroyNFTOption {
 // RemainderAddress specifies the address where the funds of the destroyed NFT will be sent. (optional)
 func RemainderAddress(address string) DestroyNFTOption {
 	return func(options *DestroyNFTOptions) error {
-		parsed, err := ledgerstate.AddressFromBase58EncodedString(address)
+		parsed, err := ledgerstate.AddressFromString(address)
 		if err != nil {
 			return err
 		}
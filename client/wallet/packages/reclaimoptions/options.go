@@ -36,7 +36,7 @@ func ConsensusManaPledgeID(nodeID string) ReclaimFundsOption {
 // Alias specifies which alias to reclaim.
 func Alias(aliasID string) ReclaimFundsOption {
 	return func(options *ReclaimFundsOptions) error {
-		parsed, err := ledgerstate.AliasAddressFromBase58EncodedString(aliasID)
+		parsed, err := ledgerstate.AliasAddressFromString(aliasID)
 		if err != nil {
 			return err
 		}
@@ -48,7 +48,7 @@ func Alias(aliasID string) ReclaimFundsOption {
 // ToAddress specifies the new governor of the alias.
 func ToAddress(address string) ReclaimFundsOption {
 	return func(options *ReclaimFundsOptions) error {
-		parsed, err := ledgerstate.AddressFromBase58EncodedString(address)
+		parsed, err := ledgerstate.AddressFromString(address)
 		if err != nil {
 			return err
 		}
@@ -36,7 +36,7 @@ func ConsensusManaPledgeID(nodeID string) SweepNFTOwnedNFTsOption {
 // Alias specifies which an aliasID that is checked for available funds.
 func Alias(aliasID string) SweepNFTOwnedNFTsOption {
 	return func(options *SweepNFTOwnedNFTsOptions) error {
-		parsed, err := ledgerstate.AliasAddressFromBase58EncodedString(aliasID)
+		parsed, err := ledgerstate.AliasAddressFromString(aliasID)
 		if err != nil {
 			return err
 		}
@@ -48,7 +48,7 @@ func Alias(aliasID string) SweepNFTOwnedNFTsOption {
 // ToAddress specifies the optional receiving address.
 func ToAddress(address string) SweepNFTOwnedNFTsOption {
 	return func(options *SweepNFTOwnedNFTsOptions) error {
-		parsed, err := ledgerstate.AddressFromBase58EncodedString(address)
+		parsed, err := ledgerstate.AddressFromString(address)
 		if err != nil {
 			return err
 		}
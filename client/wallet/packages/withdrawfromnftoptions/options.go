@@ -28,7 +28,7 @@ func Amount(amount map[ledgerstate.Color]uint64) WithdrawFundsFromNFTOption {
 // Alias specifies which alias to transfer.
 func Alias(aliasID string) WithdrawFundsFromNFTOption {
 	return func(options *WithdrawFundsFromNFTOptions) error {
-		parsed, err := ledgerstate.AliasAddressFromBase58EncodedString(aliasID)
+		parsed, err := ledgerstate.AliasAddressFromString(aliasID)
 		if err != nil {
 			return err
 		}
@@ -40,7 +40,7 @@ func Alias(aliasID string) WithdrawFundsFromNFTOption {
 // ToAddress specifies the new governor of the alias.
 func ToAddress(address string) WithdrawFundsFromNFTOption {
 	return func(options *WithdrawFundsFromNFTOptions) error {
-		parsed, err := ledgerstate.AddressFromBase58EncodedString(address)
+		parsed, err := ledgerstate.AddressFromString(address)
 		if err != nil {
 			return err
 		}
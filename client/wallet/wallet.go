@@ -86,6 +86,7 @@ func New(options ...Option) (wallet *Wallet) {
 	if wallet.assetRegistry == nil {
 		wallet.assetRegistry = NewAssetRegistry(DefaultAssetRegistryNetwork)
 	}
+	wallet.assetRegistry.SetSupplyProvider(wallet.AssetSupply)
 
 	// initialize wallet with default connector (server) if none was provided
 	if wallet.connector == nil {
@@ -1387,6 +1388,20 @@ func (wallet *Wallet) AllowedPledgeNodeIDs() (res map[mana.Type][]string, err er
 
 // endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
 
+// region AssetSupply //////////////////////////////////////////////////////////////////////////////////////////////////
+
+// AssetSupply retrieves the minted, burned and circulating supply of the given asset as tracked by the ledger.
+func (wallet *Wallet) AssetSupply(color ledgerstate.Color) (supply *AssetSupply, err error) {
+	webConnector, ok := wallet.connector.(*WebConnector)
+	if !ok {
+		return nil, errors.Errorf("the connector of the wallet does not support supply queries")
+	}
+
+	return webConnector.GetAssetSupply(color)
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
 // region AssetRegistry ////////////////////////////////////////////////////////////////////////////////////////////////
 
 // AssetRegistry return the internal AssetRegistry instance of the wallet.
@@ -1814,7 +1829,7 @@ func (wallet *Wallet) AliasBalance(refresh ...bool) (
 // AvailableOutputsOnNFT returns all outputs that are either owned (SigLocked***, Extended, stateControlled Alias) or governed
 // (governance controlled alias outputs) and are not currently locked.
 func (wallet Wallet) AvailableOutputsOnNFT(nftID string) (owned, governed ledgerstate.Outputs, err error) {
-	aliasAddress, err := ledgerstate.AliasAddressFromBase58EncodedString(nftID)
+	aliasAddress, err := ledgerstate.AliasAddressFromString(nftID)
 	if err != nil {
 		return
 	}
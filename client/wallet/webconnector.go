@@ -175,6 +175,26 @@ func (webConnector WebConnector) GetUnspentAliasOutput(addr *ledgerstate.AliasAd
 	return nil, errors.Errorf("couldn't find unspent alias output for alias addr %s", addr.Base58())
 }
 
+// GetAssetSupply fetches the supply of the given asset that is tracked by the ledger of the node.
+func (webConnector WebConnector) GetAssetSupply(color ledgerstate.Color) (supply *AssetSupply, err error) {
+	res, err := webConnector.client.GetColorSupply(color.Base58())
+	if err != nil {
+		return
+	}
+	mintingTransactionID, err := ledgerstate.TransactionIDFromBase58(res.MintingTransactionID)
+	if err != nil {
+		return
+	}
+
+	return &AssetSupply{
+		MintingTransactionID: mintingTransactionID,
+		Minted:               res.Minted,
+		Burned:               res.Burned,
+		Circulating:          res.Circulating,
+		Holders:              res.Holders,
+	}, nil
+}
+
 // colorFromString is an internal utility method that parses the given string into a Color.
 func colorFromString(colorStr string) (color ledgerstate.Color) {
 	if colorStr == "IOTA" {
@@ -0,0 +1,84 @@
+package batchverifier
+
+import (
+	"github.com/iotaledger/hive.go/crypto/bls"
+	"go.dedis.ch/kyber/v3/pairing/bn256"
+	kyberbls "go.dedis.ch/kyber/v3/sign/bls"
+	"go.dedis.ch/kyber/v3/util/random"
+)
+
+// blsSuite contains the pairing suite that is used by the BLS signatures of the ledger.
+var blsSuite = bn256.NewSuite()
+
+// region VerifyBLSAggregated //////////////////////////////////////////////////////////////////////////////////////////
+
+// VerifyBLSAggregated verifies the given BLS signatures of the same data by aggregating them into a single signature
+// that is checked against the aggregated public key. It returns true if all signatures are valid and a slice that
+// contains the validity of every single signature. If the aggregated signature is invalid, the signatures are checked
+// individually to determine which one is invalid.
+//
+// Every signature and public key is weighted with a random scalar before being aggregated, so that a set of invalid
+// signatures can not be crafted in a way that cancels out in the aggregated signature.
+func VerifyBLSAggregated(data []byte, signatures []bls.SignatureWithPublicKey) (allValid bool, valid []bool) {
+	switch len(signatures) {
+	case 0:
+		return true, []bool{}
+	case 1:
+		allValid = signatures[0].IsValid(data)
+		return allValid, []bool{allValid}
+	}
+
+	if aggregatedSignatureValid(data, signatures) {
+		valid = make([]bool, len(signatures))
+		for i := range valid {
+			valid[i] = true
+		}
+
+		return true, valid
+	}
+
+	return verifyBLSIndividually(data, signatures)
+}
+
+// aggregatedSignatureValid returns true if the randomly weighted aggregate of the given signatures is valid.
+func aggregatedSignatureValid(data []byte, signatures []bls.SignatureWithPublicKey) bool {
+	randomness := random.New()
+	aggregatedSignature := blsSuite.G1().Point().Null()
+	aggregatedPublicKey := blsSuite.G2().Point().Null()
+	for _, signature := range signatures {
+		if signature.PublicKey.Point == nil {
+			return false
+		}
+
+		signaturePoint := blsSuite.G1().Point()
+		if err := signaturePoint.UnmarshalBinary(signature.Signature.Bytes()); err != nil {
+			return false
+		}
+
+		weight := blsSuite.G1().Scalar().Pick(randomness)
+		aggregatedSignature.Add(aggregatedSignature, signaturePoint.Mul(weight, signaturePoint))
+		aggregatedPublicKey.Add(aggregatedPublicKey, blsSuite.G2().Point().Mul(weight, signature.PublicKey.Point))
+	}
+
+	aggregatedSignatureBytes, err := aggregatedSignature.MarshalBinary()
+	if err != nil {
+		return false
+	}
+
+	return kyberbls.Verify(blsSuite, aggregatedPublicKey, data, aggregatedSignatureBytes) == nil
+}
+
+// verifyBLSIndividually verifies the given BLS signatures one by one.
+func verifyBLSIndividually(data []byte, signatures []bls.SignatureWithPublicKey) (allValid bool, valid []bool) {
+	allValid = true
+	valid = make([]bool, len(signatures))
+	for i, signature := range signatures {
+		if valid[i] = signature.IsValid(data); !valid[i] {
+			allValid = false
+		}
+	}
+
+	return allValid, valid
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -0,0 +1,73 @@
+package batchverifier
+
+import (
+	"testing"
+
+	"github.com/iotaledger/hive.go/crypto/bls"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifyBLSAggregated(t *testing.T) {
+	data := []byte("transaction essence")
+
+	for _, count := range []int{0, 1, 2, 5} {
+		signatures := blsTestSignatures(t, data, count)
+
+		allValid, valid := VerifyBLSAggregated(data, signatures)
+		assert.True(t, allValid, "count %d", count)
+		assert.Len(t, valid, count)
+		for i := range valid {
+			assert.True(t, valid[i])
+		}
+
+		if count == 0 {
+			continue
+		}
+
+		// replace one of the signatures with a signature of different data
+		invalidIndex := count / 2
+		signatures[invalidIndex] = blsSign(t, bls.PrivateKeyFromRandomness(), []byte("different data"))
+		allValid, valid = VerifyBLSAggregated(data, signatures)
+		assert.False(t, allValid, "count %d", count)
+		for i := range valid {
+			assert.Equal(t, i != invalidIndex, valid[i], "count %d, index %d", count, i)
+		}
+	}
+}
+
+func BenchmarkVerifyBLS_Individually(b *testing.B) {
+	data := []byte("transaction essence")
+	signatures := blsTestSignatures(b, data, 8)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		verifyBLSIndividually(data, signatures)
+	}
+}
+
+func BenchmarkVerifyBLS_Aggregated(b *testing.B) {
+	data := []byte("transaction essence")
+	signatures := blsTestSignatures(b, data, 8)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		VerifyBLSAggregated(data, signatures)
+	}
+}
+
+func blsTestSignatures(t testing.TB, data []byte, count int) (signatures []bls.SignatureWithPublicKey) {
+	signatures = make([]bls.SignatureWithPublicKey, count)
+	for i := range signatures {
+		signatures[i] = blsSign(t, bls.PrivateKeyFromRandomness(), data)
+	}
+
+	return signatures
+}
+
+func blsSign(t testing.TB, privateKey bls.PrivateKey, data []byte) bls.SignatureWithPublicKey {
+	signature, err := privateKey.Sign(data)
+	require.NoError(t, err)
+
+	return signature
+}
@@ -0,0 +1,185 @@
+package batchverifier
+
+import (
+	"sync"
+
+	"github.com/iotaledger/hive.go/crypto/ed25519"
+	oasised25519 "github.com/oasisprotocol/ed25519"
+)
+
+// region VerifyED25519 ////////////////////////////////////////////////////////////////////////////////////////////////
+
+// VerifyED25519 verifies the given ED25519 signatures in a single batch. It returns true if all signatures are valid
+// and a slice that contains the validity of every single signature. If the batch contains an invalid signature, the
+// signatures are checked individually to determine which one is invalid.
+func VerifyED25519(publicKeys []ed25519.PublicKey, data [][]byte, signatures []ed25519.Signature) (allValid bool, valid []bool) {
+	if len(publicKeys) != len(data) || len(data) != len(signatures) {
+		panic("batchverifier: the amount of public keys, data and signatures does not match")
+	}
+
+	switch len(publicKeys) {
+	case 0:
+		return true, []bool{}
+	case 1:
+		allValid = publicKeys[0].VerifySignature(data[0], signatures[0])
+		return allValid, []bool{allValid}
+	}
+
+	rawPublicKeys := make([]oasised25519.PublicKey, len(publicKeys))
+	rawSignatures := make([][]byte, len(signatures))
+	for i := range publicKeys {
+		rawPublicKeys[i] = publicKeys[i][:]
+		rawSignatures[i] = signatures[i][:]
+	}
+
+	allValid, valid, err := oasised25519.VerifyBatch(nil, rawPublicKeys, data, rawSignatures, &oasised25519.Options{})
+	if err != nil {
+		// the batch could not be processed (i.e. no entropy available) so we fall back to individual checks
+		return verifyED25519Individually(publicKeys, data, signatures)
+	}
+
+	return allValid, valid
+}
+
+// verifyED25519Individually verifies the given ED25519 signatures one by one.
+func verifyED25519Individually(publicKeys []ed25519.PublicKey, data [][]byte, signatures []ed25519.Signature) (allValid bool, valid []bool) {
+	allValid = true
+	valid = make([]bool, len(publicKeys))
+	for i := range publicKeys {
+		if valid[i] = publicKeys[i].VerifySignature(data[i], signatures[i]); !valid[i] {
+			allValid = false
+		}
+	}
+
+	return allValid, valid
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region ED25519Verifier //////////////////////////////////////////////////////////////////////////////////////////////
+
+// DefaultMaxBatchSize defines the maximum amount of signatures that are verified in a single batch by default.
+const DefaultMaxBatchSize = 64
+
+// ED25519Verifier is a component that verifies ED25519 signatures that are submitted concurrently in batches.
+//
+// It does not introduce any artificial delays: the first caller verifies its own signature together with all the
+// signatures that were submitted by other goroutines in the meantime, while those goroutines wait for the result.
+// Under low load every signature is therefore verified individually, while bursts of signatures are verified in
+// batches.
+type ED25519Verifier struct {
+	maxBatchSize int
+	pending      []*ed25519Request
+	verifying    bool
+	mutex        sync.Mutex
+}
+
+// NewED25519Verifier is the constructor of the ED25519Verifier. It verifies at most maxBatchSize signatures at once.
+func NewED25519Verifier(maxBatchSize int) *ED25519Verifier {
+	if maxBatchSize < 1 {
+		maxBatchSize = DefaultMaxBatchSize
+	}
+
+	return &ED25519Verifier{
+		maxBatchSize: maxBatchSize,
+	}
+}
+
+// Verify returns true if the signature of the given data is valid. The call blocks until the signature was verified.
+func (e *ED25519Verifier) Verify(publicKey ed25519.PublicKey, data []byte, signature ed25519.Signature) bool {
+	request := &ed25519Request{
+		publicKey: publicKey,
+		data:      data,
+		signature: signature,
+		result:    make(chan ed25519Result, 1),
+	}
+
+	e.mutex.Lock()
+	e.pending = append(e.pending, request)
+	if e.verifying {
+		e.mutex.Unlock()
+
+		// wait until another goroutine verified our signature or handed the verification over to us
+		if result := <-request.result; result != resultPromoted {
+			return result == resultValid
+		}
+	} else {
+		e.verifying = true
+		e.mutex.Unlock()
+	}
+
+	e.verifyNextBatch(request)
+
+	return request.valid
+}
+
+// verifyNextBatch verifies the next batch of pending signatures (which always contains the signature of the given
+// request) and hands over the verification of the remaining signatures to the next waiting goroutine.
+func (e *ED25519Verifier) verifyNextBatch(ownRequest *ed25519Request) {
+	e.mutex.Lock()
+	batchSize := len(e.pending)
+	if batchSize > e.maxBatchSize {
+		batchSize = e.maxBatchSize
+	}
+	batch := e.pending[:batchSize]
+	e.pending = e.pending[batchSize:]
+	e.mutex.Unlock()
+
+	publicKeys := make([]ed25519.PublicKey, len(batch))
+	data := make([][]byte, len(batch))
+	signatures := make([]ed25519.Signature, len(batch))
+	for i, request := range batch {
+		publicKeys[i] = request.publicKey
+		data[i] = request.data
+		signatures[i] = request.signature
+	}
+
+	_, validity := VerifyED25519(publicKeys, data, signatures)
+	for i, request := range batch {
+		request.valid = validity[i]
+		if request == ownRequest {
+			continue
+		}
+
+		if request.valid {
+			request.result <- resultValid
+		} else {
+			request.result <- resultInvalid
+		}
+	}
+
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	if len(e.pending) == 0 {
+		e.verifying = false
+		return
+	}
+	e.pending[0].result <- resultPromoted
+}
+
+// ed25519Request is an internal utility type that represents a signature that is waiting to be verified.
+type ed25519Request struct {
+	publicKey ed25519.PublicKey
+	data      []byte
+	signature ed25519.Signature
+	valid     bool
+	result    chan ed25519Result
+}
+
+// ed25519Result is an internal utility type that is used to inform waiting goroutines about the outcome of their
+// request.
+type ed25519Result uint8
+
+const (
+	// resultValid signals that the signature is valid.
+	resultValid ed25519Result = iota
+
+	// resultInvalid signals that the signature is invalid.
+	resultInvalid
+
+	// resultPromoted signals that the receiving goroutine is responsible for verifying the next batch.
+	resultPromoted
+)
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
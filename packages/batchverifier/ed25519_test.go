@@ -0,0 +1,114 @@
+package batchverifier
+
+import (
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/iotaledger/hive.go/crypto/ed25519"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifyED25519(t *testing.T) {
+	for _, batchSize := range []int{0, 1, 3, 64, 100} {
+		publicKeys, data, signatures := ed25519TestData(batchSize)
+
+		allValid, valid := VerifyED25519(publicKeys, data, signatures)
+		assert.True(t, allValid, "batch size %d", batchSize)
+		assert.Len(t, valid, batchSize)
+		for i := range valid {
+			assert.True(t, valid[i])
+		}
+
+		if batchSize == 0 {
+			continue
+		}
+
+		// tamper with one of the signed messages
+		invalidIndex := batchSize / 2
+		data[invalidIndex] = []byte("tampered")
+		allValid, valid = VerifyED25519(publicKeys, data, signatures)
+		assert.False(t, allValid, "batch size %d", batchSize)
+		for i := range valid {
+			assert.Equal(t, i != invalidIndex, valid[i], "batch size %d, index %d", batchSize, i)
+		}
+	}
+}
+
+func TestED25519Verifier(t *testing.T) {
+	const signatureCount = 500
+	publicKeys, data, signatures := ed25519TestData(signatureCount)
+	for i := 0; i < signatureCount; i += 7 {
+		data[i] = []byte("tampered")
+	}
+
+	verifier := NewED25519Verifier(DefaultMaxBatchSize)
+
+	var wg sync.WaitGroup
+	results := make([]bool, signatureCount)
+	for i := 0; i < signatureCount; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i] = verifier.Verify(publicKeys[i], data[i], signatures[i])
+		}(i)
+	}
+	wg.Wait()
+
+	for i, result := range results {
+		assert.Equal(t, i%7 != 0, result, "index %d", i)
+	}
+
+	// the verifier needs to be idle again after all requests have been processed
+	verifier.mutex.Lock()
+	defer verifier.mutex.Unlock()
+	require.Empty(t, verifier.pending)
+	require.False(t, verifier.verifying)
+}
+
+func BenchmarkVerifyED25519_Individually(b *testing.B) {
+	publicKeys, data, signatures := ed25519TestData(DefaultMaxBatchSize)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		verifyED25519Individually(publicKeys, data, signatures)
+	}
+}
+
+func BenchmarkVerifyED25519_Batch(b *testing.B) {
+	publicKeys, data, signatures := ed25519TestData(DefaultMaxBatchSize)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		VerifyED25519(publicKeys, data, signatures)
+	}
+}
+
+func BenchmarkED25519Verifier_Parallel(b *testing.B) {
+	publicKeys, data, signatures := ed25519TestData(DefaultMaxBatchSize)
+	verifier := NewED25519Verifier(DefaultMaxBatchSize)
+
+	b.SetParallelism(DefaultMaxBatchSize)
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for i := 0; pb.Next(); i++ {
+			index := i % DefaultMaxBatchSize
+			verifier.Verify(publicKeys[index], data[index], signatures[index])
+		}
+	})
+}
+
+func ed25519TestData(count int) (publicKeys []ed25519.PublicKey, data [][]byte, signatures []ed25519.Signature) {
+	publicKeys = make([]ed25519.PublicKey, count)
+	data = make([][]byte, count)
+	signatures = make([]ed25519.Signature, count)
+	for i := 0; i < count; i++ {
+		keyPair := ed25519.GenerateKeyPair()
+		publicKeys[i] = keyPair.PublicKey
+		data[i] = []byte("message" + strconv.Itoa(i))
+		signatures[i] = keyPair.PrivateKey.Sign(data[i])
+	}
+
+	return publicKeys, data, signatures
+}
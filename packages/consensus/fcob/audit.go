@@ -0,0 +1,229 @@
+package fcob
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/iotaledger/hive.go/stringify"
+
+	"github.com/iotaledger/goshimmer/packages/ledgerstate"
+	"github.com/iotaledger/goshimmer/packages/tangle"
+	"github.com/iotaledger/goshimmer/packages/vote/opinion"
+)
+
+// ErrOpinionNotFound is returned if no opinion is stored for the audited Transaction or Message.
+var ErrOpinionNotFound = errors.New("opinion not found")
+
+// region OpinionContext ///////////////////////////////////////////////////////////////////////////////////////////////
+
+// OpinionContext contains the information about a Transaction that the FCoB rules derive its Opinion from.
+type OpinionContext struct {
+	// SolidificationTime is the time the Transaction became solid which is the arrival time used by FCoB.
+	SolidificationTime time.Time
+
+	// Conflicts contains the stored opinions of the other Transactions of the conflict set.
+	Conflicts ConflictSet
+
+	// Rejected is true if the Branch of the Transaction is rejected.
+	Rejected bool
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region OpinionAudit /////////////////////////////////////////////////////////////////////////////////////////////////
+
+// OpinionAudit is the result of comparing a stored Opinion with the Opinion re-derived from the stored arrival times
+// and conflict set of its Transaction.
+type OpinionAudit struct {
+	TransactionID   ledgerstate.TransactionID
+	Stored          OpinionEssence
+	FCOBTime1       time.Time
+	FCOBTime2       time.Time
+	Expected        OpinionEssence
+	Inconsistencies []string
+}
+
+// AuditOpinion re-derives the Opinion of the given Transaction at the given time from its OpinionContext and lists
+// the inconsistencies with the stored Opinion. Opinions with a LevelOfKnowledge of Three were decided by the ledger
+// and Opinions that were voted on may differ from their initial FCoB opinion without being inconsistent.
+func AuditOpinion(storedOpinion *Opinion, opinionContext *OpinionContext, parameters *Parameters, now time.Time) (audit *OpinionAudit) {
+	audit = &OpinionAudit{
+		TransactionID:   storedOpinion.transactionID,
+		Stored:          storedOpinion.OpinionEssence,
+		FCOBTime1:       storedOpinion.FCOBTime1(),
+		FCOBTime2:       storedOpinion.FCOBTime2(),
+		Expected:        reevaluateOpinion(opinionContext, parameters, now),
+		Inconsistencies: make([]string, 0),
+	}
+
+	stored := audit.Stored
+	if !opinionContext.SolidificationTime.IsZero() && !stored.timestamp.Equal(opinionContext.SolidificationTime) {
+		audit.addInconsistency("timestamp %s differs from the solidification time %s", stored.timestamp, opinionContext.SolidificationTime)
+	}
+	if !audit.FCOBTime1.IsZero() {
+		if stored.levelOfKnowledge == Pending {
+			audit.addInconsistency("FCOBTime1 is set but the level of knowledge is still %s", Pending)
+		}
+		if audit.FCOBTime1.Before(stored.timestamp.Add(parameters.LikedThreshold)) {
+			audit.addInconsistency("FCOBTime1 %s is before the LikedThreshold elapsed", audit.FCOBTime1)
+		}
+	}
+	if !audit.FCOBTime2.IsZero() {
+		if audit.FCOBTime1.IsZero() {
+			audit.addInconsistency("FCOBTime2 is set without FCOBTime1")
+		}
+		if audit.FCOBTime2.Before(stored.timestamp.Add(parameters.LocallyFinalizedThreshold)) {
+			audit.addInconsistency("FCOBTime2 %s is before the LocallyFinalizedThreshold elapsed", audit.FCOBTime2)
+		}
+	}
+
+	switch {
+	case stored.levelOfKnowledge == Three:
+		// the opinion was set by the confirmation or rejection of the Branch
+	case opinionContext.Rejected && !stored.liked:
+		// the opinion was taken over from the rejected Branch
+	case stored.levelOfKnowledge == Pending && audit.Expected.levelOfKnowledge != Pending:
+		audit.addInconsistency("opinion is still %s after the LikedThreshold elapsed", Pending)
+	case stored.levelOfKnowledge == Two && audit.Expected.levelOfKnowledge == One:
+		// the opinion was decided by a vote
+	case stored.levelOfKnowledge == One && audit.Expected.levelOfKnowledge == Two:
+		audit.addInconsistency("level of knowledge is %s but the re-derived level of knowledge is %s", One, Two)
+	case stored.levelOfKnowledge != Pending && audit.Expected.levelOfKnowledge != Pending && stored.liked != audit.Expected.liked:
+		audit.addInconsistency("liked flag %t differs from the re-derived liked flag %t", stored.liked, audit.Expected.liked)
+	}
+
+	return
+}
+
+// Consistent returns true if no inconsistencies were found.
+func (o *OpinionAudit) Consistent() bool {
+	return len(o.Inconsistencies) == 0
+}
+
+// String returns a human readable version of the OpinionAudit.
+func (o *OpinionAudit) String() string {
+	return stringify.Struct("OpinionAudit",
+		stringify.StructField("transactionID", o.TransactionID),
+		stringify.StructField("stored", o.Stored),
+		stringify.StructField("fcobTime1", o.FCOBTime1),
+		stringify.StructField("fcobTime2", o.FCOBTime2),
+		stringify.StructField("expected", o.Expected),
+		stringify.StructField("inconsistencies", o.Inconsistencies),
+	)
+}
+
+func (o *OpinionAudit) addInconsistency(format string, args ...interface{}) {
+	o.Inconsistencies = append(o.Inconsistencies, fmt.Sprintf(format, args...))
+}
+
+// reevaluateOpinion replays the FCoB rules of the ConsensusMechanism for a Transaction with the given OpinionContext.
+// The conflicts are ordered by their timestamps to determine which of them were known when the Transaction was booked,
+// when the LikedThreshold elapsed and when the LocallyFinalizedThreshold elapsed.
+func reevaluateOpinion(opinionContext *OpinionContext, parameters *Parameters, now time.Time) (expected OpinionEssence) {
+	timestamp := opinionContext.SolidificationTime
+	if opinionContext.Rejected {
+		return OpinionEssence{timestamp: timestamp, liked: false, levelOfKnowledge: Two}
+	}
+
+	likedDeadline := timestamp.Add(parameters.LikedThreshold)
+	locallyFinalizedDeadline := timestamp.Add(parameters.LocallyFinalizedThreshold)
+
+	knownAtBooking := make(ConflictSet, 0)
+	knownAtLikedThreshold := make(ConflictSet, 0)
+	conflictingAtLocallyFinalizedThreshold := false
+	for _, conflict := range opinionContext.Conflicts {
+		if conflict.timestamp.IsZero() {
+			continue
+		}
+		if conflict.timestamp.Before(timestamp) {
+			knownAtBooking = append(knownAtBooking, conflict)
+		}
+		if conflict.timestamp.Before(likedDeadline) {
+			knownAtLikedThreshold = append(knownAtLikedThreshold, conflict)
+		}
+		if conflict.timestamp.Before(locallyFinalizedDeadline) {
+			conflictingAtLocallyFinalizedThreshold = true
+		}
+	}
+
+	if len(knownAtBooking) > 0 {
+		if expected = deriveOpinion(timestamp, knownAtBooking); expected.levelOfKnowledge != Pending {
+			return
+		}
+	}
+
+	if now.Before(likedDeadline) {
+		return OpinionEssence{timestamp: timestamp, levelOfKnowledge: Pending}
+	}
+	if len(knownAtLikedThreshold) > 0 {
+		return OpinionEssence{timestamp: timestamp, liked: knownAtLikedThreshold.finalizedAsDisliked(OpinionEssence{}), levelOfKnowledge: One}
+	}
+	if now.Before(locallyFinalizedDeadline) || conflictingAtLocallyFinalizedThreshold {
+		return OpinionEssence{timestamp: timestamp, liked: true, levelOfKnowledge: One}
+	}
+
+	return OpinionEssence{timestamp: timestamp, liked: true, levelOfKnowledge: Two}
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region TimestampOpinionAudit ////////////////////////////////////////////////////////////////////////////////////////
+
+// TimestampOpinionAudit is the result of comparing a stored TimestampOpinion with the TimestampOpinion re-derived from
+// the issuing and arrival time of its Message.
+type TimestampOpinionAudit struct {
+	MessageID       tangle.MessageID
+	IssuingTime     time.Time
+	ReceivedTime    time.Time
+	Stored          *TimestampOpinion
+	Expected        *TimestampOpinion
+	Inconsistencies []string
+}
+
+// AuditTimestampOpinion re-derives the TimestampOpinion of a Message from its issuing and arrival time and lists the
+// inconsistencies with the stored TimestampOpinion. TimestampOpinions with a LevelOfKnowledge of Two that were
+// re-derived with a LevelOfKnowledge of One were decided by a vote.
+func AuditTimestampOpinion(storedTimestampOpinion *TimestampOpinion, issuingTime, receivedTime time.Time, parameters *Parameters) (audit *TimestampOpinionAudit) {
+	audit = &TimestampOpinionAudit{
+		MessageID:       storedTimestampOpinion.MessageID,
+		IssuingTime:     issuingTime,
+		ReceivedTime:    receivedTime,
+		Stored:          storedTimestampOpinion,
+		Expected:        TimestampQuality(storedTimestampOpinion.MessageID, issuingTime, receivedTime, parameters),
+		Inconsistencies: make([]string, 0),
+	}
+
+	stored := audit.Stored
+	if stored.LoK == Two && audit.Expected.LoK == One {
+		return
+	}
+	if stored.Value != audit.Expected.Value || stored.LoK != audit.Expected.LoK {
+		inconsistency := fmt.Sprintf("%s with level of knowledge %s differs from the re-derived %s with level of knowledge %s", stored.Value, stored.LoK, audit.Expected.Value, audit.Expected.LoK)
+		if stored.Value == opinion.Like && stored.LoK == Two {
+			inconsistency += " (the message may have been received while the node was not in sync)"
+		}
+		audit.Inconsistencies = append(audit.Inconsistencies, inconsistency)
+	}
+
+	return
+}
+
+// Consistent returns true if no inconsistencies were found.
+func (t *TimestampOpinionAudit) Consistent() bool {
+	return len(t.Inconsistencies) == 0
+}
+
+// String returns a human readable version of the TimestampOpinionAudit.
+func (t *TimestampOpinionAudit) String() string {
+	return stringify.Struct("TimestampOpinionAudit",
+		stringify.StructField("messageID", t.MessageID),
+		stringify.StructField("issuingTime", t.IssuingTime),
+		stringify.StructField("receivedTime", t.ReceivedTime),
+		stringify.StructField("stored", t.Stored),
+		stringify.StructField("expected", t.Expected),
+		stringify.StructField("inconsistencies", t.Inconsistencies),
+	)
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
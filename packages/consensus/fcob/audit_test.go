@@ -0,0 +1,119 @@
+package fcob
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/iotaledger/goshimmer/packages/ledgerstate"
+	"github.com/iotaledger/goshimmer/packages/tangle"
+	"github.com/iotaledger/goshimmer/packages/vote/opinion"
+)
+
+func TestAuditOpinion(t *testing.T) {
+	parameters := DefaultParameters()
+	timestamp := time.Now().Add(-time.Minute)
+	transactionID := ledgerstate.GenesisTransactionID
+
+	newOpinion := func(liked bool, levelOfKnowledge LevelOfKnowledge, fcobTime1, fcobTime2 time.Time) *Opinion {
+		return &Opinion{
+			transactionID:  transactionID,
+			OpinionEssence: OpinionEssence{timestamp: timestamp, liked: liked, levelOfKnowledge: levelOfKnowledge},
+			fcobTime1:      fcobTime1,
+			fcobTime2:      fcobTime2,
+		}
+	}
+	time1 := timestamp.Add(parameters.LikedThreshold)
+	time2 := timestamp.Add(parameters.LocallyFinalizedThreshold)
+
+	t.Run("CASE: Locally finalized", func(t *testing.T) {
+		audit := AuditOpinion(newOpinion(true, Two, time1, time2), &OpinionContext{SolidificationTime: timestamp}, parameters, time.Now())
+		assert.True(t, audit.Consistent(), audit.Inconsistencies)
+		assert.Equal(t, OpinionEssence{timestamp: timestamp, liked: true, levelOfKnowledge: Two}, audit.Expected)
+	})
+
+	t.Run("CASE: Disliked without conflicts", func(t *testing.T) {
+		audit := AuditOpinion(newOpinion(false, Two, time1, time2), &OpinionContext{SolidificationTime: timestamp}, parameters, time.Now())
+		assert.False(t, audit.Consistent())
+		assert.Len(t, audit.Inconsistencies, 1)
+	})
+
+	t.Run("CASE: Pending after LikedThreshold", func(t *testing.T) {
+		audit := AuditOpinion(newOpinion(false, Pending, time.Time{}, time.Time{}), &OpinionContext{SolidificationTime: timestamp}, parameters, time.Now())
+		assert.False(t, audit.Consistent())
+
+		audit = AuditOpinion(newOpinion(false, Pending, time.Time{}, time.Time{}), &OpinionContext{SolidificationTime: timestamp}, parameters, timestamp)
+		assert.True(t, audit.Consistent(), audit.Inconsistencies)
+	})
+
+	t.Run("CASE: Inconsistent FCoB times", func(t *testing.T) {
+		audit := AuditOpinion(newOpinion(true, Two, time.Time{}, timestamp), &OpinionContext{SolidificationTime: timestamp}, parameters, time.Now())
+		assert.Len(t, audit.Inconsistencies, 2)
+	})
+
+	t.Run("CASE: Later conflict disliked", func(t *testing.T) {
+		opinionContext := &OpinionContext{
+			SolidificationTime: timestamp,
+			Conflicts:          ConflictSet{{timestamp: timestamp.Add(-time.Second), liked: true, levelOfKnowledge: Two}},
+		}
+		audit := AuditOpinion(newOpinion(false, Two, time.Time{}, time.Time{}), opinionContext, parameters, time.Now())
+		assert.True(t, audit.Consistent(), audit.Inconsistencies)
+
+		audit = AuditOpinion(newOpinion(true, Two, time1, time2), opinionContext, parameters, time.Now())
+		assert.False(t, audit.Consistent())
+	})
+
+	t.Run("CASE: Conflict within LikedThreshold", func(t *testing.T) {
+		opinionContext := &OpinionContext{
+			SolidificationTime: timestamp,
+			Conflicts:          ConflictSet{{timestamp: timestamp.Add(time.Second), levelOfKnowledge: One}},
+		}
+		assert.Equal(t, OpinionEssence{timestamp: timestamp, liked: false, levelOfKnowledge: One}, reevaluateOpinion(opinionContext, parameters, time.Now()))
+
+		// the vote decided differently than the initial opinion
+		audit := AuditOpinion(newOpinion(true, Two, time1, time.Time{}), opinionContext, parameters, time.Now())
+		assert.True(t, audit.Consistent(), audit.Inconsistencies)
+
+		audit = AuditOpinion(newOpinion(true, One, time1, time.Time{}), opinionContext, parameters, time.Now())
+		assert.False(t, audit.Consistent())
+	})
+
+	t.Run("CASE: Conflict before LocallyFinalizedThreshold", func(t *testing.T) {
+		opinionContext := &OpinionContext{
+			SolidificationTime: timestamp,
+			Conflicts:          ConflictSet{{timestamp: time1.Add(time.Second), levelOfKnowledge: One}},
+		}
+		assert.Equal(t, OpinionEssence{timestamp: timestamp, liked: true, levelOfKnowledge: One}, reevaluateOpinion(opinionContext, parameters, time.Now()))
+	})
+
+	t.Run("CASE: Decided by the ledger", func(t *testing.T) {
+		audit := AuditOpinion(newOpinion(false, Three, time1, time2), &OpinionContext{SolidificationTime: timestamp}, parameters, time.Now())
+		assert.True(t, audit.Consistent(), audit.Inconsistencies)
+	})
+
+	t.Run("CASE: Rejected branch", func(t *testing.T) {
+		audit := AuditOpinion(newOpinion(false, Two, time.Time{}, time.Time{}), &OpinionContext{SolidificationTime: timestamp, Rejected: true}, parameters, time.Now())
+		assert.True(t, audit.Consistent(), audit.Inconsistencies)
+	})
+}
+
+func TestAuditTimestampOpinion(t *testing.T) {
+	parameters := DefaultParameters()
+	received := time.Now()
+
+	audit := AuditTimestampOpinion(&TimestampOpinion{MessageID: tangle.EmptyMessageID, Value: opinion.Like, LoK: Three}, received, received, parameters)
+	assert.True(t, audit.Consistent(), audit.Inconsistencies)
+
+	issued := received.Add(-parameters.TimestampWindow - 3*parameters.GratuitousNetworkDelay)
+	audit = AuditTimestampOpinion(&TimestampOpinion{MessageID: tangle.EmptyMessageID, Value: opinion.Dislike, LoK: Three}, issued, received, parameters)
+	assert.True(t, audit.Consistent(), audit.Inconsistencies)
+
+	audit = AuditTimestampOpinion(&TimestampOpinion{MessageID: tangle.EmptyMessageID, Value: opinion.Like, LoK: Three}, issued, received, parameters)
+	assert.False(t, audit.Consistent())
+
+	// decided by a vote
+	issued = received.Add(-parameters.TimestampWindow)
+	audit = AuditTimestampOpinion(&TimestampOpinion{MessageID: tangle.EmptyMessageID, Value: opinion.Like, LoK: Two}, issued, received, parameters)
+	assert.True(t, audit.Consistent(), audit.Inconsistencies)
+}
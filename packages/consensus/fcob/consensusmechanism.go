@@ -4,11 +4,13 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/cockroachdb/errors"
 	"github.com/iotaledger/hive.go/datastructure/walker"
 	"github.com/iotaledger/hive.go/events"
 	"github.com/iotaledger/hive.go/timedexecutor"
 	"github.com/iotaledger/hive.go/timedqueue"
 
+	"github.com/iotaledger/goshimmer/packages/clock"
 	"github.com/iotaledger/goshimmer/packages/ledgerstate"
 	"github.com/iotaledger/goshimmer/packages/tangle"
 	"github.com/iotaledger/goshimmer/packages/vote"
@@ -16,14 +18,6 @@ import (
 	voter "github.com/iotaledger/goshimmer/packages/vote/opinion"
 )
 
-var (
-	// LikedThreshold is the first time threshold of FCoB.
-	LikedThreshold = 2 * time.Second
-
-	// LocallyFinalizedThreshold is the second time threshold of FCoB.
-	LocallyFinalizedThreshold = 4 * time.Second
-)
-
 // region ConsensusMechanism ///////////////////////////////////////////////////////////////////////////////////////////
 
 // ConsensusMechanism represents the FCoB consensus that can be used as a ConsensusMechanism in the Tangle.
@@ -32,20 +26,34 @@ type ConsensusMechanism struct {
 
 	tangle                   *tangle.Tangle
 	Storage                  *Storage
+	parameters               *Parameters
 	likedThresholdExecutor   *timedexecutor.TimedExecutor
 	locallyFinalizedExecutor *timedexecutor.TimedExecutor
 }
 
-// NewConsensusMechanism is the constructor for the FCoB consensus mechanism.
-func NewConsensusMechanism() *ConsensusMechanism {
-	return &ConsensusMechanism{
+// NewConsensusMechanism is the constructor for the FCoB consensus mechanism. It uses the DefaultParameters unless
+// Parameters are given.
+func NewConsensusMechanism(parameters ...*Parameters) *ConsensusMechanism {
+	consensusMechanism := &ConsensusMechanism{
 		Events: &ConsensusMechanismEvents{
-			Error: events.NewEvent(events.ErrorCaller),
-			Vote:  events.NewEvent(voteEventHandler),
+			Error:         events.NewEvent(events.ErrorCaller),
+			Vote:          events.NewEvent(voteEventHandler),
+			TimestampVote: events.NewEvent(voteEventHandler),
 		},
+		parameters:               DefaultParameters(),
 		likedThresholdExecutor:   timedexecutor.New(1),
 		locallyFinalizedExecutor: timedexecutor.New(1),
 	}
+	if len(parameters) > 0 {
+		consensusMechanism.parameters = parameters[0]
+	}
+
+	return consensusMechanism
+}
+
+// Parameters returns the Parameters of the ConsensusMechanism.
+func (f *ConsensusMechanism) Parameters() *Parameters {
+	return f.parameters
 }
 
 // Init initializes the ConsensusMechanism by making the Tangle object available that is using it.
@@ -91,6 +99,7 @@ func (f *ConsensusMechanism) SetTransactionLiked(transactionID ledgerstate.Trans
 	f.Storage.Opinion(transactionID).Consume(func(opinion *Opinion) {
 		modified = opinion.SetLiked(liked)
 		opinion.SetLevelOfKnowledge(Three)
+		f.recordOpinion(opinion)
 	})
 
 	return modified
@@ -119,24 +128,37 @@ func (f *ConsensusMechanism) Evaluate(messageID tangle.MessageID) {
 // EvaluateTimestamp evaluates the honesty of the timestamp of the given Message.
 func (f *ConsensusMechanism) EvaluateTimestamp(messageID tangle.MessageID) {
 	f.Storage.StoreMessageMetadata(NewMessageMetadata(messageID))
-	f.Storage.StoreTimestampOpinion(&TimestampOpinion{
-		MessageID: messageID,
-		Value:     voter.Like,
-		LoK:       Two,
-	})
 
-	f.setEligibility(messageID)
-
-	f.setTimestampOpinionDone(messageID)
+	timestampOpinion := f.timestampQuality(messageID)
+	f.Storage.StoreTimestampOpinion(timestampOpinion)
 
-	if f.messageDone(messageID) {
-		f.tangle.Utils.WalkMessageID(f.createMessageOpinion, tangle.MessageIDs{messageID}, true)
+	// the timestamp is close to the border of the timestamp window, so we need to vote on it
+	if timestampOpinion.LoK == One {
+		f.Events.TimestampVote.Trigger(messageID.Base58(), timestampOpinion.Value)
+		return
 	}
+
+	f.onTimestampOpinionFormed(messageID)
+}
+
+// RequeueTimestampVotes triggers the TimestampVote event for every timestamp opinion that is still waiting for the
+// outcome of a vote, e.g. because the node was shut down while voting on it. It returns the number of re-queued votes.
+func (f *ConsensusMechanism) RequeueTimestampVotes() (requeued int) {
+	f.Storage.ForEachTimestampOpinion(func(timestampOpinion *TimestampOpinion) bool {
+		if timestampOpinion.LoK == One {
+			f.Events.TimestampVote.Trigger(timestampOpinion.MessageID.Base58(), timestampOpinion.Value)
+			requeued++
+		}
+		return true
+	})
+
+	return requeued
 }
 
 // ProcessVote allows an external voter to hand in the results of the voting process.
 func (f *ConsensusMechanism) ProcessVote(ev *vote.OpinionEvent) {
-	if ev.Ctx.Type == vote.ConflictType {
+	switch ev.Ctx.Type {
+	case vote.ConflictType:
 		transactionID, err := ledgerstate.TransactionIDFromBase58(ev.ID)
 		if err != nil {
 			f.Events.Error.Trigger(err)
@@ -146,12 +168,27 @@ func (f *ConsensusMechanism) ProcessVote(ev *vote.OpinionEvent) {
 		f.Storage.Opinion(transactionID).Consume(func(opinion *Opinion) {
 			opinion.SetLiked(ev.Opinion == voter.Like)
 			opinion.SetLevelOfKnowledge(Two)
+			f.recordOpinion(opinion)
 			// trigger PayloadOpinionFormed event
 			messageIDs := f.tangle.Storage.AttachmentMessageIDs(transactionID)
 			for _, messageID := range messageIDs {
 				f.onPayloadOpinionFormed(messageID, opinion.liked)
 			}
 		})
+	case vote.TimestampType:
+		messageID, err := tangle.NewMessageID(ev.ID)
+		if err != nil {
+			f.Events.Error.Trigger(err)
+			return
+		}
+
+		if !f.Storage.TimestampOpinion(messageID).Consume(func(timestampOpinion *TimestampOpinion) {
+			timestampOpinion.SetOpinion(ev.Opinion, Two)
+		}) {
+			f.Events.Error.Trigger(fmt.Errorf("failed to load timestamp opinion of message %s", messageID))
+			return
+		}
+		f.onTimestampOpinionFormed(messageID)
 	}
 }
 
@@ -175,6 +212,75 @@ func (f *ConsensusMechanism) OpinionsEssence(targetID ledgerstate.TransactionID,
 	return
 }
 
+// AuditOpinion compares the stored Opinion of the given Transaction with the Opinion re-derived from its
+// solidification time and the stored opinions of its conflicts.
+func (f *ConsensusMechanism) AuditOpinion(transactionID ledgerstate.TransactionID) (audit *OpinionAudit, err error) {
+	if !f.Storage.Opinion(transactionID).Consume(func(opinion *Opinion) {
+		audit = AuditOpinion(opinion, f.opinionContext(transactionID), f.parameters, clock.SyncedTime())
+	}) {
+		err = errors.Errorf("failed to load opinion of transaction %s: %w", transactionID, ErrOpinionNotFound)
+	}
+
+	return
+}
+
+// AuditOpinions audits all stored Opinions and calls the consumer for every OpinionAudit. The iteration stops if the
+// consumer returns false.
+func (f *ConsensusMechanism) AuditOpinions(consumer func(audit *OpinionAudit) bool) {
+	now := clock.SyncedTime()
+	f.Storage.ForEachOpinion(func(opinion *Opinion) bool {
+		return consumer(AuditOpinion(opinion, f.opinionContext(opinion.transactionID), f.parameters, now))
+	})
+}
+
+// AuditTimestampOpinion compares the stored TimestampOpinion of the given Message with the TimestampOpinion
+// re-derived from its issuing and arrival time.
+func (f *ConsensusMechanism) AuditTimestampOpinion(messageID tangle.MessageID) (audit *TimestampOpinionAudit, err error) {
+	if !f.Storage.TimestampOpinion(messageID).Consume(func(timestampOpinion *TimestampOpinion) {
+		audit = f.auditTimestampOpinion(timestampOpinion)
+	}) {
+		err = errors.Errorf("failed to load timestamp opinion of message %s: %w", messageID, ErrOpinionNotFound)
+	}
+
+	return
+}
+
+// AuditTimestampOpinions audits all stored TimestampOpinions and calls the consumer for every TimestampOpinionAudit.
+// The iteration stops if the consumer returns false.
+func (f *ConsensusMechanism) AuditTimestampOpinions(consumer func(audit *TimestampOpinionAudit) bool) {
+	f.Storage.ForEachTimestampOpinion(func(timestampOpinion *TimestampOpinion) bool {
+		return consumer(f.auditTimestampOpinion(timestampOpinion))
+	})
+}
+
+// opinionContext collects the information that the FCoB rules use to derive the Opinion of the given Transaction.
+func (f *ConsensusMechanism) opinionContext(transactionID ledgerstate.TransactionID) (opinionContext *OpinionContext) {
+	opinionContext = &OpinionContext{
+		Conflicts: make(ConflictSet, 0),
+		Rejected:  f.tangle.LedgerState.BranchInclusionState(f.tangle.LedgerState.BranchID(transactionID)) == ledgerstate.Rejected,
+	}
+	f.tangle.LedgerState.TransactionMetadata(transactionID).Consume(func(transactionMetadata *ledgerstate.TransactionMetadata) {
+		opinionContext.SolidificationTime = transactionMetadata.SolidificationTime()
+	})
+	if f.tangle.LedgerState.TransactionConflicting(transactionID) {
+		opinionContext.Conflicts = f.OpinionsEssence(transactionID, f.tangle.LedgerState.ConflictSet(transactionID))
+	}
+
+	return
+}
+
+func (f *ConsensusMechanism) auditTimestampOpinion(timestampOpinion *TimestampOpinion) (audit *TimestampOpinionAudit) {
+	var issuingTime, receivedTime time.Time
+	f.tangle.Storage.Message(timestampOpinion.MessageID).Consume(func(message *tangle.Message) {
+		issuingTime = message.IssuingTime()
+	})
+	f.tangle.Storage.MessageMetadata(timestampOpinion.MessageID).Consume(func(messageMetadata *tangle.MessageMetadata) {
+		receivedTime = messageMetadata.ReceivedTime()
+	})
+
+	return AuditTimestampOpinion(timestampOpinion, issuingTime, receivedTime, f.parameters)
+}
+
 func (f *ConsensusMechanism) onTransactionBooked(transactionID ledgerstate.TransactionID, messageID tangle.MessageID) {
 	// if the opinion for this transactionID is already present,
 	// it's a reattachment and thus, we re-use the same opinion.
@@ -218,6 +324,7 @@ func (f *ConsensusMechanism) onTransactionBooked(transactionID ledgerstate.Trans
 		newOpinion.OpinionEssence = deriveOpinion(timestamp, f.OpinionsEssence(transactionID, f.tangle.LedgerState.ConflictSet(transactionID)))
 
 		f.Storage.opinionStorage.Store(newOpinion).Release()
+		f.recordOpinion(newOpinion)
 
 		switch newOpinion.LevelOfKnowledge() {
 		case Pending:
@@ -262,12 +369,14 @@ func (f *ConsensusMechanism) onTransactionBooked(transactionID ledgerstate.Trans
 				if conflictSet.finalizedAsDisliked(opinion.OpinionEssence) {
 					opinion.SetLiked(true)
 					opinion.SetLevelOfKnowledge(One)
+					f.recordOpinion(opinion)
 					// trigger voting for this transactionID
 					f.Events.Vote.Trigger(transactionID.Base58(), voter.Like)
 					return
 				}
 				opinion.SetLevelOfKnowledge(One)
 				opinion.SetLiked(false)
+				f.recordOpinion(opinion)
 				// trigger voting for this transactionID
 				f.Events.Vote.Trigger(transactionID.Base58(), voter.Dislike)
 				return
@@ -286,6 +395,7 @@ func (f *ConsensusMechanism) onTransactionBooked(transactionID ledgerstate.Trans
 
 					opinion.SetLiked(true)
 					if f.tangle.LedgerState.TransactionConflicting(transactionID) {
+						f.recordOpinion(opinion)
 						// trigger voting for this transactionID
 						f.Events.Vote.Trigger(transactionID.Base58(), voter.Like)
 						return
@@ -299,9 +409,14 @@ func (f *ConsensusMechanism) onTransactionBooked(transactionID ledgerstate.Trans
 				}) {
 					panic(fmt.Sprintf("could not load opinion of transaction %s", transactionID))
 				}
-			}, timestamp.Add(LocallyFinalizedThreshold))
+			}, timestamp.Add(f.parameters.LocallyFinalizedThreshold))
 		}
-	}, timestamp.Add(LikedThreshold))
+	}, timestamp.Add(f.parameters.LikedThreshold))
+}
+
+// recordOpinion records the given Opinion in the timeline of the Conflicts of its Transaction.
+func (f *ConsensusMechanism) recordOpinion(opinion *Opinion) {
+	f.tangle.LedgerState.ConflictTimeline.RecordOpinion(ledgerstate.NewBranchID(opinion.transactionID), opinion.Liked(), uint8(opinion.LevelOfKnowledge()))
 }
 
 func (f *ConsensusMechanism) onPayloadOpinionFormed(messageID tangle.MessageID, liked bool) {
@@ -324,6 +439,36 @@ func (f *ConsensusMechanism) onPayloadOpinionFormed(messageID tangle.MessageID,
 	}
 }
 
+// timestampQuality returns the TimestampOpinion of the given Message based on the time it was received. Messages that
+// are received while the node is not in sync are not judged, as their arrival time says nothing about their timestamp.
+func (f *ConsensusMechanism) timestampQuality(messageID tangle.MessageID) (timestampOpinion *TimestampOpinion) {
+	timestampOpinion = &TimestampOpinion{
+		MessageID: messageID,
+		Value:     voter.Like,
+		LoK:       Two,
+	}
+	if !f.tangle.TimeManager.Synced() {
+		return timestampOpinion
+	}
+
+	f.tangle.Storage.Message(messageID).Consume(func(message *tangle.Message) {
+		f.tangle.Storage.MessageMetadata(messageID).Consume(func(messageMetadata *tangle.MessageMetadata) {
+			timestampOpinion = TimestampQuality(messageID, message.IssuingTime(), messageMetadata.ReceivedTime(), f.parameters)
+		})
+	})
+	return timestampOpinion
+}
+
+func (f *ConsensusMechanism) onTimestampOpinionFormed(messageID tangle.MessageID) {
+	f.setEligibility(messageID)
+
+	f.setTimestampOpinionDone(messageID)
+
+	if f.messageDone(messageID) {
+		f.tangle.Utils.WalkMessageID(f.createMessageOpinion, tangle.MessageIDs{messageID}, true)
+	}
+}
+
 func (f *ConsensusMechanism) createMessageOpinion(messageID tangle.MessageID, walker *walker.Walker) {
 	if !f.parentsDone(messageID) {
 		return
@@ -447,8 +592,11 @@ type ConsensusMechanismEvents struct {
 	// Error gets called when FCOB faces an error.
 	Error *events.Event
 
-	// Vote gets called when FCOB needs to vote.
+	// Vote gets called when FCOB needs to vote on a conflict.
 	Vote *events.Event
+
+	// TimestampVote gets called when FCOB needs to vote on the timestamp of a message.
+	TimestampVote *events.Event
 }
 
 func voteEventHandler(handler interface{}, params ...interface{}) {
@@ -11,6 +11,7 @@ import (
 	"github.com/iotaledger/hive.go/crypto/ed25519"
 	"github.com/iotaledger/hive.go/events"
 	"github.com/iotaledger/hive.go/identity"
+	"github.com/iotaledger/hive.go/kvstore/mapdb"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
@@ -36,10 +37,11 @@ func getTotalAccessMana() float64 {
 }
 
 func TestOpinionFormer_Scenario2(t *testing.T) {
-	LikedThreshold = 2 * time.Second
-	LocallyFinalizedThreshold = 2 * time.Second
+	parameters := DefaultParameters()
+	parameters.LikedThreshold = 2 * time.Second
+	parameters.LocallyFinalizedThreshold = 2 * time.Second
 
-	consensusProvider := NewConsensusMechanism()
+	consensusProvider := NewConsensusMechanism(parameters)
 	cacheTimeProvider := database.NewCacheTimeProvider(0)
 
 	testTangle := tangle.New(tangle.Consensus(consensusProvider), tangle.SchedulerConfig(schedulerParams),
@@ -241,10 +243,11 @@ func TestOpinionFormer_Scenario2(t *testing.T) {
 }
 
 func TestOpinionFormer(t *testing.T) {
-	LikedThreshold = 1 * time.Second
-	LocallyFinalizedThreshold = 2 * time.Second
+	parameters := DefaultParameters()
+	parameters.LikedThreshold = 1 * time.Second
+	parameters.LocallyFinalizedThreshold = 2 * time.Second
 
-	consensusProvider := NewConsensusMechanism()
+	consensusProvider := NewConsensusMechanism(parameters)
 	cacheTimeProvider := database.NewCacheTimeProvider(0)
 
 	testTangle := tangle.New(tangle.Consensus(consensusProvider), tangle.SchedulerConfig(schedulerParams),
@@ -632,3 +635,81 @@ func addressFromInput(input ledgerstate.Input, outputsByID ledgerstate.OutputsBy
 		panic("unexpected Output type")
 	}
 }
+
+func TestTimestampVote(t *testing.T) {
+	parameters := DefaultParameters()
+	parameters.TimestampWindow = 1 * time.Minute
+	parameters.GratuitousNetworkDelay = 15 * time.Second
+
+	consensusProvider := NewConsensusMechanism(parameters)
+	cacheTimeProvider := database.NewCacheTimeProvider(0)
+
+	// timestamps are only judged while synced, which requires the mana based scheduler
+	syncedSchedulerParams := schedulerParams
+	syncedSchedulerParams.MaxBufferSize = 1000000
+
+	testTangle := tangle.New(tangle.Consensus(consensusProvider), tangle.SchedulerConfig(syncedSchedulerParams),
+		tangle.CacheTimeProvider(cacheTimeProvider), tangle.StartSynced(true))
+	defer testTangle.Shutdown()
+	testTangle.Setup()
+
+	// the timestamp of the disputed message is right at the border of the timestamp window
+	honestMessage := newTestDataMessage("honest")
+	disputedMessage := tangle.NewMessage([]tangle.MessageID{tangle.EmptyMessageID}, []tangle.MessageID{}, time.Now().Add(-parameters.TimestampWindow), ed25519.PublicKey{}, nextSequenceNumber(), payload.NewGenericDataPayload([]byte("disputed")), 0, ed25519.Signature{})
+
+	votes := make(chan string, 2)
+	consensusProvider.Events.TimestampVote.Attach(events.NewClosure(func(messageID string, initialOpinion opinion.Opinion) {
+		votes <- messageID
+		consensusProvider.ProcessVote(&vote.OpinionEvent{
+			ID:      messageID,
+			Opinion: opinion.Like,
+			Ctx:     vote.Context{Type: vote.TimestampType},
+		})
+	}))
+
+	var wg sync.WaitGroup
+	testTangle.ConsensusManager.Events.MessageOpinionFormed.Attach(events.NewClosure(func(messageID tangle.MessageID) {
+		assert.True(t, testTangle.ConsensusManager.MessageEligible(messageID))
+		wg.Done()
+	}))
+
+	wg.Add(2)
+	testTangle.Storage.StoreMessage(honestMessage)
+	testTangle.Storage.StoreMessage(disputedMessage)
+	wg.Wait()
+
+	require.Len(t, votes, 1)
+	assert.Equal(t, disputedMessage.ID().Base58(), <-votes)
+
+	assert.True(t, consensusProvider.Storage.TimestampOpinion(honestMessage.ID()).Consume(func(timestampOpinion *TimestampOpinion) {
+		assert.Equal(t, opinion.Like, timestampOpinion.Value)
+		assert.Equal(t, Three, timestampOpinion.LoK)
+	}))
+	assert.True(t, consensusProvider.Storage.TimestampOpinion(disputedMessage.ID()).Consume(func(timestampOpinion *TimestampOpinion) {
+		assert.Equal(t, opinion.Like, timestampOpinion.Value)
+		assert.Equal(t, Two, timestampOpinion.LoK)
+	}))
+}
+
+func TestRequeueTimestampVotes(t *testing.T) {
+	store := mapdb.NewMapDB()
+	pendingMessageID := newTestDataMessage("pending").ID()
+	decidedMessageID := newTestDataMessage("decided").ID()
+
+	storage := NewStorage(store, database.NewCacheTimeProvider(0))
+	storage.StoreTimestampOpinion(&TimestampOpinion{MessageID: pendingMessageID, Value: opinion.Dislike, LoK: One})
+	storage.StoreTimestampOpinion(&TimestampOpinion{MessageID: decidedMessageID, Value: opinion.Like, LoK: Two})
+	storage.Shutdown()
+
+	// the vote on the pending timestamp is re-queued after a restart
+	consensusProvider := NewConsensusMechanism()
+	consensusProvider.Storage = NewStorage(store, database.NewCacheTimeProvider(0))
+	defer consensusProvider.Storage.Shutdown()
+
+	votes := make(map[string]opinion.Opinion)
+	consensusProvider.Events.TimestampVote.Attach(events.NewClosure(func(messageID string, initialOpinion opinion.Opinion) {
+		votes[messageID] = initialOpinion
+	}))
+	assert.Equal(t, 1, consensusProvider.RequeueTimestampVotes())
+	assert.Equal(t, map[string]opinion.Opinion{pendingMessageID.Base58(): opinion.Dislike}, votes)
+}
@@ -0,0 +1,83 @@
+package fcob
+
+import (
+	"time"
+
+	"github.com/cockroachdb/errors"
+)
+
+const (
+	// GratuitousNetworkDelayStrategyName is the name of the strategy that grades the level of knowledge in steps of
+	// the gratuitous network delay.
+	GratuitousNetworkDelayStrategyName = "gratuitousNetworkDelay"
+	// BinaryStrategyName is the name of the strategy that either votes on a timestamp or considers it final.
+	BinaryStrategyName = "binary"
+)
+
+// ErrInvalidLevelOfKnowledgeStrategy is returned if an unknown level of knowledge strategy is requested.
+var ErrInvalidLevelOfKnowledgeStrategy = errors.New("invalid level of knowledge strategy")
+
+// region LevelOfKnowledgeStrategy /////////////////////////////////////////////////////////////////////////////////////
+
+// LevelOfKnowledgeStrategy defines how certain the node is about its opinion on the timestamp of a message.
+type LevelOfKnowledgeStrategy interface {
+	// Name returns the name of the strategy.
+	Name() string
+	// LevelOfKnowledge returns the level of knowledge of a timestamp opinion, given the distance between the
+	// difference of the timestamp and the arrival time of a message and the border of the timestamp window.
+	LevelOfKnowledge(distanceToWindow, gratuitousNetworkDelay time.Duration) LevelOfKnowledge
+}
+
+// LevelOfKnowledgeStrategyFromString returns the LevelOfKnowledgeStrategy with the given name.
+func LevelOfKnowledgeStrategyFromString(name string) (LevelOfKnowledgeStrategy, error) {
+	switch name {
+	case GratuitousNetworkDelayStrategyName:
+		return GratuitousNetworkDelayStrategy{}, nil
+	case BinaryStrategyName:
+		return BinaryStrategy{}, nil
+	default:
+		return nil, errors.Errorf("failed to parse level of knowledge strategy %s: %w", name, ErrInvalidLevelOfKnowledgeStrategy)
+	}
+}
+
+// GratuitousNetworkDelayStrategy votes on timestamps within one gratuitous network delay of the border of the
+// timestamp window, likes or dislikes timestamps within two gratuitous network delays without voting and considers all
+// other timestamp opinions final.
+type GratuitousNetworkDelayStrategy struct{}
+
+// Name returns the name of the strategy.
+func (GratuitousNetworkDelayStrategy) Name() string {
+	return GratuitousNetworkDelayStrategyName
+}
+
+// LevelOfKnowledge returns the level of knowledge of a timestamp opinion.
+func (GratuitousNetworkDelayStrategy) LevelOfKnowledge(distanceToWindow, gratuitousNetworkDelay time.Duration) LevelOfKnowledge {
+	switch {
+	case distanceToWindow < gratuitousNetworkDelay:
+		return One
+	case distanceToWindow < 2*gratuitousNetworkDelay:
+		return Two
+	default:
+		return Three
+	}
+}
+
+// BinaryStrategy votes on timestamps within one gratuitous network delay of the border of the timestamp window and
+// considers all other timestamp opinions final.
+type BinaryStrategy struct{}
+
+// Name returns the name of the strategy.
+func (BinaryStrategy) Name() string {
+	return BinaryStrategyName
+}
+
+// LevelOfKnowledge returns the level of knowledge of a timestamp opinion.
+func (BinaryStrategy) LevelOfKnowledge(distanceToWindow, gratuitousNetworkDelay time.Duration) LevelOfKnowledge {
+	if distanceToWindow < gratuitousNetworkDelay {
+		return One
+	}
+
+	return Three
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
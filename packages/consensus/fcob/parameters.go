@@ -0,0 +1,54 @@
+package fcob
+
+import (
+	"time"
+
+	"github.com/cockroachdb/errors"
+)
+
+// ErrInvalidParameters is returned if the Parameters of the FCoB rule are inconsistent.
+var ErrInvalidParameters = errors.New("invalid FCoB parameters")
+
+// Parameters define the parameters of the FCoB consensus mechanism.
+type Parameters struct {
+	// LikedThreshold is the first time threshold of FCoB after which a non-conflicting transaction is liked.
+	LikedThreshold time.Duration
+	// LocallyFinalizedThreshold is the second time threshold of FCoB after which the opinion about a non-conflicting
+	// transaction is locally finalized.
+	LocallyFinalizedThreshold time.Duration
+	// TimestampWindow defines the time window for assessing the timestamp quality.
+	TimestampWindow time.Duration
+	// GratuitousNetworkDelay defines the time after which we assume all messages are delivered.
+	GratuitousNetworkDelay time.Duration
+	// LevelOfKnowledgeStrategy maps the arrival time of a message to the level of knowledge of its timestamp opinion.
+	LevelOfKnowledgeStrategy LevelOfKnowledgeStrategy
+}
+
+// DefaultParameters returns the default parameters used in FCoB.
+func DefaultParameters() *Parameters {
+	return &Parameters{
+		LikedThreshold:            2 * time.Second,
+		LocallyFinalizedThreshold: 4 * time.Second,
+		TimestampWindow:           1 * time.Minute,
+		GratuitousNetworkDelay:    15 * time.Second,
+		LevelOfKnowledgeStrategy:  GratuitousNetworkDelayStrategy{},
+	}
+}
+
+// Validate checks that the Parameters are consistent.
+func (p *Parameters) Validate() error {
+	switch {
+	case p.LikedThreshold <= 0:
+		return errors.Errorf("liked threshold must be positive, got %s: %w", p.LikedThreshold, ErrInvalidParameters)
+	case p.LocallyFinalizedThreshold < p.LikedThreshold:
+		return errors.Errorf("locally finalized threshold (%s) must not be smaller than the liked threshold (%s): %w", p.LocallyFinalizedThreshold, p.LikedThreshold, ErrInvalidParameters)
+	case p.TimestampWindow <= 0:
+		return errors.Errorf("timestamp window must be positive, got %s: %w", p.TimestampWindow, ErrInvalidParameters)
+	case p.GratuitousNetworkDelay <= 0:
+		return errors.Errorf("gratuitous network delay must be positive, got %s: %w", p.GratuitousNetworkDelay, ErrInvalidParameters)
+	case p.LevelOfKnowledgeStrategy == nil:
+		return errors.Errorf("missing level of knowledge strategy: %w", ErrInvalidParameters)
+	}
+
+	return nil
+}
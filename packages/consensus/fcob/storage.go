@@ -70,6 +70,32 @@ func (s *Storage) TimestampOpinion(messageID tangle.MessageID) (cachedTimestampO
 	return &CachedTimestampOpinion{CachedObject: s.timestampOpinionStorage.Load(messageID.Bytes())}
 }
 
+// ForEachOpinion iterates through the stored Opinions and calls the consumer for every one of them. The iteration
+// stops if the consumer returns false.
+func (s *Storage) ForEachOpinion(consumer func(opinion *Opinion) bool) {
+	s.opinionStorage.ForEach(func(key []byte, cachedObject objectstorage.CachedObject) (next bool) {
+		next = true
+		(&CachedOpinion{CachedObject: cachedObject}).Consume(func(opinion *Opinion) {
+			next = consumer(opinion)
+		})
+
+		return
+	})
+}
+
+// ForEachTimestampOpinion iterates through the stored TimestampOpinions and calls the consumer for every one of them.
+// The iteration stops if the consumer returns false.
+func (s *Storage) ForEachTimestampOpinion(consumer func(timestampOpinion *TimestampOpinion) bool) {
+	s.timestampOpinionStorage.ForEach(func(key []byte, cachedObject objectstorage.CachedObject) (next bool) {
+		next = true
+		(&CachedTimestampOpinion{CachedObject: cachedObject}).Consume(func(timestampOpinion *TimestampOpinion) {
+			next = consumer(timestampOpinion)
+		})
+
+		return
+	})
+}
+
 // MessageMetadata returns the MessageMetadata associated with given MessageID.
 func (s *Storage) MessageMetadata(messageID tangle.MessageID) (cachedMessageMetadata *CachedMessageMetadata) {
 	return &CachedMessageMetadata{CachedObject: s.messageMetadataStorage.Load(messageID.Bytes())}
@@ -516,6 +542,19 @@ func (t *TimestampOpinion) String() string {
 	)
 }
 
+// SetOpinion sets the value and the level of knowledge of the TimestampOpinion. It returns true if it was modified.
+func (t *TimestampOpinion) SetOpinion(value opinion.Opinion, loK LevelOfKnowledge) (modified bool) {
+	if t.Value == value && t.LoK == loK {
+		return false
+	}
+
+	t.Value = value
+	t.LoK = loK
+	t.SetModified()
+
+	return true
+}
+
 // Update is disabled and panics if it ever gets called - it is required to match the StorableObject interface.
 func (t *TimestampOpinion) Update(objectstorage.StorableObject) {
 	panic("updates disabled")
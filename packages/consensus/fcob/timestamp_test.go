@@ -11,8 +11,9 @@ import (
 )
 
 func TestTimestampQuality(t *testing.T) {
-	TimestampWindow = 1 * time.Minute
-	GratuitousNetworkDelay = 15 * time.Second
+	parameters := DefaultParameters()
+	parameters.TimestampWindow = 1 * time.Minute
+	parameters.GratuitousNetworkDelay = 15 * time.Second
 
 	offset := 200 * time.Millisecond
 
@@ -20,51 +21,94 @@ func TestTimestampQuality(t *testing.T) {
 
 	// Testing Like
 	issuedTime := current.Add(-offset)
-	o := TimestampQuality(tangle.EmptyMessageID, issuedTime, current)
+	o := TimestampQuality(tangle.EmptyMessageID, issuedTime, current, parameters)
 	assert.True(t, o.Equals(&TimestampOpinion{MessageID: tangle.EmptyMessageID, Value: opinion.Like, LoK: Three}))
 
 	issuedTime = current.Add(offset)
-	o = TimestampQuality(tangle.EmptyMessageID, issuedTime, current)
+	o = TimestampQuality(tangle.EmptyMessageID, issuedTime, current, parameters)
 	assert.True(t, o.Equals(&TimestampOpinion{MessageID: tangle.EmptyMessageID, Value: opinion.Like, LoK: Three}))
 
-	issuedTime = current.Add(-offset - (2 * GratuitousNetworkDelay))
-	o = TimestampQuality(tangle.EmptyMessageID, issuedTime, current)
+	issuedTime = current.Add(-offset - (2 * parameters.GratuitousNetworkDelay))
+	o = TimestampQuality(tangle.EmptyMessageID, issuedTime, current, parameters)
 	assert.True(t, o.Equals(&TimestampOpinion{MessageID: tangle.EmptyMessageID, Value: opinion.Like, LoK: Two}))
 
-	issuedTime = current.Add(offset + (2 * GratuitousNetworkDelay))
-	o = TimestampQuality(tangle.EmptyMessageID, issuedTime, current)
+	issuedTime = current.Add(offset + (2 * parameters.GratuitousNetworkDelay))
+	o = TimestampQuality(tangle.EmptyMessageID, issuedTime, current, parameters)
 	assert.True(t, o.Equals(&TimestampOpinion{MessageID: tangle.EmptyMessageID, Value: opinion.Like, LoK: Two}))
 
-	issuedTime = current.Add(-offset - (3 * GratuitousNetworkDelay))
-	o = TimestampQuality(tangle.EmptyMessageID, issuedTime, current)
+	issuedTime = current.Add(-offset - (3 * parameters.GratuitousNetworkDelay))
+	o = TimestampQuality(tangle.EmptyMessageID, issuedTime, current, parameters)
 	assert.True(t, o.Equals(&TimestampOpinion{MessageID: tangle.EmptyMessageID, Value: opinion.Like, LoK: One}))
 
-	issuedTime = current.Add(offset + (3 * GratuitousNetworkDelay))
-	o = TimestampQuality(tangle.EmptyMessageID, issuedTime, current)
+	issuedTime = current.Add(offset + (3 * parameters.GratuitousNetworkDelay))
+	o = TimestampQuality(tangle.EmptyMessageID, issuedTime, current, parameters)
 	assert.True(t, o.Equals(&TimestampOpinion{MessageID: tangle.EmptyMessageID, Value: opinion.Like, LoK: One}))
 
 	// Testing Dislike
-	issuedTime = current.Add(-offset - TimestampWindow)
-	o = TimestampQuality(tangle.EmptyMessageID, issuedTime, current)
+	issuedTime = current.Add(-offset - parameters.TimestampWindow)
+	o = TimestampQuality(tangle.EmptyMessageID, issuedTime, current, parameters)
 	assert.True(t, o.Equals(&TimestampOpinion{MessageID: tangle.EmptyMessageID, Value: opinion.Dislike, LoK: One}))
 
-	issuedTime = current.Add(offset + TimestampWindow)
-	o = TimestampQuality(tangle.EmptyMessageID, issuedTime, current)
+	issuedTime = current.Add(offset + parameters.TimestampWindow)
+	o = TimestampQuality(tangle.EmptyMessageID, issuedTime, current, parameters)
 	assert.True(t, o.Equals(&TimestampOpinion{MessageID: tangle.EmptyMessageID, Value: opinion.Dislike, LoK: One}))
 
-	issuedTime = current.Add(-(GratuitousNetworkDelay) - TimestampWindow)
-	o = TimestampQuality(tangle.EmptyMessageID, issuedTime, current)
+	issuedTime = current.Add(-(parameters.GratuitousNetworkDelay) - parameters.TimestampWindow)
+	o = TimestampQuality(tangle.EmptyMessageID, issuedTime, current, parameters)
 	assert.True(t, o.Equals(&TimestampOpinion{MessageID: tangle.EmptyMessageID, Value: opinion.Dislike, LoK: Two}))
 
-	issuedTime = current.Add(GratuitousNetworkDelay + TimestampWindow)
-	o = TimestampQuality(tangle.EmptyMessageID, issuedTime, current)
+	issuedTime = current.Add(parameters.GratuitousNetworkDelay + parameters.TimestampWindow)
+	o = TimestampQuality(tangle.EmptyMessageID, issuedTime, current, parameters)
 	assert.True(t, o.Equals(&TimestampOpinion{MessageID: tangle.EmptyMessageID, Value: opinion.Dislike, LoK: Two}))
 
-	issuedTime = current.Add(-(2 * GratuitousNetworkDelay) - TimestampWindow)
-	o = TimestampQuality(tangle.EmptyMessageID, issuedTime, current)
+	issuedTime = current.Add(-(2 * parameters.GratuitousNetworkDelay) - parameters.TimestampWindow)
+	o = TimestampQuality(tangle.EmptyMessageID, issuedTime, current, parameters)
 	assert.True(t, o.Equals(&TimestampOpinion{MessageID: tangle.EmptyMessageID, Value: opinion.Dislike, LoK: Three}))
 
-	issuedTime = current.Add((2 * GratuitousNetworkDelay) + TimestampWindow)
-	o = TimestampQuality(tangle.EmptyMessageID, issuedTime, current)
+	issuedTime = current.Add((2 * parameters.GratuitousNetworkDelay) + parameters.TimestampWindow)
+	o = TimestampQuality(tangle.EmptyMessageID, issuedTime, current, parameters)
 	assert.True(t, o.Equals(&TimestampOpinion{MessageID: tangle.EmptyMessageID, Value: opinion.Dislike, LoK: Three}))
 }
+
+func TestTimestampQuality_BinaryStrategy(t *testing.T) {
+	parameters := DefaultParameters()
+	parameters.LevelOfKnowledgeStrategy = BinaryStrategy{}
+
+	current := time.Now()
+
+	o := TimestampQuality(tangle.EmptyMessageID, current.Add(-parameters.TimestampWindow), current, parameters)
+	assert.True(t, o.Equals(&TimestampOpinion{MessageID: tangle.EmptyMessageID, Value: opinion.Dislike, LoK: One}))
+
+	// timestamps that the default strategy likes without voting are final
+	o = TimestampQuality(tangle.EmptyMessageID, current.Add(-parameters.TimestampWindow+parameters.GratuitousNetworkDelay+time.Second), current, parameters)
+	assert.True(t, o.Equals(&TimestampOpinion{MessageID: tangle.EmptyMessageID, Value: opinion.Like, LoK: Three}))
+}
+
+func TestLevelOfKnowledgeStrategyFromString(t *testing.T) {
+	strategy, err := LevelOfKnowledgeStrategyFromString(BinaryStrategyName)
+	assert.NoError(t, err)
+	assert.Equal(t, BinaryStrategyName, strategy.Name())
+
+	_, err = LevelOfKnowledgeStrategyFromString("unknown")
+	assert.ErrorIs(t, err, ErrInvalidLevelOfKnowledgeStrategy)
+}
+
+func TestParameters_Validate(t *testing.T) {
+	assert.NoError(t, DefaultParameters().Validate())
+
+	parameters := DefaultParameters()
+	parameters.LikedThreshold = 100 * time.Millisecond
+	parameters.LocallyFinalizedThreshold = 200 * time.Millisecond
+	assert.NoError(t, parameters.Validate())
+
+	parameters.LocallyFinalizedThreshold = 50 * time.Millisecond
+	assert.ErrorIs(t, parameters.Validate(), ErrInvalidParameters)
+
+	parameters = DefaultParameters()
+	parameters.GratuitousNetworkDelay = 0
+	assert.ErrorIs(t, parameters.Validate(), ErrInvalidParameters)
+
+	parameters = DefaultParameters()
+	parameters.LevelOfKnowledgeStrategy = nil
+	assert.ErrorIs(t, parameters.Validate(), ErrInvalidParameters)
+}
@@ -24,4 +24,13 @@ const (
 
 	// PrefixEpochs defines the storage prefix for the epochs package.
 	PrefixEpochs
+
+	// PrefixDRNG defines the storage prefix for the drng package.
+	PrefixDRNG
+
+	// PrefixStatement defines the storage prefix for the statement package.
+	PrefixStatement
+
+	// PrefixManaRefresher defines the storage prefix for the delegation records of the manarefresher plugin.
+	PrefixManaRefresher
 )
@@ -0,0 +1,443 @@
+package drng
+
+import (
+	"bytes"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/iotaledger/hive.go/byteutils"
+	"github.com/iotaledger/hive.go/cerrors"
+	"github.com/iotaledger/hive.go/kvstore"
+	"github.com/iotaledger/hive.go/marshalutil"
+	"github.com/iotaledger/hive.go/objectstorage"
+	"github.com/iotaledger/hive.go/stringify"
+
+	"github.com/iotaledger/goshimmer/packages/database"
+	"github.com/iotaledger/goshimmer/packages/tangle"
+)
+
+const (
+	// PrefixBeacon defines the storage prefix for the beacons of the BeaconHistory.
+	PrefixBeacon byte = iota
+
+	// MaxHistoryRange defines the maximum amount of rounds that can be retrieved from the BeaconHistory at once.
+	MaxHistoryRange = 1000
+
+	beaconCacheTime = 10 * time.Second
+)
+
+// ErrInvalidHistoryRange is returned if a range of rounds is requested that is empty or too large.
+var ErrInvalidHistoryRange = errors.New("invalid range of rounds")
+
+// region BeaconHistory ////////////////////////////////////////////////////////////////////////////////////////////////
+
+// BeaconHistory is a persistent record of all the verified beacons of the dRNG instances. It keeps track of the
+// latest stored round of every instance to detect the rounds that were never received and it checks that the beacons
+// form a chain (i.e. that the previous signature of a beacon matches the signature of the beacon of the previous round).
+type BeaconHistory struct {
+	beaconStorage *objectstorage.ObjectStorage
+	latestRounds  map[uint32]uint64
+	mutex         sync.Mutex
+	shutdownOnce  sync.Once
+}
+
+// NewBeaconHistory is the constructor of the BeaconHistory.
+func NewBeaconHistory(store kvstore.KVStore, cacheProvider *database.CacheTimeProvider) *BeaconHistory {
+	osFactory := objectstorage.NewFactory(store, database.PrefixDRNG)
+
+	return &BeaconHistory{
+		beaconStorage: osFactory.New(PrefixBeacon, BeaconFromObjectStorage, cacheProvider.CacheTime(beaconCacheTime), BeaconKeyPartition, objectstorage.LeakDetectionEnabled(false)),
+		latestRounds:  make(map[uint32]uint64),
+	}
+}
+
+// StoreBeacon stores the given verified Beacon. It returns the range of rounds that were skipped since the latest
+// stored Beacon of the same instance (nil if there is none) and whether the Beacon breaks the chain of the previous
+// round. Beacons that do not commit to a previous signature (i.e. unchained beacons) never break the chain.
+func (b *BeaconHistory) StoreBeacon(beacon *Beacon) (gap *GapEvent, chainBroken bool) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	latestRound, exists := b.latestRound(beacon.InstanceID())
+
+	cachedBeacon, stored := b.beaconStorage.StoreIfAbsent(beacon)
+	if !stored {
+		return nil, false
+	}
+	cachedBeacon.Release()
+
+	if beacon.Round() > latestRound {
+		b.latestRounds[beacon.InstanceID()] = beacon.Round()
+		if exists && beacon.Round() > latestRound+1 {
+			gap = &GapEvent{
+				InstanceID: beacon.InstanceID(),
+				FirstRound: latestRound + 1,
+				LastRound:  beacon.Round() - 1,
+			}
+		}
+	}
+
+	if beacon.Round() > 0 && !isZero(beacon.PrevSignature()) {
+		b.Beacon(beacon.InstanceID(), beacon.Round()-1).Consume(func(previousBeacon *Beacon) {
+			chainBroken = !bytes.Equal(previousBeacon.Signature(), beacon.PrevSignature())
+		})
+	}
+
+	return gap, chainBroken
+}
+
+// Beacon retrieves the Beacon of the given round of the given instance from the object storage.
+func (b *BeaconHistory) Beacon(instanceID uint32, round uint64) *CachedBeacon {
+	return &CachedBeacon{CachedObject: b.beaconStorage.Load(beaconKey(instanceID, round))}
+}
+
+// Beacons returns the stored Beacons of the given instance between the rounds from and to (both inclusive) ordered
+// by their round. Rounds without a stored Beacon are skipped.
+func (b *BeaconHistory) Beacons(instanceID uint32, from, to uint64) (beacons Beacons, err error) {
+	if from > to || to-from >= MaxHistoryRange {
+		return nil, errors.Errorf("rounds %d to %d (at most %d rounds): %w", from, to, MaxHistoryRange, ErrInvalidHistoryRange)
+	}
+
+	for round := from; ; round++ {
+		b.Beacon(instanceID, round).Consume(func(beacon *Beacon) {
+			beacons = append(beacons, beacon)
+		})
+		if round == to {
+			break
+		}
+	}
+
+	return beacons, nil
+}
+
+// LatestRound returns the latest stored round of the given instance and a flag that indicates if there is any.
+func (b *BeaconHistory) LatestRound(instanceID uint32) (round uint64, exists bool) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	return b.latestRound(instanceID)
+}
+
+// Shutdown shuts down the BeaconHistory and persists its state.
+func (b *BeaconHistory) Shutdown() {
+	b.shutdownOnce.Do(func() {
+		b.beaconStorage.Shutdown()
+	})
+}
+
+// latestRound returns the latest stored round of the given instance. The first call for an instance restores the
+// round from the object storage. It must be called while holding the mutex.
+func (b *BeaconHistory) latestRound(instanceID uint32) (round uint64, exists bool) {
+	if round, exists = b.latestRounds[instanceID]; exists {
+		return round, exists
+	}
+
+	b.beaconStorage.ForEach(func(key []byte, cachedObject objectstorage.CachedObject) bool {
+		(&CachedBeacon{CachedObject: cachedObject}).Consume(func(beacon *Beacon) {
+			if !exists || beacon.Round() > round {
+				round = beacon.Round()
+				exists = true
+			}
+		})
+		return true
+	}, objectstorage.WithIteratorPrefix(marshalutil.New(marshalutil.Uint32Size).WriteUint32(instanceID).Bytes()))
+
+	if exists {
+		b.latestRounds[instanceID] = round
+	}
+
+	return round, exists
+}
+
+// beaconKey returns the storage key of the Beacon of the given round of the given instance.
+func beaconKey(instanceID uint32, round uint64) []byte {
+	return marshalutil.New(marshalutil.Uint32Size + marshalutil.Uint64Size).
+		WriteUint32(instanceID).
+		WriteUint64(round).
+		Bytes()
+}
+
+// isZero returns true if the given signature is empty or only consists of zeros.
+func isZero(signature []byte) bool {
+	for _, b := range signature {
+		if b != 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region GapEvent /////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// GapEvent holds the range of rounds of an instance that were never received.
+type GapEvent struct {
+	// InstanceID of the missing beacons.
+	InstanceID uint32
+	// FirstRound is the first missing round.
+	FirstRound uint64
+	// LastRound is the last missing round.
+	LastRound uint64
+}
+
+// MissingRounds returns the amount of missing rounds.
+func (g *GapEvent) MissingRounds() uint64 {
+	return g.LastRound - g.FirstRound + 1
+}
+
+// String returns a human readable version of the GapEvent.
+func (g *GapEvent) String() string {
+	return stringify.Struct("GapEvent",
+		stringify.StructField("instanceID", g.InstanceID),
+		stringify.StructField("firstRound", g.FirstRound),
+		stringify.StructField("lastRound", g.LastRound),
+	)
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region Beacon ///////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// BeaconKeyPartition defines the partition of the storage key of the Beacon model.
+var BeaconKeyPartition = objectstorage.PartitionKey(marshalutil.Uint32Size, marshalutil.Uint64Size)
+
+// Beacon is the persisted version of a verified collective beacon together with the randomness that was derived from
+// it and the message that contained it.
+type Beacon struct {
+	instanceID    uint32
+	round         uint64
+	timestamp     time.Time
+	messageID     tangle.MessageID
+	prevSignature []byte
+	signature     []byte
+	randomness    []byte
+
+	objectstorage.StorableObjectFlags
+}
+
+// NewBeacon is the constructor of a Beacon.
+func NewBeacon(cb *CollectiveBeaconEvent, randomness []byte) *Beacon {
+	return &Beacon{
+		instanceID:    cb.InstanceID,
+		round:         cb.Round,
+		timestamp:     cb.Timestamp,
+		messageID:     cb.MessageID,
+		prevSignature: cb.PrevSignature,
+		signature:     cb.Signature,
+		randomness:    randomness,
+	}
+}
+
+// BeaconFromBytes unmarshals a Beacon from a sequence of bytes.
+func BeaconFromBytes(bytes []byte) (beacon *Beacon, consumedBytes int, err error) {
+	marshalUtil := marshalutil.New(bytes)
+	if beacon, err = BeaconFromMarshalUtil(marshalUtil); err != nil {
+		err = errors.Errorf("failed to parse Beacon from MarshalUtil: %w", err)
+		return
+	}
+	consumedBytes = marshalUtil.ReadOffset()
+
+	return
+}
+
+// BeaconFromMarshalUtil unmarshals a Beacon using a MarshalUtil (for easier unmarshaling).
+func BeaconFromMarshalUtil(marshalUtil *marshalutil.MarshalUtil) (beacon *Beacon, err error) {
+	beacon = &Beacon{}
+	if beacon.instanceID, err = marshalUtil.ReadUint32(); err != nil {
+		err = errors.Errorf("failed to parse instanceID (%v): %w", err, cerrors.ErrParseBytesFailed)
+		return
+	}
+	if beacon.round, err = marshalUtil.ReadUint64(); err != nil {
+		err = errors.Errorf("failed to parse round (%v): %w", err, cerrors.ErrParseBytesFailed)
+		return
+	}
+	timestamp, err := marshalUtil.ReadInt64()
+	if err != nil {
+		err = errors.Errorf("failed to parse timestamp (%v): %w", err, cerrors.ErrParseBytesFailed)
+		return
+	}
+	beacon.timestamp = time.Unix(0, timestamp)
+	if beacon.messageID, err = tangle.MessageIDFromMarshalUtil(marshalUtil); err != nil {
+		err = errors.Errorf("failed to parse MessageID from MarshalUtil: %w", err)
+		return
+	}
+	if beacon.prevSignature, err = marshalUtil.ReadBytes(SignatureSize); err != nil {
+		err = errors.Errorf("failed to parse previous signature (%v): %w", err, cerrors.ErrParseBytesFailed)
+		return
+	}
+	if beacon.signature, err = marshalUtil.ReadBytes(SignatureSize); err != nil {
+		err = errors.Errorf("failed to parse signature (%v): %w", err, cerrors.ErrParseBytesFailed)
+		return
+	}
+	if beacon.randomness, err = marshalUtil.ReadBytes(RandomnessSize); err != nil {
+		err = errors.Errorf("failed to parse randomness (%v): %w", err, cerrors.ErrParseBytesFailed)
+		return
+	}
+
+	return
+}
+
+// BeaconFromObjectStorage restores a Beacon object that was stored in the ObjectStorage.
+func BeaconFromObjectStorage(key []byte, data []byte) (beacon objectstorage.StorableObject, err error) {
+	if beacon, _, err = BeaconFromBytes(byteutils.ConcatBytes(key, data)); err != nil {
+		err = errors.Errorf("failed to parse Beacon from bytes: %w", err)
+		return
+	}
+
+	return
+}
+
+// InstanceID returns the identifier of the dRNG instance that produced the Beacon.
+func (b *Beacon) InstanceID() uint32 {
+	return b.instanceID
+}
+
+// Round returns the round of the Beacon.
+func (b *Beacon) Round() uint64 {
+	return b.round
+}
+
+// Timestamp returns the issuing time of the message that contained the Beacon.
+func (b *Beacon) Timestamp() time.Time {
+	return b.timestamp
+}
+
+// MessageID returns the identifier of the message that contained the Beacon.
+func (b *Beacon) MessageID() tangle.MessageID {
+	return b.messageID
+}
+
+// PrevSignature returns the collective signature of the previous round.
+func (b *Beacon) PrevSignature() []byte {
+	return b.prevSignature
+}
+
+// Signature returns the collective signature of the round.
+func (b *Beacon) Signature() []byte {
+	return b.signature
+}
+
+// Randomness returns the randomness that was derived from the signature.
+func (b *Beacon) Randomness() []byte {
+	return b.randomness
+}
+
+// Bytes returns a marshaled version of the Beacon.
+func (b *Beacon) Bytes() []byte {
+	return byteutils.ConcatBytes(b.ObjectStorageKey(), b.ObjectStorageValue())
+}
+
+// String returns a human readable version of the Beacon.
+func (b *Beacon) String() string {
+	return stringify.Struct("Beacon",
+		stringify.StructField("instanceID", b.InstanceID()),
+		stringify.StructField("round", b.Round()),
+		stringify.StructField("timestamp", b.Timestamp()),
+		stringify.StructField("messageID", b.MessageID()),
+		stringify.StructField("prevSignature", b.PrevSignature()),
+		stringify.StructField("signature", b.Signature()),
+		stringify.StructField("randomness", b.Randomness()),
+	)
+}
+
+// Update is disabled and panics if it ever gets called - it is required to match the StorableObject interface.
+func (b *Beacon) Update(objectstorage.StorableObject) {
+	panic("updates disabled")
+}
+
+// ObjectStorageKey returns the key that is used to store the object in the database. It is required to match the
+// StorableObject interface.
+func (b *Beacon) ObjectStorageKey() []byte {
+	return beaconKey(b.instanceID, b.round)
+}
+
+// ObjectStorageValue marshals the Beacon into a sequence of bytes. The instanceID and the round are not serialized
+// here as they are only used as a key in the ObjectStorage.
+func (b *Beacon) ObjectStorageValue() []byte {
+	return marshalutil.New(marshalutil.Int64Size + tangle.MessageIDLength + 2*SignatureSize + RandomnessSize).
+		WriteInt64(b.timestamp.UnixNano()).
+		Write(b.messageID).
+		WriteBytes(fixedSize(b.prevSignature, SignatureSize)).
+		WriteBytes(fixedSize(b.signature, SignatureSize)).
+		WriteBytes(fixedSize(b.randomness, RandomnessSize)).
+		Bytes()
+}
+
+// fixedSize returns a copy of the given bytes that is padded with zeros or truncated to the given size.
+func fixedSize(bytes []byte, size int) []byte {
+	result := make([]byte, size)
+	copy(result, bytes)
+
+	return result
+}
+
+// code contract (make sure the type implements all required methods)
+var _ objectstorage.StorableObject = &Beacon{}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region Beacons //////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// Beacons represents a collection of Beacons.
+type Beacons []*Beacon
+
+// String returns a human readable version of the Beacons.
+func (b Beacons) String() string {
+	structBuilder := stringify.StructBuilder("Beacons")
+	for i, beacon := range b {
+		structBuilder.AddField(stringify.StructField(strconv.Itoa(i), beacon))
+	}
+
+	return structBuilder.String()
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region CachedBeacon /////////////////////////////////////////////////////////////////////////////////////////////////
+
+// CachedBeacon is a wrapper for the generic CachedObject returned by the object storage that overrides the accessor
+// methods with a type-casted one.
+type CachedBeacon struct {
+	objectstorage.CachedObject
+}
+
+// Retain marks this CachedObject to still be in use by the program.
+func (c *CachedBeacon) Retain() *CachedBeacon {
+	return &CachedBeacon{c.CachedObject.Retain()}
+}
+
+// Unwrap is the type-casted equivalent of Get. It returns nil if the object does not exist.
+func (c *CachedBeacon) Unwrap() *Beacon {
+	untypedObject := c.Get()
+	if untypedObject == nil {
+		return nil
+	}
+
+	typedObject := untypedObject.(*Beacon)
+	if typedObject == nil || typedObject.IsDeleted() {
+		return nil
+	}
+
+	return typedObject
+}
+
+// Consume unwraps the CachedObject and passes a type-casted version to the consumer. It automatically releases the
+// object when the consumer finishes and returns true of there was at least one object that was consumed.
+func (c *CachedBeacon) Consume(consumer func(beacon *Beacon), forceRelease ...bool) (consumed bool) {
+	return c.CachedObject.Consume(func(object objectstorage.StorableObject) {
+		consumer(object.(*Beacon))
+	}, forceRelease...)
+}
+
+// String returns a human readable version of the CachedBeacon.
+func (c *CachedBeacon) String() string {
+	return stringify.Struct("CachedBeacon",
+		stringify.StructField("CachedObject", c.Unwrap()),
+	)
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
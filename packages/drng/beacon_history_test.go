@@ -0,0 +1,116 @@
+package drng
+
+import (
+	"testing"
+	"time"
+
+	"github.com/iotaledger/hive.go/kvstore/mapdb"
+	"github.com/iotaledger/hive.go/marshalutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/goshimmer/packages/database"
+	"github.com/iotaledger/goshimmer/packages/tangle"
+)
+
+func TestBeacon_Bytes(t *testing.T) {
+	beacon := testBeacon(3, []byte{1}, []byte{2})
+	restoredBeacon, consumedBytes, err := BeaconFromBytes(beacon.Bytes())
+	require.NoError(t, err)
+	assert.Equal(t, len(beacon.Bytes()), consumedBytes)
+	assert.Equal(t, beacon.Bytes(), restoredBeacon.Bytes())
+	assert.Equal(t, beacon.MessageID(), restoredBeacon.MessageID())
+	assert.True(t, beacon.Timestamp().Equal(restoredBeacon.Timestamp()))
+}
+
+func TestBeaconHistory_Gaps(t *testing.T) {
+	store := mapdb.NewMapDB()
+	history := NewBeaconHistory(store, database.NewCacheTimeProvider(0))
+
+	gap, chainBroken := history.StoreBeacon(testBeacon(1, nil, []byte{1}))
+	assert.Nil(t, gap)
+	assert.False(t, chainBroken)
+	gap, chainBroken = history.StoreBeacon(testBeacon(2, []byte{1}, []byte{2}))
+	assert.Nil(t, gap)
+	assert.False(t, chainBroken)
+
+	// rounds 3 and 4 are missing
+	gap, _ = history.StoreBeacon(testBeacon(5, []byte{4}, []byte{5}))
+	assert.Equal(t, &GapEvent{InstanceID: testCommitteeInstanceID, FirstRound: 3, LastRound: 4}, gap)
+	assert.Equal(t, uint64(2), gap.MissingRounds())
+
+	// late beacons fill the gap without reporting a new one
+	gap, _ = history.StoreBeacon(testBeacon(3, []byte{2}, []byte{3}))
+	assert.Nil(t, gap)
+	round, exists := history.LatestRound(testCommitteeInstanceID)
+	assert.True(t, exists)
+	assert.Equal(t, uint64(5), round)
+
+	// the latest round survives a restart
+	history.Shutdown()
+	history = NewBeaconHistory(store, database.NewCacheTimeProvider(0))
+	defer history.Shutdown()
+	gap, _ = history.StoreBeacon(testBeacon(7, nil, []byte{7}))
+	assert.Equal(t, &GapEvent{InstanceID: testCommitteeInstanceID, FirstRound: 6, LastRound: 6}, gap)
+
+	beacons, err := history.Beacons(testCommitteeInstanceID, 0, 10)
+	require.NoError(t, err)
+	var rounds []uint64
+	for _, beacon := range beacons {
+		rounds = append(rounds, beacon.Round())
+	}
+	assert.Equal(t, []uint64{1, 2, 3, 5, 7}, rounds)
+	assert.Equal(t, []byte{5}, beacons[3].Signature()[:1])
+
+	_, err = history.Beacons(testCommitteeInstanceID, 10, 9)
+	assert.ErrorIs(t, err, ErrInvalidHistoryRange)
+	_, err = history.Beacons(testCommitteeInstanceID, 0, MaxHistoryRange)
+	assert.ErrorIs(t, err, ErrInvalidHistoryRange)
+}
+
+func TestBeaconHistory_ChainMismatch(t *testing.T) {
+	history := NewBeaconHistory(mapdb.NewMapDB(), database.NewCacheTimeProvider(0))
+	defer history.Shutdown()
+
+	history.StoreBeacon(testBeacon(1, nil, []byte{1}))
+	_, chainBroken := history.StoreBeacon(testBeacon(2, []byte{9}, []byte{2}))
+	assert.True(t, chainBroken)
+
+	// unchained beacons do not commit to the previous round
+	_, chainBroken = history.StoreBeacon(testBeacon(3, nil, []byte{3}))
+	assert.False(t, chainBroken)
+}
+
+func TestDispatcher_BeaconHistory(t *testing.T) {
+	marshalUtil := marshalutil.New(testPayload().Bytes())
+	parsedPayload, err := PayloadFromMarshalUtil(marshalUtil)
+	require.NoError(t, err)
+	config := make(map[uint32][]Option)
+	config[1] = []Option{SetCommittee(committeeTest)}
+
+	drng := New(config)
+	history := NewBeaconHistory(mapdb.NewMapDB(), database.NewCacheTimeProvider(0))
+	defer history.Shutdown()
+	drng.SetBeaconHistory(history)
+
+	messageID := tangle.MessageID{7}
+	require.NoError(t, drng.Dispatch(messageID, issuerPK, timestampTest, parsedPayload))
+
+	assert.True(t, history.Beacon(1, 1).Consume(func(beacon *Beacon) {
+		assert.Equal(t, messageID, beacon.MessageID())
+		assert.Equal(t, signatureTest, beacon.Signature())
+		assert.Equal(t, prevSignatureTest, beacon.PrevSignature())
+		assert.Equal(t, randomnessTest.Randomness, beacon.Randomness())
+	}))
+}
+
+func testBeacon(round uint64, prevSignature, signature []byte) *Beacon {
+	return NewBeacon(&CollectiveBeaconEvent{
+		Timestamp:     time.Unix(1000+int64(round), 0),
+		MessageID:     tangle.MessageID{byte(round)},
+		InstanceID:    testCommitteeInstanceID,
+		Round:         round,
+		PrevSignature: fixedSize(prevSignature, SignatureSize),
+		Signature:     fixedSize(signature, SignatureSize),
+	}, make([]byte, RandomnessSize))
+}
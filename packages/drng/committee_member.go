@@ -0,0 +1,740 @@
+package drng
+
+import (
+	"sync"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/drand/drand/chain"
+	"github.com/drand/drand/key"
+	"github.com/drand/kyber"
+	"github.com/drand/kyber/encrypt/ecies"
+	"github.com/drand/kyber/share"
+	"github.com/drand/kyber/util/random"
+	"github.com/iotaledger/hive.go/crypto/ed25519"
+	"github.com/iotaledger/hive.go/events"
+
+	"github.com/iotaledger/goshimmer/packages/tangle/payload"
+)
+
+var (
+	// ErrNotCommitteeMember is returned if a Member is created for a node that is not part of the committee.
+	ErrNotCommitteeMember = errors.New("node is not a member of the committee")
+	// ErrInvalidThreshold is returned if the threshold can not be reached by the committee.
+	ErrInvalidThreshold = errors.New("invalid threshold")
+	// ErrInvalidDeal is returned if a deal of the distributed key generation is invalid.
+	ErrInvalidDeal = errors.New("invalid DKG deal")
+	// ErrInvalidPartialSignature is returned if a partial signature does not verify.
+	ErrInvalidPartialSignature = errors.New("invalid partial signature")
+)
+
+// unchainedPrevSignature is used as previous signature of every beacon issued by a Member. The beacons are not chained,
+// so that a round without enough partial signatures does not stall the following rounds.
+var unchainedPrevSignature = make([]byte, SignatureSize)
+
+// region Member ///////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// Member is a member of a dRNG committee that runs the distributed key generation with the other members and then
+// collectively signs one beacon per period. All messages are exchanged as dRNG payloads, so that they can be sent over
+// any channel that broadcasts payloads to all members, e.g. the tangle. Such a channel is public: the shares of a deal
+// are only readable by their recipient, as they are encrypted with the announced key of the recipient, and the
+// payloads must be authenticated by the channel, e.g. by the signature of the issuer of a message.
+//
+// The distributed key generation is a Joint-Feldman protocol that requires all committee members to participate:
+// every member announces a key, then deals the shares of a random polynomial, encrypted with the announced keys of
+// their recipients, and finally sums up the shares it received. A member that receives an invalid deal broadcasts a
+// complaint, and every member excludes the deals with complaints. Once every deal was either accepted or excluded and
+// no complaint arrived for one period, the remaining deals are combined, provided that at least a threshold of them is
+// valid. After that, a threshold of the members is sufficient to produce a beacon.
+type Member struct {
+	// Events contains the events triggered by the Member.
+	Events *MemberEvents
+
+	instanceID uint32
+	committee  []ed25519.PublicKey
+	index      int
+	threshold  int
+	period     time.Duration
+	broadcast  func(payload.Payload) error
+
+	dkgSecret    kyber.Scalar
+	dkgKey       []byte
+	keys         map[int]kyber.Point
+	deal         *DKGDeal
+	pubPolys     map[int]*share.PubPoly
+	shares       map[int]*share.PriShare
+	complaints   map[int]bool
+	disqualified map[int]bool
+	dkgUpdated   time.Time
+	lastTick     time.Time
+	lastResend   time.Time
+	resend       bool
+	resendKey    bool
+
+	share   *share.PriShare
+	pubPoly *share.PubPoly
+	dpk     []byte
+
+	lastSignedRound uint64
+	partials        map[uint64]map[int][]byte
+	recovered       map[uint64]*CollectiveBeaconPayload
+	issued          map[uint64]bool
+	observedRound   uint64
+
+	deferred []func()
+	mutex    sync.Mutex
+}
+
+// NewMember creates a new Member of the given committee. The beacons are produced every period and are issued using
+// the broadcast function, which is also used for all messages of the distributed key generation.
+func NewMember(instanceID uint32, committee []ed25519.PublicKey, threshold int, identity ed25519.PublicKey, period time.Duration, broadcast func(payload.Payload) error) (*Member, error) {
+	index := -1
+	for i, member := range committee {
+		if member == identity {
+			index = i
+		}
+	}
+	if index < 0 {
+		return nil, errors.Errorf("failed to create member %s: %w", identity, ErrNotCommitteeMember)
+	}
+	if threshold < 1 || threshold > len(committee) {
+		return nil, errors.Errorf("threshold %d not in [1,%d]: %w", threshold, len(committee), ErrInvalidThreshold)
+	}
+
+	dkgSecret := key.KeyGroup.Scalar().Pick(random.New())
+	dkgKey, err := key.KeyGroup.Point().Mul(dkgSecret, nil).MarshalBinary()
+	if err != nil {
+		return nil, errors.Errorf("failed to marshal DKG key: %w", err)
+	}
+
+	return &Member{
+		Events:       newMemberEvents(),
+		instanceID:   instanceID,
+		committee:    committee,
+		index:        index,
+		threshold:    threshold,
+		period:       period,
+		broadcast:    broadcast,
+		dkgSecret:    dkgSecret,
+		dkgKey:       dkgKey,
+		keys:         make(map[int]kyber.Point),
+		pubPolys:     make(map[int]*share.PubPoly),
+		shares:       make(map[int]*share.PriShare),
+		complaints:   make(map[int]bool),
+		disqualified: make(map[int]bool),
+		partials:     make(map[uint64]map[int][]byte),
+		recovered:    make(map[uint64]*CollectiveBeaconPayload),
+		issued:       make(map[uint64]bool),
+	}, nil
+}
+
+// Index returns the index of the Member in the committee.
+func (m *Member) Index() int {
+	return m.index
+}
+
+// DistributedPublicKey returns the distributed public key of the committee or nil if the key generation is not
+// completed yet.
+func (m *Member) DistributedPublicKey() []byte {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	return m.dpk
+}
+
+// Tick advances the Member to the given time. Before the key generation is completed, it periodically announces its key
+// and deal, afterwards it signs the beacon of every new round.
+func (m *Member) Tick(now time.Time) {
+	m.mutex.Lock()
+	defer m.flush()
+	defer m.mutex.Unlock()
+
+	m.lastTick = now
+	if m.dpk == nil || m.resend {
+		if now.Sub(m.lastResend) >= m.period {
+			m.announce(now)
+		}
+		if m.dpk == nil {
+			if !m.keyGenerationSettled(now) {
+				return
+			}
+			if err := m.completeKeyGeneration(); err != nil {
+				m.deferred = append(m.deferred, func() {
+					m.Events.Error.Trigger(err)
+				})
+				return
+			}
+		}
+	}
+
+	round := m.roundAt(now)
+	if round > m.lastSignedRound {
+		m.lastSignedRound = round
+		m.signRound(round)
+	}
+
+	for recoveredRound, beacon := range m.recovered {
+		switch {
+		case recoveredRound+2 < round:
+			delete(m.recovered, recoveredRound)
+			delete(m.issued, recoveredRound)
+		case recoveredRound < round && recoveredRound > m.observedRound && !m.issued[recoveredRound] && m.isBackupIssuer(recoveredRound):
+			// the leader of the round did not issue the beacon in time
+			m.issue(beacon)
+		}
+	}
+	for partialRound := range m.partials {
+		if partialRound+2 < round {
+			delete(m.partials, partialRound)
+		}
+	}
+}
+
+// HandlePayload processes a dRNG payload issued by the given node.
+func (m *Member) HandlePayload(issuer ed25519.PublicKey, p *Payload) error {
+	if p.InstanceID != m.instanceID {
+		return nil
+	}
+	issuerIndex := m.memberIndex(issuer)
+	if issuerIndex < 0 {
+		return errors.Errorf("payload issued by %s: %w", issuer, ErrInvalidIssuer)
+	}
+
+	m.mutex.Lock()
+	defer m.flush()
+	defer m.mutex.Unlock()
+
+	switch p.PayloadType {
+	case TypeDKGKey:
+		dkgKey, err := DKGKeyFromPayload(p)
+		if err != nil {
+			return err
+		}
+		return m.handleKey(issuerIndex, dkgKey)
+	case TypeDKGDeal:
+		deal, err := DKGDealFromPayload(p)
+		if err != nil {
+			return err
+		}
+		return m.handleDeal(issuerIndex, deal)
+	case TypeDKGComplaint:
+		complaint, err := DKGComplaintFromPayload(p)
+		if err != nil {
+			return err
+		}
+		return m.handleComplaint(complaint)
+	case TypePartialSignature:
+		partialSignature, err := PartialSignatureFromPayload(p)
+		if err != nil {
+			return err
+		}
+		return m.handlePartialSignature(issuerIndex, partialSignature)
+	case TypeCollectiveBeacon:
+		beacon, _, err := CollectiveBeaconPayloadFromBytes(p.Bytes())
+		if err != nil {
+			return err
+		}
+		if beacon.Round > m.observedRound {
+			m.observedRound = beacon.Round
+		}
+		return nil
+	default:
+		return nil
+	}
+}
+
+// KeyShare returns the result of the distributed key generation, so that it can be persisted and restored after a
+// restart, or nil if the key generation is not completed yet.
+func (m *Member) KeyShare() (*DistributedKeyShare, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if m.dpk == nil {
+		return nil, nil
+	}
+	shareBytes, err := m.share.V.MarshalBinary()
+	if err != nil {
+		return nil, errors.Errorf("failed to marshal share: %w", err)
+	}
+	keyShare := &DistributedKeyShare{
+		InstanceID:      m.instanceID,
+		CommitteeDigest: committeeDigest(m.committee, m.threshold),
+		Index:           uint8(m.index),
+		Share:           shareBytes,
+	}
+	_, commits := m.pubPoly.Info()
+	for _, commit := range commits {
+		commitment, err := commit.MarshalBinary()
+		if err != nil {
+			return nil, errors.Errorf("failed to marshal commitment: %w", err)
+		}
+		keyShare.Commitments = append(keyShare.Commitments, commitment)
+	}
+
+	return keyShare, nil
+}
+
+// RestoreKeyShare restores the result of a previous distributed key generation of the same committee, so that the
+// Member continues to sign beacons with the same distributed key instead of announcing a new key generation.
+func (m *Member) RestoreKeyShare(keyShare *DistributedKeyShare) error {
+	if keyShare.InstanceID != m.instanceID || keyShare.CommitteeDigest != committeeDigest(m.committee, m.threshold) {
+		return errors.Errorf("key share of instance %d was generated for a different committee: %w", keyShare.InstanceID, ErrInvalidKeyShare)
+	}
+	if int(keyShare.Index) != m.index {
+		return errors.Errorf("key share belongs to member %d instead of %d: %w", keyShare.Index, m.index, ErrInvalidKeyShare)
+	}
+	if len(keyShare.Commitments) != m.threshold {
+		return errors.Errorf("key share contains %d commitments instead of %d: %w", len(keyShare.Commitments), m.threshold, ErrInvalidKeyShare)
+	}
+
+	commits := make([]kyber.Point, len(keyShare.Commitments))
+	for i, commitment := range keyShare.Commitments {
+		commits[i] = key.KeyGroup.Point()
+		if err := commits[i].UnmarshalBinary(commitment); err != nil {
+			return errors.Errorf("failed to parse commitment (%v): %w", err, ErrInvalidKeyShare)
+		}
+	}
+	pubPoly := share.NewPubPoly(key.KeyGroup, key.KeyGroup.Point().Base(), commits)
+	priShare := &share.PriShare{I: m.index, V: key.KeyGroup.Scalar()}
+	if err := priShare.V.UnmarshalBinary(keyShare.Share); err != nil {
+		return errors.Errorf("failed to parse share (%v): %w", err, ErrInvalidKeyShare)
+	}
+	if !pubPoly.Check(priShare) {
+		return errors.Errorf("share does not match its commitments: %w", ErrInvalidKeyShare)
+	}
+	dpk, err := pubPoly.Commit().MarshalBinary()
+	if err != nil {
+		return errors.Errorf("failed to marshal distributed public key: %w", err)
+	}
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	m.share = priShare
+	m.pubPoly = pubPoly
+	m.dpk = dpk
+	return nil
+}
+
+// DKGState returns the state of the running distributed key generation, so that it can be persisted and restored after
+// a restart, or nil if the key generation is completed.
+func (m *Member) DKGState() (*DKGState, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if m.dpk != nil {
+		return nil, nil
+	}
+	secret, err := m.dkgSecret.MarshalBinary()
+	if err != nil {
+		return nil, errors.Errorf("failed to marshal DKG secret: %w", err)
+	}
+
+	return &DKGState{
+		InstanceID:      m.instanceID,
+		CommitteeDigest: committeeDigest(m.committee, m.threshold),
+		Index:           uint8(m.index),
+		Secret:          secret,
+		Deal:            m.deal,
+	}, nil
+}
+
+// RestoreDKGState restores the state of a distributed key generation of the same committee that was interrupted by a
+// restart, so that the Member continues with the key and deal it announced before. It must be called before the Member
+// is advanced for the first time.
+func (m *Member) RestoreDKGState(state *DKGState) error {
+	if state.InstanceID != m.instanceID || state.CommitteeDigest != committeeDigest(m.committee, m.threshold) {
+		return errors.Errorf("DKG state of instance %d belongs to a different committee: %w", state.InstanceID, ErrInvalidDKGState)
+	}
+	if int(state.Index) != m.index {
+		return errors.Errorf("DKG state belongs to member %d instead of %d: %w", state.Index, m.index, ErrInvalidDKGState)
+	}
+
+	dkgSecret := key.KeyGroup.Scalar()
+	if err := dkgSecret.UnmarshalBinary(state.Secret); err != nil {
+		return errors.Errorf("failed to parse DKG secret (%v): %w", err, ErrInvalidDKGState)
+	}
+	dkgKey, err := key.KeyGroup.Point().Mul(dkgSecret, nil).MarshalBinary()
+	if err != nil {
+		return errors.Errorf("failed to marshal DKG key: %w", err)
+	}
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if state.Deal != nil {
+		pubPoly, priShare, err := m.verifyDeal(m.index, state.Deal, dkgSecret)
+		if err != nil {
+			return errors.Errorf("failed to restore deal (%v): %w", err, ErrInvalidDKGState)
+		}
+		m.pubPolys[m.index] = pubPoly
+		m.shares[m.index] = priShare
+	}
+	m.dkgSecret = dkgSecret
+	m.dkgKey = dkgKey
+	m.deal = state.Deal
+	return nil
+}
+
+// announce broadcasts the key of the Member, as long as it is needed by others, its deal, if it was created already,
+// and its complaints.
+func (m *Member) announce(now time.Time) {
+	if m.dpk == nil || m.resendKey {
+		m.send(NewDKGKey(m.instanceID, m.dkgKey).Payload())
+	}
+	m.lastResend = now
+	m.resend = false
+	m.resendKey = false
+	if m.deal != nil {
+		m.send(m.deal.Payload())
+	}
+	for dealer := range m.complaints {
+		m.send(NewDKGComplaint(m.instanceID, uint8(dealer)).Payload())
+	}
+}
+
+func (m *Member) handleKey(issuerIndex int, dkgKey *DKGKey) error {
+	if _, exists := m.keys[issuerIndex]; exists {
+		// the member did not complete the key generation and might still be waiting for our deal
+		if issuerIndex != m.index {
+			m.resend = true
+			// our key is only needed by members that did not create their deal yet, so that members that completed the
+			// key generation do not trigger each other's resends
+			if _, dealReceived := m.pubPolys[issuerIndex]; !dealReceived {
+				m.resendKey = true
+			}
+		}
+		return nil
+	}
+
+	point := key.KeyGroup.Point()
+	if err := point.UnmarshalBinary(dkgKey.Key); err != nil {
+		return errors.Errorf("failed to parse DKG key of member %d (%v): %w", issuerIndex, err, ErrInvalidCommitteePayload)
+	}
+	m.keys[issuerIndex] = point
+
+	// a Member that restored its key share must not deal again, as its new deal would not match the distributed key
+	if m.deal == nil && m.dpk == nil && len(m.keys) == len(m.committee) {
+		return m.createDeal()
+	}
+	return nil
+}
+
+// createDeal creates the deal of the Member once the keys of all members are known.
+func (m *Member) createDeal() error {
+	priPoly := share.NewPriPoly(key.KeyGroup, m.threshold, key.KeyGroup.Scalar().Pick(random.New()), random.New())
+	_, commits := priPoly.Commit(key.KeyGroup.Point().Base()).Info()
+
+	deal := &DKGDeal{InstanceID: m.instanceID}
+	for _, commit := range commits {
+		commitment, err := commit.MarshalBinary()
+		if err != nil {
+			return errors.Errorf("failed to marshal commitment: %w", err)
+		}
+		deal.Commitments = append(deal.Commitments, commitment)
+	}
+	for i := range m.committee {
+		value, err := priPoly.Eval(i).V.MarshalBinary()
+		if err != nil {
+			return errors.Errorf("failed to marshal share: %w", err)
+		}
+		ciphertext, err := ecies.Encrypt(key.KeyGroup, m.keys[i], value, nil)
+		if err != nil {
+			return errors.Errorf("failed to encrypt share of member %d: %w", i, err)
+		}
+		deal.Shares = append(deal.Shares, &EncryptedShare{Index: uint8(i), Ciphertext: ciphertext})
+	}
+
+	m.deal = deal
+	// the deal is persisted before it is broadcast, so that a restarted Member does not deal a different polynomial
+	m.deferred = append(m.deferred, func() {
+		m.Events.DealCreated.Trigger(deal)
+	})
+	m.send(deal.Payload())
+	return m.handleDeal(m.index, deal)
+}
+
+func (m *Member) handleDeal(issuerIndex int, deal *DKGDeal) error {
+	if _, exists := m.pubPolys[issuerIndex]; exists || m.disqualified[issuerIndex] {
+		return nil
+	}
+
+	pubPoly, priShare, err := m.verifyDeal(issuerIndex, deal, m.dkgSecret)
+	if err != nil {
+		// a completed key generation no longer depends on the deals, e.g. a Member that restored its key share can not
+		// decrypt the shares dealt to its previous key
+		if m.dpk != nil {
+			return nil
+		}
+		m.complaints[issuerIndex] = true
+		m.send(NewDKGComplaint(m.instanceID, uint8(issuerIndex)).Payload())
+		m.disqualify(issuerIndex)
+		return err
+	}
+
+	m.pubPolys[issuerIndex] = pubPoly
+	m.shares[issuerIndex] = priShare
+	m.dkgUpdated = m.lastTick
+	return nil
+}
+
+// verifyDeal decrypts the share of the Member with the given DKG secret and checks it against the commitments of the
+// deal.
+func (m *Member) verifyDeal(issuerIndex int, deal *DKGDeal, dkgSecret kyber.Scalar) (*share.PubPoly, *share.PriShare, error) {
+	if len(deal.Commitments) != m.threshold {
+		return nil, nil, errors.Errorf("deal of member %d contains %d commitments instead of %d: %w", issuerIndex, len(deal.Commitments), m.threshold, ErrInvalidDeal)
+	}
+
+	commits := make([]kyber.Point, len(deal.Commitments))
+	for i, commitment := range deal.Commitments {
+		commits[i] = key.KeyGroup.Point()
+		if err := commits[i].UnmarshalBinary(commitment); err != nil {
+			return nil, nil, errors.Errorf("failed to parse commitment of member %d (%v): %w", issuerIndex, err, ErrInvalidDeal)
+		}
+	}
+	pubPoly := share.NewPubPoly(key.KeyGroup, key.KeyGroup.Point().Base(), commits)
+
+	encryptedShare := deal.Share(uint8(m.index))
+	if encryptedShare == nil {
+		return nil, nil, errors.Errorf("deal of member %d contains no share for member %d: %w", issuerIndex, m.index, ErrInvalidDeal)
+	}
+	value, err := ecies.Decrypt(key.KeyGroup, dkgSecret, encryptedShare.Ciphertext, nil)
+	if err != nil {
+		return nil, nil, errors.Errorf("failed to decrypt share of member %d (%v): %w", issuerIndex, err, ErrInvalidDeal)
+	}
+	priShare := &share.PriShare{I: m.index, V: key.KeyGroup.Scalar()}
+	if err = priShare.V.UnmarshalBinary(value); err != nil {
+		return nil, nil, errors.Errorf("failed to parse share of member %d (%v): %w", issuerIndex, err, ErrInvalidDeal)
+	}
+	if !pubPoly.Check(priShare) {
+		return nil, nil, errors.Errorf("share of member %d does not match its commitments: %w", issuerIndex, ErrInvalidDeal)
+	}
+
+	return pubPoly, priShare, nil
+}
+
+func (m *Member) handleComplaint(complaint *DKGComplaint) error {
+	if int(complaint.Dealer) >= len(m.committee) {
+		return errors.Errorf("complaint about unknown member %d: %w", complaint.Dealer, ErrInvalidCommitteePayload)
+	}
+	if m.dpk == nil {
+		m.disqualify(int(complaint.Dealer))
+	}
+	return nil
+}
+
+// disqualify excludes the deal of the given member from the distributed key.
+func (m *Member) disqualify(dealer int) {
+	if m.disqualified[dealer] {
+		return
+	}
+	m.disqualified[dealer] = true
+	m.dkgUpdated = m.lastTick
+}
+
+// keyGenerationSettled returns true if the deal of every member was either accepted or excluded, at least a threshold of
+// the deals was accepted and no deal was accepted or excluded for one period, so that the complaints of the other
+// members had the time to arrive.
+func (m *Member) keyGenerationSettled(now time.Time) bool {
+	if now.Sub(m.dkgUpdated) < m.period {
+		return false
+	}
+
+	accepted := 0
+	for i := range m.committee {
+		if m.disqualified[i] {
+			continue
+		}
+		if _, exists := m.shares[i]; !exists {
+			return false
+		}
+		accepted++
+	}
+	return accepted >= m.threshold
+}
+
+// completeKeyGeneration combines the shares and commitments of all accepted deals.
+func (m *Member) completeKeyGeneration() (err error) {
+	distributedShare := &share.PriShare{I: m.index, V: key.KeyGroup.Scalar().Zero()}
+	var distributedPubPoly *share.PubPoly
+	for i := range m.committee {
+		if m.disqualified[i] {
+			continue
+		}
+		distributedShare.V.Add(distributedShare.V, m.shares[i].V)
+		if distributedPubPoly == nil {
+			distributedPubPoly = m.pubPolys[i]
+			continue
+		}
+		if distributedPubPoly, err = distributedPubPoly.Add(m.pubPolys[i]); err != nil {
+			return errors.Errorf("failed to combine commitments: %w", err)
+		}
+	}
+
+	dpk, err := distributedPubPoly.Commit().MarshalBinary()
+	if err != nil {
+		return errors.Errorf("failed to marshal distributed public key: %w", err)
+	}
+	m.share = distributedShare
+	m.pubPoly = distributedPubPoly
+	m.dpk = dpk
+
+	m.deferred = append(m.deferred, func() {
+		m.Events.KeyGenerationCompleted.Trigger(dpk)
+	})
+	return nil
+}
+
+// signRound broadcasts the partial signature of the Member for the given round.
+func (m *Member) signRound(round uint64) {
+	signature, err := key.Scheme.Sign(m.share, chain.Message(round, unchainedPrevSignature))
+	if err != nil {
+		m.deferred = append(m.deferred, func() {
+			m.Events.Error.Trigger(errors.Errorf("failed to sign round %d: %w", round, err))
+		})
+		return
+	}
+
+	partialSignature := NewPartialSignature(m.instanceID, round, signature)
+	m.send(partialSignature.Payload())
+	if err = m.handlePartialSignature(m.index, partialSignature); err != nil {
+		m.deferred = append(m.deferred, func() {
+			m.Events.Error.Trigger(err)
+		})
+	}
+}
+
+func (m *Member) handlePartialSignature(issuerIndex int, partialSignature *PartialSignature) error {
+	// the partial signatures can only be verified once the key generation is completed
+	if m.pubPoly == nil {
+		return nil
+	}
+	if _, exists := m.recovered[partialSignature.Round]; exists {
+		return nil
+	}
+	if signerIndex, err := key.Scheme.IndexOf(partialSignature.Signature); err != nil || signerIndex != issuerIndex {
+		return errors.Errorf("partial signature of member %d has the index of another member: %w", issuerIndex, ErrInvalidPartialSignature)
+	}
+	msg := chain.Message(partialSignature.Round, unchainedPrevSignature)
+	if err := key.Scheme.VerifyPartial(m.pubPoly, msg, partialSignature.Signature); err != nil {
+		return errors.Errorf("partial signature of member %d for round %d (%v): %w", issuerIndex, partialSignature.Round, err, ErrInvalidPartialSignature)
+	}
+
+	if _, exists := m.partials[partialSignature.Round]; !exists {
+		m.partials[partialSignature.Round] = make(map[int][]byte)
+	}
+	m.partials[partialSignature.Round][issuerIndex] = partialSignature.Signature
+	if len(m.partials[partialSignature.Round]) < m.threshold {
+		return nil
+	}
+
+	signatures := make([][]byte, 0, len(m.partials[partialSignature.Round]))
+	for _, signature := range m.partials[partialSignature.Round] {
+		signatures = append(signatures, signature)
+	}
+	signature, err := key.Scheme.Recover(m.pubPoly, msg, signatures, m.threshold, len(m.committee))
+	if err != nil {
+		return errors.Errorf("failed to recover signature of round %d: %w", partialSignature.Round, err)
+	}
+	delete(m.partials, partialSignature.Round)
+
+	beacon := NewCollectiveBeaconPayload(m.instanceID, partialSignature.Round, unchainedPrevSignature, signature, m.dpk)
+	m.recovered[partialSignature.Round] = beacon
+	m.deferred = append(m.deferred, func() {
+		m.Events.Beacon.Trigger(beacon)
+	})
+	if m.isLeader(partialSignature.Round) {
+		m.issue(beacon)
+	}
+	return nil
+}
+
+// issue broadcasts the given beacon.
+func (m *Member) issue(beacon *CollectiveBeaconPayload) {
+	m.issued[beacon.Round] = true
+	m.send(beacon)
+}
+
+// send broadcasts the given payload once the lock of the Member is released.
+func (m *Member) send(p payload.Payload) {
+	m.deferred = append(m.deferred, func() {
+		if err := m.broadcast(p); err != nil {
+			m.Events.Error.Trigger(errors.Errorf("failed to broadcast dRNG payload: %w", err))
+		}
+	})
+}
+
+// flush executes the broadcasts and events that were deferred while holding the lock.
+func (m *Member) flush() {
+	m.mutex.Lock()
+	deferred := m.deferred
+	m.deferred = nil
+	m.mutex.Unlock()
+
+	for _, f := range deferred {
+		f()
+	}
+}
+
+// roundAt returns the beacon round of the given time.
+func (m *Member) roundAt(t time.Time) uint64 {
+	return uint64(t.UnixNano() / int64(m.period))
+}
+
+// isLeader returns true if the Member issues the beacon of the given round.
+func (m *Member) isLeader(round uint64) bool {
+	return int(round%uint64(len(m.committee))) == m.index
+}
+
+// isBackupIssuer returns true if the Member issues the beacon of the given round when its leader did not.
+func (m *Member) isBackupIssuer(round uint64) bool {
+	return int((round+1)%uint64(len(m.committee))) == m.index
+}
+
+// memberIndex returns the index of the given node in the committee or -1 if it is not a member.
+func (m *Member) memberIndex(identity ed25519.PublicKey) int {
+	for i, member := range m.committee {
+		if member == identity {
+			return i
+		}
+	}
+	return -1
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region MemberEvents /////////////////////////////////////////////////////////////////////////////////////////////////
+
+// MemberEvents holds the events triggered by a Member.
+type MemberEvents struct {
+	// DealCreated is triggered with the deal of the Member before it is broadcast.
+	DealCreated *events.Event
+	// KeyGenerationCompleted is triggered with the distributed public key once the key generation is completed.
+	KeyGenerationCompleted *events.Event
+	// Beacon is triggered each time the Member recovered the collective signature of a round.
+	Beacon *events.Event
+	// Error is triggered if the Member fails to sign or broadcast.
+	Error *events.Event
+}
+
+func newMemberEvents() *MemberEvents {
+	return &MemberEvents{
+		DealCreated:            events.NewEvent(dkgDealCaller),
+		KeyGenerationCompleted: events.NewEvent(distributedPublicKeyCaller),
+		Beacon:                 events.NewEvent(collectiveBeaconPayloadCaller),
+		Error:                  events.NewEvent(events.ErrorCaller),
+	}
+}
+
+func dkgDealCaller(handler interface{}, params ...interface{}) {
+	handler.(func(*DKGDeal))(params[0].(*DKGDeal))
+}
+
+func distributedPublicKeyCaller(handler interface{}, params ...interface{}) {
+	handler.(func([]byte))(params[0].([]byte))
+}
+
+func collectiveBeaconPayloadCaller(handler interface{}, params ...interface{}) {
+	handler.(func(*CollectiveBeaconPayload))(params[0].(*CollectiveBeaconPayload))
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -0,0 +1,385 @@
+package drng
+
+import (
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/drand/drand/key"
+	"github.com/drand/kyber/share"
+	"github.com/drand/kyber/util/random"
+	"github.com/iotaledger/hive.go/crypto/ed25519"
+	"github.com/iotaledger/hive.go/events"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/goshimmer/packages/tangle/payload"
+)
+
+const testCommitteeInstanceID = 42
+
+func TestNewMember(t *testing.T) {
+	committee := []ed25519.PublicKey{ed25519.GenerateKeyPair().PublicKey, ed25519.GenerateKeyPair().PublicKey}
+
+	_, err := NewMember(testCommitteeInstanceID, committee, 2, ed25519.GenerateKeyPair().PublicKey, time.Second, nil)
+	assert.ErrorIs(t, err, ErrNotCommitteeMember)
+	_, err = NewMember(testCommitteeInstanceID, committee, 3, committee[0], time.Second, nil)
+	assert.ErrorIs(t, err, ErrInvalidThreshold)
+
+	member, err := NewMember(testCommitteeInstanceID, committee, 2, committee[1], time.Second, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 1, member.Index())
+	assert.Nil(t, member.DistributedPublicKey())
+}
+
+func TestCommitteePayloads(t *testing.T) {
+	dkgKey := NewDKGKey(testCommitteeInstanceID, make([]byte, PublicKeySize))
+	parsedKey, err := DKGKeyFromPayload(parsePayload(t, dkgKey.Payload()))
+	require.NoError(t, err)
+	assert.Equal(t, dkgKey, parsedKey)
+
+	deal := &DKGDeal{
+		InstanceID:  testCommitteeInstanceID,
+		Commitments: [][]byte{make([]byte, PublicKeySize), make([]byte, PublicKeySize)},
+		Shares:      []*EncryptedShare{{Index: 0, Ciphertext: []byte{1, 2, 3}}, {Index: 1, Ciphertext: []byte{4, 5}}},
+	}
+	parsedDeal, err := DKGDealFromPayload(parsePayload(t, deal.Payload()))
+	require.NoError(t, err)
+	assert.Equal(t, deal, parsedDeal)
+	assert.Equal(t, []byte{4, 5}, parsedDeal.Share(1).Ciphertext)
+	assert.Nil(t, parsedDeal.Share(2))
+
+	complaint := NewDKGComplaint(testCommitteeInstanceID, 3)
+	parsedComplaint, err := DKGComplaintFromPayload(parsePayload(t, complaint.Payload()))
+	require.NoError(t, err)
+	assert.Equal(t, complaint, parsedComplaint)
+
+	partialSignature := NewPartialSignature(testCommitteeInstanceID, 7, make([]byte, PartialSignatureSize))
+	parsedPartialSignature, err := PartialSignatureFromPayload(parsePayload(t, partialSignature.Payload()))
+	require.NoError(t, err)
+	assert.Equal(t, partialSignature, parsedPartialSignature)
+
+	_, err = PartialSignatureFromPayload(dkgKey.Payload())
+	assert.ErrorIs(t, err, ErrInvalidCommitteePayload)
+	_, err = DKGKeyFromPayload(NewPayload(NewHeader(TypeDKGKey, testCommitteeInstanceID), []byte{1}))
+	assert.ErrorIs(t, err, ErrInvalidCommitteePayload)
+}
+
+func TestCommittee(t *testing.T) {
+	committee := newTestCommittee(t, 5, 3)
+	start := time.Unix(1000, 0)
+
+	// the deals are combined once no complaint arrived for one period
+	committee.tick(start)
+	assert.Nil(t, committee.members[0].DistributedPublicKey())
+	committee.tick(start.Add(time.Second))
+	dpk := committee.members[0].DistributedPublicKey()
+	require.NotNil(t, dpk)
+	for _, member := range committee.members {
+		assert.Equal(t, dpk, member.DistributedPublicKey())
+	}
+
+	for i := 2; i <= 5; i++ {
+		committee.tick(start.Add(time.Duration(i) * time.Second))
+	}
+	assert.Equal(t, []uint64{1001, 1002, 1003, 1004, 1005}, committee.verifyBeacons(t, dpk))
+	assert.Empty(t, committee.errors)
+}
+
+func TestCommittee_OfflineMembers(t *testing.T) {
+	committee := newTestCommittee(t, 5, 3)
+	start := time.Unix(1000, 0)
+
+	committee.tick(start)
+	committee.tick(start.Add(time.Second))
+	dpk := committee.members[0].DistributedPublicKey()
+	require.NotNil(t, dpk)
+
+	// the beacons of the rounds led by the offline members are issued by the backup issuers
+	committee.offline[0] = true
+	committee.offline[2] = true
+	for i := 2; i <= 6; i++ {
+		committee.tick(start.Add(time.Duration(i) * time.Second))
+	}
+	assert.Equal(t, []uint64{1001, 1002, 1003, 1004, 1005, 1006}, committee.verifyBeacons(t, dpk))
+
+	// without a threshold of members no beacons are produced
+	committee.offline[1] = true
+	for i := 7; i <= 9; i++ {
+		committee.tick(start.Add(time.Duration(i) * time.Second))
+	}
+	assert.Equal(t, []uint64{1001, 1002, 1003, 1004, 1005, 1006}, committee.verifyBeacons(t, dpk))
+	assert.Empty(t, committee.errors)
+}
+
+func TestCommittee_LateMember(t *testing.T) {
+	committee := newTestCommittee(t, 4, 3)
+	start := time.Unix(1000, 0)
+
+	// the key generation requires all members
+	committee.offline[3] = true
+	committee.tick(start)
+	assert.Nil(t, committee.members[0].DistributedPublicKey())
+
+	committee.offline[3] = false
+	committee.tick(start.Add(time.Second))
+	committee.tick(start.Add(2 * time.Second))
+	dpk := committee.members[0].DistributedPublicKey()
+	require.NotNil(t, dpk)
+	for _, member := range committee.members {
+		assert.Equal(t, dpk, member.DistributedPublicKey())
+	}
+
+	committee.tick(start.Add(3 * time.Second))
+	assert.Contains(t, committee.verifyBeacons(t, dpk), uint64(1003))
+	assert.Empty(t, committee.errors)
+}
+
+func TestCommittee_RestartedMember(t *testing.T) {
+	committee := newTestCommittee(t, 4, 3)
+	start := time.Unix(1000, 0)
+
+	committee.tick(start)
+	committee.tick(start.Add(time.Second))
+	dpk := committee.members[1].DistributedPublicKey()
+	require.NotNil(t, dpk)
+	keyShare, err := committee.members[1].KeyShare()
+	require.NoError(t, err)
+	keyShare, err = DistributedKeyShareFromBytes(keyShare.Bytes())
+	require.NoError(t, err)
+
+	// a share of another member or committee is rejected
+	invalidIndex := *keyShare
+	invalidIndex.Index = 2
+	assert.ErrorIs(t, committee.newMember(t, 1, 3).RestoreKeyShare(&invalidIndex), ErrInvalidKeyShare)
+	assert.ErrorIs(t, committee.newMember(t, 1, 4).RestoreKeyShare(keyShare), ErrInvalidKeyShare)
+	invalidShare := *keyShare
+	invalidShare.Share = append([]byte(nil), keyShare.Share...)
+	invalidShare.Share[len(invalidShare.Share)-1] ^= 1
+	assert.ErrorIs(t, committee.newMember(t, 1, 3).RestoreKeyShare(&invalidShare), ErrInvalidKeyShare)
+
+	// the restarted member continues to sign with the restored share instead of starting a new key generation
+	restarted := committee.newMember(t, 1, 3)
+	require.NoError(t, restarted.RestoreKeyShare(keyShare))
+	assert.Equal(t, dpk, restarted.DistributedPublicKey())
+	committee.members[1] = restarted
+	committee.offline[0] = true
+	committee.offline[2] = true
+
+	committee.tick(start.Add(2 * time.Second))
+	assert.Equal(t, []uint64{1001}, committee.verifyBeacons(t, dpk))
+	committee.offline[2] = false
+	for i := 3; i <= 6; i++ {
+		committee.tick(start.Add(time.Duration(i) * time.Second))
+	}
+	assert.Equal(t, []uint64{1001, 1003, 1004, 1005, 1006}, committee.verifyBeacons(t, dpk))
+	for _, member := range committee.members {
+		assert.Equal(t, dpk, member.DistributedPublicKey())
+	}
+	assert.Empty(t, committee.errors)
+}
+
+func TestCommittee_RestartedDuringKeyGeneration(t *testing.T) {
+	committee := newTestCommittee(t, 4, 3)
+	start := time.Unix(1000, 0)
+
+	committee.tick(start)
+	state, err := committee.members[1].DKGState()
+	require.NoError(t, err)
+	require.NotNil(t, state.Deal)
+	state, err = DKGStateFromBytes(state.Bytes())
+	require.NoError(t, err)
+
+	// a state of another member or committee is rejected
+	invalidIndex := *state
+	invalidIndex.Index = 2
+	assert.ErrorIs(t, committee.newMember(t, 1, 3).RestoreDKGState(&invalidIndex), ErrInvalidDKGState)
+	assert.ErrorIs(t, committee.newMember(t, 1, 4).RestoreDKGState(state), ErrInvalidDKGState)
+
+	// the restarted member lost the received deals, but continues with its announced key and deal
+	restarted := committee.newMember(t, 1, 3)
+	require.NoError(t, restarted.RestoreDKGState(state))
+	committee.members[1] = restarted
+
+	committee.tick(start.Add(time.Second))
+	committee.tick(start.Add(2 * time.Second))
+	dpk := committee.members[0].DistributedPublicKey()
+	require.NotNil(t, dpk)
+	for _, member := range committee.members {
+		assert.Equal(t, dpk, member.DistributedPublicKey())
+	}
+	state, err = restarted.DKGState()
+	require.NoError(t, err)
+	assert.Nil(t, state)
+
+	committee.tick(start.Add(3 * time.Second))
+	assert.Contains(t, committee.verifyBeacons(t, dpk), uint64(1003))
+	assert.Empty(t, committee.errors)
+}
+
+func TestCommittee_InvalidDeal(t *testing.T) {
+	committee := newTestCommittee(t, 4, 3)
+	start := time.Unix(1000, 0)
+
+	// the last member announces a valid key, but deals shares that can not be decrypted
+	dkgKey, err := key.KeyGroup.Point().Pick(random.New()).MarshalBinary()
+	require.NoError(t, err)
+	_, commits := share.NewPriPoly(key.KeyGroup, 3, nil, random.New()).Commit(key.KeyGroup.Point().Base()).Info()
+	invalidDeal := &DKGDeal{InstanceID: testCommitteeInstanceID}
+	for i, commit := range commits {
+		commitment, err := commit.MarshalBinary()
+		require.NoError(t, err)
+		invalidDeal.Commitments = append(invalidDeal.Commitments, commitment)
+		invalidDeal.Shares = append(invalidDeal.Shares, &EncryptedShare{Index: uint8(i), Ciphertext: []byte{1, 2, 3}})
+	}
+	committee.offline[3] = true
+	committee.queue = append(committee.queue,
+		queuedPayload{issuer: 3, payload: NewDKGKey(testCommitteeInstanceID, dkgKey).Payload()},
+		queuedPayload{issuer: 3, payload: invalidDeal.Payload()},
+	)
+
+	// the invalid deal is excluded and the remaining deals still reach the threshold
+	committee.tick(start)
+	committee.tick(start.Add(time.Second))
+	dpk := committee.members[0].DistributedPublicKey()
+	require.NotNil(t, dpk)
+	for _, member := range committee.members[:3] {
+		assert.Equal(t, dpk, member.DistributedPublicKey())
+	}
+	require.Len(t, committee.errors, 3)
+	for _, err := range committee.errors {
+		assert.ErrorIs(t, err, ErrInvalidDeal)
+	}
+	committee.errors = nil
+
+	committee.tick(start.Add(2 * time.Second))
+	assert.Equal(t, []uint64{1001, 1002}, committee.verifyBeacons(t, dpk))
+	assert.Empty(t, committee.errors)
+}
+
+func TestMember_InvalidPartialSignature(t *testing.T) {
+	committee := newTestCommittee(t, 3, 2)
+	committee.tick(time.Unix(1000, 0))
+	committee.tick(time.Unix(1001, 0))
+
+	signature := NewPartialSignature(testCommitteeInstanceID, 1002, make([]byte, PartialSignatureSize))
+	err := committee.members[0].HandlePayload(committee.identities[1], signature.Payload())
+	assert.ErrorIs(t, err, ErrInvalidPartialSignature)
+
+	err = committee.members[0].HandlePayload(ed25519.GenerateKeyPair().PublicKey, signature.Payload())
+	assert.ErrorIs(t, err, ErrInvalidIssuer)
+}
+
+// testCommittee connects the members of a committee with a broadcast that delivers every payload to all online members.
+type testCommittee struct {
+	identities []ed25519.PublicKey
+	members    []*Member
+	offline    map[int]bool
+	queue      []queuedPayload
+	beacons    map[uint64]*CollectiveBeaconEvent
+	errors     []error
+}
+
+type queuedPayload struct {
+	issuer  int
+	payload payload.Payload
+}
+
+func newTestCommittee(t *testing.T, n, threshold int) *testCommittee {
+	committee := &testCommittee{
+		offline: make(map[int]bool),
+		beacons: make(map[uint64]*CollectiveBeaconEvent),
+	}
+	for i := 0; i < n; i++ {
+		committee.identities = append(committee.identities, ed25519.GenerateKeyPair().PublicKey)
+	}
+	for i := 0; i < n; i++ {
+		committee.members = append(committee.members, committee.newMember(t, i, threshold))
+	}
+
+	return committee
+}
+
+// newMember creates the Member with the given index that broadcasts to the committee.
+func (c *testCommittee) newMember(t *testing.T, index, threshold int) *Member {
+	member, err := NewMember(testCommitteeInstanceID, c.identities, threshold, c.identities[index], time.Second, func(p payload.Payload) error {
+		c.queue = append(c.queue, queuedPayload{issuer: index, payload: p})
+		return nil
+	})
+	require.NoError(t, err)
+	member.Events.Error.Attach(events.NewClosure(func(err error) {
+		c.errors = append(c.errors, err)
+	}))
+
+	return member
+}
+
+// tick advances all online members to the given time and delivers the resulting payloads.
+func (c *testCommittee) tick(now time.Time) {
+	for i, member := range c.members {
+		if !c.offline[i] {
+			member.Tick(now)
+		}
+	}
+
+	for len(c.queue) > 0 {
+		queued := c.queue[0]
+		c.queue = c.queue[1:]
+
+		p, _, err := FromBytes(queued.payload.Bytes())
+		if err != nil {
+			c.errors = append(c.errors, err)
+			continue
+		}
+		if p.PayloadType == TypeCollectiveBeacon {
+			beacon, _, err := CollectiveBeaconPayloadFromBytes(p.Bytes())
+			if err != nil {
+				c.errors = append(c.errors, err)
+				continue
+			}
+			c.beacons[beacon.Round] = &CollectiveBeaconEvent{
+				IssuerPublicKey: c.identities[queued.issuer],
+				Timestamp:       now,
+				InstanceID:      beacon.InstanceID,
+				Round:           beacon.Round,
+				PrevSignature:   beacon.PrevSignature,
+				Signature:       beacon.Signature,
+				Dpk:             beacon.Dpk,
+			}
+		}
+		for i, member := range c.members {
+			if c.offline[i] {
+				continue
+			}
+			if err := member.HandlePayload(c.identities[queued.issuer], p); err != nil {
+				c.errors = append(c.errors, err)
+			}
+		}
+	}
+}
+
+// verifyBeacons processes all issued beacons in order and returns their rounds.
+func (c *testCommittee) verifyBeacons(t *testing.T, dpk []byte) (rounds []uint64) {
+	state := NewState(SetCommittee(&Committee{
+		InstanceID: testCommitteeInstanceID,
+		Threshold:  uint8(len(c.members)),
+		Identities: c.identities,
+	}))
+
+	for round := range c.beacons {
+		rounds = append(rounds, round)
+	}
+	sort.Slice(rounds, func(i, j int) bool { return rounds[i] < rounds[j] })
+	for _, round := range rounds {
+		assert.Equal(t, dpk, c.beacons[round].Dpk)
+		require.NoError(t, ProcessBeacon(state, c.beacons[round]))
+	}
+
+	return rounds
+}
+
+func parsePayload(t *testing.T, p *Payload) *Payload {
+	parsed, _, err := FromBytes(p.Bytes())
+	require.NoError(t, err)
+	return parsed
+}
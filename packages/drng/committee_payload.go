@@ -0,0 +1,234 @@
+package drng
+
+import (
+	"github.com/cockroachdb/errors"
+	"github.com/iotaledger/hive.go/marshalutil"
+)
+
+// ErrInvalidCommitteePayload is returned if a payload exchanged between committee members can not be parsed.
+var ErrInvalidCommitteePayload = errors.New("invalid committee payload")
+
+// region DKGKey ///////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// DKGKey announces the public key a committee member wants to receive its shares of the distributed key generation
+// encrypted with.
+type DKGKey struct {
+	// InstanceID of the dRNG.
+	InstanceID uint32
+	// Key is the public key of the committee member.
+	Key []byte
+}
+
+// NewDKGKey creates a new DKGKey.
+func NewDKGKey(instanceID uint32, key []byte) *DKGKey {
+	return &DKGKey{
+		InstanceID: instanceID,
+		Key:        key,
+	}
+}
+
+// DKGKeyFromPayload parses a DKGKey from the given payload.
+func DKGKeyFromPayload(payload *Payload) (*DKGKey, error) {
+	if payload.PayloadType != TypeDKGKey {
+		return nil, errors.Errorf("payload type %d is not a DKG key: %w", payload.PayloadType, ErrInvalidCommitteePayload)
+	}
+	if len(payload.Data) != PublicKeySize {
+		return nil, errors.Errorf("DKG key has length %d instead of %d: %w", len(payload.Data), PublicKeySize, ErrInvalidCommitteePayload)
+	}
+	return NewDKGKey(payload.InstanceID, payload.Data), nil
+}
+
+// Payload returns the DKGKey as a dRNG payload.
+func (d *DKGKey) Payload() *Payload {
+	return NewPayload(NewHeader(TypeDKGKey, d.InstanceID), d.Key)
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region DKGDeal //////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// DKGDeal contains the contribution of a committee member to the distributed key generation: the commitments to its
+// secret polynomial and the shares of that polynomial, each encrypted with the key of its recipient.
+type DKGDeal struct {
+	// InstanceID of the dRNG.
+	InstanceID uint32
+	// Commitments are the commitments to the coefficients of the secret polynomial.
+	Commitments [][]byte
+	// Shares contain the encrypted share of every committee member.
+	Shares []*EncryptedShare
+}
+
+// EncryptedShare is a share of a secret polynomial encrypted with the key of its recipient.
+type EncryptedShare struct {
+	// Index is the index of the recipient in the committee.
+	Index uint8
+	// Ciphertext is the encrypted share.
+	Ciphertext []byte
+}
+
+// DKGDealFromPayload parses a DKGDeal from the given payload.
+func DKGDealFromPayload(payload *Payload) (deal *DKGDeal, err error) {
+	if payload.PayloadType != TypeDKGDeal {
+		return nil, errors.Errorf("payload type %d is not a DKG deal: %w", payload.PayloadType, ErrInvalidCommitteePayload)
+	}
+
+	deal = &DKGDeal{InstanceID: payload.InstanceID}
+	marshalUtil := marshalutil.New(payload.Data)
+	commitmentsCount, err := marshalUtil.ReadByte()
+	if err != nil {
+		return nil, errors.Errorf("failed to parse commitments count of DKG deal (%v): %w", err, ErrInvalidCommitteePayload)
+	}
+	for i := 0; i < int(commitmentsCount); i++ {
+		commitment, err := marshalUtil.ReadBytes(PublicKeySize)
+		if err != nil {
+			return nil, errors.Errorf("failed to parse commitment of DKG deal (%v): %w", err, ErrInvalidCommitteePayload)
+		}
+		deal.Commitments = append(deal.Commitments, commitment)
+	}
+
+	sharesCount, err := marshalUtil.ReadByte()
+	if err != nil {
+		return nil, errors.Errorf("failed to parse shares count of DKG deal (%v): %w", err, ErrInvalidCommitteePayload)
+	}
+	for i := 0; i < int(sharesCount); i++ {
+		share := &EncryptedShare{}
+		if share.Index, err = marshalUtil.ReadByte(); err != nil {
+			return nil, errors.Errorf("failed to parse share index of DKG deal (%v): %w", err, ErrInvalidCommitteePayload)
+		}
+		length, err := marshalUtil.ReadUint16()
+		if err != nil {
+			return nil, errors.Errorf("failed to parse share length of DKG deal (%v): %w", err, ErrInvalidCommitteePayload)
+		}
+		if share.Ciphertext, err = marshalUtil.ReadBytes(int(length)); err != nil {
+			return nil, errors.Errorf("failed to parse share of DKG deal (%v): %w", err, ErrInvalidCommitteePayload)
+		}
+		deal.Shares = append(deal.Shares, share)
+	}
+	if marshalUtil.ReadOffset() != len(payload.Data) {
+		return nil, errors.Errorf("DKG deal contains trailing bytes: %w", ErrInvalidCommitteePayload)
+	}
+
+	return deal, nil
+}
+
+// Share returns the encrypted share of the committee member with the given index.
+func (d *DKGDeal) Share(index uint8) *EncryptedShare {
+	for _, share := range d.Shares {
+		if share.Index == index {
+			return share
+		}
+	}
+	return nil
+}
+
+// Payload returns the DKGDeal as a dRNG payload.
+func (d *DKGDeal) Payload() *Payload {
+	marshalUtil := marshalutil.New()
+	marshalUtil.WriteByte(byte(len(d.Commitments)))
+	for _, commitment := range d.Commitments {
+		marshalUtil.WriteBytes(commitment)
+	}
+	marshalUtil.WriteByte(byte(len(d.Shares)))
+	for _, share := range d.Shares {
+		marshalUtil.WriteByte(share.Index)
+		marshalUtil.WriteUint16(uint16(len(share.Ciphertext)))
+		marshalUtil.WriteBytes(share.Ciphertext)
+	}
+
+	return NewPayload(NewHeader(TypeDKGDeal, d.InstanceID), marshalUtil.Bytes())
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region DKGComplaint /////////////////////////////////////////////////////////////////////////////////////////////////
+
+// DKGComplaint announces that a committee member received an invalid deal from the dealer with the given index, so that
+// all members exclude that deal from the distributed key.
+type DKGComplaint struct {
+	// InstanceID of the dRNG.
+	InstanceID uint32
+	// Dealer is the index of the committee member whose deal is invalid.
+	Dealer uint8
+}
+
+// NewDKGComplaint creates a new DKGComplaint.
+func NewDKGComplaint(instanceID uint32, dealer uint8) *DKGComplaint {
+	return &DKGComplaint{
+		InstanceID: instanceID,
+		Dealer:     dealer,
+	}
+}
+
+// DKGComplaintFromPayload parses a DKGComplaint from the given payload.
+func DKGComplaintFromPayload(payload *Payload) (*DKGComplaint, error) {
+	if payload.PayloadType != TypeDKGComplaint {
+		return nil, errors.Errorf("payload type %d is not a DKG complaint: %w", payload.PayloadType, ErrInvalidCommitteePayload)
+	}
+	if len(payload.Data) != marshalutil.Uint8Size {
+		return nil, errors.Errorf("DKG complaint has length %d instead of %d: %w", len(payload.Data), marshalutil.Uint8Size, ErrInvalidCommitteePayload)
+	}
+	return NewDKGComplaint(payload.InstanceID, payload.Data[0]), nil
+}
+
+// Payload returns the DKGComplaint as a dRNG payload.
+func (d *DKGComplaint) Payload() *Payload {
+	return NewPayload(NewHeader(TypeDKGComplaint, d.InstanceID), []byte{d.Dealer})
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region PartialSignature /////////////////////////////////////////////////////////////////////////////////////////////
+
+// PartialSignatureSize defines the size of a partial signature: the index of the signer followed by the signature.
+const PartialSignatureSize = marshalutil.Uint16Size + SignatureSize
+
+// PartialSignature contains the partial signature of a committee member on the message of a beacon round.
+type PartialSignature struct {
+	// InstanceID of the dRNG.
+	InstanceID uint32
+	// Round of the beacon.
+	Round uint64
+	// Signature is the partial signature, prefixed with the index of the signer.
+	Signature []byte
+}
+
+// NewPartialSignature creates a new PartialSignature.
+func NewPartialSignature(instanceID uint32, round uint64, signature []byte) *PartialSignature {
+	return &PartialSignature{
+		InstanceID: instanceID,
+		Round:      round,
+		Signature:  signature,
+	}
+}
+
+// PartialSignatureFromPayload parses a PartialSignature from the given payload.
+func PartialSignatureFromPayload(payload *Payload) (*PartialSignature, error) {
+	if payload.PayloadType != TypePartialSignature {
+		return nil, errors.Errorf("payload type %d is not a partial signature: %w", payload.PayloadType, ErrInvalidCommitteePayload)
+	}
+	if len(payload.Data) != marshalutil.Uint64Size+PartialSignatureSize {
+		return nil, errors.Errorf("partial signature has length %d instead of %d: %w", len(payload.Data), marshalutil.Uint64Size+PartialSignatureSize, ErrInvalidCommitteePayload)
+	}
+
+	marshalUtil := marshalutil.New(payload.Data)
+	round, err := marshalUtil.ReadUint64()
+	if err != nil {
+		return nil, errors.Errorf("failed to parse round of partial signature (%v): %w", err, ErrInvalidCommitteePayload)
+	}
+	signature, err := marshalUtil.ReadBytes(PartialSignatureSize)
+	if err != nil {
+		return nil, errors.Errorf("failed to parse partial signature (%v): %w", err, ErrInvalidCommitteePayload)
+	}
+	return NewPartialSignature(payload.InstanceID, round, signature), nil
+}
+
+// Payload returns the PartialSignature as a dRNG payload.
+func (p *PartialSignature) Payload() *Payload {
+	marshalUtil := marshalutil.New(marshalutil.Uint64Size + PartialSignatureSize)
+	marshalUtil.WriteUint64(p.Round)
+	marshalUtil.WriteBytes(p.Signature)
+
+	return NewPayload(NewHeader(TypePartialSignature, p.InstanceID), marshalUtil.Bytes())
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -6,4 +6,7 @@ const (
 
 	// PublicKeySize defines the BLS Public Key size in bytes.
 	PublicKeySize = 48
+
+	// RandomnessSize defines the size of the randomness derived from a signature in bytes.
+	RandomnessSize = 64
 )
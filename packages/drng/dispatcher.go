@@ -6,10 +6,12 @@ import (
 	"github.com/cockroachdb/errors"
 	"github.com/iotaledger/hive.go/crypto/ed25519"
 	"github.com/iotaledger/hive.go/marshalutil"
+
+	"github.com/iotaledger/goshimmer/packages/tangle"
 )
 
 // Dispatch parses a DRNG message and processes it based on its subtype
-func (d *DRNG) Dispatch(issuer ed25519.PublicKey, timestamp time.Time, payload *Payload) error {
+func (d *DRNG) Dispatch(messageID tangle.MessageID, issuer ed25519.PublicKey, timestamp time.Time, payload *Payload) error {
 	switch payload.PayloadType {
 	case TypeCollectiveBeacon:
 		// parse as CollectiveBeaconType
@@ -22,6 +24,7 @@ func (d *DRNG) Dispatch(issuer ed25519.PublicKey, timestamp time.Time, payload *
 		cbEvent := &CollectiveBeaconEvent{
 			IssuerPublicKey: issuer,
 			Timestamp:       timestamp,
+			MessageID:       messageID,
 			InstanceID:      parsedPayload.Header.InstanceID,
 			Round:           parsedPayload.Round,
 			PrevSignature:   parsedPayload.PrevSignature,
@@ -31,21 +34,38 @@ func (d *DRNG) Dispatch(issuer ed25519.PublicKey, timestamp time.Time, payload *
 		d.Events.CollectiveBeacon.Trigger(cbEvent)
 
 		// process collectiveBeacon
-		if _, ok := d.State[cbEvent.InstanceID]; !ok {
+		state := d.LoadState(cbEvent.InstanceID)
+		if state == nil {
 			return ErrInstanceIDMismatch
 		}
-		if err := ProcessBeacon(d.State[cbEvent.InstanceID], cbEvent); err != nil {
+		if err := ProcessBeacon(state, cbEvent); err != nil {
 			return err
 		}
 
 		// update the dpk (if not set) from the valid beacon
-		if len(d.State[cbEvent.InstanceID].committee.DistributedPK) == 0 {
-			d.State[cbEvent.InstanceID].UpdateDPK(cbEvent.Dpk)
+		if len(state.Committee().DistributedPK) == 0 {
+			state.UpdateDPK(cbEvent.Dpk)
+		}
+
+		// record the beacon and check its continuity
+		if history := d.BeaconHistory(); history != nil {
+			beacon := NewBeacon(cbEvent, state.Randomness().Randomness)
+			gap, chainBroken := history.StoreBeacon(beacon)
+			if gap != nil {
+				d.Events.MissingRounds.Trigger(gap)
+			}
+			if chainBroken {
+				d.Events.ChainMismatch.Trigger(beacon)
+			}
 		}
 
 		// trigger RandomnessEvent
-		d.Events.Randomness.Trigger(d.State[cbEvent.InstanceID])
+		d.Events.Randomness.Trigger(state)
+
+		return nil
 
+	case TypeDKGKey, TypeDKGDeal, TypeDKGComplaint, TypePartialSignature:
+		// messages exchanged between the committee members are processed by the members themselves
 		return nil
 
 	default:
@@ -11,6 +11,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/iotaledger/goshimmer/packages/clock"
+	"github.com/iotaledger/goshimmer/packages/tangle"
 )
 
 var (
@@ -64,9 +65,9 @@ func TestDispatcher(t *testing.T) {
 	config[1] = []Option{SetCommittee(committeeTest)}
 
 	drng := New(config)
-	err = drng.Dispatch(issuerPK, timestampTest, parsedPayload)
+	err = drng.Dispatch(tangle.EmptyMessageID, issuerPK, timestampTest, parsedPayload)
 	require.NoError(t, err)
-	require.Equal(t, *randomnessTest, drng.State[1].Randomness())
+	require.Equal(t, *randomnessTest, drng.LoadState(1).Randomness())
 }
 
 func TestEmptyState(t *testing.T) {
@@ -76,7 +77,7 @@ func TestEmptyState(t *testing.T) {
 	config := make(map[uint32][]Option)
 
 	drng := New(config)
-	err = drng.Dispatch(issuerPK, timestampTest, parsedPayload)
+	err = drng.Dispatch(tangle.EmptyMessageID, issuerPK, timestampTest, parsedPayload)
 	if assert.Error(t, err) {
 		assert.Equal(t, ErrInstanceIDMismatch, err)
 	}
@@ -2,27 +2,41 @@ package drng
 
 import (
 	"encoding/binary"
+	"sort"
 	"sync"
 	"time"
 
+	"github.com/cockroachdb/errors"
 	"github.com/iotaledger/hive.go/crypto/ed25519"
 )
 
+var (
+	// ErrInstanceExists is returned if an instance is loaded whose instanceID is already in use.
+	ErrInstanceExists = errors.New("dRNG instance already loaded")
+	// ErrInstanceNotFound is returned if an instance is not loaded.
+	ErrInstanceNotFound = errors.New("dRNG instance not loaded")
+	// ErrMissingCommittee is returned if an instance is loaded without a committee.
+	ErrMissingCommittee = errors.New("dRNG instance has no committee")
+)
+
 // DRNG holds the state and events of a drng instance.
 type DRNG struct {
-	State  map[uint32]*State // The state of the DRNG.
-	Events *Event            // The events fired on the DRNG.
+	Events *Event // The events fired on the DRNG.
+
+	state   map[uint32]*State // The state of the loaded instances.
+	history *BeaconHistory    // The persisted beacons of all instances (optional).
+	mutex   sync.RWMutex
 }
 
 // New creates a new DRNG instance.
 func New(config map[uint32][]Option) *DRNG {
 	drng := &DRNG{
-		State:  make(map[uint32]*State),
+		state:  make(map[uint32]*State),
 		Events: newEvent(),
 	}
 
 	for id, setters := range config {
-		drng.State[id] = NewState(setters...)
+		drng.state[id] = NewState(setters...)
 	}
 
 	return drng
@@ -30,13 +44,87 @@ func New(config map[uint32][]Option) *DRNG {
 
 // LoadState returns the pointer to the state associated to the given instanceID.
 func (d *DRNG) LoadState(instanceID uint32) *State {
-	s, ok := d.State[instanceID]
+	d.mutex.RLock()
+	defer d.mutex.RUnlock()
+
+	s, ok := d.state[instanceID]
 	if !ok {
 		return nil
 	}
 	return s
 }
 
+// SetBeaconHistory sets the BeaconHistory that records every verified beacon.
+func (d *DRNG) SetBeaconHistory(history *BeaconHistory) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	d.history = history
+}
+
+// BeaconHistory returns the BeaconHistory that records every verified beacon (nil if none was set).
+func (d *DRNG) BeaconHistory() *BeaconHistory {
+	d.mutex.RLock()
+	defer d.mutex.RUnlock()
+	return d.history
+}
+
+// States returns the states of all loaded instances ordered by their instanceID.
+func (d *DRNG) States() []*State {
+	d.mutex.RLock()
+	defer d.mutex.RUnlock()
+
+	states := make([]*State, 0, len(d.state))
+	for _, state := range d.state {
+		states = append(states, state)
+	}
+	sort.Slice(states, func(i, j int) bool {
+		return states[i].Committee().InstanceID < states[j].Committee().InstanceID
+	})
+	return states
+}
+
+// InstanceCount returns the number of loaded instances.
+func (d *DRNG) InstanceCount() int {
+	d.mutex.RLock()
+	defer d.mutex.RUnlock()
+	return len(d.state)
+}
+
+// LoadInstance adds a new instance with the given options. The options must contain the committee of the instance.
+func (d *DRNG) LoadInstance(setters ...Option) (*State, error) {
+	state := NewState(setters...)
+	if state.committee == nil {
+		return nil, ErrMissingCommittee
+	}
+	instanceID := state.committee.InstanceID
+
+	d.mutex.Lock()
+	if _, exists := d.state[instanceID]; exists {
+		d.mutex.Unlock()
+		return nil, errors.Errorf("failed to load instance %d: %w", instanceID, ErrInstanceExists)
+	}
+	d.state[instanceID] = state
+	d.mutex.Unlock()
+
+	d.Events.InstanceLoaded.Trigger(state)
+	return state, nil
+}
+
+// UnloadInstance removes the instance with the given instanceID. Beacons of that instance are rejected afterwards.
+func (d *DRNG) UnloadInstance(instanceID uint32) error {
+	d.mutex.Lock()
+	state, exists := d.state[instanceID]
+	if !exists {
+		d.mutex.Unlock()
+		return errors.Errorf("failed to unload instance %d: %w", instanceID, ErrInstanceNotFound)
+	}
+	delete(d.state, instanceID)
+	d.mutex.Unlock()
+
+	d.Events.InstanceUnloaded.Trigger(state)
+	return nil
+}
+
 // Options define state options of a DRNG.
 type Options struct {
 	// The initial committee of the DRNG.
@@ -87,6 +175,8 @@ type Committee struct {
 	Identities []ed25519.PublicKey
 	// DistributedPK holds the drand distributed public key.
 	DistributedPK []byte
+	// RoundInterval holds the expected time between two rounds (zero if unknown).
+	RoundInterval time.Duration
 }
 
 // State represents the state of the DRNG.
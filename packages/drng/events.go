@@ -5,6 +5,8 @@ import (
 
 	"github.com/iotaledger/hive.go/crypto/ed25519"
 	"github.com/iotaledger/hive.go/events"
+
+	"github.com/iotaledger/goshimmer/packages/tangle"
 )
 
 // CollectiveBeaconEvent holds data about a collective beacon event.
@@ -13,6 +15,8 @@ type CollectiveBeaconEvent struct {
 	IssuerPublicKey ed25519.PublicKey
 	// Timestamp when the beacon was issued.
 	Timestamp time.Time
+	// MessageID of the message that contained the beacon.
+	MessageID tangle.MessageID
 	// InstanceID of the beacon.
 	InstanceID uint32
 	// Round of the current beacon.
@@ -36,15 +40,36 @@ type Event struct {
 	CollectiveBeacon *events.Event
 	// Randomness is triggered each time we receive a new and valid CollectiveBeacon message.
 	Randomness *events.Event
+	// InstanceLoaded is triggered each time an instance is loaded at runtime.
+	InstanceLoaded *events.Event
+	// InstanceUnloaded is triggered each time an instance is unloaded.
+	InstanceUnloaded *events.Event
+	// MissingRounds is triggered each time a beacon is stored whose round does not follow the latest stored round.
+	MissingRounds *events.Event
+	// ChainMismatch is triggered each time a beacon is stored whose previous signature does not match the stored beacon
+	// of the previous round.
+	ChainMismatch *events.Event
 }
 
 func newEvent() *Event {
 	return &Event{
 		CollectiveBeacon: events.NewEvent(CollectiveBeaconReceived),
 		Randomness:       events.NewEvent(randomnessReceived),
+		InstanceLoaded:   events.NewEvent(randomnessReceived),
+		InstanceUnloaded: events.NewEvent(randomnessReceived),
+		MissingRounds:    events.NewEvent(gapEventCaller),
+		ChainMismatch:    events.NewEvent(beaconEventCaller),
 	}
 }
 
 func randomnessReceived(handler interface{}, params ...interface{}) {
 	handler.(func(*State))(params[0].(*State))
 }
+
+func gapEventCaller(handler interface{}, params ...interface{}) {
+	handler.(func(*GapEvent))(params[0].(*GapEvent))
+}
+
+func beaconEventCaller(handler interface{}, params ...interface{}) {
+	handler.(func(*Beacon))(params[0].(*Beacon))
+}
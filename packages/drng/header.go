@@ -10,6 +10,14 @@ type Type = byte
 const (
 	// TypeCollectiveBeacon defines a CollectiveBeacon payload type
 	TypeCollectiveBeacon Type = 1
+	// TypeDKGKey defines a payload type announcing the key generation key of a committee member
+	TypeDKGKey Type = 2
+	// TypeDKGDeal defines a payload type containing the commitments and encrypted shares of a committee member
+	TypeDKGDeal Type = 3
+	// TypePartialSignature defines a payload type containing the partial beacon signature of a committee member
+	TypePartialSignature Type = 4
+	// TypeDKGComplaint defines a payload type containing the complaint of a committee member about an invalid deal
+	TypeDKGComplaint Type = 5
 )
 
 // HeaderLength defines the length of a DRNG header
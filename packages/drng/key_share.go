@@ -0,0 +1,257 @@
+package drng
+
+import (
+	"github.com/cockroachdb/errors"
+	"github.com/iotaledger/hive.go/crypto/ed25519"
+	"github.com/iotaledger/hive.go/kvstore"
+	"github.com/iotaledger/hive.go/marshalutil"
+	"golang.org/x/crypto/blake2b"
+
+	"github.com/iotaledger/goshimmer/packages/database"
+)
+
+const (
+	// PrefixKeyShare defines the storage prefix for the DistributedKeyShares of the committee members.
+	PrefixKeyShare byte = PrefixBeacon + 1
+	// PrefixDKGState defines the storage prefix for the DKGStates of the committee members.
+	PrefixDKGState byte = PrefixKeyShare + 1
+)
+
+// ErrInvalidKeyShare is returned if a DistributedKeyShare can not be parsed or does not belong to the committee.
+var ErrInvalidKeyShare = errors.New("invalid key share")
+
+// ErrInvalidDKGState is returned if a DKGState can not be parsed or does not belong to the committee.
+var ErrInvalidDKGState = errors.New("invalid DKG state")
+
+// region DistributedKeyShare //////////////////////////////////////////////////////////////////////////////////////////
+
+// DistributedKeyShare is the result of the distributed key generation of a committee member: its share of the
+// distributed secret and the commitments to the distributed polynomial, whose first coefficient is the distributed
+// public key. It allows the member to continue signing beacons after a restart.
+type DistributedKeyShare struct {
+	// InstanceID of the dRNG.
+	InstanceID uint32
+	// CommitteeDigest identifies the committee and threshold the key was generated for.
+	CommitteeDigest [blake2b.Size256]byte
+	// Index is the index of the member in the committee.
+	Index uint8
+	// Share is the share of the distributed secret of the member.
+	Share []byte
+	// Commitments are the commitments to the coefficients of the distributed polynomial.
+	Commitments [][]byte
+}
+
+// DistributedKeyShareFromBytes parses a DistributedKeyShare from the given bytes.
+func DistributedKeyShareFromBytes(bytes []byte) (keyShare *DistributedKeyShare, err error) {
+	marshalUtil := marshalutil.New(bytes)
+	keyShare = &DistributedKeyShare{}
+	if keyShare.InstanceID, err = marshalUtil.ReadUint32(); err != nil {
+		return nil, errors.Errorf("failed to parse instance ID (%v): %w", err, ErrInvalidKeyShare)
+	}
+	committeeDigest, err := marshalUtil.ReadBytes(blake2b.Size256)
+	if err != nil {
+		return nil, errors.Errorf("failed to parse committee digest (%v): %w", err, ErrInvalidKeyShare)
+	}
+	copy(keyShare.CommitteeDigest[:], committeeDigest)
+	if keyShare.Index, err = marshalUtil.ReadByte(); err != nil {
+		return nil, errors.Errorf("failed to parse index (%v): %w", err, ErrInvalidKeyShare)
+	}
+	shareLength, err := marshalUtil.ReadUint16()
+	if err != nil {
+		return nil, errors.Errorf("failed to parse share length (%v): %w", err, ErrInvalidKeyShare)
+	}
+	if keyShare.Share, err = marshalUtil.ReadBytes(int(shareLength)); err != nil {
+		return nil, errors.Errorf("failed to parse share (%v): %w", err, ErrInvalidKeyShare)
+	}
+	commitmentsCount, err := marshalUtil.ReadByte()
+	if err != nil {
+		return nil, errors.Errorf("failed to parse commitments count (%v): %w", err, ErrInvalidKeyShare)
+	}
+	for i := 0; i < int(commitmentsCount); i++ {
+		commitment, err := marshalUtil.ReadBytes(PublicKeySize)
+		if err != nil {
+			return nil, errors.Errorf("failed to parse commitment (%v): %w", err, ErrInvalidKeyShare)
+		}
+		keyShare.Commitments = append(keyShare.Commitments, commitment)
+	}
+	if marshalUtil.ReadOffset() != len(bytes) {
+		return nil, errors.Errorf("key share contains trailing bytes: %w", ErrInvalidKeyShare)
+	}
+
+	return keyShare, nil
+}
+
+// Bytes returns a marshaled version of the DistributedKeyShare.
+func (d *DistributedKeyShare) Bytes() []byte {
+	marshalUtil := marshalutil.New()
+	marshalUtil.WriteUint32(d.InstanceID)
+	marshalUtil.WriteBytes(d.CommitteeDigest[:])
+	marshalUtil.WriteByte(d.Index)
+	marshalUtil.WriteUint16(uint16(len(d.Share)))
+	marshalUtil.WriteBytes(d.Share)
+	marshalUtil.WriteByte(byte(len(d.Commitments)))
+	for _, commitment := range d.Commitments {
+		marshalUtil.WriteBytes(commitment)
+	}
+
+	return marshalUtil.Bytes()
+}
+
+// committeeDigest returns the digest that identifies the given committee and threshold.
+func committeeDigest(committee []ed25519.PublicKey, threshold int) [blake2b.Size256]byte {
+	marshalUtil := marshalutil.New()
+	marshalUtil.WriteByte(byte(threshold))
+	for _, member := range committee {
+		marshalUtil.WriteBytes(member.Bytes())
+	}
+
+	return blake2b.Sum256(marshalUtil.Bytes())
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region DKGState /////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// DKGState is the state of a committee member during the distributed key generation: the secret of its announced DKG
+// key and its own deal. The other members keep the announced key and the deal, so a member that restarts during the key
+// generation has to continue with both to decrypt the shares dealt to it and to deal the same polynomial to everyone.
+type DKGState struct {
+	// InstanceID of the dRNG.
+	InstanceID uint32
+	// CommitteeDigest identifies the committee and threshold of the key generation.
+	CommitteeDigest [blake2b.Size256]byte
+	// Index is the index of the member in the committee.
+	Index uint8
+	// Secret is the secret of the announced DKG key.
+	Secret []byte
+	// Deal is the deal of the member or nil if it was not created yet.
+	Deal *DKGDeal
+}
+
+// DKGStateFromBytes parses a DKGState from the given bytes.
+func DKGStateFromBytes(bytes []byte) (state *DKGState, err error) {
+	marshalUtil := marshalutil.New(bytes)
+	state = &DKGState{}
+	if state.InstanceID, err = marshalUtil.ReadUint32(); err != nil {
+		return nil, errors.Errorf("failed to parse instance ID (%v): %w", err, ErrInvalidDKGState)
+	}
+	committeeDigest, err := marshalUtil.ReadBytes(blake2b.Size256)
+	if err != nil {
+		return nil, errors.Errorf("failed to parse committee digest (%v): %w", err, ErrInvalidDKGState)
+	}
+	copy(state.CommitteeDigest[:], committeeDigest)
+	if state.Index, err = marshalUtil.ReadByte(); err != nil {
+		return nil, errors.Errorf("failed to parse index (%v): %w", err, ErrInvalidDKGState)
+	}
+	secretLength, err := marshalUtil.ReadUint16()
+	if err != nil {
+		return nil, errors.Errorf("failed to parse secret length (%v): %w", err, ErrInvalidDKGState)
+	}
+	if state.Secret, err = marshalUtil.ReadBytes(int(secretLength)); err != nil {
+		return nil, errors.Errorf("failed to parse secret (%v): %w", err, ErrInvalidDKGState)
+	}
+	dealExists, err := marshalUtil.ReadBool()
+	if err != nil {
+		return nil, errors.Errorf("failed to parse deal flag (%v): %w", err, ErrInvalidDKGState)
+	}
+	if dealExists {
+		dealPayload, err := PayloadFromMarshalUtil(marshalUtil)
+		if err != nil {
+			return nil, errors.Errorf("failed to parse deal (%v): %w", err, ErrInvalidDKGState)
+		}
+		if state.Deal, err = DKGDealFromPayload(dealPayload); err != nil {
+			return nil, errors.Errorf("failed to parse deal (%v): %w", err, ErrInvalidDKGState)
+		}
+	}
+	if marshalUtil.ReadOffset() != len(bytes) {
+		return nil, errors.Errorf("DKG state contains trailing bytes: %w", ErrInvalidDKGState)
+	}
+
+	return state, nil
+}
+
+// Bytes returns a marshaled version of the DKGState.
+func (d *DKGState) Bytes() []byte {
+	marshalUtil := marshalutil.New()
+	marshalUtil.WriteUint32(d.InstanceID)
+	marshalUtil.WriteBytes(d.CommitteeDigest[:])
+	marshalUtil.WriteByte(d.Index)
+	marshalUtil.WriteUint16(uint16(len(d.Secret)))
+	marshalUtil.WriteBytes(d.Secret)
+	marshalUtil.WriteBool(d.Deal != nil)
+	if d.Deal != nil {
+		marshalUtil.WriteBytes(d.Deal.Payload().Bytes())
+	}
+
+	return marshalUtil.Bytes()
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region KeyShareStorage //////////////////////////////////////////////////////////////////////////////////////////////
+
+// KeyShareStorage persists the DistributedKeyShare of every dRNG instance the node is a committee member of, as well as
+// the DKGState of a key generation that is not completed yet.
+type KeyShareStorage struct {
+	store    kvstore.KVStore
+	dkgStore kvstore.KVStore
+}
+
+// NewKeyShareStorage is the constructor of the KeyShareStorage.
+func NewKeyShareStorage(store kvstore.KVStore) *KeyShareStorage {
+	return &KeyShareStorage{
+		store:    store.WithRealm([]byte{database.PrefixDRNG, PrefixKeyShare}),
+		dkgStore: store.WithRealm([]byte{database.PrefixDRNG, PrefixDKGState}),
+	}
+}
+
+// Store persists the given DistributedKeyShare, replacing a previous one of the same instance.
+func (k *KeyShareStorage) Store(keyShare *DistributedKeyShare) error {
+	if err := k.store.Set(marshalutil.New().WriteUint32(keyShare.InstanceID).Bytes(), keyShare.Bytes()); err != nil {
+		return errors.Errorf("failed to store key share of instance %d: %w", keyShare.InstanceID, err)
+	}
+	return nil
+}
+
+// Load returns the persisted DistributedKeyShare of the given instance or nil if there is none.
+func (k *KeyShareStorage) Load(instanceID uint32) (*DistributedKeyShare, error) {
+	bytes, err := k.store.Get(marshalutil.New().WriteUint32(instanceID).Bytes())
+	if errors.Is(err, kvstore.ErrKeyNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Errorf("failed to load key share of instance %d: %w", instanceID, err)
+	}
+	return DistributedKeyShareFromBytes(bytes)
+}
+
+// StoreDKGState persists the given DKGState, replacing a previous one of the same instance.
+func (k *KeyShareStorage) StoreDKGState(state *DKGState) error {
+	if err := k.dkgStore.Set(marshalutil.New().WriteUint32(state.InstanceID).Bytes(), state.Bytes()); err != nil {
+		return errors.Errorf("failed to store DKG state of instance %d: %w", state.InstanceID, err)
+	}
+	return nil
+}
+
+// LoadDKGState returns the persisted DKGState of the given instance or nil if there is none.
+func (k *KeyShareStorage) LoadDKGState(instanceID uint32) (*DKGState, error) {
+	bytes, err := k.dkgStore.Get(marshalutil.New().WriteUint32(instanceID).Bytes())
+	if errors.Is(err, kvstore.ErrKeyNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Errorf("failed to load DKG state of instance %d: %w", instanceID, err)
+	}
+	return DKGStateFromBytes(bytes)
+}
+
+// DeleteDKGState removes the persisted DKGState of the given instance, as it is no longer needed once the key generation
+// is completed.
+func (k *KeyShareStorage) DeleteDKGState(instanceID uint32) error {
+	if err := k.dkgStore.Delete(marshalutil.New().WriteUint32(instanceID).Bytes()); err != nil {
+		return errors.Errorf("failed to delete DKG state of instance %d: %w", instanceID, err)
+	}
+	return nil
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
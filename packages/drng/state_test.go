@@ -2,8 +2,10 @@ package drng
 
 import (
 	"testing"
+	"time"
 
 	"github.com/iotaledger/hive.go/crypto/ed25519"
+	"github.com/iotaledger/hive.go/events"
 	"github.com/stretchr/testify/require"
 
 	"github.com/iotaledger/goshimmer/packages/clock"
@@ -32,7 +34,7 @@ func TestState(t *testing.T) {
 	require.Equal(t, *dummyCommittee(), stateTest.Committee())
 
 	// committee setters - getters
-	newCommittee := &Committee{1, 1, []ed25519.PublicKey{}, []byte{11}}
+	newCommittee := &Committee{1, 1, []ed25519.PublicKey{}, []byte{11}, time.Second}
 	stateTest.UpdateCommittee(newCommittee)
 	require.Equal(t, *newCommittee, stateTest.Committee())
 
@@ -48,3 +50,32 @@ func TestFloat64(t *testing.T) {
 	stateTest := NewState(SetRandomness(r))
 	require.Equal(t, 0.9999999999999999, stateTest.Randomness().Float64())
 }
+
+func TestDRNG_LoadInstance(t *testing.T) {
+	drng := New(map[uint32][]Option{1: {SetCommittee(&Committee{InstanceID: 1})}})
+	var loaded, unloaded []uint32
+	drng.Events.InstanceLoaded.Attach(events.NewClosure(func(state *State) {
+		loaded = append(loaded, state.Committee().InstanceID)
+	}))
+	drng.Events.InstanceUnloaded.Attach(events.NewClosure(func(state *State) {
+		unloaded = append(unloaded, state.Committee().InstanceID)
+	}))
+
+	_, err := drng.LoadInstance(SetCommittee(&Committee{InstanceID: 1}))
+	require.ErrorIs(t, err, ErrInstanceExists)
+	_, err = drng.LoadInstance()
+	require.ErrorIs(t, err, ErrMissingCommittee)
+
+	state, err := drng.LoadInstance(SetCommittee(&Committee{InstanceID: 0, RoundInterval: time.Second}))
+	require.NoError(t, err)
+	require.Equal(t, state, drng.LoadState(0))
+	require.Equal(t, []*State{state, drng.LoadState(1)}, drng.States())
+
+	require.NoError(t, drng.UnloadInstance(1))
+	require.ErrorIs(t, drng.UnloadInstance(1), ErrInstanceNotFound)
+	require.Nil(t, drng.LoadState(1))
+	require.Equal(t, []*State{state}, drng.States())
+
+	require.Equal(t, []uint32{0}, loaded)
+	require.Equal(t, []uint32{1}, unloaded)
+}
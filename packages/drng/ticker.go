@@ -17,6 +17,8 @@ type Ticker struct {
 	missingDRNG         bool
 	delayedRoundStart   time.Duration
 	delayedRoundStartMu sync.RWMutex
+	round               uint64
+	roundMu             sync.RWMutex
 	c                   chan float64
 	exit                chan struct{}
 	fromRandomnessEvent chan Randomness
@@ -93,6 +95,20 @@ func (t *Ticker) setDelayedRoundStart(d time.Duration) {
 	t.delayedRoundStart = d
 }
 
+// Round returns the latest dRNG round that was known when the last random number was sent. It is the same for all nodes
+// that received the same beacons, even if the default value was sent because the randomness was not fresh.
+func (t *Ticker) Round() uint64 {
+	t.roundMu.RLock()
+	defer t.roundMu.RUnlock()
+	return t.round
+}
+
+func (t *Ticker) setRound(round uint64) {
+	t.roundMu.Lock()
+	defer t.roundMu.Unlock()
+	t.round = round
+}
+
 // sends the next random number to the consumer channel.
 func (t *Ticker) send() {
 	t.setDelayedRoundStart(0)
@@ -145,6 +161,10 @@ func (t *Ticker) send() {
 		}
 	}
 
+	if t.dRNGState() != nil {
+		t.setRound(t.dRNGState().Randomness().Round)
+	}
+
 	// skip slow consumers
 	select {
 	case t.c <- randomness:
@@ -15,4 +15,8 @@ var (
 	ErrInvalidPacket = errors.New("invalid packet")
 	// ErrNeighborQueueFull is returned when the send queue is already full.
 	ErrNeighborQueueFull = errors.New("send queue is full")
+	// ErrUnsupportedPacket is returned when a packet type is not supported by the neighbor.
+	ErrUnsupportedPacket = errors.New("packet type not supported by neighbor")
+	// ErrPacketTooLarge is returned when a packet exceeds the maximum message size of the neighbor.
+	ErrPacketTooLarge = errors.New("packet exceeds max message size of neighbor")
 )
@@ -3,7 +3,6 @@ package gossip
 import (
 	"context"
 	"fmt"
-	"net"
 	"runtime"
 	"sync"
 
@@ -22,7 +21,7 @@ import (
 
 const (
 	// maxPacketSize defines the maximum packet size allowed for gossip and bufferedconn.
-	maxPacketSize = 65 * 1024
+	maxPacketSize = pb.MaxPacketSize
 )
 
 var (
@@ -163,14 +162,14 @@ func (m *Manager) getNeighbor(id identity.ID, group NeighborsGroup) (*Neighbor,
 // If no peer is provided, all neighbors are queried.
 func (m *Manager) RequestMessage(messageID []byte, to ...identity.ID) {
 	msgReq := &pb.MessageRequest{Id: messageID}
-	m.send(marshal(msgReq), to...)
+	m.send(msgReq, to...)
 }
 
 // SendMessage adds the given message the send queue of the neighbors.
 // The actual send then happens asynchronously. If no peer is provided, it is send to all neighbors.
 func (m *Manager) SendMessage(msgData []byte, to ...identity.ID) {
 	msg := &pb.Message{Data: msgData}
-	m.send(marshal(msg), to...)
+	m.send(msg, to...)
 }
 
 // AllNeighbors returns all the neighbors that are currently connected.
@@ -200,13 +199,18 @@ func (m *Manager) getNeighborsByID(ids []identity.ID) []*Neighbor {
 	return result
 }
 
-func (m *Manager) send(b []byte, to ...identity.ID) {
+func (m *Manager) send(packet pb.Packet, to ...identity.ID) {
 	neighbors := m.getNeighborsByID(to)
 	if len(neighbors) == 0 {
 		neighbors = m.AllNeighbors()
 	}
 
+	b := marshal(packet)
 	for _, nbr := range neighbors {
+		if err := checkPacket(packet.Type(), len(b), nbr); err != nil {
+			m.log.Debugw("packet not sent", "peer-id", nbr.ID(), "err", err)
+			continue
+		}
 		if _, err := nbr.Write(b); err != nil {
 			m.log.Warnw("send error", "peer-id", nbr.ID(), "err", err)
 		}
@@ -214,7 +218,7 @@ func (m *Manager) send(b []byte, to ...identity.ID) {
 }
 
 func (m *Manager) addNeighbor(ctx context.Context, p *peer.Peer, group NeighborsGroup,
-	connectorFunc func(context.Context, *peer.Peer, ...server.ConnectPeerOption) (net.Conn, error),
+	connectorFunc func(context.Context, *peer.Peer, ...server.ConnectPeerOption) (*server.Conn, error),
 	connectOpts []server.ConnectPeerOption,
 ) error {
 	if p.ID() == m.local.ID() {
@@ -358,6 +362,25 @@ func (m *Manager) processMessageRequest(data []byte, nbr *Neighbor) {
 		return
 	}
 
-	// send the loaded message directly to the neighbor
-	_, _ = nbr.Write(marshal(&pb.Message{Data: msgBytes}))
+	// send the loaded message directly to the requesting neighbor, never to any other neighbor
+	b := marshal(&pb.Message{Data: msgBytes})
+	if err := checkPacket(pb.PacketMessage, len(b), nbr); err != nil {
+		m.log.Debugw("message not sent", "peer-id", nbr.ID(), "msg-id", msgID, "err", err)
+		return
+	}
+	if _, err := nbr.Write(b); err != nil {
+		m.log.Debugw("send error", "peer-id", nbr.ID(), "err", err)
+	}
+}
+
+// checkPacket checks whether the packet with the given type and size can be sent to the neighbor.
+func checkPacket(packetType pb.PacketType, size int, nbr *Neighbor) error {
+	features := nbr.Features()
+	if !features.SupportsPacketType(packetType) {
+		return errors.Errorf("packet type %d: %w", packetType, ErrUnsupportedPacket)
+	}
+	if size > int(features.MaxMessageSize) {
+		return errors.Errorf("packet size %d, max %d: %w", size, features.MaxMessageSize, ErrPacketTooLarge)
+	}
+	return nil
 }
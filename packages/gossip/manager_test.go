@@ -359,6 +359,58 @@ func TestMessageRequest(t *testing.T) {
 	mgrB.AssertExpectations(t)
 }
 
+func TestUnsupportedPacketType(t *testing.T) {
+	mgrA, closeA, peerA := newMockedManager(t, "A")
+	// B does not answer message requests
+	mgrB, closeB, peerB := newMockedManager(t, "B", server.WithFeatures(server.Features{
+		Version:        server.DefaultFeatures().Version,
+		PacketTypes:    []pb.PacketType{pb.PacketMessage},
+		MaxMessageSize: pb.MaxPacketSize,
+	}))
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+
+	// connect in the following way
+	// B -> A
+	mgrA.On("neighborAdded", mock.Anything).Once()
+	mgrB.On("neighborAdded", mock.Anything).Once()
+
+	go func() {
+		defer wg.Done()
+		err := mgrA.AddInbound(context.Background(), peerB, NeighborsGroupAuto)
+		assert.NoError(t, err)
+	}()
+	time.Sleep(graceTime)
+	go func() {
+		defer wg.Done()
+		err := mgrB.AddOutbound(context.Background(), peerA, NeighborsGroupAuto)
+		assert.NoError(t, err)
+	}()
+
+	// wait for the connections to establish
+	wg.Wait()
+
+	// the message request must not be sent to B, so A does not receive any message
+	id := tangle.MessageID{}
+	mgrA.RequestMessage(id[:])
+
+	// messages are still sent to B
+	mgrB.On("messageReceived", &MessageReceivedEvent{Data: testMessageData, Peer: peerA}).Once()
+	mgrA.SendMessage(testMessageData)
+	time.Sleep(graceTime)
+
+	mgrA.On("neighborRemoved", mock.Anything).Once()
+	mgrB.On("neighborRemoved", mock.Anything).Once()
+
+	closeA()
+	closeB()
+	time.Sleep(graceTime)
+
+	mgrA.AssertExpectations(t)
+	mgrB.AssertExpectations(t)
+}
+
 func TestDropNeighbor(t *testing.T) {
 	mgrA, closeA, peerA := newTestManager(t, "A")
 	defer closeA()
@@ -474,7 +526,7 @@ func newTestDB(t require.TestingT) *peer.DB {
 	return db
 }
 
-func newTestManager(t require.TestingT, name string) (*Manager, func(), *peer.Peer) {
+func newTestManager(t require.TestingT, name string, opts ...server.ServerOption) (*Manager, func(), *peer.Peer) {
 	l := log.Named(name)
 
 	laddr, err := net.ResolveTCPAddr("tcp", "127.0.0.1:0")
@@ -489,7 +541,7 @@ func newTestManager(t require.TestingT, name string) (*Manager, func(), *peer.Pe
 	local, err := peer.NewLocal(lis.Addr().(*net.TCPAddr).IP, services, newTestDB(t))
 	require.NoError(t, err)
 
-	srv := server.ServeTCP(local, lis, l)
+	srv := server.ServeTCP(local, lis, l, opts...)
 
 	// start the actual gossipping
 	mgr := NewManager(local, loadTestMessage, l)
@@ -503,8 +555,8 @@ func newTestManager(t require.TestingT, name string) (*Manager, func(), *peer.Pe
 	return mgr, detach, local.Peer
 }
 
-func newMockedManager(t *testing.T, name string) (*mockedManager, func(), *peer.Peer) {
-	mgr, detach, p := newTestManager(t, name)
+func newMockedManager(t *testing.T, name string, opts ...server.ServerOption) (*mockedManager, func(), *peer.Peer) {
+	mgr, detach, p := newTestManager(t, name, opts...)
 	return mockManager(t, mgr), detach, p
 }
 
@@ -2,7 +2,6 @@ package gossip
 
 import (
 	"io"
-	"net"
 	"strings"
 	"sync"
 	"time"
@@ -12,6 +11,8 @@ import (
 	"github.com/iotaledger/hive.go/netutil"
 	"github.com/iotaledger/hive.go/netutil/buffconn"
 	"go.uber.org/atomic"
+
+	"github.com/iotaledger/goshimmer/packages/gossip/server"
 )
 
 const (
@@ -36,6 +37,7 @@ type Neighbor struct {
 	*buffconn.BufferedConnection
 
 	Group           NeighborsGroup
+	features        server.Features
 	log             *logger.Logger
 	queue           chan []byte
 	messagesDropped atomic.Int32
@@ -48,7 +50,7 @@ type Neighbor struct {
 }
 
 // NewNeighbor creates a new neighbor from the provided peer and connection.
-func NewNeighbor(p *peer.Peer, group NeighborsGroup, conn net.Conn, log *logger.Logger) *Neighbor {
+func NewNeighbor(p *peer.Peer, group NeighborsGroup, conn *server.Conn, log *logger.Logger) *Neighbor {
 	if !IsSupported(p) {
 		panic("peer does not support gossip")
 	}
@@ -58,11 +60,13 @@ func NewNeighbor(p *peer.Peer, group NeighborsGroup, conn net.Conn, log *logger.
 		"id", p.ID(),
 		"network", conn.LocalAddr().Network(),
 		"addr", conn.RemoteAddr().String(),
+		"version", conn.Features().Version,
 	)
 
 	return &Neighbor{
 		Peer:                  p,
 		Group:                 group,
+		features:              conn.Features(),
 		BufferedConnection:    buffconn.NewBufferedConnection(conn, maxPacketSize),
 		log:                   log,
 		queue:                 make(chan []byte, neighborQueueSize),
@@ -71,6 +75,11 @@ func NewNeighbor(p *peer.Peer, group NeighborsGroup, conn net.Conn, log *logger.
 	}
 }
 
+// Features returns the gossip protocol features of the neighbor that were exchanged during the handshake.
+func (n *Neighbor) Features() server.Features {
+	return n.features
+}
+
 // ConnectionEstablished returns the connection established.
 func (n *Neighbor) ConnectionEstablished() time.Time {
 	return n.connectionEstablished
@@ -15,6 +15,8 @@ import (
 	"github.com/iotaledger/hive.go/identity"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/goshimmer/packages/gossip/server"
 )
 
 var testData = []byte("foobar")
@@ -121,7 +123,7 @@ func TestNeighborParallelWrite(t *testing.T) {
 }
 
 func newTestNeighbor(name string, conn net.Conn) *Neighbor {
-	return NewNeighbor(newTestPeer(name, conn), NeighborsGroupAuto, conn, log.Named(name))
+	return NewNeighbor(newTestPeer(name, conn), NeighborsGroupAuto, server.NewConn(conn, server.DefaultFeatures()), log.Named(name))
 }
 
 func newTestPeer(name string, conn net.Conn) *peer.Peer {
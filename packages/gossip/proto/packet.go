@@ -13,6 +13,14 @@ const (
 	PacketMessageRequest
 )
 
+// MaxPacketSize defines the maximum size of a gossip packet.
+const MaxPacketSize = 65 * 1024
+
+// SupportedPacketTypes returns the types of all packets that are supported by this version of the gossip protocol.
+func SupportedPacketTypes() []PacketType {
+	return []PacketType{PacketMessage, PacketMessageRequest}
+}
+
 // Packet extends the proto.Message interface with additional util functions.
 type Packet interface {
 	proto.Message
@@ -0,0 +1,73 @@
+package server
+
+import (
+	"net"
+
+	gossippb "github.com/iotaledger/goshimmer/packages/gossip/proto"
+)
+
+// Features describes the gossip protocol features of a node that are exchanged during the handshake.
+type Features struct {
+	// Version is the gossip protocol version.
+	Version uint32
+	// PacketTypes contains the types of the gossip packets that the node supports.
+	PacketTypes []gossippb.PacketType
+	// MaxMessageSize is the maximum size of a gossip packet that the node accepts.
+	MaxMessageSize uint32
+}
+
+// DefaultFeatures returns the gossip protocol features of this version of the node.
+func DefaultFeatures() Features {
+	return Features{
+		Version:        versionNum,
+		PacketTypes:    gossippb.SupportedPacketTypes(),
+		MaxMessageSize: gossippb.MaxPacketSize,
+	}
+}
+
+// SupportsPacketType checks whether the given packet type is supported.
+func (f Features) SupportsPacketType(packetType gossippb.PacketType) bool {
+	for _, supported := range f.PacketTypes {
+		if supported == packetType {
+			return true
+		}
+	}
+	return false
+}
+
+func (f Features) packetTypes() []uint32 {
+	result := make([]uint32, len(f.PacketTypes))
+	for i, packetType := range f.PacketTypes {
+		result[i] = uint32(packetType)
+	}
+	return result
+}
+
+func newFeatures(version uint32, packetTypes []uint32, maxMessageSize uint32) Features {
+	f := Features{
+		Version:        version,
+		PacketTypes:    make([]gossippb.PacketType, len(packetTypes)),
+		MaxMessageSize: maxMessageSize,
+	}
+	for i, packetType := range packetTypes {
+		f.PacketTypes[i] = gossippb.PacketType(packetType)
+	}
+	return f
+}
+
+// Conn is an established gossip connection together with the features of the neighbor exchanged during the handshake.
+type Conn struct {
+	net.Conn
+	features Features
+}
+
+// NewConn creates a new Conn for the given connection and features of the neighbor.
+func NewConn(conn net.Conn, features Features) *Conn {
+	return &Conn{Conn: conn, features: features}
+}
+
+// Features returns the gossip protocol features of the neighbor. The version is the highest version supported by both
+// nodes.
+func (c *Conn) Features() Features {
+	return c.features
+}
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"time"
 
+	"github.com/cockroachdb/errors"
 	"github.com/iotaledger/hive.go/autopeering/server"
 	"google.golang.org/protobuf/proto"
 
@@ -11,7 +12,10 @@ import (
 )
 
 const (
-	versionNum          = 0
+	// versionNum is the gossip protocol version of the node.
+	versionNum = 1
+	// minVersionNum is the oldest gossip protocol version of a neighbor that is still supported.
+	minVersionNum       = 1
 	handshakeExpiration = 20 * time.Second
 )
 
@@ -20,60 +24,157 @@ func isExpired(ts int64) bool {
 	return time.Since(time.Unix(ts, 0)) >= handshakeExpiration
 }
 
-func newHandshakeRequest(toAddr string) ([]byte, error) {
+func (t *TCP) newHandshakeRequest(toAddr string, noiseMessage []byte) ([]byte, error) {
 	m := &pb.HandshakeRequest{
-		Version:   versionNum,
-		To:        toAddr,
-		Timestamp: time.Now().Unix(),
+		Version:        t.features.Version,
+		To:             toAddr,
+		Timestamp:      time.Now().Unix(),
+		Capabilities:   t.capabilities(),
+		MinVersion:     t.minVersion,
+		PacketTypes:    t.features.packetTypes(),
+		MaxMessageSize: t.features.MaxMessageSize,
+		NoiseMessage:   noiseMessage,
 	}
 	return proto.Marshal(m)
 }
 
-func newHandshakeResponse(reqData []byte) ([]byte, error) {
+func (t *TCP) newHandshakeResponse(reqData []byte, capabilities uint32, noiseMessage []byte) ([]byte, error) {
 	m := &pb.HandshakeResponse{
-		ReqHash: server.PacketHash(reqData),
+		ReqHash:        server.PacketHash(reqData),
+		Version:        t.features.Version,
+		Capabilities:   capabilities,
+		MinVersion:     t.minVersion,
+		PacketTypes:    t.features.packetTypes(),
+		MaxMessageSize: t.features.MaxMessageSize,
+		NoiseMessage:   noiseMessage,
 	}
 	return proto.Marshal(m)
 }
 
-func (t *TCP) validateHandshakeRequest(reqData []byte) bool {
+// newHandshakeRejection creates a response that only contains the supported versions, so that the requester can tell
+// that its version is not compatible.
+func (t *TCP) newHandshakeRejection(reqData []byte) ([]byte, error) {
+	m := &pb.HandshakeResponse{
+		ReqHash:    server.PacketHash(reqData),
+		Version:    t.features.Version,
+		MinVersion: t.minVersion,
+	}
+	return proto.Marshal(m)
+}
+
+func parseHandshakeRequest(reqData []byte) (*pb.HandshakeRequest, error) {
 	m := new(pb.HandshakeRequest)
 	if err := proto.Unmarshal(reqData, m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (t *TCP) validateHandshakeRequest(reqData []byte) (*pb.HandshakeRequest, error) {
+	m, err := parseHandshakeRequest(reqData)
+	if err != nil {
 		t.log.Debugw("invalid handshake",
 			"err", err,
 		)
-		return false
+		return nil, ErrInvalidHandshake
 	}
-	if m.GetVersion() != versionNum {
-		t.log.Debugw("invalid handshake",
-			"version", m.GetVersion(),
-			"want", versionNum,
-		)
-		return false
+	if err = t.checkVersion(m.GetVersion(), m.GetMinVersion()); err != nil {
+		return nil, err
 	}
 	if isExpired(m.GetTimestamp()) {
 		t.log.Debugw("invalid handshake",
 			"timestamp", time.Unix(m.GetTimestamp(), 0),
 		)
+		return nil, ErrInvalidHandshake
+	}
+	if !t.acceptsCapabilities(m.GetCapabilities(), m.GetNoiseMessage()) {
+		t.log.Warnw("invalid handshake: unsupported transport",
+			"capabilities", m.GetCapabilities(),
+			"want", t.capabilities(),
+		)
+		return nil, ErrInvalidHandshake
+	}
+	if m.GetMaxMessageSize() == 0 {
+		t.log.Debugw("invalid handshake",
+			"maxMessageSize", m.GetMaxMessageSize(),
+		)
+		return nil, ErrInvalidHandshake
 	}
 
-	return true
+	return m, nil
 }
 
-func (t *TCP) validateHandshakeResponse(resData []byte, reqData []byte) bool {
+func (t *TCP) validateHandshakeResponse(resData []byte, reqData []byte) (*pb.HandshakeResponse, error) {
 	m := new(pb.HandshakeResponse)
 	if err := proto.Unmarshal(resData, m); err != nil {
 		t.log.Debugw("invalid handshake",
 			"err", err,
 		)
-		return false
+		return nil, ErrInvalidHandshake
 	}
 	if !bytes.Equal(m.GetReqHash(), server.PacketHash(reqData)) {
 		t.log.Debugw("invalid handshake",
 			"hash", m.GetReqHash(),
 		)
-		return false
+		return nil, ErrInvalidHandshake
+	}
+	if err := t.checkVersion(m.GetVersion(), m.GetMinVersion()); err != nil {
+		return nil, err
+	}
+	if !t.acceptsCapabilities(m.GetCapabilities(), m.GetNoiseMessage()) {
+		t.log.Warnw("invalid handshake: unsupported transport",
+			"capabilities", m.GetCapabilities(),
+			"want", t.capabilities(),
+		)
+		return nil, ErrInvalidHandshake
+	}
+	if m.GetMaxMessageSize() == 0 {
+		t.log.Debugw("invalid handshake",
+			"maxMessageSize", m.GetMaxMessageSize(),
+		)
+		return nil, ErrInvalidHandshake
 	}
 
-	return true
+	return m, nil
+}
+
+// checkVersion checks that the local node supports the protocol version of the remote node and vice versa.
+func (t *TCP) checkVersion(version, minVersion uint32) error {
+	if version >= t.minVersion && t.features.Version >= minVersion {
+		return nil
+	}
+
+	t.log.Warnw("invalid handshake: incompatible gossip protocol version",
+		"version", version,
+		"minVersion", minVersion,
+		"localVersion", t.features.Version,
+		"localMinVersion", t.minVersion,
+	)
+	return errors.Errorf("version %d (min %d) is not compatible with local version %d (min %d): %w",
+		version, minVersion, t.features.Version, t.minVersion, ErrIncompatibleVersion)
+}
+
+// neighborFeatures returns the features of the neighbor that are used for the connection.
+func (t *TCP) neighborFeatures(version uint32, packetTypes []uint32, maxMessageSize uint32) Features {
+	if version > t.features.Version {
+		version = t.features.Version
+	}
+	return newFeatures(version, packetTypes, maxMessageSize)
+}
+
+// capabilities returns the transport capabilities supported by the local node.
+func (t *TCP) capabilities() uint32 {
+	if t.plaintext {
+		return 0
+	}
+	return capEncryption
+}
+
+// acceptsCapabilities checks whether the given capabilities of the peer match the transport of the local node, i.e.
+// plaintext nodes only connect to plaintext nodes and all other nodes require encryption.
+func (t *TCP) acceptsCapabilities(capabilities uint32, noiseMessage []byte) bool {
+	if capabilities&capEncryption == 0 {
+		return t.plaintext
+	}
+	return !t.plaintext && len(noiseMessage) > 0
 }
@@ -0,0 +1,209 @@
+package server
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"io"
+	"net"
+	"sync"
+
+	"github.com/cockroachdb/errors"
+	"github.com/flynn/noise"
+	"github.com/iotaledger/hive.go/autopeering/peer"
+	"github.com/iotaledger/hive.go/crypto/ed25519"
+)
+
+const (
+	// capEncryption signals that the sender supports the encrypted transport.
+	capEncryption uint32 = 1 << 0
+
+	// maxFramePayloadSize is the maximum number of plaintext bytes in a single encrypted frame.
+	maxFramePayloadSize = 64 * 1024
+	// frameHeaderSize is the size of the length prefix of an encrypted frame.
+	frameHeaderSize = 4
+	// frameOverhead is the size of the ChaCha20-Poly1305 authentication tag of an encrypted frame.
+	frameOverhead = 16
+)
+
+var (
+	// noiseCipherSuite is the cipher suite of the Noise handshake and of the encrypted frames.
+	noiseCipherSuite = noise.NewCipherSuite(noise.DH25519, noise.CipherChaChaPoly, noise.HashBLAKE2b)
+	// noisePrologue binds the Noise handshake to the gossip protocol.
+	noisePrologue = []byte("goshimmer gossip")
+	// staticKeySignaturePrefix is prepended to the static Noise key before it is signed with the node identity, so that
+	// the signature can not be confused with any other signature of the node.
+	staticKeySignaturePrefix = []byte("goshimmer gossip static key:")
+)
+
+// ErrInvalidFrame is returned when an encrypted frame could not be authenticated.
+var ErrInvalidFrame = errors.New("invalid encrypted frame")
+
+// noiseHandshake performs the Noise XX handshake of a single connection. The static Noise key is generated for the
+// connection and signed with the node identity. The signature is sent as payload of the handshake message that
+// transmits the static key, so that both peers authenticate the key exchange with their node identities.
+type noiseHandshake struct {
+	state     *noise.HandshakeState
+	signature []byte
+}
+
+func newNoiseHandshake(local *peer.Local, initiator bool) (*noiseHandshake, error) {
+	staticKey, err := noiseCipherSuite.GenerateKeypair(rand.Reader)
+	if err != nil {
+		return nil, errors.Errorf("failed to generate static key: %w", err)
+	}
+	state, err := noise.NewHandshakeState(noise.Config{
+		CipherSuite:   noiseCipherSuite,
+		Random:        rand.Reader,
+		Pattern:       noise.HandshakeXX,
+		Initiator:     initiator,
+		Prologue:      noisePrologue,
+		StaticKeypair: staticKey,
+	})
+	if err != nil {
+		return nil, errors.Errorf("failed to start noise handshake: %w", err)
+	}
+
+	return &noiseHandshake{
+		state:     state,
+		signature: local.Sign(staticKeyMessage(staticKey.Public)).Bytes(),
+	}, nil
+}
+
+// writeMessage returns the next message of the handshake. Once the handshake is complete, it also returns the cipher
+// states of the initiator and of the responder.
+func (h *noiseHandshake) writeMessage() ([]byte, *noise.CipherState, *noise.CipherState, error) {
+	var payload []byte
+	// the first message only contains the ephemeral key, all others the static key
+	if h.state.MessageIndex() > 0 {
+		payload = h.signature
+	}
+	message, initiatorState, responderState, err := h.state.WriteMessage(nil, payload)
+	if err != nil {
+		return nil, nil, nil, errors.Errorf("failed to write noise message: %w", err)
+	}
+	return message, initiatorState, responderState, nil
+}
+
+// readMessage processes the next message of the handshake. If the message transmits the static key of the peer, it
+// checks that the key is signed by the given node identity. Once the handshake is complete, it also returns the cipher
+// states of the initiator and of the responder.
+func (h *noiseHandshake) readMessage(message []byte, key ed25519.PublicKey) (*noise.CipherState, *noise.CipherState, error) {
+	payload, initiatorState, responderState, err := h.state.ReadMessage(nil, message)
+	if err != nil {
+		return nil, nil, errors.Errorf("failed to read noise message (%v): %w", err, ErrInvalidHandshake)
+	}
+	if len(h.state.PeerStatic()) == 0 {
+		return initiatorState, responderState, nil
+	}
+
+	signature, _, err := ed25519.SignatureFromBytes(payload)
+	if err != nil || !key.VerifySignature(staticKeyMessage(h.state.PeerStatic()), signature) {
+		return nil, nil, errors.Errorf("static key is not signed by the peer: %w", ErrInvalidHandshake)
+	}
+	return initiatorState, responderState, nil
+}
+
+// staticKeyMessage returns the message that is signed to bind the given static Noise key to the node identity.
+func staticKeyMessage(staticKey []byte) []byte {
+	return append(append([]byte{}, staticKeySignaturePrefix...), staticKey...)
+}
+
+// writeHandshakeFrame writes a handshake message that is not part of the signed handshake packets.
+func writeHandshakeFrame(conn net.Conn, message []byte) error {
+	frame := make([]byte, frameHeaderSize, frameHeaderSize+len(message))
+	binary.BigEndian.PutUint32(frame, uint32(len(message)))
+	_, err := conn.Write(append(frame, message...))
+	return err
+}
+
+// readHandshakeFrame reads a handshake message that is not part of the signed handshake packets.
+func readHandshakeFrame(conn net.Conn) ([]byte, error) {
+	header := make([]byte, frameHeaderSize)
+	if _, err := io.ReadFull(conn, header); err != nil {
+		return nil, err
+	}
+	length := binary.BigEndian.Uint32(header)
+	if length > maxHandshakePacketSize {
+		return nil, errors.Errorf("handshake frame length %d: %w", length, ErrInvalidHandshake)
+	}
+	message := make([]byte, length)
+	if _, err := io.ReadFull(conn, message); err != nil {
+		return nil, err
+	}
+	return message, nil
+}
+
+// secureConn is a net.Conn that encrypts and authenticates all data with the cipher states of the Noise handshake. The
+// data is sent in frames consisting of the length of the ciphertext and the ciphertext.
+type secureConn struct {
+	net.Conn
+
+	sendState *noise.CipherState
+	sendMutex sync.Mutex
+
+	recvState  *noise.CipherState
+	recvBuffer []byte
+	recvMutex  sync.Mutex
+}
+
+func newSecureConn(conn net.Conn, sendState, recvState *noise.CipherState) *secureConn {
+	return &secureConn{Conn: conn, sendState: sendState, recvState: recvState}
+}
+
+// Write encrypts the given data and writes it to the underlying connection.
+func (c *secureConn) Write(b []byte) (int, error) {
+	c.sendMutex.Lock()
+	defer c.sendMutex.Unlock()
+
+	written := 0
+	for written < len(b) {
+		end := written + maxFramePayloadSize
+		if end > len(b) {
+			end = len(b)
+		}
+
+		frame := make([]byte, frameHeaderSize, frameHeaderSize+end-written+frameOverhead)
+		frame, err := c.sendState.Encrypt(frame, nil, b[written:end])
+		if err != nil {
+			return written, errors.Errorf("failed to encrypt frame: %w", err)
+		}
+		binary.BigEndian.PutUint32(frame, uint32(len(frame)-frameHeaderSize))
+
+		if _, err := c.Conn.Write(frame); err != nil {
+			return written, err
+		}
+		written = end
+	}
+	return written, nil
+}
+
+// Read reads and decrypts data from the underlying connection.
+func (c *secureConn) Read(b []byte) (int, error) {
+	c.recvMutex.Lock()
+	defer c.recvMutex.Unlock()
+
+	if len(c.recvBuffer) == 0 {
+		header := make([]byte, frameHeaderSize)
+		if _, err := io.ReadFull(c.Conn, header); err != nil {
+			return 0, err
+		}
+		length := int(binary.BigEndian.Uint32(header))
+		if length < frameOverhead || length > maxFramePayloadSize+frameOverhead {
+			return 0, errors.Errorf("frame length %d: %w", length, ErrInvalidFrame)
+		}
+
+		ciphertext := make([]byte, length)
+		if _, err := io.ReadFull(c.Conn, ciphertext); err != nil {
+			return 0, err
+		}
+		plaintext, err := c.recvState.Decrypt(ciphertext[:0], nil, ciphertext)
+		if err != nil {
+			return 0, errors.Errorf("%s: %w", err, ErrInvalidFrame)
+		}
+		c.recvBuffer = plaintext
+	}
+
+	n := copy(b, c.recvBuffer)
+	c.recvBuffer = c.recvBuffer[n:]
+	return n, nil
+}
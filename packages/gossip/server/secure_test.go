@@ -0,0 +1,121 @@
+package server
+
+import (
+	"bytes"
+	"io"
+	"net"
+	"testing"
+
+	"github.com/flynn/noise"
+	"github.com/iotaledger/hive.go/autopeering/peer"
+	"github.com/iotaledger/hive.go/autopeering/peer/service"
+	"github.com/iotaledger/hive.go/crypto/ed25519"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestLocal(t *testing.T) *peer.Local {
+	services := service.New()
+	services.Update(service.PeeringKey, "peering", 0)
+	local, err := peer.NewLocal(net.IPv4(127, 0, 0, 1), services, newTestDB(t))
+	require.NoError(t, err)
+	return local
+}
+
+// runNoiseHandshake performs the Noise handshake between the initiator and the responder, where each side expects the
+// given identity of the other side.
+func runNoiseHandshake(t *testing.T, initiator, responder *noiseHandshake, initiatorKey, responderKey ed25519.PublicKey) (initiatorStates, responderStates [2]*noise.CipherState, err error) {
+	msg, _, _, err := initiator.writeMessage()
+	require.NoError(t, err)
+	if _, _, err = responder.readMessage(msg, initiatorKey); err != nil {
+		return initiatorStates, responderStates, err
+	}
+	msg, _, _, err = responder.writeMessage()
+	require.NoError(t, err)
+	if _, _, err = initiator.readMessage(msg, responderKey); err != nil {
+		return initiatorStates, responderStates, err
+	}
+	msg, initiatorStates[0], initiatorStates[1], err = initiator.writeMessage()
+	require.NoError(t, err)
+	responderStates[0], responderStates[1], err = responder.readMessage(msg, initiatorKey)
+	return initiatorStates, responderStates, err
+}
+
+func newSecureConnPair(t *testing.T) (*secureConn, *secureConn, net.Conn) {
+	localA, localB := newTestLocal(t), newTestLocal(t)
+	handshakeA, err := newNoiseHandshake(localA, true)
+	require.NoError(t, err)
+	handshakeB, err := newNoiseHandshake(localB, false)
+	require.NoError(t, err)
+
+	statesA, statesB, err := runNoiseHandshake(t, handshakeA, handshakeB, localA.PublicKey(), localB.PublicKey())
+	require.NoError(t, err)
+
+	rawA, rawB := net.Pipe()
+	return newSecureConn(rawA, statesA[0], statesA[1]), newSecureConn(rawB, statesB[1], statesB[0]), rawB
+}
+
+func TestNoiseHandshake_WrongIdentity(t *testing.T) {
+	localA, localB, impostor := newTestLocal(t), newTestLocal(t), newTestLocal(t)
+
+	// the responder does not have the expected identity
+	handshakeA, err := newNoiseHandshake(localA, true)
+	require.NoError(t, err)
+	handshakeB, err := newNoiseHandshake(impostor, false)
+	require.NoError(t, err)
+	_, _, err = runNoiseHandshake(t, handshakeA, handshakeB, localA.PublicKey(), localB.PublicKey())
+	assert.ErrorIs(t, err, ErrInvalidHandshake)
+
+	// the initiator does not have the expected identity
+	handshakeA, err = newNoiseHandshake(impostor, true)
+	require.NoError(t, err)
+	handshakeB, err = newNoiseHandshake(localB, false)
+	require.NoError(t, err)
+	_, _, err = runNoiseHandshake(t, handshakeA, handshakeB, localA.PublicKey(), localB.PublicKey())
+	assert.ErrorIs(t, err, ErrInvalidHandshake)
+}
+
+func TestSecureConn(t *testing.T) {
+	connA, connB, _ := newSecureConnPair(t)
+	defer connA.Close()
+	defer connB.Close()
+
+	// the data is split into several frames
+	data := bytes.Repeat([]byte("gossip"), maxFramePayloadSize)
+	go func() {
+		_, err := connA.Write(data)
+		assert.NoError(t, err)
+	}()
+
+	received := make([]byte, len(data))
+	_, err := io.ReadFull(connB, received)
+	require.NoError(t, err)
+	assert.Equal(t, data, received)
+}
+
+func TestSecureConn_Encrypted(t *testing.T) {
+	connA, connB, rawB := newSecureConnPair(t)
+	defer connA.Close()
+	defer connB.Close()
+
+	data := []byte("gossip")
+	go func() {
+		_, err := connA.Write(data)
+		assert.NoError(t, err)
+	}()
+
+	// the frame on the wire does not contain the plaintext
+	frame := make([]byte, frameHeaderSize+len(data)+frameOverhead)
+	_, err := io.ReadFull(rawB, frame)
+	require.NoError(t, err)
+	assert.False(t, bytes.Contains(frame, data))
+
+	// a modified frame is rejected
+	frame[len(frame)-1] ^= 1
+	go func() {
+		_, err := connA.Conn.Write(frame)
+		assert.NoError(t, err)
+	}()
+	_, err = connB.Read(make([]byte, len(data)))
+	assert.ErrorIs(t, err, ErrInvalidFrame)
+}
@@ -34,6 +34,8 @@ var (
 	ErrInvalidHandshake = errors.New("invalid handshake")
 	// ErrNoGossip means that the given peer does not support the gossip service.
 	ErrNoGossip = errors.New("peer does not have a gossip service")
+	// ErrIncompatibleVersion is returned when the gossip protocol version of the peer is not supported.
+	ErrIncompatibleVersion = errors.New("incompatible gossip protocol version")
 )
 
 // connection timeouts
@@ -42,17 +44,22 @@ const (
 	handshakeTimeout     = 500 * time.Millisecond             // read/write timeout of the handshake packages
 	defaultAcceptTimeout = 3*time.Second + 2*handshakeTimeout // timeout after which the connection must be accepted.
 
-	maxHandshakePacketSize = 256
+	maxHandshakePacketSize = 512
 )
 
 // retry net.Dial once, on fail after 0.5s
 var dialRetryPolicy = backoff.ConstantBackOff(500 * time.Millisecond).With(backoff.MaxRetries(1))
 
 // TCP establishes verified incoming and outgoing TCP connections to other peers.
+// Unless plaintext is enabled, all connections are encrypted with keys that are negotiated in a Noise XX handshake.
 type TCP struct {
-	local    *peer.Local
-	listener *net.TCPListener
-	log      *zap.SugaredLogger
+	local     *peer.Local
+	listener  *net.TCPListener
+	log       *zap.SugaredLogger
+	plaintext bool
+
+	features   Features // features of the local node that are announced in the handshake
+	minVersion uint32   // oldest protocol version of a neighbor that is accepted
 
 	acceptReceivedCh chan accept
 	matchersMap      map[identity.ID]*acceptMatcher
@@ -65,7 +72,7 @@ type TCP struct {
 
 // connectResult contains the result of an incoming connection.
 type connectResult struct {
-	c   net.Conn
+	c   *Conn
 	err error
 }
 
@@ -82,22 +89,48 @@ type accept struct {
 	fromID identity.ID // ID of the connecting peer
 	req    []byte      // raw data of the handshake request
 	conn   net.Conn    // the actual network connection
+	err    error       // error of the handshake request that needs to be reported to the peer
+}
+
+// ServerOption defines an option for the TCP server.
+type ServerOption func(t *TCP)
+
+// WithPlaintext returns a ServerOption that disables the encryption of the connections.
+// It should only be used for debugging, as plaintext nodes can only connect to other plaintext nodes.
+func WithPlaintext() ServerOption {
+	return func(t *TCP) {
+		t.plaintext = true
+	}
+}
+
+// WithFeatures returns a ServerOption that sets the features announced to the neighbors in the handshake.
+func WithFeatures(features Features) ServerOption {
+	return func(t *TCP) {
+		t.features = features
+	}
 }
 
 // ServeTCP creates the object and starts listening for incoming connections.
-func ServeTCP(local *peer.Local, listener *net.TCPListener, log *zap.SugaredLogger) *TCP {
+func ServeTCP(local *peer.Local, listener *net.TCPListener, log *zap.SugaredLogger, opts ...ServerOption) *TCP {
 	t := &TCP{
 		local:            local,
 		listener:         listener,
 		log:              log,
+		features:         DefaultFeatures(),
+		minVersion:       minVersionNum,
 		acceptReceivedCh: make(chan accept),
 		matchersMap:      map[identity.ID]*acceptMatcher{},
 		closing:          make(chan struct{}),
 	}
+	for _, opt := range opts {
+		opt(t)
+	}
 
 	t.log.Debugw("server started",
 		"network", listener.Addr().Network(),
 		"address", listener.Addr().String(),
+		"plaintext", t.plaintext,
+		"version", t.features.Version,
 	)
 	t.wg.Add(2)
 	go t.run()
@@ -148,14 +181,14 @@ func WithNoDefaultTimeout() ConnectPeerOption {
 
 // DialPeer establishes a gossip connection to the given peer.
 // If the peer does not accept the connection or the handshake fails, an error is returned.
-func (t *TCP) DialPeer(ctx context.Context, p *peer.Peer, opts ...ConnectPeerOption) (net.Conn, error) {
+func (t *TCP) DialPeer(ctx context.Context, p *peer.Peer, opts ...ConnectPeerOption) (*Conn, error) {
 	conf := buildConnectPeerConfig(opts)
 	gossipEndpoint := p.Services().Get(service.GossipKey)
 	if gossipEndpoint == nil {
 		return nil, ErrNoGossip
 	}
 
-	var conn net.Conn
+	var conn *Conn
 	if err := backoff.Retry(dialRetryPolicy, func() error {
 		var err error
 		address := net.JoinHostPort(p.IP().String(), strconv.Itoa(gossipEndpoint.Port()))
@@ -163,13 +196,19 @@ func (t *TCP) DialPeer(ctx context.Context, p *peer.Peer, opts ...ConnectPeerOpt
 		if conf.useDefaultTimeout {
 			dialer.Timeout = defaultDialTimeout
 		}
-		conn, err = dialer.DialContext(ctx, "tcp", address)
+		rawConn, err := dialer.DialContext(ctx, "tcp", address)
 		if err != nil {
 			return fmt.Errorf("dial %s / %s failed: %w", address, p.ID(), err)
 		}
 
-		if err = t.doHandshake(p.PublicKey(), address, conn); err != nil {
-			return fmt.Errorf("handshake %s / %s failed: %w", address, p.ID(), err)
+		if conn, err = t.doHandshake(p.PublicKey(), address, rawConn); err != nil {
+			t.closeConnection(rawConn)
+			err = fmt.Errorf("handshake %s / %s failed: %w", address, p.ID(), err)
+			if errors.Is(err, ErrIncompatibleVersion) {
+				// retrying does not change the version of the peer
+				return backoff.Permanent(err)
+			}
+			return err
 		}
 		return nil
 	}); err != nil {
@@ -179,13 +218,14 @@ func (t *TCP) DialPeer(ctx context.Context, p *peer.Peer, opts ...ConnectPeerOpt
 	t.log.Debugw("outgoing connection established",
 		"id", p.ID(),
 		"addr", conn.RemoteAddr(),
+		"version", conn.Features().Version,
 	)
 	return conn, nil
 }
 
 // AcceptPeer awaits an incoming connection from the given peer.
 // If the peer does not establish the connection or the handshake fails, an error is returned.
-func (t *TCP) AcceptPeer(ctx context.Context, p *peer.Peer, opts ...ConnectPeerOption) (net.Conn, error) {
+func (t *TCP) AcceptPeer(ctx context.Context, p *peer.Peer, opts ...ConnectPeerOption) (*Conn, error) {
 	gossipEndpoint := p.Services().Get(service.GossipKey)
 	if gossipEndpoint == nil {
 		return nil, ErrNoGossip
@@ -200,11 +240,12 @@ func (t *TCP) AcceptPeer(ctx context.Context, p *peer.Peer, opts ...ConnectPeerO
 	t.log.Debugw("incoming connection established",
 		"id", p.ID(),
 		"addr", conn.RemoteAddr(),
+		"version", conn.Features().Version,
 	)
 	return conn, nil
 }
 
-func (t *TCP) acceptPeer(ctx context.Context, p *peer.Peer, opts []ConnectPeerOption) (net.Conn, error) {
+func (t *TCP) acceptPeer(ctx context.Context, p *peer.Peer, opts []ConnectPeerOption) (*Conn, error) {
 	t.wg.Add(1)
 	defer t.wg.Done()
 	conf := buildConnectPeerConfig(opts)
@@ -267,7 +308,7 @@ func (t *TCP) matchNewConnection(a accept) bool {
 	}
 	// finish the handshake
 	t.wg.Add(1)
-	go t.matchAccept(m, a.req, a.conn)
+	go t.matchAccept(m, a)
 	return true
 }
 
@@ -288,13 +329,25 @@ func (t *TCP) removeAcceptMatcher(m *acceptMatcher) {
 	delete(t.matchersMap, m.peer.ID())
 }
 
-func (t *TCP) matchAccept(m *acceptMatcher, req []byte, conn net.Conn) {
+func (t *TCP) matchAccept(m *acceptMatcher, a accept) {
 	defer t.wg.Done()
 
-	if err := t.writeHandshakeResponse(req, conn); err != nil {
+	if a.err != nil {
+		// let the peer know which versions are supported before closing the connection
+		if err := t.writeHandshakeRejection(a.req, a.conn); err != nil {
+			t.log.Debugw("failed to reject handshake", "id", a.fromID, "err", err)
+		}
+		m.connectCh <- connectResult{nil, fmt.Errorf("incoming handshake failed: %w", a.err)}
+
+		t.closeConnection(a.conn)
+		return
+	}
+
+	conn, err := t.writeHandshakeResponse(m.peer.PublicKey(), a.req, a.conn)
+	if err != nil {
 		m.connectCh <- connectResult{nil, fmt.Errorf("incoming handshake failed: %w", err)}
 
-		t.closeConnection(conn)
+		t.closeConnection(a.conn)
 		return
 	}
 	m.connectCh <- connectResult{conn, nil}
@@ -319,7 +372,7 @@ func (t *TCP) listenLoop() {
 		}
 
 		key, req, err := t.readHandshakeRequest(conn)
-		if err != nil {
+		if err != nil && !errors.Is(err, ErrIncompatibleVersion) {
 			t.log.Warnw("failed handshake", "addr", conn.RemoteAddr(), "err", err)
 			t.closeConnection(conn)
 			continue
@@ -330,6 +383,7 @@ func (t *TCP) listenLoop() {
 			fromID: identity.NewID(key),
 			req:    req,
 			conn:   conn,
+			err:    err,
 		}:
 		case <-t.closing:
 			t.closeConnection(conn)
@@ -338,10 +392,22 @@ func (t *TCP) listenLoop() {
 	}
 }
 
-func (t *TCP) doHandshake(key ed25519.PublicKey, remoteAddr string, conn net.Conn) error {
-	reqData, err := newHandshakeRequest(remoteAddr)
+// doHandshake performs the handshake on an outgoing connection and returns the connection to use for the gossip.
+func (t *TCP) doHandshake(key ed25519.PublicKey, remoteAddr string, conn net.Conn) (*Conn, error) {
+	var handshake *noiseHandshake
+	var noiseMessage []byte
+	if !t.plaintext {
+		var err error
+		if handshake, err = newNoiseHandshake(t.local, true); err != nil {
+			return nil, err
+		}
+		if noiseMessage, _, _, err = handshake.writeMessage(); err != nil {
+			return nil, err
+		}
+	}
+	reqData, err := t.newHandshakeRequest(remoteAddr, noiseMessage)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	pkt := &pb.Packet{
@@ -351,46 +417,65 @@ func (t *TCP) doHandshake(key ed25519.PublicKey, remoteAddr string, conn net.Con
 	}
 	b, err := proto.Marshal(pkt)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	if l := len(b); l > maxHandshakePacketSize {
-		return fmt.Errorf("handshake size too large: %d, max %d", l, maxHandshakePacketSize)
+		return nil, fmt.Errorf("handshake size too large: %d, max %d", l, maxHandshakePacketSize)
 	}
 
 	err = conn.SetWriteDeadline(time.Now().Add(handshakeTimeout))
 	if err != nil {
-		return err
+		return nil, err
 	}
 	_, err = conn.Write(b)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	err = conn.SetReadDeadline(time.Now().Add(handshakeTimeout))
 	if err != nil {
-		return err
+		return nil, err
 	}
 	b = make([]byte, maxHandshakePacketSize)
 	n, err := conn.Read(b)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	pkt = &pb.Packet{}
 	err = proto.Unmarshal(b[:n], pkt)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	signer, err := peer.RecoverKeyFromSignedData(pkt)
 	if err != nil || !bytes.Equal(key.Bytes(), signer.Bytes()) {
-		return ErrInvalidHandshake
+		return nil, ErrInvalidHandshake
 	}
-	if !t.validateHandshakeResponse(pkt.GetData(), reqData) {
-		return ErrInvalidHandshake
+	res, err := t.validateHandshakeResponse(pkt.GetData(), reqData)
+	if err != nil {
+		return nil, err
+	}
+	features := t.neighborFeatures(res.GetVersion(), res.GetPacketTypes(), res.GetMaxMessageSize())
+	if res.GetCapabilities()&capEncryption == 0 {
+		return NewConn(conn, features), nil
 	}
 
-	return nil
+	// the response contains the static key of the peer, which must be signed by the expected node
+	if _, _, err = handshake.readMessage(res.GetNoiseMessage(), key); err != nil {
+		return nil, err
+	}
+	finalMessage, sendState, recvState, err := handshake.writeMessage()
+	if err != nil {
+		return nil, err
+	}
+	if err = conn.SetWriteDeadline(time.Now().Add(handshakeTimeout)); err != nil {
+		return nil, err
+	}
+	if err = writeHandshakeFrame(conn, finalMessage); err != nil {
+		return nil, err
+	}
+	return NewConn(newSecureConn(conn, sendState, recvState), features), nil
 }
 
 func (t *TCP) readHandshakeRequest(conn net.Conn) (ed25519.PublicKey, []byte, error) {
@@ -414,19 +499,79 @@ func (t *TCP) readHandshakeRequest(conn net.Conn) (ed25519.PublicKey, []byte, er
 		return ed25519.PublicKey{}, nil, err
 	}
 
-	if !t.validateHandshakeRequest(pkt.GetData()) {
-		return ed25519.PublicKey{}, nil, ErrInvalidHandshake
+	if _, err = t.validateHandshakeRequest(pkt.GetData()); err != nil {
+		if errors.Is(err, ErrIncompatibleVersion) {
+			// the request is still returned, so that the peer can be told about the supported versions
+			return key, pkt.GetData(), err
+		}
+		return ed25519.PublicKey{}, nil, err
 	}
 
 	return key, pkt.GetData(), nil
 }
 
-func (t *TCP) writeHandshakeResponse(reqData []byte, conn net.Conn) error {
-	data, err := newHandshakeResponse(reqData)
+// writeHandshakeResponse answers the handshake request of an incoming connection from the peer with the given key and
+// returns the connection to use for the gossip.
+func (t *TCP) writeHandshakeResponse(key ed25519.PublicKey, reqData []byte, conn net.Conn) (*Conn, error) {
+	req, err := parseHandshakeRequest(reqData)
+	if err != nil {
+		return nil, err
+	}
+
+	// the request has already been checked to match the local transport
+	capabilities := req.GetCapabilities() & t.capabilities()
+	var handshake *noiseHandshake
+	var noiseMessage []byte
+	if capabilities&capEncryption != 0 {
+		if handshake, err = newNoiseHandshake(t.local, false); err != nil {
+			return nil, err
+		}
+		if _, _, err = handshake.readMessage(req.GetNoiseMessage(), key); err != nil {
+			return nil, err
+		}
+		if noiseMessage, _, _, err = handshake.writeMessage(); err != nil {
+			return nil, err
+		}
+	}
+
+	data, err := t.newHandshakeResponse(reqData, capabilities, noiseMessage)
+	if err != nil {
+		return nil, err
+	}
+	if err = t.writeSignedHandshake(data, conn); err != nil {
+		return nil, err
+	}
+
+	features := t.neighborFeatures(req.GetVersion(), req.GetPacketTypes(), req.GetMaxMessageSize())
+	if handshake == nil {
+		return NewConn(conn, features), nil
+	}
+
+	// the final message contains the static key of the peer, which must be signed by the requesting node
+	if err = conn.SetReadDeadline(time.Now().Add(handshakeTimeout)); err != nil {
+		return nil, err
+	}
+	finalMessage, err := readHandshakeFrame(conn)
+	if err != nil {
+		return nil, err
+	}
+	recvState, sendState, err := handshake.readMessage(finalMessage, key)
+	if err != nil {
+		return nil, err
+	}
+	return NewConn(newSecureConn(conn, sendState, recvState), features), nil
+}
+
+// writeHandshakeRejection answers the handshake request of an incoming connection with an incompatible version.
+func (t *TCP) writeHandshakeRejection(reqData []byte, conn net.Conn) error {
+	data, err := t.newHandshakeRejection(reqData)
 	if err != nil {
 		return err
 	}
+	return t.writeSignedHandshake(data, conn)
+}
 
+func (t *TCP) writeSignedHandshake(data []byte, conn net.Conn) error {
 	pkt := &pb.Packet{
 		PublicKey: t.local.PublicKey().Bytes(),
 		Signature: t.local.Sign(data).Bytes(),
@@ -445,9 +590,5 @@ func (t *TCP) writeHandshakeResponse(reqData []byte, conn net.Conn) error {
 		return err
 	}
 	_, err = conn.Write(b)
-	if err != nil {
-		return err
-	}
-
-	return nil
+	return err
 }
@@ -2,6 +2,7 @@ package server
 
 import (
 	"context"
+	"io"
 	"net"
 	"sync"
 	"testing"
@@ -13,6 +14,10 @@ import (
 	"github.com/iotaledger/hive.go/logger"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	gossippb "github.com/iotaledger/goshimmer/packages/gossip/proto"
+	pb "github.com/iotaledger/goshimmer/packages/gossip/server/proto"
 )
 
 const graceTime = 5 * time.Millisecond
@@ -150,6 +155,179 @@ func TestConnect(t *testing.T) {
 	wg.Wait()
 }
 
+func TestConnectExchange(t *testing.T) {
+	for _, plaintext := range []bool{false, true} {
+		var opts []ServerOption
+		if plaintext {
+			opts = append(opts, WithPlaintext())
+		}
+		transA, closeA := newTestServer(t, "A", opts...)
+		transB, closeB := newTestServer(t, "B", opts...)
+
+		var wg sync.WaitGroup
+		wg.Add(2)
+
+		data := []byte("gossip")
+		go func() {
+			defer wg.Done()
+			c, err := transA.AcceptPeer(context.Background(), getPeer(transB))
+			require.NoError(t, err)
+			defer c.Close()
+
+			_, isSecure := c.Conn.(*secureConn)
+			assert.Equal(t, !plaintext, isSecure)
+			received := make([]byte, len(data))
+			_, err = io.ReadFull(c, received)
+			assert.NoError(t, err)
+			assert.Equal(t, data, received)
+		}()
+		time.Sleep(graceTime)
+		go func() {
+			defer wg.Done()
+			c, err := transB.DialPeer(context.Background(), getPeer(transA))
+			require.NoError(t, err)
+			defer c.Close()
+
+			_, err = c.Write(data)
+			assert.NoError(t, err)
+		}()
+
+		wg.Wait()
+		closeA()
+		closeB()
+	}
+}
+
+func TestTransportMismatch(t *testing.T) {
+	transA, closeA := newTestServer(t, "A", WithPlaintext())
+	defer closeA()
+	transB, closeB := newTestServer(t, "B")
+	defer closeB()
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+
+	// A only uses plaintext, but B requires encryption
+	go func() {
+		defer wg.Done()
+		_, err := transA.AcceptPeer(context.Background(), getPeer(transB))
+		assert.Error(t, err)
+	}()
+	time.Sleep(graceTime)
+	go func() {
+		defer wg.Done()
+		_, err := transB.DialPeer(context.Background(), getPeer(transA))
+		assert.Error(t, err)
+	}()
+
+	wg.Wait()
+}
+
+func TestExpiredHandshake(t *testing.T) {
+	transA, closeA := newTestServer(t, "A", WithPlaintext())
+	defer closeA()
+
+	newRequest := func(timestamp time.Time) []byte {
+		reqData, err := proto.Marshal(&pb.HandshakeRequest{
+			Version:        versionNum,
+			MinVersion:     minVersionNum,
+			Timestamp:      timestamp.Unix(),
+			MaxMessageSize: DefaultFeatures().MaxMessageSize,
+		})
+		require.NoError(t, err)
+		return reqData
+	}
+
+	_, err := transA.validateHandshakeRequest(newRequest(time.Now()))
+	assert.NoError(t, err)
+	_, err = transA.validateHandshakeRequest(newRequest(time.Now().Add(-handshakeExpiration)))
+	assert.ErrorIs(t, err, ErrInvalidHandshake)
+}
+
+func TestIncompatibleVersion(t *testing.T) {
+	transA, closeA := newTestServer(t, "A")
+	defer closeA()
+	// B no longer supports the version of A
+	transB, closeB := newTestServer(t, "B", WithFeatures(Features{
+		Version:        versionNum + 1,
+		PacketTypes:    DefaultFeatures().PacketTypes,
+		MaxMessageSize: DefaultFeatures().MaxMessageSize,
+	}), func(t *TCP) { t.minVersion = versionNum + 1 })
+	defer closeB()
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+
+	go func() {
+		defer wg.Done()
+		_, err := transA.AcceptPeer(context.Background(), getPeer(transB))
+		assert.ErrorIs(t, err, ErrIncompatibleVersion)
+	}()
+	time.Sleep(graceTime)
+	go func() {
+		defer wg.Done()
+		_, err := transB.DialPeer(context.Background(), getPeer(transA))
+		assert.ErrorIs(t, err, ErrIncompatibleVersion)
+	}()
+
+	wg.Wait()
+
+	// the dialing peer learns about the incompatible version from the rejection
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		_, err := transB.AcceptPeer(context.Background(), getPeer(transA))
+		assert.ErrorIs(t, err, ErrIncompatibleVersion)
+	}()
+	time.Sleep(graceTime)
+	go func() {
+		defer wg.Done()
+		_, err := transA.DialPeer(context.Background(), getPeer(transB))
+		assert.ErrorIs(t, err, ErrIncompatibleVersion)
+	}()
+
+	wg.Wait()
+}
+
+func TestFeaturesExchange(t *testing.T) {
+	featuresA := Features{
+		Version:        versionNum + 1,
+		PacketTypes:    []gossippb.PacketType{gossippb.PacketMessage},
+		MaxMessageSize: 1024,
+	}
+	transA, closeA := newTestServer(t, "A", WithFeatures(featuresA))
+	defer closeA()
+	transB, closeB := newTestServer(t, "B")
+	defer closeB()
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+
+	go func() {
+		defer wg.Done()
+		c, err := transA.AcceptPeer(context.Background(), getPeer(transB))
+		require.NoError(t, err)
+		defer c.Close()
+
+		assert.Equal(t, DefaultFeatures(), c.Features())
+	}()
+	time.Sleep(graceTime)
+	go func() {
+		defer wg.Done()
+		c, err := transB.DialPeer(context.Background(), getPeer(transA))
+		require.NoError(t, err)
+		defer c.Close()
+
+		// the connection uses the highest version supported by both nodes
+		assert.EqualValues(t, versionNum, c.Features().Version)
+		assert.True(t, c.Features().SupportsPacketType(gossippb.PacketMessage))
+		assert.False(t, c.Features().SupportsPacketType(gossippb.PacketMessageRequest))
+		assert.EqualValues(t, 1024, c.Features().MaxMessageSize)
+	}()
+
+	wg.Wait()
+}
+
 func TestWrongConnect(t *testing.T) {
 	transA, closeA := newTestServer(t, "A")
 	defer closeA()
@@ -182,7 +360,7 @@ func newTestDB(t require.TestingT) *peer.DB {
 	return db
 }
 
-func newTestServer(t require.TestingT, name string) (*TCP, func()) {
+func newTestServer(t require.TestingT, name string, opts ...ServerOption) (*TCP, func()) {
 	l := log.Named(name)
 
 	laddr, err := net.ResolveTCPAddr("tcp", "127.0.0.1:0")
@@ -197,7 +375,7 @@ func newTestServer(t require.TestingT, name string) (*TCP, func()) {
 	local, err := peer.NewLocal(lis.Addr().(*net.TCPAddr).IP, services, newTestDB(t))
 	require.NoError(t, err)
 
-	srv := ServeTCP(local, lis, l)
+	srv := ServeTCP(local, lis, l, opts...)
 
 	teardown := func() {
 		srv.Close()
@@ -25,6 +25,13 @@ type Committee struct {
 	Threshold     uint8    `json:"threshold,omitempty"`
 	Identities    []string `json:"identities,omitempty"`
 	DistributedPK string   `json:"distributedPK,omitempty"`
+	RoundInterval string   `json:"roundInterval,omitempty"`
+}
+
+// LoadInstanceResponse is the HTTP response from loading a dRNG instance.
+type LoadInstanceResponse struct {
+	Committee *Committee `json:"committee,omitempty"`
+	Error     string     `json:"error,omitempty"`
 }
 
 // RandomnessResponse is the HTTP message containing the current DRNG randomness.
@@ -40,3 +47,20 @@ type Randomness struct {
 	Timestamp  time.Time `json:"timestamp,omitempty"`
 	Randomness []byte    `json:"randomness,omitempty"`
 }
+
+// HistoryResponse is the HTTP message containing the persisted beacons of a DRNG instance.
+type HistoryResponse struct {
+	InstanceID uint32   `json:"instanceID,omitempty"`
+	Beacons    []Beacon `json:"beacons,omitempty"`
+	Error      string   `json:"error,omitempty"`
+}
+
+// Beacon defines a persisted collective beacon and the randomness derived from it.
+type Beacon struct {
+	Round         uint64    `json:"round"`
+	Timestamp     time.Time `json:"timestamp"`
+	MessageID     string    `json:"messageID"`
+	PrevSignature []byte    `json:"prevSignature"`
+	Signature     []byte    `json:"signature"`
+	Randomness    []byte    `json:"randomness"`
+}
@@ -0,0 +1,97 @@
+package jsonmodels
+
+import (
+	"time"
+
+	"github.com/iotaledger/goshimmer/packages/consensus/fcob"
+)
+
+// region OpinionAudit /////////////////////////////////////////////////////////////////////////////////////////////////
+
+// OpinionAudit represents the JSON model of the audit of a stored FCoB opinion of a transaction.
+type OpinionAudit struct {
+	TransactionID   string   `json:"transactionID"`
+	Timestamp       int64    `json:"timestamp"`
+	Liked           bool     `json:"liked"`
+	LoK             string   `json:"lok"`
+	FCOBTime1       int64    `json:"fcobTime1"`
+	FCOBTime2       int64    `json:"fcobTime2"`
+	ExpectedLiked   bool     `json:"expectedLiked"`
+	ExpectedLoK     string   `json:"expectedLoK"`
+	Consistent      bool     `json:"consistent"`
+	Inconsistencies []string `json:"inconsistencies,omitempty"`
+	Error           string   `json:"error,omitempty"`
+}
+
+// NewOpinionAudit returns the OpinionAudit from the given fcob.OpinionAudit.
+func NewOpinionAudit(audit *fcob.OpinionAudit) *OpinionAudit {
+	return &OpinionAudit{
+		TransactionID:   audit.TransactionID.Base58(),
+		Timestamp:       unixNano(audit.Stored.Timestamp()),
+		Liked:           audit.Stored.Liked(),
+		LoK:             audit.Stored.LevelOfKnowledge().String(),
+		FCOBTime1:       unixNano(audit.FCOBTime1),
+		FCOBTime2:       unixNano(audit.FCOBTime2),
+		ExpectedLiked:   audit.Expected.Liked(),
+		ExpectedLoK:     audit.Expected.LevelOfKnowledge().String(),
+		Consistent:      audit.Consistent(),
+		Inconsistencies: audit.Inconsistencies,
+	}
+}
+
+// OpinionAuditsResponse is the HTTP response containing the audits of the stored FCoB opinions of transactions.
+type OpinionAuditsResponse struct {
+	Audits []*OpinionAudit `json:"audits"`
+	Error  string          `json:"error,omitempty"`
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region TimestampOpinionAudit ////////////////////////////////////////////////////////////////////////////////////////
+
+// TimestampOpinionAudit represents the JSON model of the audit of a stored FCoB timestamp opinion of a message.
+type TimestampOpinionAudit struct {
+	MessageID       string   `json:"messageID"`
+	IssuingTime     int64    `json:"issuingTime"`
+	ReceivedTime    int64    `json:"receivedTime"`
+	Opinion         string   `json:"opinion"`
+	LoK             string   `json:"lok"`
+	ExpectedOpinion string   `json:"expectedOpinion"`
+	ExpectedLoK     string   `json:"expectedLoK"`
+	Consistent      bool     `json:"consistent"`
+	Inconsistencies []string `json:"inconsistencies,omitempty"`
+	Error           string   `json:"error,omitempty"`
+}
+
+// NewTimestampOpinionAudit returns the TimestampOpinionAudit from the given fcob.TimestampOpinionAudit.
+func NewTimestampOpinionAudit(audit *fcob.TimestampOpinionAudit) *TimestampOpinionAudit {
+	return &TimestampOpinionAudit{
+		MessageID:       audit.MessageID.Base58(),
+		IssuingTime:     unixNano(audit.IssuingTime),
+		ReceivedTime:    unixNano(audit.ReceivedTime),
+		Opinion:         audit.Stored.Value.String(),
+		LoK:             audit.Stored.LoK.String(),
+		ExpectedOpinion: audit.Expected.Value.String(),
+		ExpectedLoK:     audit.Expected.LoK.String(),
+		Consistent:      audit.Consistent(),
+		Inconsistencies: audit.Inconsistencies,
+	}
+}
+
+// TimestampOpinionAuditsResponse is the HTTP response containing the audits of the stored FCoB timestamp opinions of
+// messages.
+type TimestampOpinionAuditsResponse struct {
+	Audits []*TimestampOpinionAudit `json:"audits"`
+	Error  string                   `json:"error,omitempty"`
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// unixNano returns the given time in nanoseconds since the unix epoch or 0 if the time is not set.
+func unixNano(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+
+	return t.UnixNano()
+}
@@ -10,6 +10,8 @@ type InfoResponse struct {
 	Version string `json:"version,omitempty"`
 	// Network Version of the autopeering
 	NetworkVersion uint32 `json:"networkVersion,omitempty"`
+	// human-readable part of the bech32 encoded addresses of the network
+	Bech32HRP string `json:"bech32HRP,omitempty"`
 	// TangleTime sync status
 	TangleTime TangleTime `json:"tangleTime,omitempty"`
 	// identity ID of the node encoded in base58
@@ -36,6 +38,8 @@ type InfoResponse struct {
 	ManaDecay float64 `json:"mana_decay"`
 	// Scheduler is the scheduler.
 	Scheduler Scheduler `json:"scheduler"`
+	// FCOB contains the parameters of the FCoB rule.
+	FCOB FCOB `json:"fcob"`
 	// error of the response
 	Error string `json:"error,omitempty"`
 }
@@ -62,6 +66,15 @@ type Scheduler struct {
 	NodeQueueSizes map[string]int `json:"nodeQueueSizes"`
 }
 
+// FCOB contains the parameters of the FCoB rule.
+type FCOB struct {
+	LikedThreshold            string `json:"likedThreshold"`
+	LocallyFinalizedThreshold string `json:"locallyFinalizedThreshold"`
+	TimestampWindow           string `json:"timestampWindow"`
+	GratuitousNetworkDelay    string `json:"gratuitousNetworkDelay"`
+	LevelOfKnowledgeStrategy  string `json:"levelOfKnowledgeStrategy"`
+}
+
 // RateSetter is the rate setter details.
 type RateSetter struct {
 	Rate float64 `json:"rate"`
@@ -18,6 +18,7 @@ import (
 type Address struct {
 	Type   string `json:"type"`
 	Base58 string `json:"base58"`
+	Bech32 string `json:"bech32"`
 }
 
 // NewAddress returns an Address from the given ledgerstate.Address.
@@ -25,6 +26,7 @@ func NewAddress(address ledgerstate.Address) *Address {
 	return &Address{
 		Type:   address.Type().String(),
 		Base58: address.Base58(),
+		Bech32: address.Bech32(),
 	}
 }
 
@@ -157,7 +159,7 @@ type SigLockedSingleOutput struct {
 
 // ToLedgerStateOutput builds a ledgerstate.Output from SigLockedSingleOutput with the given outputID.
 func (s *SigLockedSingleOutput) ToLedgerStateOutput(id ledgerstate.OutputID) (ledgerstate.Output, error) {
-	addy, err := ledgerstate.AddressFromBase58EncodedString(s.Address)
+	addy, err := ledgerstate.AddressFromString(s.Address)
 	if err != nil {
 		return nil, errors.Errorf("wrong address in SigLockedSingleOutput: %w", err)
 	}
@@ -201,7 +203,7 @@ type SigLockedColoredOutput struct {
 
 // ToLedgerStateOutput builds a ledgerstate.Output from SigLockedSingleOutput with the given outputID.
 func (s *SigLockedColoredOutput) ToLedgerStateOutput(id ledgerstate.OutputID) (ledgerstate.Output, error) {
-	addy, err := ledgerstate.AddressFromBase58EncodedString(s.Address)
+	addy, err := ledgerstate.AddressFromString(s.Address)
 	if err != nil {
 		return nil, errors.Errorf("wrong address in SigLockedSingleOutput: %w", err)
 	}
@@ -267,12 +269,12 @@ func (a *AliasOutput) ToLedgerStateOutput(id ledgerstate.OutputID) (ledgerstate.
 		return nil, errors.Errorf("failed to parse colored balances: %w", err)
 	}
 	// alias address
-	aliasAddy, aErr := ledgerstate.AliasAddressFromBase58EncodedString(a.AliasAddress)
+	aliasAddy, aErr := ledgerstate.AliasAddressFromString(a.AliasAddress)
 	if aErr != nil {
 		return nil, errors.Errorf("wrong alias address in AliasOutput: %w", err)
 	}
 	// state address
-	stateAddy, aErr := ledgerstate.AddressFromBase58EncodedString(a.StateAddress)
+	stateAddy, aErr := ledgerstate.AddressFromString(a.StateAddress)
 	if aErr != nil {
 		return nil, errors.Errorf("wrong state address in AliasOutput: %w", err)
 	}
@@ -322,7 +324,7 @@ func (a *AliasOutput) ToLedgerStateOutput(id ledgerstate.OutputID) (ledgerstate.
 		}
 	}
 	if a.GoverningAddress != "" {
-		addy, gErr := ledgerstate.AddressFromBase58EncodedString(a.GoverningAddress)
+		addy, gErr := ledgerstate.AddressFromString(a.GoverningAddress)
 		if gErr != nil {
 			return nil, gErr
 		}
@@ -391,7 +393,7 @@ type ExtendedLockedOutput struct {
 
 // ToLedgerStateOutput builds a ledgerstate.Output from ExtendedLockedOutput with the given outputID.
 func (e *ExtendedLockedOutput) ToLedgerStateOutput(id ledgerstate.OutputID) (ledgerstate.Output, error) {
-	addy, err := ledgerstate.AddressFromBase58EncodedString(e.Address)
+	addy, err := ledgerstate.AddressFromString(e.Address)
 	if err != nil {
 		return nil, errors.Errorf("wrong address in ExtendedLockedOutput: %w", err)
 	}
@@ -403,7 +405,7 @@ func (e *ExtendedLockedOutput) ToLedgerStateOutput(id ledgerstate.OutputID) (led
 	res := ledgerstate.NewExtendedLockedOutput(balances.Map(), addy)
 
 	if e.FallbackAddress != "" && e.FallbackDeadline != 0 {
-		fallbackAddy, fErr := ledgerstate.AddressFromBase58EncodedString(e.FallbackAddress)
+		fallbackAddy, fErr := ledgerstate.AddressFromString(e.FallbackAddress)
 		if fErr != nil {
 			return nil, errors.Errorf("wrong fallback address in ExtendedLockedOutput: %w", err)
 		}
@@ -624,6 +626,114 @@ func NewConflict(conflictID ledgerstate.ConflictID, branchIDs []ledgerstate.Bran
 
 // endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
 
+// region ConflictTimelineEntry ////////////////////////////////////////////////////////////////////////////////////////
+
+// ConflictTimelineEntry represents the JSON model of a ledgerstate.ConflictTimelineEntry.
+type ConflictTimelineEntry struct {
+	Timestamp        int64   `json:"timestamp"`
+	Type             string  `json:"type"`
+	BranchID         string  `json:"branchID"`
+	Liked            bool    `json:"liked"`
+	LevelOfKnowledge uint8   `json:"levelOfKnowledge,omitempty"`
+	Round            uint32  `json:"round,omitempty"`
+	ProportionLiked  float64 `json:"proportionLiked,omitempty"`
+	ApprovalWeight   float64 `json:"approvalWeight,omitempty"`
+}
+
+// NewConflictTimelineEntry returns a ConflictTimelineEntry from the given ledgerstate.ConflictTimelineEntry.
+func NewConflictTimelineEntry(conflictTimelineEntry *ledgerstate.ConflictTimelineEntry) *ConflictTimelineEntry {
+	result := &ConflictTimelineEntry{
+		Timestamp:        conflictTimelineEntry.Timestamp().UnixNano(),
+		Type:             conflictTimelineEntry.Type().String(),
+		BranchID:         conflictTimelineEntry.BranchID().Base58(),
+		Liked:            conflictTimelineEntry.Liked(),
+		LevelOfKnowledge: conflictTimelineEntry.LevelOfKnowledge(),
+		Round:            conflictTimelineEntry.Round(),
+	}
+
+	switch conflictTimelineEntry.Type() {
+	case ledgerstate.VotingRoundEntryType:
+		result.ProportionLiked = conflictTimelineEntry.Value()
+	case ledgerstate.ApprovalWeightEntryType:
+		result.ApprovalWeight = conflictTimelineEntry.Value()
+	}
+
+	return result
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region ColorSupply //////////////////////////////////////////////////////////////////////////////////////////////////
+
+// ColorSupply represents the JSON model of a ledgerstate.ColorSupply.
+type ColorSupply struct {
+	Color                string `json:"color"`
+	MintingTransactionID string `json:"mintingTransactionID"`
+	Minted               uint64 `json:"minted"`
+	Burned               uint64 `json:"burned"`
+	Circulating          uint64 `json:"circulating"`
+	Holders              uint64 `json:"holders"`
+}
+
+// NewColorSupply returns a ColorSupply from the given ledgerstate.ColorSupply.
+func NewColorSupply(colorSupply *ledgerstate.ColorSupply) *ColorSupply {
+	return &ColorSupply{
+		Color:                colorSupply.Color().Base58(),
+		MintingTransactionID: colorSupply.MintingTransactionID().Base58(),
+		Minted:               colorSupply.Minted(),
+		Burned:               colorSupply.Burned(),
+		Circulating:          colorSupply.Circulating(),
+		Holders:              colorSupply.Holders(),
+	}
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region AliasStateRecord /////////////////////////////////////////////////////////////////////////////////////////////
+
+// AliasStateRecord represents the JSON model of a ledgerstate.AliasStateRecord.
+type AliasStateRecord struct {
+	StateIndex       uint32    `json:"stateIndex"`
+	OutputID         *OutputID `json:"outputID"`
+	TransactionID    string    `json:"transactionID"`
+	Timestamp        int64     `json:"timestamp"`
+	GovernanceUpdate bool      `json:"governanceUpdate"`
+	InclusionState   string    `json:"inclusionState"`
+}
+
+// NewAliasStateRecord returns an AliasStateRecord from the given ledgerstate.AliasStateRecord and the InclusionState of
+// the Transaction that created it.
+func NewAliasStateRecord(aliasStateRecord *ledgerstate.AliasStateRecord, inclusionState ledgerstate.InclusionState) *AliasStateRecord {
+	return &AliasStateRecord{
+		StateIndex:       aliasStateRecord.StateIndex(),
+		OutputID:         NewOutputID(aliasStateRecord.OutputID()),
+		TransactionID:    aliasStateRecord.TransactionID().Base58(),
+		Timestamp:        aliasStateRecord.Timestamp().Unix(),
+		GovernanceUpdate: aliasStateRecord.GovernanceUpdate(),
+		InclusionState:   inclusionState.String(),
+	}
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region AliasState ///////////////////////////////////////////////////////////////////////////////////////////////////
+
+// AliasState represents the JSON model of a single version of an alias chain together with its AliasOutput.
+type AliasState struct {
+	Record *AliasStateRecord `json:"record"`
+	Output *Output           `json:"output"`
+}
+
+// NewAliasState returns an AliasState from the given details.
+func NewAliasState(aliasStateRecord *ledgerstate.AliasStateRecord, inclusionState ledgerstate.InclusionState, output ledgerstate.Output) *AliasState {
+	return &AliasState{
+		Record: NewAliasStateRecord(aliasStateRecord, inclusionState),
+		Output: NewOutput(output),
+	}
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
 // region Transaction //////////////////////////////////////////////////////////////////////////////////////////////////
 
 // Transaction represents the JSON model of a ledgerstate.Transaction.
@@ -124,8 +124,65 @@ type AllowedManaPledgeResponse struct {
 	Error     string        `json:"error,omitempty"`
 }
 
-// AllowedPledge represents the nodes that mana is allowed to be pledged to.
+// AllowedPledge represents the pledge policy that decides to which nodes mana is allowed to be pledged to.
 type AllowedPledge struct {
 	IsFilterEnabled bool     `json:"isFilterEnabled"`
 	Allowed         []string `json:"allowed,omitempty"`
+	Mode            string   `json:"mode"`
+	Denied          []string `json:"denied,omitempty"`
+	MinMana         float64  `json:"minMana"`
+	MaxShare        float64  `json:"maxShare"`
+	RateLimit       int      `json:"rateLimit"`
+	RateLimitWindow string   `json:"rateLimitWindow"`
+}
+
+// GetManaHistoryResponse is the response of mana/history.
+type GetManaHistoryResponse struct {
+	Error       string             `json:"error,omitempty"`
+	ShortNodeID string             `json:"shortNodeID"`
+	NodeID      string             `json:"nodeID"`
+	Access      []ManaHistoryPoint `json:"access"`
+	Consensus   []ManaHistoryPoint `json:"consensus"`
+	From        int64              `json:"from"`
+	To          int64              `json:"to"`
+}
+
+// ManaHistoryPoint is the mana of a node at a certain time.
+type ManaHistoryPoint struct {
+	Mana      float64 `json:"mana"`
+	Timestamp int64   `json:"timestamp"`
+}
+
+// GetDelegationsResponse is the response of mana/delegations.
+type GetDelegationsResponse struct {
+	Delegations []*DelegationRecord `json:"delegations"`
+	Error       string              `json:"error,omitempty"`
+}
+
+// GetDelegationResponse is the response of mana/delegations/:delegationID.
+type GetDelegationResponse struct {
+	Delegation *DelegationRecord `json:"delegation,omitempty"`
+	Error      string            `json:"error,omitempty"`
+}
+
+// DelegationRecord contains the accounting information of a single delegation to a node.
+type DelegationRecord struct {
+	DelegationID         string               `json:"delegationID"`
+	Delegator            string               `json:"delegator"`
+	OutputID             string               `json:"outputID"`
+	Amount               uint64               `json:"amount"`
+	Timelock             int64                `json:"timelock,omitempty"`
+	TimelockExpired      bool                 `json:"timelockExpired"`
+	FirstSeen            int64                `json:"firstSeen"`
+	PledgedAccessMana    float64              `json:"pledgedAccessMana"`
+	PledgedConsensusMana float64              `json:"pledgedConsensusMana"`
+	Refreshes            []*DelegationRefresh `json:"refreshes"`
+}
+
+// DelegationRefresh contains the information of a single refresh of a delegation.
+type DelegationRefresh struct {
+	Time          int64   `json:"time"`
+	TransactionID string  `json:"transactionID"`
+	AccessMana    float64 `json:"accessMana"`
+	ConsensusMana float64 `json:"consensusMana"`
 }
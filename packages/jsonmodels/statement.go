@@ -0,0 +1,150 @@
+package jsonmodels
+
+import (
+	"sort"
+
+	"github.com/iotaledger/hive.go/identity"
+	"github.com/mr-tron/base58"
+
+	"github.com/iotaledger/goshimmer/packages/vote/opinion"
+	"github.com/iotaledger/goshimmer/packages/vote/statement"
+)
+
+// region NodeStatement ////////////////////////////////////////////////////////////////////////////////////////////////
+
+// NodeStatement represents the JSON model of a Statement that was issued by a node.
+type NodeStatement struct {
+	MessageID         string             `json:"messageID"`
+	ReceivedTime      int64              `json:"receivedTime"`
+	Version           uint8              `json:"version"`
+	Checkpoint        bool               `json:"checkpoint"`
+	SequenceNumber    uint64             `json:"sequenceNumber"`
+	RoundsElapsed     uint8              `json:"roundsElapsed"`
+	Conflicts         []StatementOpinion `json:"conflicts"`
+	Timestamps        []StatementOpinion `json:"timestamps"`
+	RemovedConflicts  []string           `json:"removedConflicts,omitempty"`
+	RemovedTimestamps []string           `json:"removedTimestamps,omitempty"`
+}
+
+// NewNodeStatement returns the NodeStatement from the given statement.NodeStatement.
+func NewNodeStatement(nodeStatement *statement.NodeStatement) *NodeStatement {
+	s := nodeStatement.Statement()
+
+	result := &NodeStatement{
+		MessageID:      nodeStatement.MessageID().Base58(),
+		ReceivedTime:   nodeStatement.ReceivedTime().UnixNano(),
+		Version:        s.Version,
+		Checkpoint:     s.Checkpoint,
+		SequenceNumber: s.SequenceNumber,
+		RoundsElapsed:  s.RoundsElapsed,
+		Conflicts:      make([]StatementOpinion, 0, len(s.Conflicts)),
+		Timestamps:     make([]StatementOpinion, 0, len(s.Timestamps)),
+	}
+	for _, conflict := range s.Conflicts {
+		result.Conflicts = append(result.Conflicts, StatementOpinion{ID: conflict.ID.Base58(), Value: conflict.Value.String(), Round: conflict.Round})
+	}
+	for _, timestamp := range s.Timestamps {
+		result.Timestamps = append(result.Timestamps, StatementOpinion{ID: timestamp.ID.Base58(), Value: timestamp.Value.String(), Round: timestamp.Round})
+	}
+	for _, conflictID := range s.RemovedConflicts {
+		result.RemovedConflicts = append(result.RemovedConflicts, conflictID.Base58())
+	}
+	for _, messageID := range s.RemovedTimestamps {
+		result.RemovedTimestamps = append(result.RemovedTimestamps, messageID.Base58())
+	}
+
+	return result
+}
+
+// StatementOpinion represents the JSON model of an opinion about a conflict or timestamp in a Statement.
+type StatementOpinion struct {
+	ID    string `json:"id"`
+	Value string `json:"value"`
+	Round uint8  `json:"round"`
+}
+
+// NodeStatementsResponse is the HTTP response containing the persisted Statements of a node.
+type NodeStatementsResponse struct {
+	NodeID      string           `json:"nodeID"`
+	ShortNodeID string           `json:"shortNodeID"`
+	Statements  []*NodeStatement `json:"statements"`
+	Error       string           `json:"error,omitempty"`
+}
+
+// NewNodeStatementsResponse returns the NodeStatementsResponse from the given node ID and statement.NodeStatements.
+func NewNodeStatementsResponse(nodeID identity.ID, nodeStatements statement.NodeStatements) *NodeStatementsResponse {
+	response := &NodeStatementsResponse{
+		NodeID:      base58.Encode(nodeID.Bytes()),
+		ShortNodeID: nodeID.String(),
+		Statements:  make([]*NodeStatement, 0, len(nodeStatements)),
+	}
+	for _, nodeStatement := range nodeStatements {
+		response.Statements = append(response.Statements, NewNodeStatement(nodeStatement))
+	}
+
+	return response
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region NodeOpinions /////////////////////////////////////////////////////////////////////////////////////////////////
+
+// NodeOpinions represents the JSON model of the opinion history that a node stated about a conflict or timestamp.
+type NodeOpinions struct {
+	NodeID      string         `json:"nodeID"`
+	ShortNodeID string         `json:"shortNodeID"`
+	Opinions    []RoundOpinion `json:"opinions"`
+	Last        string         `json:"last"`
+	Diverging   bool           `json:"diverging"`
+}
+
+// RoundOpinion represents the JSON model of the opinion of a node in an FPC round.
+type RoundOpinion struct {
+	Value string `json:"value"`
+	Round uint8  `json:"round"`
+}
+
+// NodesOpinionsResponse is the HTTP response containing the opinions that all nodes stated about a conflict or
+// timestamp. A node is diverging if its opinion of the last round differs from the majority.
+type NodesOpinionsResponse struct {
+	ID       string          `json:"id"`
+	Majority string          `json:"majority"`
+	Nodes    []*NodeOpinions `json:"nodes"`
+	Error    string          `json:"error,omitempty"`
+}
+
+// NewNodesOpinionsResponse returns the NodesOpinionsResponse from the given ID and opinion histories of the nodes.
+func NewNodesOpinionsResponse(id string, nodesOpinions map[identity.ID]statement.Opinions) *NodesOpinionsResponse {
+	majority := statement.MajorityOpinion(nodesOpinions)
+
+	response := &NodesOpinionsResponse{
+		ID:       id,
+		Majority: majority.String(),
+		Nodes:    make([]*NodeOpinions, 0, len(nodesOpinions)),
+	}
+	for nodeID, opinions := range nodesOpinions {
+		if len(opinions) == 0 {
+			continue
+		}
+
+		last := opinions.Last()
+		nodeOpinions := &NodeOpinions{
+			NodeID:      base58.Encode(nodeID.Bytes()),
+			ShortNodeID: nodeID.String(),
+			Opinions:    make([]RoundOpinion, 0, len(opinions)),
+			Last:        last.Value.String(),
+			Diverging:   majority != opinion.Unknown && last.Value != majority,
+		}
+		for _, o := range opinions {
+			nodeOpinions.Opinions = append(nodeOpinions.Opinions, RoundOpinion{Value: o.Value.String(), Round: o.Round})
+		}
+		response.Nodes = append(response.Nodes, nodeOpinions)
+	}
+	sort.Slice(response.Nodes, func(i, j int) bool {
+		return response.Nodes[i].NodeID < response.Nodes[j].NodeID
+	})
+
+	return response
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
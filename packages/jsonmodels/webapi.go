@@ -100,6 +100,61 @@ func NewGetBranchConflictsResponse(branchID ledgerstate.BranchID, branchIDsPerCo
 
 // endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
 
+// region GetConflictTimelineResponse //////////////////////////////////////////////////////////////////////////////////
+
+// GetConflictTimelineResponse represents the JSON model of a response from the GetConflictTimeline endpoint.
+type GetConflictTimelineResponse struct {
+	ConflictID string                   `json:"conflictID"`
+	BranchIDs  []string                 `json:"branchIDs"`
+	Timeline   []*ConflictTimelineEntry `json:"timeline"`
+}
+
+// NewGetConflictTimelineResponse returns a GetConflictTimelineResponse from the given details.
+func NewGetConflictTimelineResponse(conflictID ledgerstate.ConflictID, branchIDs []ledgerstate.BranchID, conflictTimelineEntries ledgerstate.ConflictTimelineEntries) *GetConflictTimelineResponse {
+	return &GetConflictTimelineResponse{
+		ConflictID: conflictID.Base58(),
+		BranchIDs: func() (mappedBranchIDs []string) {
+			mappedBranchIDs = make([]string, 0)
+			for _, branchID := range branchIDs {
+				mappedBranchIDs = append(mappedBranchIDs, branchID.Base58())
+			}
+
+			return
+		}(),
+		Timeline: func() (mappedConflictTimelineEntries []*ConflictTimelineEntry) {
+			mappedConflictTimelineEntries = make([]*ConflictTimelineEntry, 0)
+			for _, conflictTimelineEntry := range conflictTimelineEntries {
+				mappedConflictTimelineEntries = append(mappedConflictTimelineEntries, NewConflictTimelineEntry(conflictTimelineEntry))
+			}
+
+			return
+		}(),
+	}
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region GetAliasHistoryResponse //////////////////////////////////////////////////////////////////////////////////////
+
+// GetAliasHistoryResponse represents the JSON model of a response from the GetAliasHistory endpoint.
+type GetAliasHistoryResponse struct {
+	AliasAddress string              `json:"aliasAddress"`
+	States       []*AliasStateRecord `json:"states"`
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region GetAliasStateResponse ////////////////////////////////////////////////////////////////////////////////////////
+
+// GetAliasStateResponse represents the JSON model of a response from the GetAliasState endpoint.
+type GetAliasStateResponse struct {
+	AliasAddress string        `json:"aliasAddress"`
+	StateIndex   uint32        `json:"stateIndex"`
+	States       []*AliasState `json:"states"`
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
 // region GetOutputConsumersResponse ///////////////////////////////////////////////////////////////////////////////////
 
 // GetOutputConsumersResponse represents the JSON model of a response from the GetOutputConsumers endpoint.
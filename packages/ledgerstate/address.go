@@ -2,6 +2,7 @@ package ledgerstate
 
 import (
 	"bytes"
+	"strings"
 
 	"github.com/cockroachdb/errors"
 	"github.com/iotaledger/hive.go/byteutils"
@@ -68,6 +69,9 @@ type Address interface {
 	// Base58 returns a base58 encoded version of the Address.
 	Base58() string
 
+	// Bech32 returns a bech32 encoded version of the Address that uses the currently configured human-readable part.
+	Bech32() string
+
 	// String returns a human readable version of the Address for debug purposes.
 	String() string
 }
@@ -99,6 +103,48 @@ func AddressFromBase58EncodedString(base58String string) (address Address, err e
 	return
 }
 
+// AddressFromBech32EncodedString creates an Address from a bech32 encoded string. The human-readable part of the
+// string has to match the currently configured one.
+func AddressFromBech32EncodedString(bech32String string) (address Address, err error) {
+	hrp, bytes, err := bech32Decode(bech32String)
+	if err != nil {
+		err = errors.Errorf("error while decoding bech32 encoded Address: %w", err)
+		return
+	}
+	if expectedHRP := Bech32HRP(); hrp != expectedHRP {
+		err = errors.Errorf("human-readable part of bech32 encoded Address (%s) does not match the network (%s): %w", hrp, expectedHRP, cerrors.ErrParseBytesFailed)
+		return
+	}
+
+	if address, _, err = AddressFromBytes(bytes); err != nil {
+		err = errors.Errorf("failed to parse Address from bytes: %w", err)
+		return
+	}
+
+	return
+}
+
+// AddressFromString creates an Address from a string that is either bech32 encoded or uses the legacy base58 encoding.
+func AddressFromString(addressString string) (address Address, err error) {
+	if isBech32Encoded(addressString) {
+		return AddressFromBech32EncodedString(addressString)
+	}
+
+	return AddressFromBase58EncodedString(addressString)
+}
+
+// isBech32Encoded returns true if the given string starts with the configured human-readable part or is a valid bech32
+// string of a different network, so that malformed or foreign Addresses are rejected with a meaningful error instead of
+// being parsed as base58.
+func isBech32Encoded(addressString string) bool {
+	if strings.HasPrefix(strings.ToLower(addressString), Bech32HRP()+string(bech32Separator)) {
+		return true
+	}
+	_, _, err := bech32Decode(addressString)
+
+	return err == nil
+}
+
 // AddressFromMarshalUtil reads an Address from the bytes in the given MarshalUtil.
 func AddressFromMarshalUtil(marshalUtil *marshalutil.MarshalUtil) (address Address, err error) {
 	addressType, err := marshalUtil.ReadByte()
@@ -132,6 +178,18 @@ func AddressFromSignature(sig Signature) (Address, error) {
 	return nil, errors.New("signature has no corresponding address")
 }
 
+// bech32EncodeAddress encodes the given Address into a bech32 string using the currently configured human-readable
+// part.
+func bech32EncodeAddress(address Address) string {
+	bech32String, err := bech32Encode(Bech32HRP(), address.Bytes())
+	if err != nil {
+		// converting 8-bit groups into 5-bit groups with padding can not fail
+		panic(err)
+	}
+
+	return bech32String
+}
+
 // endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
 
 // region ED25519Address ///////////////////////////////////////////////////////////////////////////////////////////////
@@ -241,6 +299,11 @@ func (e *ED25519Address) Base58() string {
 	return base58.Encode(e.Bytes())
 }
 
+// Bech32 returns a bech32 encoded version of the Address that uses the currently configured human-readable part.
+func (e *ED25519Address) Bech32() string {
+	return bech32EncodeAddress(e)
+}
+
 // String returns a human readable version of the addresses for debug purposes.
 func (e *ED25519Address) String() string {
 	return stringify.Struct("ED25519Address",
@@ -361,6 +424,11 @@ func (b *BLSAddress) Base58() string {
 	return base58.Encode(b.Bytes())
 }
 
+// Bech32 returns a bech32 encoded version of the Address that uses the currently configured human-readable part.
+func (b *BLSAddress) Bech32() string {
+	return bech32EncodeAddress(b)
+}
+
 // String returns a human readable version of the addresses for debug purposes.
 func (b *BLSAddress) String() string {
 	return stringify.Struct("BLSAddress",
@@ -421,6 +489,24 @@ func AliasAddressFromBase58EncodedString(base58String string) (address *AliasAdd
 	return
 }
 
+// AliasAddressFromString creates an AliasAddress from a string that is either bech32 encoded or uses the legacy base58
+// encoding.
+func AliasAddressFromString(addressString string) (address *AliasAddress, err error) {
+	parsedAddress, err := AddressFromString(addressString)
+	if err != nil {
+		err = errors.Errorf("failed to parse AliasAddress: %w", err)
+		return
+	}
+
+	address, ok := parsedAddress.(*AliasAddress)
+	if !ok {
+		err = errors.Errorf("invalid AddressType (%s): %w", parsedAddress.Type(), cerrors.ErrParseBytesFailed)
+		return
+	}
+
+	return
+}
+
 // AliasAddressFromMarshalUtil parses a AliasAddress from the given MarshalUtil.
 func AliasAddressFromMarshalUtil(marshalUtil *marshalutil.MarshalUtil) (address *AliasAddress, err error) {
 	addressType, err := marshalUtil.ReadByte()
@@ -480,6 +566,11 @@ func (a *AliasAddress) Base58() string {
 	return base58.Encode(a.Bytes())
 }
 
+// Bech32 returns a bech32 encoded version of the Address that uses the currently configured human-readable part.
+func (a *AliasAddress) Bech32() string {
+	return bech32EncodeAddress(a)
+}
+
 // String returns a human readable version of the addresses for debug purposes.
 func (a *AliasAddress) String() string {
 	return stringify.Struct("AliasAddress",
@@ -34,6 +34,18 @@ func TestED25519Address(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, address.Type(), addressFromBase58.Type())
 	assert.Equal(t, address.Digest(), addressFromBase58.Digest())
+
+	// ED25519 address from bech32 string
+	addressFromBech32, err := AddressFromString(address.Bech32())
+	require.NoError(t, err)
+	assert.Equal(t, address.Type(), addressFromBech32.Type())
+	assert.Equal(t, address.Digest(), addressFromBech32.Digest())
+
+	// ED25519 address from legacy base58 string using AddressFromString
+	addressFromString, err := AddressFromString(address.Base58())
+	require.NoError(t, err)
+	assert.Equal(t, address.Type(), addressFromString.Type())
+	assert.Equal(t, address.Digest(), addressFromString.Digest())
 }
 
 func TestBLSAddress(t *testing.T) {
@@ -63,6 +75,18 @@ func TestBLSAddress(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, address.Type(), addressFromBase58.Type())
 	assert.Equal(t, address.Digest(), addressFromBase58.Digest())
+
+	// BLS address from bech32 string
+	addressFromBech32, err := AddressFromString(address.Bech32())
+	require.NoError(t, err)
+	assert.Equal(t, address.Type(), addressFromBech32.Type())
+	assert.Equal(t, address.Digest(), addressFromBech32.Digest())
+
+	// BLS address from legacy base58 string using AddressFromString
+	addressFromString, err := AddressFromString(address.Base58())
+	require.NoError(t, err)
+	assert.Equal(t, address.Type(), addressFromString.Type())
+	assert.Equal(t, address.Digest(), addressFromString.Digest())
 }
 
 func TestAliasAddressClone(t *testing.T) {
@@ -82,3 +106,25 @@ func TestAliasAddressIsNil(t *testing.T) {
 	require.False(t, notNilAddr.IsNil())
 	require.True(t, nilAddr.Equals(&AliasAddress{}))
 }
+
+func TestAliasAddressFromString(t *testing.T) {
+	address := NewAliasAddress([]byte("data"))
+
+	addressFromBech32, err := AliasAddressFromString(address.Bech32())
+	require.NoError(t, err)
+	assert.True(t, address.Equals(addressFromBech32))
+
+	addressFromBase58, err := AliasAddressFromString(address.Base58())
+	require.NoError(t, err)
+	assert.True(t, address.Equals(addressFromBase58))
+
+	// corrupted checksum
+	corrupted := []byte(address.Bech32())
+	corrupted[len(corrupted)-1] = map[bool]byte{true: 'q', false: 'p'}[corrupted[len(corrupted)-1] != 'q']
+	_, err = AliasAddressFromString(string(corrupted))
+	assert.Error(t, err)
+
+	// wrong address type
+	_, err = AliasAddressFromString(randEd25119Address().Bech32())
+	assert.Error(t, err)
+}
@@ -0,0 +1,345 @@
+package ledgerstate
+
+import (
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/iotaledger/hive.go/byteutils"
+	"github.com/iotaledger/hive.go/cerrors"
+	"github.com/iotaledger/hive.go/kvstore"
+	"github.com/iotaledger/hive.go/marshalutil"
+	"github.com/iotaledger/hive.go/objectstorage"
+	"github.com/iotaledger/hive.go/stringify"
+
+	"github.com/iotaledger/goshimmer/packages/database"
+)
+
+// region AliasStateHistory ////////////////////////////////////////////////////////////////////////////////////////////
+
+// AliasStateHistory is a ledger component that indexes every version of an AliasOutput that was booked into the ledger
+// so that the full chain of an alias can be retrieved without walking the Consumers of its Outputs.
+type AliasStateHistory struct {
+	utxoDAG IUTXODAG
+
+	aliasStateRecordStorage *objectstorage.ObjectStorage
+	shutdownOnce            sync.Once
+}
+
+// NewAliasStateHistory is the constructor of the AliasStateHistory.
+func NewAliasStateHistory(store kvstore.KVStore, cacheProvider *database.CacheTimeProvider, utxoDAG IUTXODAG) (aliasStateHistory *AliasStateHistory) {
+	options := buildObjectStorageOptions(cacheProvider)
+	osFactory := objectstorage.NewFactory(store, database.PrefixLedgerState)
+
+	return &AliasStateHistory{
+		utxoDAG:                 utxoDAG,
+		aliasStateRecordStorage: osFactory.New(PrefixAliasStateRecordStorage, AliasStateRecordFromObjectStorage, options.aliasStateRecordStorageOptions...),
+	}
+}
+
+// Shutdown shuts down the AliasStateHistory and persists its state.
+func (a *AliasStateHistory) Shutdown() {
+	a.shutdownOnce.Do(func() {
+		a.aliasStateRecordStorage.Shutdown()
+	})
+}
+
+// IndexTransaction stores an AliasStateRecord for every AliasOutput that was created by the given (booked) Transaction.
+func (a *AliasStateHistory) IndexTransaction(transactionID TransactionID) (err error) {
+	cachedTransaction := a.utxoDAG.CachedTransaction(transactionID)
+	defer cachedTransaction.Release()
+	transaction := cachedTransaction.Unwrap()
+	if transaction == nil {
+		return errors.Errorf("failed to load Transaction with %s: %w", transactionID, cerrors.ErrFatal)
+	}
+
+	for _, essenceOutput := range transaction.Essence().Outputs() {
+		if essenceOutput.Type() != AliasOutputType {
+			continue
+		}
+
+		// the stored Output carries the AliasAddress of freshly minted aliases
+		if !a.utxoDAG.CachedOutput(essenceOutput.ID()).Consume(func(output Output) {
+			a.storeAliasStateRecord(output.(*AliasOutput), transaction.Essence().Timestamp())
+		}) {
+			return errors.Errorf("failed to load created Output with %s: %w", essenceOutput.ID(), cerrors.ErrFatal)
+		}
+	}
+
+	return nil
+}
+
+// LoadSnapshot indexes the unspent AliasOutputs of the given Snapshot.
+func (a *AliasStateHistory) LoadSnapshot(snapshot *Snapshot) {
+	for _, record := range snapshot.Transactions {
+		for i, output := range record.Essence.Outputs() {
+			if !record.UnspentOutputs[i] || output.Type() != AliasOutputType {
+				continue
+			}
+
+			a.storeAliasStateRecord(output.(*AliasOutput), record.Essence.Timestamp())
+		}
+	}
+}
+
+// AliasStateRecords returns all AliasStateRecords of the given AliasAddress ordered by their state index and timestamp.
+func (a *AliasStateHistory) AliasStateRecords(aliasAddress *AliasAddress) (aliasStateRecords AliasStateRecords) {
+	return a.aliasStateRecords(aliasAddress.Bytes())
+}
+
+// AliasStateRecordsByStateIndex returns the AliasStateRecords of the given AliasAddress that have the given state index.
+// There can be more than one AliasStateRecord for the same state index if the alias was updated by a governance
+// transition or if there are conflicting state transitions.
+func (a *AliasStateHistory) AliasStateRecordsByStateIndex(aliasAddress *AliasAddress, stateIndex uint32) (aliasStateRecords AliasStateRecords) {
+	return a.aliasStateRecords(marshalutil.New(AddressLength + marshalutil.Uint32Size).
+		Write(aliasAddress).
+		WriteUint32(stateIndex).
+		Bytes())
+}
+
+// aliasStateRecords is an internal utility function that collects and sorts the AliasStateRecords with the given prefix.
+func (a *AliasStateHistory) aliasStateRecords(prefix []byte) (aliasStateRecords AliasStateRecords) {
+	aliasStateRecords = make(AliasStateRecords, 0)
+	a.aliasStateRecordStorage.ForEach(func(key []byte, cachedObject objectstorage.CachedObject) bool {
+		(&CachedAliasStateRecord{CachedObject: cachedObject}).Consume(func(aliasStateRecord *AliasStateRecord) {
+			aliasStateRecords = append(aliasStateRecords, aliasStateRecord)
+		})
+
+		return true
+	}, objectstorage.WithIteratorPrefix(prefix))
+
+	sort.Slice(aliasStateRecords, func(i, j int) bool {
+		if aliasStateRecords[i].StateIndex() != aliasStateRecords[j].StateIndex() {
+			return aliasStateRecords[i].StateIndex() < aliasStateRecords[j].StateIndex()
+		}
+
+		return aliasStateRecords[i].Timestamp().Before(aliasStateRecords[j].Timestamp())
+	})
+
+	return
+}
+
+// storeAliasStateRecord is an internal utility function that stores the AliasStateRecord of the given AliasOutput.
+func (a *AliasStateHistory) storeAliasStateRecord(aliasOutput *AliasOutput, timestamp time.Time) {
+	aliasStateRecord := NewAliasStateRecord(aliasOutput.GetAliasAddress(), aliasOutput.GetStateIndex(), aliasOutput.ID(), timestamp, aliasOutput.GetIsGovernanceUpdated())
+	if cachedAliasStateRecord, stored := a.aliasStateRecordStorage.StoreIfAbsent(aliasStateRecord); stored {
+		cachedAliasStateRecord.Release()
+	}
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region AliasStateRecord /////////////////////////////////////////////////////////////////////////////////////////////
+
+// AliasStateRecordKeyPartition defines the partition of the storage key of the AliasStateRecord model.
+var AliasStateRecordKeyPartition = objectstorage.PartitionKey(AddressLength, marshalutil.Uint32Size, OutputIDLength)
+
+// AliasStateRecord represents a single version of an alias chain, i.e. an AliasOutput that was booked into the ledger.
+type AliasStateRecord struct {
+	aliasAddress     *AliasAddress
+	stateIndex       uint32
+	outputID         OutputID
+	timestamp        time.Time
+	governanceUpdate bool
+
+	objectstorage.StorableObjectFlags
+}
+
+// NewAliasStateRecord is the constructor for the AliasStateRecord.
+func NewAliasStateRecord(aliasAddress *AliasAddress, stateIndex uint32, outputID OutputID, timestamp time.Time, governanceUpdate bool) *AliasStateRecord {
+	return &AliasStateRecord{
+		aliasAddress:     aliasAddress,
+		stateIndex:       stateIndex,
+		outputID:         outputID,
+		timestamp:        timestamp,
+		governanceUpdate: governanceUpdate,
+	}
+}
+
+// AliasStateRecordFromBytes unmarshals an AliasStateRecord from a sequence of bytes.
+func AliasStateRecordFromBytes(bytes []byte) (aliasStateRecord *AliasStateRecord, consumedBytes int, err error) {
+	marshalUtil := marshalutil.New(bytes)
+	if aliasStateRecord, err = AliasStateRecordFromMarshalUtil(marshalUtil); err != nil {
+		err = errors.Errorf("failed to parse AliasStateRecord from MarshalUtil: %w", err)
+		return
+	}
+	consumedBytes = marshalUtil.ReadOffset()
+
+	return
+}
+
+// AliasStateRecordFromMarshalUtil unmarshals an AliasStateRecord using a MarshalUtil (for easier unmarshaling).
+func AliasStateRecordFromMarshalUtil(marshalUtil *marshalutil.MarshalUtil) (aliasStateRecord *AliasStateRecord, err error) {
+	aliasStateRecord = &AliasStateRecord{}
+	if aliasStateRecord.aliasAddress, err = AliasAddressFromMarshalUtil(marshalUtil); err != nil {
+		err = errors.Errorf("failed to parse AliasAddress from MarshalUtil: %w", err)
+		return
+	}
+	if aliasStateRecord.stateIndex, err = marshalUtil.ReadUint32(); err != nil {
+		err = errors.Errorf("failed to parse state index (%v): %w", err, cerrors.ErrParseBytesFailed)
+		return
+	}
+	if aliasStateRecord.outputID, err = OutputIDFromMarshalUtil(marshalUtil); err != nil {
+		err = errors.Errorf("failed to parse OutputID from MarshalUtil: %w", err)
+		return
+	}
+	if aliasStateRecord.timestamp, err = marshalUtil.ReadTime(); err != nil {
+		err = errors.Errorf("failed to parse timestamp (%v): %w", err, cerrors.ErrParseBytesFailed)
+		return
+	}
+	if aliasStateRecord.governanceUpdate, err = marshalUtil.ReadBool(); err != nil {
+		err = errors.Errorf("failed to parse governance update flag (%v): %w", err, cerrors.ErrParseBytesFailed)
+		return
+	}
+
+	return
+}
+
+// AliasStateRecordFromObjectStorage restores an AliasStateRecord object that was stored in the ObjectStorage.
+func AliasStateRecordFromObjectStorage(key []byte, data []byte) (aliasStateRecord objectstorage.StorableObject, err error) {
+	if aliasStateRecord, _, err = AliasStateRecordFromBytes(byteutils.ConcatBytes(key, data)); err != nil {
+		err = errors.Errorf("failed to parse AliasStateRecord from bytes: %w", err)
+		return
+	}
+
+	return
+}
+
+// AliasAddress returns the AliasAddress of the alias chain that this AliasStateRecord belongs to.
+func (a *AliasStateRecord) AliasAddress() *AliasAddress {
+	return a.aliasAddress
+}
+
+// StateIndex returns the state index of the AliasOutput.
+func (a *AliasStateRecord) StateIndex() uint32 {
+	return a.stateIndex
+}
+
+// OutputID returns the identifier of the AliasOutput.
+func (a *AliasStateRecord) OutputID() OutputID {
+	return a.outputID
+}
+
+// TransactionID returns the identifier of the Transaction that created the AliasOutput.
+func (a *AliasStateRecord) TransactionID() TransactionID {
+	return a.outputID.TransactionID()
+}
+
+// Timestamp returns the timestamp of the Transaction that created the AliasOutput.
+func (a *AliasStateRecord) Timestamp() time.Time {
+	return a.timestamp
+}
+
+// GovernanceUpdate returns true if the AliasOutput was created by a governance transition.
+func (a *AliasStateRecord) GovernanceUpdate() bool {
+	return a.governanceUpdate
+}
+
+// Bytes returns a marshaled version of the AliasStateRecord.
+func (a *AliasStateRecord) Bytes() []byte {
+	return byteutils.ConcatBytes(a.ObjectStorageKey(), a.ObjectStorageValue())
+}
+
+// String returns a human readable version of the AliasStateRecord.
+func (a *AliasStateRecord) String() string {
+	return stringify.Struct("AliasStateRecord",
+		stringify.StructField("aliasAddress", a.AliasAddress()),
+		stringify.StructField("stateIndex", a.StateIndex()),
+		stringify.StructField("outputID", a.OutputID()),
+		stringify.StructField("timestamp", a.Timestamp()),
+		stringify.StructField("governanceUpdate", a.GovernanceUpdate()),
+	)
+}
+
+// Update is disabled and panics if it ever gets called - it is required to match the StorableObject interface.
+func (a *AliasStateRecord) Update(objectstorage.StorableObject) {
+	panic("updates disabled")
+}
+
+// ObjectStorageKey returns the key that is used to store the object in the database. It is required to match the
+// StorableObject interface.
+func (a *AliasStateRecord) ObjectStorageKey() []byte {
+	return marshalutil.New(AddressLength + marshalutil.Uint32Size + OutputIDLength).
+		Write(a.aliasAddress).
+		WriteUint32(a.stateIndex).
+		Write(a.outputID).
+		Bytes()
+}
+
+// ObjectStorageValue marshals the AliasStateRecord into a sequence of bytes. The AliasAddress, the state index and the
+// OutputID are not serialized here as they are only used as a key in the ObjectStorage.
+func (a *AliasStateRecord) ObjectStorageValue() []byte {
+	return marshalutil.New(marshalutil.TimeSize + marshalutil.BoolSize).
+		WriteTime(a.timestamp).
+		WriteBool(a.governanceUpdate).
+		Bytes()
+}
+
+// code contract (make sure the type implements all required methods)
+var _ objectstorage.StorableObject = &AliasStateRecord{}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region AliasStateRecords ////////////////////////////////////////////////////////////////////////////////////////////
+
+// AliasStateRecords represents a collection of AliasStateRecords.
+type AliasStateRecords []*AliasStateRecord
+
+// String returns a human readable version of the AliasStateRecords.
+func (a AliasStateRecords) String() string {
+	structBuilder := stringify.StructBuilder("AliasStateRecords")
+	for i, aliasStateRecord := range a {
+		structBuilder.AddField(stringify.StructField(strconv.Itoa(i), aliasStateRecord))
+	}
+
+	return structBuilder.String()
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region CachedAliasStateRecord ///////////////////////////////////////////////////////////////////////////////////////
+
+// CachedAliasStateRecord is a wrapper for the generic CachedObject returned by the object storage that overrides the
+// accessor methods with a type-casted one.
+type CachedAliasStateRecord struct {
+	objectstorage.CachedObject
+}
+
+// Retain marks this CachedObject to still be in use by the program.
+func (c *CachedAliasStateRecord) Retain() *CachedAliasStateRecord {
+	return &CachedAliasStateRecord{c.CachedObject.Retain()}
+}
+
+// Unwrap is the type-casted equivalent of Get. It returns nil if the object does not exist.
+func (c *CachedAliasStateRecord) Unwrap() *AliasStateRecord {
+	untypedObject := c.Get()
+	if untypedObject == nil {
+		return nil
+	}
+
+	typedObject := untypedObject.(*AliasStateRecord)
+	if typedObject == nil || typedObject.IsDeleted() {
+		return nil
+	}
+
+	return typedObject
+}
+
+// Consume unwraps the CachedObject and passes a type-casted version to the consumer. It automatically releases the
+// object when the consumer finishes and returns true of there was at least one object that was consumed.
+func (c *CachedAliasStateRecord) Consume(consumer func(aliasStateRecord *AliasStateRecord), forceRelease ...bool) (consumed bool) {
+	return c.CachedObject.Consume(func(object objectstorage.StorableObject) {
+		consumer(object.(*AliasStateRecord))
+	}, forceRelease...)
+}
+
+// String returns a human readable version of the CachedAliasStateRecord.
+func (c *CachedAliasStateRecord) String() string {
+	return stringify.Struct("CachedAliasStateRecord",
+		stringify.StructField("CachedObject", c.Unwrap()),
+	)
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -0,0 +1,72 @@
+package ledgerstate
+
+import (
+	"testing"
+	"time"
+
+	"github.com/iotaledger/hive.go/identity"
+	"github.com/iotaledger/hive.go/kvstore/mapdb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/goshimmer/packages/database"
+)
+
+func TestAliasStateHistory(t *testing.T) {
+	branchDAG, utxoDAG := setupDependencies(t)
+	defer branchDAG.Shutdown()
+	aliasStateHistory := NewAliasStateHistory(mapdb.NewMapDB(), database.NewCacheTimeProvider(0), utxoDAG)
+	defer aliasStateHistory.Shutdown()
+
+	wallets := createWallets(1)
+	input := generateOutput(utxoDAG, wallets[0].address, 0)
+
+	// mint the alias
+	mintedAlias, err := NewAliasOutputMint(map[Color]uint64{ColorIOTA: DustThresholdAliasOutputIOTA}, wallets[0].address)
+	require.NoError(t, err)
+	mintTransaction := bookAliasTransaction(t, utxoDAG, wallets[0], input.ID(), mintedAlias)
+	require.NoError(t, aliasStateHistory.IndexTransaction(mintTransaction.ID()))
+
+	var storedAlias *AliasOutput
+	utxoDAG.CachedOutput(mintTransaction.Essence().Outputs()[0].ID()).Consume(func(output Output) {
+		storedAlias = output.(*AliasOutput)
+	})
+	aliasAddress := storedAlias.GetAliasAddress()
+
+	// perform a state transition and a governance update
+	stateTransaction := bookAliasTransaction(t, utxoDAG, wallets[0], storedAlias.ID(), storedAlias.NewAliasOutputNext())
+	require.NoError(t, aliasStateHistory.IndexTransaction(stateTransaction.ID()))
+	governanceTransaction := bookAliasTransaction(t, utxoDAG, wallets[0], stateTransaction.Essence().Outputs()[0].ID(), storedAlias.NewAliasOutputNext().NewAliasOutputNext(true))
+	require.NoError(t, aliasStateHistory.IndexTransaction(governanceTransaction.ID()))
+
+	// indexing twice does not create duplicates
+	require.NoError(t, aliasStateHistory.IndexTransaction(governanceTransaction.ID()))
+
+	aliasStateRecords := aliasStateHistory.AliasStateRecords(aliasAddress)
+	require.Len(t, aliasStateRecords, 3)
+	assert.Equal(t, uint32(0), aliasStateRecords[0].StateIndex())
+	assert.Equal(t, mintTransaction.ID(), aliasStateRecords[0].TransactionID())
+	assert.Equal(t, uint32(1), aliasStateRecords[1].StateIndex())
+	assert.False(t, aliasStateRecords[1].GovernanceUpdate())
+	assert.Equal(t, uint32(1), aliasStateRecords[2].StateIndex())
+	assert.True(t, aliasStateRecords[2].GovernanceUpdate())
+
+	stateOneRecords := aliasStateHistory.AliasStateRecordsByStateIndex(aliasAddress, 1)
+	require.Len(t, stateOneRecords, 2)
+	assert.Equal(t, stateTransaction.ID(), stateOneRecords[0].TransactionID())
+	assert.Equal(t, governanceTransaction.ID(), stateOneRecords[1].TransactionID())
+
+	assert.Empty(t, aliasStateHistory.AliasStateRecordsByStateIndex(aliasAddress, 2))
+}
+
+func bookAliasTransaction(t *testing.T, utxoDAG *UTXODAG, w wallet, inputID OutputID, aliasOutput *AliasOutput) *Transaction {
+	// make sure the timestamps of the transactions are strictly increasing
+	time.Sleep(time.Millisecond)
+
+	essence := NewTransactionEssence(0, time.Now(), identity.ID{}, identity.ID{}, NewInputs(NewUTXOInput(inputID)), NewOutputs(aliasOutput))
+	transaction := NewTransaction(essence, w.unlockBlocks(essence))
+	_, err := utxoDAG.BookTransaction(transaction)
+	require.NoError(t, err)
+
+	return transaction
+}
@@ -0,0 +1,214 @@
+package ledgerstate
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/cockroachdb/errors"
+	"github.com/iotaledger/hive.go/cerrors"
+)
+
+// region Bech32 HRP ///////////////////////////////////////////////////////////////////////////////////////////////////
+
+// DefaultBech32HRP contains the human-readable part that is used for bech32 encoded Addresses if no other prefix was
+// configured.
+const DefaultBech32HRP = "atoi"
+
+var (
+	bech32HRP      = DefaultBech32HRP
+	bech32HRPMutex sync.RWMutex
+)
+
+// Bech32HRP returns the human-readable part that is currently used to encode and decode bech32 Addresses.
+func Bech32HRP() string {
+	bech32HRPMutex.RLock()
+	defer bech32HRPMutex.RUnlock()
+
+	return bech32HRP
+}
+
+// SetBech32HRP sets the human-readable part that is used to encode and decode bech32 Addresses (i.e. to distinguish
+// the Addresses of different networks).
+func SetBech32HRP(hrp string) (err error) {
+	if err = validateBech32HRP(hrp); err != nil {
+		return errors.Errorf("invalid human-readable part '%s': %w", hrp, err)
+	}
+
+	bech32HRPMutex.Lock()
+	defer bech32HRPMutex.Unlock()
+
+	bech32HRP = hrp
+
+	return nil
+}
+
+// validateBech32HRP checks if the given string is a valid human-readable part according to BIP-173.
+func validateBech32HRP(hrp string) error {
+	if len(hrp) < 1 || len(hrp) > bech32MaxHRPLength {
+		return errors.Errorf("length of human-readable part must be between 1 and %d: %w", bech32MaxHRPLength, cerrors.ErrParseBytesFailed)
+	}
+	for _, char := range hrp {
+		if char < 33 || char > 126 {
+			return errors.Errorf("human-readable part contains invalid character '%c': %w", char, cerrors.ErrParseBytesFailed)
+		}
+		if char >= 'A' && char <= 'Z' {
+			return errors.Errorf("human-readable part must be lower case: %w", cerrors.ErrParseBytesFailed)
+		}
+	}
+
+	return nil
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region Bech32 encoding //////////////////////////////////////////////////////////////////////////////////////////////
+
+const (
+	// bech32Charset contains the characters of the 5-bit groups in the data part of a bech32 string.
+	bech32Charset = "qpzry9x8gf2tvdw0s3jn54khce6mua7l"
+
+	// bech32Separator separates the human-readable part from the data part.
+	bech32Separator = '1'
+
+	// bech32ChecksumLength contains the amount of characters used for the checksum.
+	bech32ChecksumLength = 6
+
+	// bech32MaxHRPLength contains the maximum length of the human-readable part.
+	bech32MaxHRPLength = 83
+)
+
+// bech32Generator contains the generator coefficients of the BCH checksum.
+var bech32Generator = [5]uint32{0x3b6a57b2, 0x26508e6d, 0x1ea119fa, 0x3d4233dd, 0x2a1462b3}
+
+// bech32Encode encodes the given bytes into a bech32 string with the given human-readable part.
+func bech32Encode(hrp string, data []byte) (string, error) {
+	values, err := convertBits(data, 8, 5, true)
+	if err != nil {
+		return "", err
+	}
+
+	var builder strings.Builder
+	builder.Grow(len(hrp) + 1 + len(values) + bech32ChecksumLength)
+	builder.WriteString(hrp)
+	builder.WriteByte(bech32Separator)
+	for _, value := range append(values, bech32Checksum(hrp, values)...) {
+		builder.WriteByte(bech32Charset[value])
+	}
+
+	return builder.String(), nil
+}
+
+// bech32Decode decodes the given bech32 string and returns its human-readable part and the decoded bytes.
+func bech32Decode(bech32String string) (hrp string, data []byte, err error) {
+	if strings.ToLower(bech32String) != bech32String && strings.ToUpper(bech32String) != bech32String {
+		err = errors.Errorf("bech32 string must not use mixed case: %w", cerrors.ErrParseBytesFailed)
+		return
+	}
+	bech32String = strings.ToLower(bech32String)
+
+	separatorIndex := strings.LastIndexByte(bech32String, bech32Separator)
+	if separatorIndex < 1 || separatorIndex+bech32ChecksumLength+1 > len(bech32String) {
+		err = errors.Errorf("invalid position of separator in bech32 string: %w", cerrors.ErrParseBytesFailed)
+		return
+	}
+	if hrp = bech32String[:separatorIndex]; validateBech32HRP(hrp) != nil {
+		err = errors.Errorf("invalid human-readable part in bech32 string: %w", cerrors.ErrParseBytesFailed)
+		return
+	}
+
+	values := make([]byte, 0, len(bech32String)-separatorIndex-1)
+	for _, char := range bech32String[separatorIndex+1:] {
+		value := strings.IndexRune(bech32Charset, char)
+		if value == -1 {
+			err = errors.Errorf("invalid character '%c' in bech32 string: %w", char, cerrors.ErrParseBytesFailed)
+			return
+		}
+		values = append(values, byte(value))
+	}
+
+	if bech32Polymod(append(bech32ExpandHRP(hrp), values...)) != 1 {
+		err = errors.Errorf("invalid checksum of bech32 string: %w", cerrors.ErrParseBytesFailed)
+		return
+	}
+
+	if data, err = convertBits(values[:len(values)-bech32ChecksumLength], 5, 8, false); err != nil {
+		err = errors.Errorf("failed to convert data part of bech32 string: %w", err)
+		return
+	}
+
+	return
+}
+
+// bech32Checksum computes the checksum of the given 5-bit values and human-readable part.
+func bech32Checksum(hrp string, values []byte) []byte {
+	polymod := bech32Polymod(append(append(bech32ExpandHRP(hrp), values...), make([]byte, bech32ChecksumLength)...)) ^ 1
+
+	checksum := make([]byte, bech32ChecksumLength)
+	for i := range checksum {
+		checksum[i] = byte((polymod >> uint(5*(5-i))) & 31)
+	}
+
+	return checksum
+}
+
+// bech32Polymod computes the BCH checksum polynomial of the given 5-bit values.
+func bech32Polymod(values []byte) uint32 {
+	checksum := uint32(1)
+	for _, value := range values {
+		top := checksum >> 25
+		checksum = (checksum&0x1ffffff)<<5 ^ uint32(value)
+		for i, generator := range bech32Generator {
+			if (top>>uint(i))&1 == 1 {
+				checksum ^= generator
+			}
+		}
+	}
+
+	return checksum
+}
+
+// bech32ExpandHRP expands the human-readable part into the values that are used for the checksum computation.
+func bech32ExpandHRP(hrp string) []byte {
+	expanded := make([]byte, 0, len(hrp)*2+1)
+	for i := 0; i < len(hrp); i++ {
+		expanded = append(expanded, hrp[i]>>5)
+	}
+	expanded = append(expanded, 0)
+	for i := 0; i < len(hrp); i++ {
+		expanded = append(expanded, hrp[i]&31)
+	}
+
+	return expanded
+}
+
+// convertBits regroups the given values from groups of fromBits to groups of toBits.
+func convertBits(data []byte, fromBits, toBits uint, pad bool) ([]byte, error) {
+	accumulator := uint32(0)
+	bits := uint(0)
+	maxValue := uint32(1)<<toBits - 1
+
+	converted := make([]byte, 0, len(data)*int(fromBits)/int(toBits)+1)
+	for _, value := range data {
+		if uint32(value)>>fromBits != 0 {
+			return nil, errors.Errorf("invalid data range (%d): %w", value, cerrors.ErrParseBytesFailed)
+		}
+		accumulator = accumulator<<fromBits | uint32(value)
+		bits += fromBits
+		for bits >= toBits {
+			bits -= toBits
+			converted = append(converted, byte(accumulator>>bits&maxValue))
+		}
+	}
+
+	if pad {
+		if bits > 0 {
+			converted = append(converted, byte(accumulator<<(toBits-bits)&maxValue))
+		}
+	} else if bits >= fromBits || accumulator<<(toBits-bits)&maxValue != 0 {
+		return nil, errors.Errorf("invalid padding: %w", cerrors.ErrParseBytesFailed)
+	}
+
+	return converted, nil
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
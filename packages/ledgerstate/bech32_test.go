@@ -0,0 +1,68 @@
+package ledgerstate
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBech32Decode(t *testing.T) {
+	// valid test vectors of BIP-173
+	for _, bech32String := range []string{
+		"A12UEL5L",
+		"a12uel5l",
+		"abcdef1qpzry9x8gf2tvdw0s3jn54khce6mua7lmqqqxw",
+		"split1checkupstagehandshakeupstreamerranterredcaperred2y9e3w",
+	} {
+		hrp, data, err := bech32Decode(bech32String)
+		require.NoError(t, err, bech32String)
+
+		// re-encoding the decoded data needs to result in the same string if the data part is byte aligned
+		if reencoded, encodeErr := bech32Encode(hrp, data); encodeErr == nil && len(reencoded) == len(bech32String) {
+			assert.Equal(t, strings.ToLower(bech32String), reencoded)
+		}
+	}
+
+	// invalid test vectors of BIP-173
+	for _, bech32String := range []string{
+		"1nwldj5",
+		"pzry9x0s0muk",
+		"1pzry9x8gf2tvdw0s3jn54khce6mua7l",
+		"x1b4n0q5v",
+		"li1dgmt3",
+		"A1G7SGD8",
+		"10a06t8",
+		"1qzzfhee",
+		"a12UEL5L",
+	} {
+		_, _, err := bech32Decode(bech32String)
+		assert.Error(t, err, bech32String)
+	}
+}
+
+func TestBech32HRP(t *testing.T) {
+	defer func() {
+		require.NoError(t, SetBech32HRP(DefaultBech32HRP))
+	}()
+
+	assert.Error(t, SetBech32HRP(""))
+	assert.Error(t, SetBech32HRP("IOTA"))
+	assert.Equal(t, DefaultBech32HRP, Bech32HRP())
+
+	address := randEd25119Address()
+	defaultEncoded := address.Bech32()
+	assert.True(t, strings.HasPrefix(defaultEncoded, DefaultBech32HRP+"1"))
+
+	require.NoError(t, SetBech32HRP("iota"))
+	assert.True(t, strings.HasPrefix(address.Bech32(), "iota1"))
+
+	// addresses of a different network are rejected
+	_, err := AddressFromString(defaultEncoded)
+	assert.Error(t, err)
+
+	parsedAddress, err := AddressFromString(address.Bech32())
+	require.NoError(t, err)
+	assert.True(t, address.Equals(parsedAddress))
+}
@@ -721,6 +721,8 @@ func (b *BranchDAG) registerConflictMember(conflictID ConflictID, branchID Branc
 			conflict.IncreaseMemberCount()
 
 			cachedConflictMember.Release()
+
+			b.Events.ConflictMemberAdded.Trigger(conflictID, branchID)
 		}
 	})
 }
@@ -1431,6 +1433,9 @@ type BranchDAGEvents struct {
 
 	// BranchPending gets triggered whenever a Branch becomes pending that was not pending before (i.e. during a reorg).
 	BranchPending *events.Event
+
+	// ConflictMemberAdded gets triggered whenever a ConflictBranch becomes a member of a Conflict.
+	ConflictMemberAdded *events.Event
 }
 
 // NewBranchDAGEvents creates a container for all of the BranchDAG related events.
@@ -1445,6 +1450,7 @@ func NewBranchDAGEvents() *BranchDAGEvents {
 		BranchConfirmed:             events.NewEvent(branchEventCaller),
 		BranchRejected:              events.NewEvent(branchEventCaller),
 		BranchPending:               events.NewEvent(branchEventCaller),
+		ConflictMemberAdded:         events.NewEvent(conflictMemberEventCaller),
 	}
 }
 
@@ -1483,4 +1489,8 @@ func branchEventCaller(handler interface{}, params ...interface{}) {
 	handler.(func(branch *BranchDAGEvent))(params[0].(*BranchDAGEvent).Retain())
 }
 
+func conflictMemberEventCaller(handler interface{}, params ...interface{}) {
+	handler.(func(conflictID ConflictID, branchID BranchID))(params[0].(ConflictID), params[1].(BranchID))
+}
+
 // endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
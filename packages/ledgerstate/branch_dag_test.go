@@ -1278,6 +1278,7 @@ func newEventMock(t *testing.T, mgr *BranchDAG) *eventMock {
 	e.attach(mgr.Events.BranchConfirmed, e.BranchConfirmed)
 	e.attach(mgr.Events.BranchRejected, e.BranchRejected)
 	e.attach(mgr.Events.BranchPending, e.BranchPending)
+	e.attach(mgr.Events.ConflictMemberAdded, e.ConflictMemberAdded)
 
 	// assure that all available events are mocked
 	numEvents := reflect.ValueOf(mgr.Events).Elem().NumField()
@@ -1407,6 +1408,14 @@ func (e *eventMock) BranchRejected(cachedBranch *BranchDAGEvent) {
 	e.calledEvents++
 }
 
+// ConflictMemberAdded is triggered for every created ConflictBranch, so it is only logged and not tracked as an
+// expected event.
+func (e *eventMock) ConflictMemberAdded(conflictID ConflictID, branchID BranchID) {
+	if debugAlias, exists := e.debugAlias[branchID]; exists {
+		e.test.Logf("EVENT TRIGGERED:\tConflictMemberAdded(%s, %s)", conflictID, debugAlias)
+	}
+}
+
 func (e *eventMock) BranchPending(cachedBranch *BranchDAGEvent) {
 	if debugAlias, exists := e.debugAlias[cachedBranch.Branch.Unwrap().ID()]; exists {
 		e.test.Logf("EVENT TRIGGERED:\tBranchPending(%s)", debugAlias)
@@ -0,0 +1,895 @@
+package ledgerstate
+
+import (
+	"sync"
+
+	"github.com/cockroachdb/errors"
+	"github.com/iotaledger/hive.go/byteutils"
+	"github.com/iotaledger/hive.go/cerrors"
+	"github.com/iotaledger/hive.go/kvstore"
+	"github.com/iotaledger/hive.go/marshalutil"
+	"github.com/iotaledger/hive.go/objectstorage"
+	"github.com/iotaledger/hive.go/stringify"
+
+	"github.com/iotaledger/goshimmer/packages/database"
+)
+
+// region ColorSupplyManager ///////////////////////////////////////////////////////////////////////////////////////////
+
+// ColorSupplyManager is a ledger component that keeps track of the minted, burned and circulating supply of every
+// Color as well as the amount of addresses that hold tokens of that Color. The supply is updated when a Transaction
+// gets confirmed and rolled back if the Branch that the Transaction is booked into gets rejected afterwards.
+type ColorSupplyManager struct {
+	utxoDAG IUTXODAG
+
+	colorSupplyStorage            *objectstorage.ObjectStorage
+	colorHoldingStorage           *objectstorage.ObjectStorage
+	colorSupplyTransactionStorage *objectstorage.ObjectStorage
+	colorSupplyBranchStorage      *objectstorage.ObjectStorage
+
+	mutex        sync.Mutex
+	shutdownOnce sync.Once
+}
+
+// NewColorSupplyManager is the constructor of the ColorSupplyManager.
+func NewColorSupplyManager(store kvstore.KVStore, cacheProvider *database.CacheTimeProvider, utxoDAG IUTXODAG) (colorSupplyManager *ColorSupplyManager) {
+	options := buildObjectStorageOptions(cacheProvider)
+	osFactory := objectstorage.NewFactory(store, database.PrefixLedgerState)
+
+	return &ColorSupplyManager{
+		utxoDAG:                       utxoDAG,
+		colorSupplyStorage:            osFactory.New(PrefixColorSupplyStorage, ColorSupplyFromObjectStorage, options.colorSupplyStorageOptions...),
+		colorHoldingStorage:           osFactory.New(PrefixColorHoldingStorage, ColorHoldingFromObjectStorage, options.colorHoldingStorageOptions...),
+		colorSupplyTransactionStorage: osFactory.New(PrefixColorSupplyTransactionStorage, colorSupplyTransactionFromObjectStorage, options.colorSupplyTransactionStorageOptions...),
+		colorSupplyBranchStorage:      osFactory.New(PrefixColorSupplyBranchStorage, colorSupplyBranchFromObjectStorage, options.colorSupplyBranchStorageOptions...),
+	}
+}
+
+// Shutdown shuts down the ColorSupplyManager and persists its state.
+func (c *ColorSupplyManager) Shutdown() {
+	c.shutdownOnce.Do(func() {
+		c.colorSupplyStorage.Shutdown()
+		c.colorHoldingStorage.Shutdown()
+		c.colorSupplyTransactionStorage.Shutdown()
+		c.colorSupplyBranchStorage.Shutdown()
+	})
+}
+
+// ColorSupply retrieves the ColorSupply of the given Color from the object storage.
+func (c *ColorSupplyManager) ColorSupply(color Color) (cachedColorSupply *CachedColorSupply) {
+	return &CachedColorSupply{CachedObject: c.colorSupplyStorage.Load(color.Bytes())}
+}
+
+// ColorHolding retrieves the ColorHolding of the given Color and Address from the object storage.
+func (c *ColorSupplyManager) ColorHolding(color Color, address Address) (cachedColorHolding *CachedColorHolding) {
+	return &CachedColorHolding{CachedObject: c.colorHoldingStorage.Load(NewColorHolding(color, address).ObjectStorageKey())}
+}
+
+// ApplyTransaction accounts the minted and burned tokens of the given (confirmed) Transaction. It returns false if the
+// Transaction was already accounted for before.
+func (c *ColorSupplyManager) ApplyTransaction(transactionID TransactionID) (applied bool, err error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.colorSupplyTransactionStorage.Contains(transactionID.Bytes()) {
+		return false, nil
+	}
+
+	branchID := UndefinedBranchID
+	if !c.utxoDAG.CachedTransactionMetadata(transactionID).Consume(func(transactionMetadata *TransactionMetadata) {
+		branchID = transactionMetadata.BranchID()
+	}) {
+		return false, errors.Errorf("failed to load TransactionMetadata with %s: %w", transactionID, cerrors.ErrFatal)
+	}
+
+	diff, err := c.transactionDiff(transactionID)
+	if err != nil {
+		return false, errors.Errorf("failed to determine supply changes of Transaction with %s: %w", transactionID, err)
+	}
+	c.applyDiff(transactionID, diff, false)
+	c.colorSupplyTransactionStorage.Store(&colorSupplyTransaction{transactionID: transactionID, branchID: branchID}).Release()
+	c.colorSupplyBranchStorage.Store(&colorSupplyBranch{branchID: branchID, transactionID: transactionID}).Release()
+
+	return true, nil
+}
+
+// RevertTransaction rolls back the changes of a previously applied Transaction (i.e. after it got rejected). It
+// returns false if the Transaction was not accounted for.
+func (c *ColorSupplyManager) RevertTransaction(transactionID TransactionID) (reverted bool, err error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return c.revertTransaction(transactionID)
+}
+
+// RevertBranch rolls back the changes of all applied Transactions that are booked into the given (rejected) Branch. It
+// returns the identifiers of the reverted Transactions.
+func (c *ColorSupplyManager) RevertBranch(branchID BranchID) (revertedTransactionIDs []TransactionID, err error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	for _, transactionID := range c.branchTransactionIDs(branchID) {
+		reverted, revertErr := c.revertTransaction(transactionID)
+		if revertErr != nil {
+			return revertedTransactionIDs, errors.Errorf("failed to revert Transaction with %s: %w", transactionID, revertErr)
+		}
+		if reverted {
+			revertedTransactionIDs = append(revertedTransactionIDs, transactionID)
+		}
+	}
+
+	return revertedTransactionIDs, nil
+}
+
+// UpdateTransactionBranch moves an applied Transaction to the Branch that it is currently booked into (i.e. after it
+// got forked), so that it is reverted if that Branch gets rejected.
+func (c *ColorSupplyManager) UpdateTransactionBranch(transactionID TransactionID) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	cachedTransaction := c.colorSupplyTransactionStorage.Load(transactionID.Bytes())
+	defer cachedTransaction.Release()
+	if !cachedTransaction.Exists() {
+		return
+	}
+	appliedTransaction := cachedTransaction.Get().(*colorSupplyTransaction)
+
+	c.utxoDAG.CachedTransactionMetadata(transactionID).Consume(func(transactionMetadata *TransactionMetadata) {
+		if transactionMetadata.BranchID() == appliedTransaction.branchID {
+			return
+		}
+
+		c.colorSupplyBranchStorage.Delete(byteutils.ConcatBytes(appliedTransaction.branchID.Bytes(), transactionID.Bytes()))
+		appliedTransaction.branchID = transactionMetadata.BranchID()
+		appliedTransaction.SetModified()
+		c.colorSupplyBranchStorage.Store(&colorSupplyBranch{branchID: appliedTransaction.branchID, transactionID: transactionID}).Release()
+	})
+}
+
+// revertTransaction is an internal utility function that rolls back the changes of a previously applied Transaction.
+func (c *ColorSupplyManager) revertTransaction(transactionID TransactionID) (reverted bool, err error) {
+	cachedTransaction := c.colorSupplyTransactionStorage.Load(transactionID.Bytes())
+	defer cachedTransaction.Release()
+	if !cachedTransaction.Exists() {
+		return false, nil
+	}
+	appliedTransaction := cachedTransaction.Get().(*colorSupplyTransaction)
+
+	diff, err := c.transactionDiff(transactionID)
+	if err != nil {
+		return false, errors.Errorf("failed to determine supply changes of Transaction with %s: %w", transactionID, err)
+	}
+	c.applyDiff(transactionID, diff, true)
+	c.colorSupplyBranchStorage.Delete(byteutils.ConcatBytes(appliedTransaction.branchID.Bytes(), transactionID.Bytes()))
+	appliedTransaction.Delete()
+
+	return true, nil
+}
+
+// branchTransactionIDs is an internal utility function that returns the applied Transactions booked into the Branch.
+func (c *ColorSupplyManager) branchTransactionIDs(branchID BranchID) (transactionIDs []TransactionID) {
+	c.colorSupplyBranchStorage.ForEach(func(key []byte, cachedObject objectstorage.CachedObject) bool {
+		cachedObject.Consume(func(object objectstorage.StorableObject) {
+			transactionIDs = append(transactionIDs, object.(*colorSupplyBranch).transactionID)
+		})
+
+		return true
+	}, objectstorage.WithIteratorPrefix(branchID.Bytes()))
+
+	return transactionIDs
+}
+
+// LoadSnapshot accounts the colored tokens held by the unspent Outputs of the given Snapshot.
+func (c *ColorSupplyManager) LoadSnapshot(snapshot *Snapshot) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	for transactionID, record := range snapshot.Transactions {
+		diff := make(map[Color]*colorSupplyDiff)
+		for i, output := range record.Essence.Outputs() {
+			if !record.UnspentOutputs[i] {
+				continue
+			}
+
+			output.Balances().ForEach(func(color Color, balance uint64) bool {
+				if color != ColorIOTA {
+					diff[color] = diff[color].produce(output.Address(), balance)
+				}
+
+				return true
+			})
+		}
+		c.applyDiff(transactionID, diff, false)
+	}
+}
+
+// transactionDiff is an internal utility function that determines the changes that the given Transaction applies to
+// the supply and the holdings of the Colors it touches.
+func (c *ColorSupplyManager) transactionDiff(transactionID TransactionID) (diff map[Color]*colorSupplyDiff, err error) {
+	cachedTransaction := c.utxoDAG.CachedTransaction(transactionID)
+	defer cachedTransaction.Release()
+	transaction := cachedTransaction.Unwrap()
+	if transaction == nil {
+		return nil, errors.Errorf("failed to load Transaction with %s: %w", transactionID, cerrors.ErrFatal)
+	}
+
+	diff = make(map[Color]*colorSupplyDiff)
+	for _, input := range transaction.Essence().Inputs() {
+		referencedOutputID := input.(*UTXOInput).ReferencedOutputID()
+		if !c.utxoDAG.CachedOutput(referencedOutputID).Consume(func(output Output) {
+			output.Balances().ForEach(func(color Color, balance uint64) bool {
+				if color != ColorIOTA {
+					diff[color] = diff[color].consume(output.Address(), balance)
+				}
+
+				return true
+			})
+		}) {
+			return nil, errors.Errorf("failed to load consumed Output with %s: %w", referencedOutputID, cerrors.ErrFatal)
+		}
+	}
+
+	// the stored Outputs already carry the newly minted Colors instead of ColorMint
+	for _, essenceOutput := range transaction.Essence().Outputs() {
+		if !c.utxoDAG.CachedOutput(essenceOutput.ID()).Consume(func(output Output) {
+			output.Balances().ForEach(func(color Color, balance uint64) bool {
+				if color != ColorIOTA {
+					diff[color] = diff[color].produce(output.Address(), balance)
+				}
+
+				return true
+			})
+		}) {
+			return nil, errors.Errorf("failed to load created Output with %s: %w", essenceOutput.ID(), cerrors.ErrFatal)
+		}
+	}
+
+	return diff, nil
+}
+
+// applyDiff is an internal utility function that writes the given changes to the object storage. It inverts the changes
+// if revert is set to true.
+func (c *ColorSupplyManager) applyDiff(transactionID TransactionID, diff map[Color]*colorSupplyDiff, revert bool) {
+	for color, colorDiff := range diff {
+		holdersAdded, holdersRemoved := c.applyHoldingDiff(color, colorDiff.holdings, revert)
+
+		cachedColorSupply := &CachedColorSupply{CachedObject: c.colorSupplyStorage.ComputeIfAbsent(color.Bytes(), func(key []byte) objectstorage.StorableObject {
+			colorSupply := NewColorSupply(color)
+			colorSupply.Persist()
+			colorSupply.SetModified()
+
+			return colorSupply
+		})}
+		cachedColorSupply.Consume(func(colorSupply *ColorSupply) {
+			switch {
+			// the tokens of a Color that the Transaction produced in excess were minted by it
+			case colorDiff.produced > colorDiff.consumed:
+				colorSupply.updateMinted(transactionID, colorDiff.produced-colorDiff.consumed, revert)
+			case colorDiff.consumed > colorDiff.produced:
+				colorSupply.updateBurned(colorDiff.consumed-colorDiff.produced, revert)
+			}
+			colorSupply.updateHolders(holdersAdded, holdersRemoved)
+
+			if colorSupply.Minted() == 0 && colorSupply.Holders() == 0 {
+				colorSupply.Delete()
+			}
+		})
+	}
+}
+
+// applyHoldingDiff is an internal utility function that updates the ColorHoldings of the given Color and returns the
+// amount of addresses that started and stopped holding tokens of that Color.
+func (c *ColorSupplyManager) applyHoldingDiff(color Color, holdings map[[AddressLength]byte]*colorHoldingDiff, revert bool) (added, removed uint64) {
+	for _, holdingDiff := range holdings {
+		increase, decrease := holdingDiff.increase, holdingDiff.decrease
+		if revert {
+			increase, decrease = decrease, increase
+		}
+		if increase == decrease {
+			continue
+		}
+
+		newColorHolding := NewColorHolding(color, holdingDiff.address)
+		(&CachedColorHolding{CachedObject: c.colorHoldingStorage.ComputeIfAbsent(newColorHolding.ObjectStorageKey(), func(key []byte) objectstorage.StorableObject {
+			newColorHolding.Persist()
+			newColorHolding.SetModified()
+
+			return newColorHolding
+		})}).Consume(func(colorHolding *ColorHolding) {
+			wasHolder := colorHolding.Balance() > 0
+			colorHolding.updateBalance(increase, decrease)
+			isHolder := colorHolding.Balance() > 0
+
+			switch {
+			case !wasHolder && isHolder:
+				added++
+			case wasHolder && !isHolder:
+				removed++
+			}
+
+			if !isHolder {
+				colorHolding.Delete()
+			}
+		})
+	}
+
+	return
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region colorSupplyDiff //////////////////////////////////////////////////////////////////////////////////////////////
+
+// colorSupplyDiff is an internal utility type that collects the changes that a Transaction applies to a single Color.
+type colorSupplyDiff struct {
+	consumed uint64
+	produced uint64
+	holdings map[[AddressLength]byte]*colorHoldingDiff
+}
+
+// colorHoldingDiff is an internal utility type that collects the changes that a Transaction applies to the tokens of a
+// single Color held by a single Address.
+type colorHoldingDiff struct {
+	address  Address
+	increase uint64
+	decrease uint64
+}
+
+// consume registers tokens that were consumed from the given Address (it lazily initializes the diff if necessary).
+func (c *colorSupplyDiff) consume(address Address, balance uint64) *colorSupplyDiff {
+	c = c.init()
+	c.consumed += balance
+	c.holding(address).decrease += balance
+
+	return c
+}
+
+// produce registers tokens that were sent to the given Address (it lazily initializes the diff if necessary).
+func (c *colorSupplyDiff) produce(address Address, balance uint64) *colorSupplyDiff {
+	c = c.init()
+	c.produced += balance
+	c.holding(address).increase += balance
+
+	return c
+}
+
+func (c *colorSupplyDiff) init() *colorSupplyDiff {
+	if c == nil {
+		return &colorSupplyDiff{holdings: make(map[[AddressLength]byte]*colorHoldingDiff)}
+	}
+
+	return c
+}
+
+func (c *colorSupplyDiff) holding(address Address) *colorHoldingDiff {
+	holdingDiff, exists := c.holdings[address.Array()]
+	if !exists {
+		holdingDiff = &colorHoldingDiff{address: address}
+		c.holdings[address.Array()] = holdingDiff
+	}
+
+	return holdingDiff
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region ColorSupply //////////////////////////////////////////////////////////////////////////////////////////////////
+
+// ColorSupply represents the supply information of a Color that was minted in the ledger.
+type ColorSupply struct {
+	color                Color
+	mintingTransactionID TransactionID
+	minted               uint64
+	burned               uint64
+	holders              uint64
+	mutex                sync.RWMutex
+
+	objectstorage.StorableObjectFlags
+}
+
+// NewColorSupply is the constructor for the ColorSupply.
+func NewColorSupply(color Color) *ColorSupply {
+	return &ColorSupply{
+		color: color,
+	}
+}
+
+// ColorSupplyFromBytes unmarshals a ColorSupply from a sequence of bytes.
+func ColorSupplyFromBytes(bytes []byte) (colorSupply *ColorSupply, consumedBytes int, err error) {
+	marshalUtil := marshalutil.New(bytes)
+	if colorSupply, err = ColorSupplyFromMarshalUtil(marshalUtil); err != nil {
+		err = errors.Errorf("failed to parse ColorSupply from MarshalUtil: %w", err)
+		return
+	}
+	consumedBytes = marshalUtil.ReadOffset()
+
+	return
+}
+
+// ColorSupplyFromMarshalUtil unmarshals a ColorSupply using a MarshalUtil (for easier unmarshaling).
+func ColorSupplyFromMarshalUtil(marshalUtil *marshalutil.MarshalUtil) (colorSupply *ColorSupply, err error) {
+	colorSupply = &ColorSupply{}
+	if colorSupply.color, err = ColorFromMarshalUtil(marshalUtil); err != nil {
+		err = errors.Errorf("failed to parse Color from MarshalUtil: %w", err)
+		return
+	}
+	if colorSupply.mintingTransactionID, err = TransactionIDFromMarshalUtil(marshalUtil); err != nil {
+		err = errors.Errorf("failed to parse minting TransactionID from MarshalUtil: %w", err)
+		return
+	}
+	if colorSupply.minted, err = marshalUtil.ReadUint64(); err != nil {
+		err = errors.Errorf("failed to parse minted supply (%v): %w", err, cerrors.ErrParseBytesFailed)
+		return
+	}
+	if colorSupply.burned, err = marshalUtil.ReadUint64(); err != nil {
+		err = errors.Errorf("failed to parse burned supply (%v): %w", err, cerrors.ErrParseBytesFailed)
+		return
+	}
+	if colorSupply.holders, err = marshalUtil.ReadUint64(); err != nil {
+		err = errors.Errorf("failed to parse holders count (%v): %w", err, cerrors.ErrParseBytesFailed)
+		return
+	}
+
+	return
+}
+
+// ColorSupplyFromObjectStorage restores a ColorSupply object that was stored in the ObjectStorage.
+func ColorSupplyFromObjectStorage(key []byte, data []byte) (colorSupply objectstorage.StorableObject, err error) {
+	if colorSupply, _, err = ColorSupplyFromBytes(byteutils.ConcatBytes(key, data)); err != nil {
+		err = errors.Errorf("failed to parse ColorSupply from bytes: %w", err)
+		return
+	}
+
+	return
+}
+
+// Color returns the Color that this ColorSupply belongs to.
+func (c *ColorSupply) Color() Color {
+	return c.color
+}
+
+// MintingTransactionID returns the identifier of the Transaction that minted the Color.
+func (c *ColorSupply) MintingTransactionID() TransactionID {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	return c.mintingTransactionID
+}
+
+// Minted returns the total amount of tokens that were minted with this Color.
+func (c *ColorSupply) Minted() uint64 {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	return c.minted
+}
+
+// Burned returns the total amount of tokens of this Color that were recolored back to ColorIOTA.
+func (c *ColorSupply) Burned() uint64 {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	return c.burned
+}
+
+// Circulating returns the amount of tokens of this Color that are currently held in the ledger.
+func (c *ColorSupply) Circulating() uint64 {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	return c.minted - c.burned
+}
+
+// Holders returns the amount of addresses that currently hold tokens of this Color.
+func (c *ColorSupply) Holders() uint64 {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	return c.holders
+}
+
+// updateMinted is an internal utility function that adds (or removes when reverting) minted tokens.
+func (c *ColorSupply) updateMinted(transactionID TransactionID, amount uint64, revert bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if revert {
+		c.minted -= amount
+		if c.mintingTransactionID == transactionID {
+			c.mintingTransactionID = GenesisTransactionID
+		}
+	} else {
+		c.minted += amount
+		if c.mintingTransactionID == GenesisTransactionID {
+			c.mintingTransactionID = transactionID
+		}
+	}
+	c.SetModified()
+}
+
+// updateBurned is an internal utility function that adds (or removes when reverting) burned tokens.
+func (c *ColorSupply) updateBurned(amount uint64, revert bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if revert {
+		c.burned -= amount
+	} else {
+		c.burned += amount
+	}
+	c.SetModified()
+}
+
+// updateHolders is an internal utility function that updates the amount of holders.
+func (c *ColorSupply) updateHolders(added, removed uint64) {
+	if added == removed {
+		return
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.holders = c.holders + added - removed
+	c.SetModified()
+}
+
+// Bytes returns a marshaled version of the ColorSupply.
+func (c *ColorSupply) Bytes() []byte {
+	return byteutils.ConcatBytes(c.ObjectStorageKey(), c.ObjectStorageValue())
+}
+
+// String returns a human readable version of the ColorSupply.
+func (c *ColorSupply) String() string {
+	return stringify.Struct("ColorSupply",
+		stringify.StructField("color", c.Color()),
+		stringify.StructField("mintingTransactionID", c.MintingTransactionID()),
+		stringify.StructField("minted", c.Minted()),
+		stringify.StructField("burned", c.Burned()),
+		stringify.StructField("holders", c.Holders()),
+	)
+}
+
+// Update is disabled and panics if it ever gets called - it is required to match the StorableObject interface.
+func (c *ColorSupply) Update(objectstorage.StorableObject) {
+	panic("updates disabled")
+}
+
+// ObjectStorageKey returns the key that is used to store the object in the database. It is required to match the
+// StorableObject interface.
+func (c *ColorSupply) ObjectStorageKey() []byte {
+	return c.color.Bytes()
+}
+
+// ObjectStorageValue marshals the ColorSupply into a sequence of bytes. The Color is not serialized here as it is only
+// used as a key in the ObjectStorage.
+func (c *ColorSupply) ObjectStorageValue() []byte {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	return marshalutil.New(TransactionIDLength + 3*marshalutil.Uint64Size).
+		Write(c.mintingTransactionID).
+		WriteUint64(c.minted).
+		WriteUint64(c.burned).
+		WriteUint64(c.holders).
+		Bytes()
+}
+
+// code contract (make sure the type implements all required methods)
+var _ objectstorage.StorableObject = &ColorSupply{}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region CachedColorSupply ////////////////////////////////////////////////////////////////////////////////////////////
+
+// CachedColorSupply is a wrapper for the generic CachedObject returned by the object storage that overrides the
+// accessor methods with a type-casted one.
+type CachedColorSupply struct {
+	objectstorage.CachedObject
+}
+
+// Retain marks this CachedObject to still be in use by the program.
+func (c *CachedColorSupply) Retain() *CachedColorSupply {
+	return &CachedColorSupply{c.CachedObject.Retain()}
+}
+
+// Unwrap is the type-casted equivalent of Get. It returns nil if the object does not exist.
+func (c *CachedColorSupply) Unwrap() *ColorSupply {
+	untypedObject := c.Get()
+	if untypedObject == nil {
+		return nil
+	}
+
+	typedObject := untypedObject.(*ColorSupply)
+	if typedObject == nil || typedObject.IsDeleted() {
+		return nil
+	}
+
+	return typedObject
+}
+
+// Consume unwraps the CachedObject and passes a type-casted version to the consumer. It automatically releases the
+// object when the consumer finishes and returns true of there was at least one object that was consumed.
+func (c *CachedColorSupply) Consume(consumer func(colorSupply *ColorSupply), forceRelease ...bool) (consumed bool) {
+	return c.CachedObject.Consume(func(object objectstorage.StorableObject) {
+		consumer(object.(*ColorSupply))
+	}, forceRelease...)
+}
+
+// String returns a human readable version of the CachedColorSupply.
+func (c *CachedColorSupply) String() string {
+	return stringify.Struct("CachedColorSupply",
+		stringify.StructField("CachedObject", c.Unwrap()),
+	)
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region ColorHolding /////////////////////////////////////////////////////////////////////////////////////////////////
+
+// ColorHoldingKeyPartition defines the partition of the storage key of the ColorHolding model.
+var ColorHoldingKeyPartition = objectstorage.PartitionKey(ColorLength, AddressLength)
+
+// ColorHolding represents the amount of tokens of a Color that are held by a single Address.
+type ColorHolding struct {
+	color        Color
+	address      Address
+	balance      uint64
+	balanceMutex sync.RWMutex
+
+	objectstorage.StorableObjectFlags
+}
+
+// NewColorHolding is the constructor for the ColorHolding.
+func NewColorHolding(color Color, address Address) *ColorHolding {
+	return &ColorHolding{
+		color:   color,
+		address: address,
+	}
+}
+
+// ColorHoldingFromBytes unmarshals a ColorHolding from a sequence of bytes.
+func ColorHoldingFromBytes(bytes []byte) (colorHolding *ColorHolding, consumedBytes int, err error) {
+	marshalUtil := marshalutil.New(bytes)
+	if colorHolding, err = ColorHoldingFromMarshalUtil(marshalUtil); err != nil {
+		err = errors.Errorf("failed to parse ColorHolding from MarshalUtil: %w", err)
+		return
+	}
+	consumedBytes = marshalUtil.ReadOffset()
+
+	return
+}
+
+// ColorHoldingFromMarshalUtil unmarshals a ColorHolding using a MarshalUtil (for easier unmarshaling).
+func ColorHoldingFromMarshalUtil(marshalUtil *marshalutil.MarshalUtil) (colorHolding *ColorHolding, err error) {
+	colorHolding = &ColorHolding{}
+	if colorHolding.color, err = ColorFromMarshalUtil(marshalUtil); err != nil {
+		err = errors.Errorf("failed to parse Color from MarshalUtil: %w", err)
+		return
+	}
+	if colorHolding.address, err = AddressFromMarshalUtil(marshalUtil); err != nil {
+		err = errors.Errorf("failed to parse Address from MarshalUtil: %w", err)
+		return
+	}
+	if colorHolding.balance, err = marshalUtil.ReadUint64(); err != nil {
+		err = errors.Errorf("failed to parse balance (%v): %w", err, cerrors.ErrParseBytesFailed)
+		return
+	}
+
+	return
+}
+
+// ColorHoldingFromObjectStorage restores a ColorHolding object that was stored in the ObjectStorage.
+func ColorHoldingFromObjectStorage(key []byte, data []byte) (colorHolding objectstorage.StorableObject, err error) {
+	if colorHolding, _, err = ColorHoldingFromBytes(byteutils.ConcatBytes(key, data)); err != nil {
+		err = errors.Errorf("failed to parse ColorHolding from bytes: %w", err)
+		return
+	}
+
+	return
+}
+
+// Color returns the Color of the held tokens.
+func (c *ColorHolding) Color() Color {
+	return c.color
+}
+
+// Address returns the Address that holds the tokens.
+func (c *ColorHolding) Address() Address {
+	return c.address
+}
+
+// Balance returns the amount of tokens of the Color that are held by the Address.
+func (c *ColorHolding) Balance() uint64 {
+	c.balanceMutex.RLock()
+	defer c.balanceMutex.RUnlock()
+
+	return c.balance
+}
+
+// updateBalance is an internal utility function that modifies the balance of the ColorHolding.
+func (c *ColorHolding) updateBalance(increase, decrease uint64) {
+	c.balanceMutex.Lock()
+	defer c.balanceMutex.Unlock()
+
+	c.balance = c.balance + increase - decrease
+	c.SetModified()
+}
+
+// Bytes returns a marshaled version of the ColorHolding.
+func (c *ColorHolding) Bytes() []byte {
+	return byteutils.ConcatBytes(c.ObjectStorageKey(), c.ObjectStorageValue())
+}
+
+// String returns a human readable version of the ColorHolding.
+func (c *ColorHolding) String() string {
+	return stringify.Struct("ColorHolding",
+		stringify.StructField("color", c.Color()),
+		stringify.StructField("address", c.Address()),
+		stringify.StructField("balance", c.Balance()),
+	)
+}
+
+// Update is disabled and panics if it ever gets called - it is required to match the StorableObject interface.
+func (c *ColorHolding) Update(objectstorage.StorableObject) {
+	panic("updates disabled")
+}
+
+// ObjectStorageKey returns the key that is used to store the object in the database. It is required to match the
+// StorableObject interface.
+func (c *ColorHolding) ObjectStorageKey() []byte {
+	return byteutils.ConcatBytes(c.color.Bytes(), c.address.Bytes())
+}
+
+// ObjectStorageValue marshals the ColorHolding into a sequence of bytes. The Color and the Address are not serialized
+// here as they are only used as a key in the ObjectStorage.
+func (c *ColorHolding) ObjectStorageValue() []byte {
+	return marshalutil.New(marshalutil.Uint64Size).
+		WriteUint64(c.Balance()).
+		Bytes()
+}
+
+// code contract (make sure the type implements all required methods)
+var _ objectstorage.StorableObject = &ColorHolding{}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region CachedColorHolding ///////////////////////////////////////////////////////////////////////////////////////////
+
+// CachedColorHolding is a wrapper for the generic CachedObject returned by the object storage that overrides the
+// accessor methods with a type-casted one.
+type CachedColorHolding struct {
+	objectstorage.CachedObject
+}
+
+// Retain marks this CachedObject to still be in use by the program.
+func (c *CachedColorHolding) Retain() *CachedColorHolding {
+	return &CachedColorHolding{c.CachedObject.Retain()}
+}
+
+// Unwrap is the type-casted equivalent of Get. It returns nil if the object does not exist.
+func (c *CachedColorHolding) Unwrap() *ColorHolding {
+	untypedObject := c.Get()
+	if untypedObject == nil {
+		return nil
+	}
+
+	typedObject := untypedObject.(*ColorHolding)
+	if typedObject == nil || typedObject.IsDeleted() {
+		return nil
+	}
+
+	return typedObject
+}
+
+// Consume unwraps the CachedObject and passes a type-casted version to the consumer. It automatically releases the
+// object when the consumer finishes and returns true of there was at least one object that was consumed.
+func (c *CachedColorHolding) Consume(consumer func(colorHolding *ColorHolding), forceRelease ...bool) (consumed bool) {
+	return c.CachedObject.Consume(func(object objectstorage.StorableObject) {
+		consumer(object.(*ColorHolding))
+	}, forceRelease...)
+}
+
+// String returns a human readable version of the CachedColorHolding.
+func (c *CachedColorHolding) String() string {
+	return stringify.Struct("CachedColorHolding",
+		stringify.StructField("CachedObject", c.Unwrap()),
+	)
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region colorSupplyTransaction ///////////////////////////////////////////////////////////////////////////////////////
+
+// colorSupplyTransaction marks a Transaction whose changes were applied to the ColorSupply together with the Branch
+// that it is booked into.
+type colorSupplyTransaction struct {
+	transactionID TransactionID
+	branchID      BranchID
+
+	objectstorage.StorableObjectFlags
+}
+
+// colorSupplyTransactionFromObjectStorage restores a colorSupplyTransaction object that was stored in the ObjectStorage.
+func colorSupplyTransactionFromObjectStorage(key []byte, data []byte) (result objectstorage.StorableObject, err error) {
+	transactionID, _, err := TransactionIDFromBytes(key)
+	if err != nil {
+		err = errors.Errorf("failed to parse TransactionID from bytes: %w", err)
+		return
+	}
+	branchID, _, err := BranchIDFromBytes(data)
+	if err != nil {
+		err = errors.Errorf("failed to parse BranchID from bytes: %w", err)
+		return
+	}
+
+	return &colorSupplyTransaction{transactionID: transactionID, branchID: branchID}, nil
+}
+
+// Update is disabled and panics if it ever gets called - it is required to match the StorableObject interface.
+func (c *colorSupplyTransaction) Update(objectstorage.StorableObject) {
+	panic("updates disabled")
+}
+
+// ObjectStorageKey returns the key that is used to store the object in the database.
+func (c *colorSupplyTransaction) ObjectStorageKey() []byte {
+	return c.transactionID.Bytes()
+}
+
+// ObjectStorageValue returns the BranchID that the Transaction is booked into.
+func (c *colorSupplyTransaction) ObjectStorageValue() []byte {
+	return c.branchID.Bytes()
+}
+
+// code contract (make sure the type implements all required methods)
+var _ objectstorage.StorableObject = &colorSupplyTransaction{}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region colorSupplyBranch ////////////////////////////////////////////////////////////////////////////////////////////
+
+// colorSupplyBranchKeyPartition defines the partition of the storage key of the colorSupplyBranch model.
+var colorSupplyBranchKeyPartition = objectstorage.PartitionKey(BranchIDLength, TransactionIDLength)
+
+// colorSupplyBranch indexes the applied Transactions by the Branch that they are booked into.
+type colorSupplyBranch struct {
+	branchID      BranchID
+	transactionID TransactionID
+
+	objectstorage.StorableObjectFlags
+}
+
+// colorSupplyBranchFromObjectStorage restores a colorSupplyBranch object that was stored in the ObjectStorage.
+func colorSupplyBranchFromObjectStorage(key []byte, _ []byte) (result objectstorage.StorableObject, err error) {
+	marshalUtil := marshalutil.New(key)
+	colorSupplyBranch := &colorSupplyBranch{}
+	if colorSupplyBranch.branchID, err = BranchIDFromMarshalUtil(marshalUtil); err != nil {
+		err = errors.Errorf("failed to parse BranchID from MarshalUtil: %w", err)
+		return
+	}
+	if colorSupplyBranch.transactionID, err = TransactionIDFromMarshalUtil(marshalUtil); err != nil {
+		err = errors.Errorf("failed to parse TransactionID from MarshalUtil: %w", err)
+		return
+	}
+
+	return colorSupplyBranch, nil
+}
+
+// Update is disabled and panics if it ever gets called - it is required to match the StorableObject interface.
+func (c *colorSupplyBranch) Update(objectstorage.StorableObject) {
+	panic("updates disabled")
+}
+
+// ObjectStorageKey returns the key that is used to store the object in the database.
+func (c *colorSupplyBranch) ObjectStorageKey() []byte {
+	return byteutils.ConcatBytes(c.branchID.Bytes(), c.transactionID.Bytes())
+}
+
+// ObjectStorageValue returns nothing as the BranchID and the TransactionID are already stored in the key.
+func (c *colorSupplyBranch) ObjectStorageValue() []byte {
+	return nil
+}
+
+// code contract (make sure the type implements all required methods)
+var _ objectstorage.StorableObject = &colorSupplyBranch{}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
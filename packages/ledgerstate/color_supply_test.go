@@ -0,0 +1,143 @@
+package ledgerstate
+
+import (
+	"testing"
+	"time"
+
+	"github.com/iotaledger/hive.go/identity"
+	"github.com/iotaledger/hive.go/kvstore/mapdb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/blake2b"
+
+	"github.com/iotaledger/goshimmer/packages/database"
+)
+
+func TestColorSupplyManager(t *testing.T) {
+	branchDAG, utxoDAG := setupDependencies(t)
+	defer branchDAG.Shutdown()
+	colorSupplyManager := NewColorSupplyManager(mapdb.NewMapDB(), database.NewCacheTimeProvider(0), utxoDAG)
+	defer colorSupplyManager.Shutdown()
+
+	wallets := createWallets(3)
+	input := generateOutput(utxoDAG, wallets[0].address, 0)
+
+	// mint 60 tokens of a new color and send them to wallets[1]
+	mintEssence := NewTransactionEssence(0, time.Now(), identity.ID{}, identity.ID{}, NewInputs(NewUTXOInput(input.ID())), NewOutputs(
+		NewSigLockedColoredOutput(NewColoredBalances(map[Color]uint64{ColorIOTA: 40, ColorMint: 60}), wallets[1].address),
+	))
+	mintTransaction := NewTransaction(mintEssence, wallets[0].unlockBlocks(mintEssence))
+	_, err := utxoDAG.BookTransaction(mintTransaction)
+	require.NoError(t, err)
+
+	mintedOutputID := mintTransaction.Essence().Outputs()[0].ID()
+	mintedColor := Color(blake2b.Sum256(mintedOutputID.Bytes()))
+
+	applied, err := colorSupplyManager.ApplyTransaction(mintTransaction.ID())
+	require.NoError(t, err)
+	assert.True(t, applied)
+	assertColorSupply(t, colorSupplyManager, mintedColor, mintTransaction.ID(), 60, 0, 1)
+
+	applied, err = colorSupplyManager.ApplyTransaction(mintTransaction.ID())
+	require.NoError(t, err)
+	assert.False(t, applied)
+
+	// send 20 tokens to wallets[2] and burn the remaining 40 tokens
+	burnEssence := NewTransactionEssence(0, time.Now(), identity.ID{}, identity.ID{}, NewInputs(NewUTXOInput(mintedOutputID)), NewOutputs(
+		NewSigLockedColoredOutput(NewColoredBalances(map[Color]uint64{mintedColor: 20}), wallets[2].address),
+		NewSigLockedSingleOutput(80, wallets[1].address),
+	))
+	burnTransaction := NewTransaction(burnEssence, wallets[1].unlockBlocks(burnEssence))
+	_, err = utxoDAG.BookTransaction(burnTransaction)
+	require.NoError(t, err)
+
+	applied, err = colorSupplyManager.ApplyTransaction(burnTransaction.ID())
+	require.NoError(t, err)
+	assert.True(t, applied)
+	assertColorSupply(t, colorSupplyManager, mintedColor, mintTransaction.ID(), 60, 40, 1)
+	assert.False(t, colorSupplyManager.ColorHolding(mintedColor, wallets[1].address).Consume(func(*ColorHolding) {}))
+	colorSupplyManager.ColorHolding(mintedColor, wallets[2].address).Consume(func(colorHolding *ColorHolding) {
+		assert.Equal(t, uint64(20), colorHolding.Balance())
+	})
+
+	// roll back the burn
+	reverted, err := colorSupplyManager.RevertTransaction(burnTransaction.ID())
+	require.NoError(t, err)
+	assert.True(t, reverted)
+	assertColorSupply(t, colorSupplyManager, mintedColor, mintTransaction.ID(), 60, 0, 1)
+	colorSupplyManager.ColorHolding(mintedColor, wallets[1].address).Consume(func(colorHolding *ColorHolding) {
+		assert.Equal(t, uint64(60), colorHolding.Balance())
+	})
+
+	// roll back the mint
+	reverted, err = colorSupplyManager.RevertTransaction(mintTransaction.ID())
+	require.NoError(t, err)
+	assert.True(t, reverted)
+	assert.False(t, colorSupplyManager.ColorSupply(mintedColor).Consume(func(*ColorSupply) {}))
+}
+
+func TestColorSupplyManager_RevertBranch(t *testing.T) {
+	branchDAG, utxoDAG := setupDependencies(t)
+	defer branchDAG.Shutdown()
+	colorSupplyManager := NewColorSupplyManager(mapdb.NewMapDB(), database.NewCacheTimeProvider(0), utxoDAG)
+	defer colorSupplyManager.Shutdown()
+
+	wallets := createWallets(2)
+	input := generateOutput(utxoDAG, wallets[0].address, 0)
+
+	mintEssence := NewTransactionEssence(0, time.Now(), identity.ID{}, identity.ID{}, NewInputs(NewUTXOInput(input.ID())), NewOutputs(
+		NewSigLockedColoredOutput(NewColoredBalances(map[Color]uint64{ColorIOTA: 40, ColorMint: 60}), wallets[1].address),
+	))
+	mintTransaction := NewTransaction(mintEssence, wallets[0].unlockBlocks(mintEssence))
+	targetBranch, err := utxoDAG.BookTransaction(mintTransaction)
+	require.NoError(t, err)
+	mintedColor := Color(blake2b.Sum256(mintTransaction.Essence().Outputs()[0].ID().Bytes()))
+
+	applied, err := colorSupplyManager.ApplyTransaction(mintTransaction.ID())
+	require.NoError(t, err)
+	assert.True(t, applied)
+
+	// the rejection of an unrelated Branch does not change the supply
+	reverted, err := colorSupplyManager.RevertBranch(BranchID{9})
+	require.NoError(t, err)
+	assert.Empty(t, reverted)
+	assertColorSupply(t, colorSupplyManager, mintedColor, mintTransaction.ID(), 60, 0, 1)
+
+	// the non-conflicting Transaction is reverted with the Branch it is booked into
+	reverted, err = colorSupplyManager.RevertBranch(targetBranch)
+	require.NoError(t, err)
+	assert.Equal(t, []TransactionID{mintTransaction.ID()}, reverted)
+	assert.False(t, colorSupplyManager.ColorSupply(mintedColor).Consume(func(*ColorSupply) {}))
+}
+
+func TestColorSupplyManager_MintExistingColor(t *testing.T) {
+	branchDAG, utxoDAG := setupDependencies(t)
+	defer branchDAG.Shutdown()
+	colorSupplyManager := NewColorSupplyManager(mapdb.NewMapDB(), database.NewCacheTimeProvider(0), utxoDAG)
+	defer colorSupplyManager.Shutdown()
+
+	wallets := createWallets(2)
+	color := Color{1}
+	firstTransactionID, secondTransactionID := TransactionID{1}, TransactionID{2}
+
+	colorSupplyManager.applyDiff(firstTransactionID, map[Color]*colorSupplyDiff{
+		color: (*colorSupplyDiff)(nil).produce(wallets[0].address, 10),
+	}, false)
+	assertColorSupply(t, colorSupplyManager, color, firstTransactionID, 10, 0, 1)
+
+	// a Transaction that produces more tokens of an existing Color than it consumes mints the difference
+	colorSupplyManager.applyDiff(secondTransactionID, map[Color]*colorSupplyDiff{
+		color: (*colorSupplyDiff)(nil).consume(wallets[0].address, 10).produce(wallets[1].address, 25),
+	}, false)
+	assertColorSupply(t, colorSupplyManager, color, firstTransactionID, 25, 0, 1)
+}
+
+func assertColorSupply(t *testing.T, colorSupplyManager *ColorSupplyManager, color Color, mintingTransactionID TransactionID, minted, burned, holders uint64) {
+	assert.True(t, colorSupplyManager.ColorSupply(color).Consume(func(colorSupply *ColorSupply) {
+		assert.Equal(t, mintingTransactionID, colorSupply.MintingTransactionID())
+		assert.Equal(t, minted, colorSupply.Minted())
+		assert.Equal(t, burned, colorSupply.Burned())
+		assert.Equal(t, minted-burned, colorSupply.Circulating())
+		assert.Equal(t, holders, colorSupply.Holders())
+	}))
+}
@@ -0,0 +1,508 @@
+package ledgerstate
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/iotaledger/hive.go/byteutils"
+	"github.com/iotaledger/hive.go/cerrors"
+	"github.com/iotaledger/hive.go/kvstore"
+	"github.com/iotaledger/hive.go/marshalutil"
+	"github.com/iotaledger/hive.go/objectstorage"
+	"github.com/iotaledger/hive.go/stringify"
+
+	"github.com/iotaledger/goshimmer/packages/database"
+)
+
+// ApprovalWeightMilestoneStep defines the granularity of the approval weight milestones that are recorded in the
+// ConflictTimeline (i.e. a milestone is recorded whenever the approval weight of a Branch crosses a multiple of 10%).
+const ApprovalWeightMilestoneStep = 0.1
+
+// region ConflictTimeline /////////////////////////////////////////////////////////////////////////////////////////////
+
+// ConflictTimeline is a ledger component that records how the Conflicts of the BranchDAG were resolved. It keeps a
+// ConflictTimelineEntry for every relevant step of the resolution of a Conflict (creation of its members, opinions of
+// the consensus mechanism, voting rounds, approval weight milestones and the final decision).
+type ConflictTimeline struct {
+	branchDAG *BranchDAG
+
+	conflictTimelineEntryStorage *objectstorage.ObjectStorage
+	shutdownOnce                 sync.Once
+}
+
+// NewConflictTimeline is the constructor of the ConflictTimeline.
+func NewConflictTimeline(store kvstore.KVStore, cacheProvider *database.CacheTimeProvider, branchDAG *BranchDAG) (conflictTimeline *ConflictTimeline) {
+	options := buildObjectStorageOptions(cacheProvider)
+	osFactory := objectstorage.NewFactory(store, database.PrefixLedgerState)
+
+	return &ConflictTimeline{
+		branchDAG:                    branchDAG,
+		conflictTimelineEntryStorage: osFactory.New(PrefixConflictTimelineEntryStorage, ConflictTimelineEntryFromObjectStorage, options.conflictTimelineEntryStorageOptions...),
+	}
+}
+
+// Shutdown shuts down the ConflictTimeline and persists its state.
+func (c *ConflictTimeline) Shutdown() {
+	c.shutdownOnce.Do(func() {
+		c.conflictTimelineEntryStorage.Shutdown()
+	})
+}
+
+// RecordBranchCreated records that the given ConflictBranch became a member of the given Conflict.
+func (c *ConflictTimeline) RecordBranchCreated(conflictID ConflictID, branchID BranchID) {
+	c.storeConflictTimelineEntry(NewConflictTimelineEntry(conflictID, branchID, BranchCreatedEntryType))
+}
+
+// RecordOpinion records the opinion (and its level of knowledge) that the consensus mechanism formed about the given
+// Branch. It does nothing if the Branch is not a ConflictBranch.
+func (c *ConflictTimeline) RecordOpinion(branchID BranchID, liked bool, levelOfKnowledge uint8) {
+	c.forEachConflictOfBranch(branchID, func(conflictID ConflictID) {
+		entry := NewConflictTimelineEntry(conflictID, branchID, OpinionEntryType)
+		entry.liked = liked
+		entry.levelOfKnowledge = levelOfKnowledge
+
+		c.storeConflictTimelineEntry(entry)
+	})
+}
+
+// RecordVotingRound records the opinion that was formed about the given Branch in the given voting round together with
+// the proportion of queried nodes that liked the Branch. It does nothing if the Branch is not a ConflictBranch.
+func (c *ConflictTimeline) RecordVotingRound(branchID BranchID, round uint32, liked bool, proportionLiked float64) {
+	c.forEachConflictOfBranch(branchID, func(conflictID ConflictID) {
+		entry := NewConflictTimelineEntry(conflictID, branchID, VotingRoundEntryType)
+		entry.round = round
+		entry.liked = liked
+		entry.value = proportionLiked
+
+		c.storeConflictTimelineEntry(entry)
+	})
+}
+
+// RecordVotingFinished records the outcome of the voting on the given Branch after the given amount of rounds. The
+// finalized flag indicates if the voting finalized or failed. It does nothing if the Branch is not a ConflictBranch.
+func (c *ConflictTimeline) RecordVotingFinished(branchID BranchID, rounds uint32, liked bool, finalized bool) {
+	entryType := VotingFailedEntryType
+	if finalized {
+		entryType = VotingFinalizedEntryType
+	}
+
+	c.forEachConflictOfBranch(branchID, func(conflictID ConflictID) {
+		entry := NewConflictTimelineEntry(conflictID, branchID, entryType)
+		entry.round = rounds
+		entry.liked = liked
+
+		c.storeConflictTimelineEntry(entry)
+	})
+}
+
+// RecordApprovalWeight records an approval weight milestone if the approval weight of the given Branch crossed a
+// multiple of the ApprovalWeightMilestoneStep while changing from previousWeight to weight. It does nothing if the
+// Branch is not a ConflictBranch.
+func (c *ConflictTimeline) RecordApprovalWeight(branchID BranchID, previousWeight, weight float64) {
+	if approvalWeightMilestone(previousWeight) == approvalWeightMilestone(weight) {
+		return
+	}
+
+	c.forEachConflictOfBranch(branchID, func(conflictID ConflictID) {
+		entry := NewConflictTimelineEntry(conflictID, branchID, ApprovalWeightEntryType)
+		entry.value = weight
+
+		c.storeConflictTimelineEntry(entry)
+	})
+}
+
+// RecordDecision records the final decision (confirmed or rejected) about the given Branch. It does nothing if the
+// Branch is not a ConflictBranch.
+func (c *ConflictTimeline) RecordDecision(branchID BranchID, inclusionState InclusionState) {
+	c.forEachConflictOfBranch(branchID, func(conflictID ConflictID) {
+		entry := NewConflictTimelineEntry(conflictID, branchID, DecisionEntryType)
+		entry.liked = inclusionState == Confirmed
+
+		c.storeConflictTimelineEntry(entry)
+	})
+}
+
+// ConflictTimelineEntries returns all ConflictTimelineEntries of the given Conflict ordered by their timestamp.
+func (c *ConflictTimeline) ConflictTimelineEntries(conflictID ConflictID) (conflictTimelineEntries ConflictTimelineEntries) {
+	conflictTimelineEntries = make(ConflictTimelineEntries, 0)
+	c.conflictTimelineEntryStorage.ForEach(func(key []byte, cachedObject objectstorage.CachedObject) bool {
+		(&CachedConflictTimelineEntry{CachedObject: cachedObject}).Consume(func(conflictTimelineEntry *ConflictTimelineEntry) {
+			conflictTimelineEntries = append(conflictTimelineEntries, conflictTimelineEntry)
+		})
+
+		return true
+	}, objectstorage.WithIteratorPrefix(conflictID.Bytes()))
+
+	sort.SliceStable(conflictTimelineEntries, func(i, j int) bool {
+		if !conflictTimelineEntries[i].Timestamp().Equal(conflictTimelineEntries[j].Timestamp()) {
+			return conflictTimelineEntries[i].Timestamp().Before(conflictTimelineEntries[j].Timestamp())
+		}
+
+		return conflictTimelineEntries[i].Type() < conflictTimelineEntries[j].Type()
+	})
+
+	return
+}
+
+// forEachConflictOfBranch is an internal utility function that executes the callback for every Conflict of the given
+// Branch if it is a ConflictBranch.
+func (c *ConflictTimeline) forEachConflictOfBranch(branchID BranchID, callback func(conflictID ConflictID)) {
+	var conflictIDs ConflictIDs
+	c.branchDAG.Branch(branchID).Consume(func(branch Branch) {
+		if conflictBranch, isConflictBranch := branch.(*ConflictBranch); isConflictBranch {
+			conflictIDs = conflictBranch.Conflicts()
+		}
+	})
+
+	for conflictID := range conflictIDs {
+		callback(conflictID)
+	}
+}
+
+// storeConflictTimelineEntry is an internal utility function that stores the given ConflictTimelineEntry.
+func (c *ConflictTimeline) storeConflictTimelineEntry(conflictTimelineEntry *ConflictTimelineEntry) {
+	if cachedConflictTimelineEntry, stored := c.conflictTimelineEntryStorage.StoreIfAbsent(conflictTimelineEntry); stored {
+		cachedConflictTimelineEntry.Release()
+	}
+}
+
+// approvalWeightMilestone returns the index of the last approval weight milestone that was reached by the given weight.
+func approvalWeightMilestone(weight float64) int {
+	// the small epsilon compensates for rounding errors of the accumulated weights (i.e. 0.3 = 0.1 + 0.1 + 0.1)
+	return int(math.Floor(weight/ApprovalWeightMilestoneStep + 1e-9))
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region ConflictTimelineEntryType ////////////////////////////////////////////////////////////////////////////////////
+
+// ConflictTimelineEntryType represents the type of a step in the resolution of a Conflict.
+type ConflictTimelineEntryType uint8
+
+const (
+	// BranchCreatedEntryType represents the creation of a member of the Conflict.
+	BranchCreatedEntryType ConflictTimelineEntryType = iota
+
+	// OpinionEntryType represents an opinion that was formed by the consensus mechanism (i.e. FCoB).
+	OpinionEntryType
+
+	// VotingRoundEntryType represents a round of the voting protocol (i.e. FPC).
+	VotingRoundEntryType
+
+	// VotingFinalizedEntryType represents the finalization of the voting protocol.
+	VotingFinalizedEntryType
+
+	// VotingFailedEntryType represents a voting that failed to finalize.
+	VotingFailedEntryType
+
+	// ApprovalWeightEntryType represents an approval weight milestone.
+	ApprovalWeightEntryType
+
+	// DecisionEntryType represents the final decision (confirmed or rejected) about a member of the Conflict.
+	DecisionEntryType
+)
+
+// ConflictTimelineEntryTypeFromMarshalUtil unmarshals a ConflictTimelineEntryType using a MarshalUtil (for easier
+// unmarshaling).
+func ConflictTimelineEntryTypeFromMarshalUtil(marshalUtil *marshalutil.MarshalUtil) (entryType ConflictTimelineEntryType, err error) {
+	entryTypeByte, err := marshalUtil.ReadByte()
+	if err != nil {
+		err = errors.Errorf("failed to parse ConflictTimelineEntryType (%v): %w", err, cerrors.ErrParseBytesFailed)
+		return
+	}
+	if entryType = ConflictTimelineEntryType(entryTypeByte); entryType > DecisionEntryType {
+		err = errors.Errorf("invalid ConflictTimelineEntryType (%d): %w", entryTypeByte, cerrors.ErrParseBytesFailed)
+		return
+	}
+
+	return
+}
+
+// Bytes returns a marshaled version of the ConflictTimelineEntryType.
+func (c ConflictTimelineEntryType) Bytes() []byte {
+	return []byte{byte(c)}
+}
+
+// String returns a human readable version of the ConflictTimelineEntryType.
+func (c ConflictTimelineEntryType) String() string {
+	switch c {
+	case BranchCreatedEntryType:
+		return "BranchCreated"
+	case OpinionEntryType:
+		return "Opinion"
+	case VotingRoundEntryType:
+		return "VotingRound"
+	case VotingFinalizedEntryType:
+		return "VotingFinalized"
+	case VotingFailedEntryType:
+		return "VotingFailed"
+	case ApprovalWeightEntryType:
+		return "ApprovalWeight"
+	case DecisionEntryType:
+		return "Decision"
+	default:
+		return fmt.Sprintf("ConflictTimelineEntryType(%X)", uint8(c))
+	}
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region ConflictTimelineEntry ////////////////////////////////////////////////////////////////////////////////////////
+
+// ConflictTimelineEntryKeyPartition defines the partition of the storage key of the ConflictTimelineEntry model.
+var ConflictTimelineEntryKeyPartition = objectstorage.PartitionKey(ConflictIDLength, marshalutil.Int64Size, BranchIDLength, 1)
+
+// ConflictTimelineEntry represents a single step in the resolution of a Conflict. Depending on its type, only some of
+// its fields carry a meaning:
+//
+//   - Opinion: Liked, LevelOfKnowledge
+//   - VotingRound: Round, Liked, Value (proportion of queried nodes that liked the Branch)
+//   - VotingFinalized / VotingFailed: Round (amount of executed rounds), Liked
+//   - ApprovalWeight: Value (approval weight of the Branch)
+//   - Decision: Liked (true if the Branch was confirmed and false if it was rejected)
+type ConflictTimelineEntry struct {
+	conflictID       ConflictID
+	timestamp        time.Time
+	branchID         BranchID
+	entryType        ConflictTimelineEntryType
+	liked            bool
+	levelOfKnowledge uint8
+	round            uint32
+	value            float64
+
+	objectstorage.StorableObjectFlags
+}
+
+// NewConflictTimelineEntry is the constructor for a ConflictTimelineEntry of the given type that happened now.
+func NewConflictTimelineEntry(conflictID ConflictID, branchID BranchID, entryType ConflictTimelineEntryType) *ConflictTimelineEntry {
+	return &ConflictTimelineEntry{
+		conflictID: conflictID,
+		timestamp:  time.Now(),
+		branchID:   branchID,
+		entryType:  entryType,
+	}
+}
+
+// ConflictTimelineEntryFromBytes unmarshals a ConflictTimelineEntry from a sequence of bytes.
+func ConflictTimelineEntryFromBytes(bytes []byte) (conflictTimelineEntry *ConflictTimelineEntry, consumedBytes int, err error) {
+	marshalUtil := marshalutil.New(bytes)
+	if conflictTimelineEntry, err = ConflictTimelineEntryFromMarshalUtil(marshalUtil); err != nil {
+		err = errors.Errorf("failed to parse ConflictTimelineEntry from MarshalUtil: %w", err)
+		return
+	}
+	consumedBytes = marshalUtil.ReadOffset()
+
+	return
+}
+
+// ConflictTimelineEntryFromMarshalUtil unmarshals a ConflictTimelineEntry using a MarshalUtil (for easier
+// unmarshaling).
+func ConflictTimelineEntryFromMarshalUtil(marshalUtil *marshalutil.MarshalUtil) (conflictTimelineEntry *ConflictTimelineEntry, err error) {
+	conflictTimelineEntry = &ConflictTimelineEntry{}
+	if conflictTimelineEntry.conflictID, err = ConflictIDFromMarshalUtil(marshalUtil); err != nil {
+		err = errors.Errorf("failed to parse ConflictID from MarshalUtil: %w", err)
+		return
+	}
+	timestamp, err := marshalUtil.ReadInt64()
+	if err != nil {
+		err = errors.Errorf("failed to parse timestamp (%v): %w", err, cerrors.ErrParseBytesFailed)
+		return
+	}
+	conflictTimelineEntry.timestamp = time.Unix(0, timestamp)
+	if conflictTimelineEntry.branchID, err = BranchIDFromMarshalUtil(marshalUtil); err != nil {
+		err = errors.Errorf("failed to parse BranchID from MarshalUtil: %w", err)
+		return
+	}
+	if conflictTimelineEntry.entryType, err = ConflictTimelineEntryTypeFromMarshalUtil(marshalUtil); err != nil {
+		err = errors.Errorf("failed to parse ConflictTimelineEntryType from MarshalUtil: %w", err)
+		return
+	}
+	if conflictTimelineEntry.liked, err = marshalUtil.ReadBool(); err != nil {
+		err = errors.Errorf("failed to parse liked flag (%v): %w", err, cerrors.ErrParseBytesFailed)
+		return
+	}
+	if conflictTimelineEntry.levelOfKnowledge, err = marshalUtil.ReadUint8(); err != nil {
+		err = errors.Errorf("failed to parse level of knowledge (%v): %w", err, cerrors.ErrParseBytesFailed)
+		return
+	}
+	if conflictTimelineEntry.round, err = marshalUtil.ReadUint32(); err != nil {
+		err = errors.Errorf("failed to parse round (%v): %w", err, cerrors.ErrParseBytesFailed)
+		return
+	}
+	value, err := marshalUtil.ReadUint64()
+	if err != nil {
+		err = errors.Errorf("failed to parse value (%v): %w", err, cerrors.ErrParseBytesFailed)
+		return
+	}
+	conflictTimelineEntry.value = math.Float64frombits(value)
+
+	return
+}
+
+// ConflictTimelineEntryFromObjectStorage restores a ConflictTimelineEntry object that was stored in the ObjectStorage.
+func ConflictTimelineEntryFromObjectStorage(key []byte, data []byte) (conflictTimelineEntry objectstorage.StorableObject, err error) {
+	if conflictTimelineEntry, _, err = ConflictTimelineEntryFromBytes(byteutils.ConcatBytes(key, data)); err != nil {
+		err = errors.Errorf("failed to parse ConflictTimelineEntry from bytes: %w", err)
+		return
+	}
+
+	return
+}
+
+// ConflictID returns the identifier of the Conflict that this ConflictTimelineEntry belongs to.
+func (c *ConflictTimelineEntry) ConflictID() ConflictID {
+	return c.conflictID
+}
+
+// Timestamp returns the time when the step happened.
+func (c *ConflictTimelineEntry) Timestamp() time.Time {
+	return c.timestamp
+}
+
+// BranchID returns the identifier of the member of the Conflict that this ConflictTimelineEntry is about.
+func (c *ConflictTimelineEntry) BranchID() BranchID {
+	return c.branchID
+}
+
+// Type returns the type of the ConflictTimelineEntry.
+func (c *ConflictTimelineEntry) Type() ConflictTimelineEntryType {
+	return c.entryType
+}
+
+// Liked returns the opinion (or the decision) about the Branch.
+func (c *ConflictTimelineEntry) Liked() bool {
+	return c.liked
+}
+
+// LevelOfKnowledge returns the level of knowledge of the opinion about the Branch.
+func (c *ConflictTimelineEntry) LevelOfKnowledge() uint8 {
+	return c.levelOfKnowledge
+}
+
+// Round returns the voting round (or the amount of executed voting rounds).
+func (c *ConflictTimelineEntry) Round() uint32 {
+	return c.round
+}
+
+// Value returns the proportion of liking nodes of a voting round or the approval weight of an approval weight milestone.
+func (c *ConflictTimelineEntry) Value() float64 {
+	return c.value
+}
+
+// Bytes returns a marshaled version of the ConflictTimelineEntry.
+func (c *ConflictTimelineEntry) Bytes() []byte {
+	return byteutils.ConcatBytes(c.ObjectStorageKey(), c.ObjectStorageValue())
+}
+
+// String returns a human readable version of the ConflictTimelineEntry.
+func (c *ConflictTimelineEntry) String() string {
+	return stringify.Struct("ConflictTimelineEntry",
+		stringify.StructField("conflictID", c.ConflictID()),
+		stringify.StructField("timestamp", c.Timestamp()),
+		stringify.StructField("branchID", c.BranchID()),
+		stringify.StructField("type", c.Type()),
+		stringify.StructField("liked", c.Liked()),
+		stringify.StructField("levelOfKnowledge", c.LevelOfKnowledge()),
+		stringify.StructField("round", c.Round()),
+		stringify.StructField("value", c.Value()),
+	)
+}
+
+// Update is disabled and panics if it ever gets called - it is required to match the StorableObject interface.
+func (c *ConflictTimelineEntry) Update(objectstorage.StorableObject) {
+	panic("updates disabled")
+}
+
+// ObjectStorageKey returns the key that is used to store the object in the database. It is required to match the
+// StorableObject interface.
+func (c *ConflictTimelineEntry) ObjectStorageKey() []byte {
+	return marshalutil.New(ConflictIDLength + marshalutil.Int64Size + BranchIDLength + 1).
+		Write(c.conflictID).
+		WriteInt64(c.timestamp.UnixNano()).
+		Write(c.branchID).
+		Write(c.entryType).
+		Bytes()
+}
+
+// ObjectStorageValue marshals the ConflictTimelineEntry into a sequence of bytes. The ConflictID, the timestamp, the
+// BranchID and the type are not serialized here as they are only used as a key in the ObjectStorage.
+func (c *ConflictTimelineEntry) ObjectStorageValue() []byte {
+	return marshalutil.New(marshalutil.BoolSize + marshalutil.Uint8Size + marshalutil.Uint32Size + marshalutil.Uint64Size).
+		WriteBool(c.liked).
+		WriteUint8(c.levelOfKnowledge).
+		WriteUint32(c.round).
+		WriteUint64(math.Float64bits(c.value)).
+		Bytes()
+}
+
+// code contract (make sure the type implements all required methods)
+var _ objectstorage.StorableObject = &ConflictTimelineEntry{}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region ConflictTimelineEntries //////////////////////////////////////////////////////////////////////////////////////
+
+// ConflictTimelineEntries represents a collection of ConflictTimelineEntries.
+type ConflictTimelineEntries []*ConflictTimelineEntry
+
+// String returns a human readable version of the ConflictTimelineEntries.
+func (c ConflictTimelineEntries) String() string {
+	structBuilder := stringify.StructBuilder("ConflictTimelineEntries")
+	for i, conflictTimelineEntry := range c {
+		structBuilder.AddField(stringify.StructField(strconv.Itoa(i), conflictTimelineEntry))
+	}
+
+	return structBuilder.String()
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region CachedConflictTimelineEntry //////////////////////////////////////////////////////////////////////////////////
+
+// CachedConflictTimelineEntry is a wrapper for the generic CachedObject returned by the object storage that overrides
+// the accessor methods with a type-casted one.
+type CachedConflictTimelineEntry struct {
+	objectstorage.CachedObject
+}
+
+// Retain marks this CachedObject to still be in use by the program.
+func (c *CachedConflictTimelineEntry) Retain() *CachedConflictTimelineEntry {
+	return &CachedConflictTimelineEntry{c.CachedObject.Retain()}
+}
+
+// Unwrap is the type-casted equivalent of Get. It returns nil if the object does not exist.
+func (c *CachedConflictTimelineEntry) Unwrap() *ConflictTimelineEntry {
+	untypedObject := c.Get()
+	if untypedObject == nil {
+		return nil
+	}
+
+	typedObject := untypedObject.(*ConflictTimelineEntry)
+	if typedObject == nil || typedObject.IsDeleted() {
+		return nil
+	}
+
+	return typedObject
+}
+
+// Consume unwraps the CachedObject and passes a type-casted version to the consumer. It automatically releases the
+// object when the consumer finishes and returns true of there was at least one object that was consumed.
+func (c *CachedConflictTimelineEntry) Consume(consumer func(conflictTimelineEntry *ConflictTimelineEntry), forceRelease ...bool) (consumed bool) {
+	return c.CachedObject.Consume(func(object objectstorage.StorableObject) {
+		consumer(object.(*ConflictTimelineEntry))
+	}, forceRelease...)
+}
+
+// String returns a human readable version of the CachedConflictTimelineEntry.
+func (c *CachedConflictTimelineEntry) String() string {
+	return stringify.Struct("CachedConflictTimelineEntry",
+		stringify.StructField("CachedObject", c.Unwrap()),
+	)
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
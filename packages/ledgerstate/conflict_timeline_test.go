@@ -0,0 +1,90 @@
+package ledgerstate
+
+import (
+	"testing"
+	"time"
+
+	"github.com/iotaledger/hive.go/events"
+	"github.com/iotaledger/hive.go/kvstore/mapdb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/goshimmer/packages/database"
+)
+
+func TestConflictTimeline(t *testing.T) {
+	branchDAG, _ := setupDependencies(t)
+	defer branchDAG.Shutdown()
+	conflictTimeline := NewConflictTimeline(mapdb.NewMapDB(), database.NewCacheTimeProvider(0), branchDAG)
+	defer conflictTimeline.Shutdown()
+	branchDAG.Events.ConflictMemberAdded.Attach(events.NewClosure(conflictTimeline.RecordBranchCreated))
+
+	conflictID := ConflictID{1}
+	for _, branchID := range []BranchID{{2}, {3}} {
+		cachedBranch, _, err := branchDAG.CreateConflictBranch(branchID, NewBranchIDs(MasterBranchID), NewConflictIDs(conflictID))
+		require.NoError(t, err)
+		cachedBranch.Release()
+
+		// make sure the timestamps of the entries are strictly increasing
+		time.Sleep(time.Millisecond)
+	}
+
+	conflictTimeline.RecordOpinion(BranchID{2}, true, 1)
+	conflictTimeline.RecordOpinion(MasterBranchID, true, 1)
+	time.Sleep(time.Millisecond)
+	conflictTimeline.RecordVotingRound(BranchID{2}, 1, true, 0.75)
+	time.Sleep(time.Millisecond)
+	conflictTimeline.RecordVotingFinished(BranchID{3}, 10, false, true)
+	time.Sleep(time.Millisecond)
+	conflictTimeline.RecordApprovalWeight(BranchID{2}, 0.05, 0.08)
+	conflictTimeline.RecordApprovalWeight(BranchID{2}, 0.1+0.1+0.05, 0.1+0.1+0.1)
+	time.Sleep(time.Millisecond)
+	conflictTimeline.RecordDecision(BranchID{2}, Confirmed)
+
+	entries := conflictTimeline.ConflictTimelineEntries(conflictID)
+	require.Len(t, entries, 7)
+
+	assert.Equal(t, BranchCreatedEntryType, entries[0].Type())
+	assert.Equal(t, BranchID{2}, entries[0].BranchID())
+	assert.Equal(t, BranchCreatedEntryType, entries[1].Type())
+	assert.Equal(t, BranchID{3}, entries[1].BranchID())
+
+	assert.Equal(t, OpinionEntryType, entries[2].Type())
+	assert.True(t, entries[2].Liked())
+	assert.Equal(t, uint8(1), entries[2].LevelOfKnowledge())
+
+	assert.Equal(t, VotingRoundEntryType, entries[3].Type())
+	assert.Equal(t, uint32(1), entries[3].Round())
+	assert.Equal(t, 0.75, entries[3].Value())
+
+	assert.Equal(t, VotingFinalizedEntryType, entries[4].Type())
+	assert.Equal(t, BranchID{3}, entries[4].BranchID())
+	assert.False(t, entries[4].Liked())
+	assert.Equal(t, uint32(10), entries[4].Round())
+
+	assert.Equal(t, ApprovalWeightEntryType, entries[5].Type())
+	assert.InDelta(t, 0.3, entries[5].Value(), 1e-9)
+
+	assert.Equal(t, DecisionEntryType, entries[6].Type())
+	assert.True(t, entries[6].Liked())
+
+	assert.Empty(t, conflictTimeline.ConflictTimelineEntries(ConflictID{2}))
+}
+
+func TestConflictTimelineEntry_Bytes(t *testing.T) {
+	entry := NewConflictTimelineEntry(ConflictID{1}, BranchID{2}, VotingRoundEntryType)
+	entry.liked = true
+	entry.round = 3
+	entry.value = 0.42
+
+	restoredEntry, consumedBytes, err := ConflictTimelineEntryFromBytes(entry.Bytes())
+	require.NoError(t, err)
+	assert.Equal(t, len(entry.Bytes()), consumedBytes)
+	assert.Equal(t, entry.ConflictID(), restoredEntry.ConflictID())
+	assert.True(t, entry.Timestamp().Equal(restoredEntry.Timestamp()))
+	assert.Equal(t, entry.BranchID(), restoredEntry.BranchID())
+	assert.Equal(t, entry.Type(), restoredEntry.Type())
+	assert.Equal(t, entry.Liked(), restoredEntry.Liked())
+	assert.Equal(t, entry.Round(), restoredEntry.Round())
+	assert.Equal(t, entry.Value(), restoredEntry.Value())
+}
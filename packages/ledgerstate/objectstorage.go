@@ -38,6 +38,26 @@ const (
 
 	// PrefixAddressOutputMappingStorage defines the storage prefix for the AddressOutputMapping object storage.
 	PrefixAddressOutputMappingStorage
+
+	// PrefixColorSupplyStorage defines the storage prefix for the ColorSupply object storage.
+	PrefixColorSupplyStorage
+
+	// PrefixColorHoldingStorage defines the storage prefix for the ColorHolding object storage.
+	PrefixColorHoldingStorage
+
+	// PrefixColorSupplyTransactionStorage defines the storage prefix for the object storage that keeps track of the
+	// Transactions that were applied to the ColorSupply.
+	PrefixColorSupplyTransactionStorage
+
+	// PrefixAliasStateRecordStorage defines the storage prefix for the AliasStateRecord object storage.
+	PrefixAliasStateRecordStorage
+
+	// PrefixConflictTimelineEntryStorage defines the storage prefix for the ConflictTimelineEntry object storage.
+	PrefixConflictTimelineEntryStorage
+
+	// PrefixColorSupplyBranchStorage defines the storage prefix for the object storage that indexes the Transactions
+	// that were applied to the ColorSupply by their Branch.
+	PrefixColorSupplyBranchStorage
 )
 
 // block of default cache time
@@ -48,6 +68,9 @@ const (
 	outputCacheTime      = 10 * time.Second
 	consumerCacheTime    = 10 * time.Second
 	addressCacheTime     = 10 * time.Second
+	colorCacheTime       = 10 * time.Second
+	aliasCacheTime       = 10 * time.Second
+	timelineCacheTime    = 10 * time.Second
 )
 
 type storageOptions struct {
@@ -80,6 +103,27 @@ type storageOptions struct {
 
 	// addressOutputMappingStorageOptions contains a list of default settings for the AddressOutputMapping object storage.
 	addressOutputMappingStorageOptions []objectstorage.Option
+
+	// colorSupplyStorageOptions contains a list of default settings for the ColorSupply object storage.
+	colorSupplyStorageOptions []objectstorage.Option
+
+	// colorHoldingStorageOptions contains a list of default settings for the ColorHolding object storage.
+	colorHoldingStorageOptions []objectstorage.Option
+
+	// colorSupplyTransactionStorageOptions contains a list of default settings for the object storage of the applied
+	// ColorSupply Transactions.
+	colorSupplyTransactionStorageOptions []objectstorage.Option
+
+	// colorSupplyBranchStorageOptions contains a list of default settings for the object storage that indexes the
+	// applied ColorSupply Transactions by their Branch.
+	colorSupplyBranchStorageOptions []objectstorage.Option
+
+	// aliasStateRecordStorageOptions contains a list of default settings for the AliasStateRecord object storage.
+	aliasStateRecordStorageOptions []objectstorage.Option
+
+	// conflictTimelineEntryStorageOptions contains a list of default settings for the ConflictTimelineEntry object
+	// storage.
+	conflictTimelineEntryStorageOptions []objectstorage.Option
 }
 
 func buildObjectStorageOptions(cacheProvider *database.CacheTimeProvider) *storageOptions {
@@ -144,5 +188,43 @@ func buildObjectStorageOptions(cacheProvider *database.CacheTimeProvider) *stora
 		objectstorage.StoreOnCreation(true),
 	}
 
+	options.colorSupplyStorageOptions = []objectstorage.Option{
+		cacheProvider.CacheTime(colorCacheTime),
+		objectstorage.LeakDetectionEnabled(false),
+	}
+
+	options.colorHoldingStorageOptions = []objectstorage.Option{
+		ColorHoldingKeyPartition,
+		cacheProvider.CacheTime(colorCacheTime),
+		objectstorage.LeakDetectionEnabled(false),
+	}
+
+	options.colorSupplyTransactionStorageOptions = []objectstorage.Option{
+		cacheProvider.CacheTime(colorCacheTime),
+		objectstorage.LeakDetectionEnabled(false),
+		objectstorage.StoreOnCreation(true),
+	}
+
+	options.colorSupplyBranchStorageOptions = []objectstorage.Option{
+		colorSupplyBranchKeyPartition,
+		cacheProvider.CacheTime(colorCacheTime),
+		objectstorage.LeakDetectionEnabled(false),
+		objectstorage.StoreOnCreation(true),
+	}
+
+	options.aliasStateRecordStorageOptions = []objectstorage.Option{
+		AliasStateRecordKeyPartition,
+		cacheProvider.CacheTime(aliasCacheTime),
+		objectstorage.LeakDetectionEnabled(false),
+		objectstorage.StoreOnCreation(true),
+	}
+
+	options.conflictTimelineEntryStorageOptions = []objectstorage.Option{
+		ConflictTimelineEntryKeyPartition,
+		cacheProvider.CacheTime(timelineCacheTime),
+		objectstorage.LeakDetectionEnabled(false),
+		objectstorage.StoreOnCreation(true),
+	}
+
 	return &options
 }
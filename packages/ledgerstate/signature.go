@@ -198,16 +198,18 @@ func (e *ED25519Signature) SignatureValid(data []byte) bool {
 
 // AddressSignatureValid returns true if the Signature signs the given Address.
 func (e *ED25519Signature) AddressSignatureValid(address Address, data []byte) bool {
+	return e.addressMatches(address) && e.SignatureValid(data)
+}
+
+// addressMatches returns true if the public key of the Signature belongs to the given Address.
+func (e *ED25519Signature) addressMatches(address Address) bool {
 	if address.Type() != ED25519AddressType {
 		return false
 	}
 
 	hashedPublicKey := blake2b.Sum256(e.PublicKey.Bytes())
-	if !bytes.Equal(hashedPublicKey[:], address.Digest()) {
-		return false
-	}
 
-	return e.SignatureValid(data)
+	return bytes.Equal(hashedPublicKey[:], address.Digest())
 }
 
 // Bytes returns a marshaled version of the Signature.
@@ -308,16 +310,18 @@ func (b *BLSSignature) SignatureValid(data []byte) bool {
 
 // AddressSignatureValid returns true if the Signature signs the given Address.
 func (b *BLSSignature) AddressSignatureValid(address Address, data []byte) bool {
+	return b.addressMatches(address) && b.SignatureValid(data)
+}
+
+// addressMatches returns true if the public key of the Signature belongs to the given Address.
+func (b *BLSSignature) addressMatches(address Address) bool {
 	if address.Type() != BLSAddressType {
 		return false
 	}
 
 	hashedPublicKey := blake2b.Sum256(b.Signature.PublicKey.Bytes())
-	if !bytes.Equal(hashedPublicKey[:], address.Digest()) {
-		return false
-	}
 
-	return b.SignatureValid(data)
+	return bytes.Equal(hashedPublicKey[:], address.Digest())
 }
 
 // Bytes returns a marshaled version of the Signature.
@@ -342,3 +346,51 @@ func (b *BLSSignature) String() string {
 var _ Signature = &BLSSignature{}
 
 // endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region verifiedSignature ////////////////////////////////////////////////////////////////////////////////////////////
+
+// addressMatcher is an internal interface for the Signatures that can check if they belong to an Address without
+// verifying the signature itself.
+type addressMatcher interface {
+	addressMatches(address Address) bool
+}
+
+// verifiedSignature is a wrapper for a Signature that was already verified for the given data (i.e. as part of a batch)
+// so that the expensive cryptographic checks do not have to be repeated.
+type verifiedSignature struct {
+	Signature
+
+	signedData []byte
+}
+
+// newVerifiedSignature wraps the given Signature that was verified to be valid for the given data.
+func newVerifiedSignature(signature Signature, signedData []byte) *verifiedSignature {
+	return &verifiedSignature{
+		Signature:  signature,
+		signedData: signedData,
+	}
+}
+
+// SignatureValid returns true if the Signature signs the given data.
+func (v *verifiedSignature) SignatureValid(data []byte) bool {
+	if bytes.Equal(data, v.signedData) {
+		return true
+	}
+
+	return v.Signature.SignatureValid(data)
+}
+
+// AddressSignatureValid returns true if the Signature signs the given Address.
+func (v *verifiedSignature) AddressSignatureValid(address Address, data []byte) bool {
+	matcher, ok := v.Signature.(addressMatcher)
+	if !ok {
+		return v.Signature.AddressSignatureValid(address, data)
+	}
+
+	return matcher.addressMatches(address) && v.SignatureValid(data)
+}
+
+// code contract (make sure the type implements all required methods)
+var _ Signature = &verifiedSignature{}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -6,14 +6,16 @@ import (
 	"io"
 	"time"
 
+	"github.com/cockroachdb/errors"
 	"github.com/iotaledger/hive.go/identity"
 	"github.com/iotaledger/hive.go/marshalutil"
 )
 
 // Snapshot defines a snapshot of the ledger state.
 type Snapshot struct {
-	Transactions     map[TransactionID]Record
-	AccessManaByNode map[identity.ID]AccessMana
+	Transactions        map[TransactionID]Record
+	AccessManaByNode    map[identity.ID]AccessMana
+	ConsensusManaByNode map[identity.ID]ConsensusMana
 }
 
 // AccessMana defines the info for the aMana snapshot.
@@ -22,6 +24,12 @@ type AccessMana struct {
 	Timestamp time.Time
 }
 
+// ConsensusMana defines the info for the cMana snapshot.
+type ConsensusMana struct {
+	BaseMana  float64
+	Timestamp time.Time
+}
+
 // Record defines a record of the snapshot.
 type Record struct {
 	Essence        *TransactionEssence
@@ -96,6 +104,25 @@ func (s *Snapshot) WriteTo(writer io.Writer) (int64, error) {
 		bytesWritten += 8
 	}
 
+	if err := binary.Write(writer, binary.LittleEndian, uint32(len(s.ConsensusManaByNode))); err != nil {
+		return 0, fmt.Errorf("unable to write ConsensusMana count: %w", err)
+	}
+	bytesWritten += 4
+	for nodeID, consensusMana := range s.ConsensusManaByNode {
+		if err := binary.Write(writer, binary.LittleEndian, nodeID.Bytes()); err != nil {
+			return 0, fmt.Errorf("unable to write nodeID with %s: %w", nodeID, err)
+		}
+		bytesWritten += identity.IDLength
+		if err := binary.Write(writer, binary.LittleEndian, consensusMana.BaseMana); err != nil {
+			return 0, fmt.Errorf("unable to write consensus base mana : %w", err)
+		}
+		bytesWritten += 8
+		if err := binary.Write(writer, binary.LittleEndian, consensusMana.Timestamp.UnixNano()); err != nil {
+			return 0, fmt.Errorf("unable to write timestamp : %w", err)
+		}
+		bytesWritten += 8
+	}
+
 	return bytesWritten, nil
 }
 
@@ -112,7 +139,12 @@ func (s *Snapshot) ReadFrom(reader io.Reader) (int64, error) {
 		return bytesAccessMana, err
 	}
 
-	return bytesTransactions + bytesAccessMana, nil
+	bytesConsensusMana, err := s.readConsensusMana(reader)
+	if err != nil {
+		return bytesConsensusMana, err
+	}
+
+	return bytesTransactions + bytesAccessMana + bytesConsensusMana, nil
 }
 
 // readTransactions reads the transactions from the snapshot
@@ -241,3 +273,51 @@ func (s *Snapshot) readAccessMana(reader io.Reader) (int64, error) {
 
 	return bytesRead, nil
 }
+
+// readConsensusMana reads the consensus mana from the snapshot. Snapshots that were created before the consensus mana
+// was part of them end after the access mana, so they result in an empty consensus mana map.
+func (s *Snapshot) readConsensusMana(reader io.Reader) (int64, error) {
+	s.ConsensusManaByNode = make(map[identity.ID]ConsensusMana)
+	var bytesRead int64
+	var consensusManaCount uint32
+
+	// read consensus mana
+	if err := binary.Read(reader, binary.LittleEndian, &consensusManaCount); err != nil {
+		if errors.Is(err, io.EOF) {
+			return 0, nil
+		}
+		return 0, fmt.Errorf("unable to read ConsensusMana count: %w", err)
+	}
+	bytesRead += 4
+	for i := 0; i < int(consensusManaCount); i++ {
+		nodeIDBytes := make([]byte, identity.IDLength)
+		if err := binary.Read(reader, binary.LittleEndian, &nodeIDBytes); err != nil {
+			return 0, fmt.Errorf("unable to read nodeID: %w", err)
+		}
+		bytesRead += identity.IDLength
+		marshalutilNodeID := marshalutil.New(nodeIDBytes)
+		nodeID, err := identity.IDFromMarshalUtil(marshalutilNodeID)
+		if err != nil {
+			return 0, fmt.Errorf("unable to parse nodeID: %w", err)
+		}
+
+		var baseMana float64
+		if err := binary.Read(reader, binary.LittleEndian, &baseMana); err != nil {
+			return 0, fmt.Errorf("unable to read consensus base mana: %w", err)
+		}
+		bytesRead += 8
+
+		var timestampUnixNano int64
+		if err := binary.Read(reader, binary.LittleEndian, &timestampUnixNano); err != nil {
+			return 0, fmt.Errorf("unable to read timestamp: %w", err)
+		}
+		bytesRead += 8
+
+		s.ConsensusManaByNode[nodeID] = ConsensusMana{
+			BaseMana:  baseMana,
+			Timestamp: time.Unix(0, timestampUnixNano),
+		}
+	}
+
+	return bytesRead, nil
+}
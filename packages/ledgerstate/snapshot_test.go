@@ -0,0 +1,60 @@
+package ledgerstate
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/iotaledger/hive.go/identity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSnapshot_ReadWrite(t *testing.T) {
+	nodeID := identity.GenerateIdentity().ID()
+	timestamp := time.Unix(time.Now().Unix(), 0)
+	// the consensus mana keeps the full precision of the time it was last updated
+	consensusTimestamp := time.Unix(0, time.Now().UnixNano())
+	snapshot := &Snapshot{
+		Transactions: map[TransactionID]Record{},
+		AccessManaByNode: map[identity.ID]AccessMana{
+			nodeID: {Value: 10, Timestamp: timestamp},
+		},
+		ConsensusManaByNode: map[identity.ID]ConsensusMana{
+			nodeID: {BaseMana: 20, Timestamp: consensusTimestamp},
+		},
+	}
+
+	var buffer bytes.Buffer
+	written, err := snapshot.WriteTo(&buffer)
+	require.NoError(t, err)
+	assert.EqualValues(t, buffer.Len(), written)
+
+	readSnapshot := &Snapshot{}
+	read, err := readSnapshot.ReadFrom(&buffer)
+	require.NoError(t, err)
+	assert.Equal(t, written, read)
+	assert.Equal(t, snapshot.AccessManaByNode, readSnapshot.AccessManaByNode)
+	assert.Equal(t, snapshot.ConsensusManaByNode, readSnapshot.ConsensusManaByNode)
+}
+
+func TestSnapshot_ReadWithoutConsensusMana(t *testing.T) {
+	snapshot := &Snapshot{
+		Transactions: map[TransactionID]Record{},
+		AccessManaByNode: map[identity.ID]AccessMana{
+			identity.GenerateIdentity().ID(): {Value: 10, Timestamp: time.Unix(time.Now().Unix(), 0)},
+		},
+	}
+
+	var buffer bytes.Buffer
+	_, err := snapshot.WriteTo(&buffer)
+	require.NoError(t, err)
+	// snapshots created before the consensus mana was added end after the access mana
+	legacySnapshot := buffer.Bytes()[:buffer.Len()-4]
+
+	readSnapshot := &Snapshot{}
+	_, err = readSnapshot.ReadFrom(bytes.NewReader(legacySnapshot))
+	require.NoError(t, err)
+	assert.Equal(t, snapshot.AccessManaByNode, readSnapshot.AccessManaByNode)
+	assert.Empty(t, readSnapshot.ConsensusManaByNode)
+}
@@ -4,6 +4,10 @@ import (
 	"math"
 
 	"github.com/cockroachdb/errors"
+	"github.com/iotaledger/hive.go/crypto/bls"
+	"github.com/iotaledger/hive.go/crypto/ed25519"
+
+	"github.com/iotaledger/goshimmer/packages/batchverifier"
 )
 
 // TransactionBalancesValid is an internal utility function that checks if the sum of the balance changes equals to 0.
@@ -67,6 +71,7 @@ func UnlockBlocksValidWithError(inputs Outputs, transaction *Transaction) (bool,
 	if cyclePresent {
 		return false, errors.New("unlock blocks contain cyclic dependency, no signature present for an unlock path")
 	}
+	unlockBlocks = verifyUnlockBlockSignatures(transaction.Essence().Bytes(), unlockBlocks)
 	for i, input := range inputs {
 		currentUnlockBlock := unlockBlocks[i]
 		if currentUnlockBlock.Type() == ReferenceUnlockBlockType {
@@ -82,6 +87,56 @@ func UnlockBlocksValidWithError(inputs Outputs, transaction *Transaction) (bool,
 	return true, nil
 }
 
+// verifyUnlockBlockSignatures verifies the Signatures of all SignatureUnlockBlocks in batches (ED25519 signatures are
+// batch verified and BLS signatures are aggregated) and returns a copy of the UnlockBlocks in which the valid Signatures
+// are marked as verified, so that the subsequent unlock checks of the Outputs only have to check the Addresses.
+func verifyUnlockBlockSignatures(essenceBytes []byte, unlockBlocks UnlockBlocks) (verifiedUnlockBlocks UnlockBlocks) {
+	ed25519Indices := make([]int, 0)
+	ed25519PublicKeys := make([]ed25519.PublicKey, 0)
+	ed25519Signatures := make([]ed25519.Signature, 0)
+	blsIndices := make([]int, 0)
+	blsSignatures := make([]bls.SignatureWithPublicKey, 0)
+	for i, unlockBlock := range unlockBlocks {
+		signatureUnlockBlock, isSignatureUnlockBlock := unlockBlock.(*SignatureUnlockBlock)
+		if !isSignatureUnlockBlock {
+			continue
+		}
+
+		switch signature := signatureUnlockBlock.Signature().(type) {
+		case *ED25519Signature:
+			ed25519Indices = append(ed25519Indices, i)
+			ed25519PublicKeys = append(ed25519PublicKeys, signature.PublicKey)
+			ed25519Signatures = append(ed25519Signatures, signature.Signature)
+		case *BLSSignature:
+			blsIndices = append(blsIndices, i)
+			blsSignatures = append(blsSignatures, signature.Signature)
+		}
+	}
+
+	verifiedUnlockBlocks = make(UnlockBlocks, len(unlockBlocks))
+	copy(verifiedUnlockBlocks, unlockBlocks)
+
+	ed25519Data := make([][]byte, len(ed25519Indices))
+	for i := range ed25519Data {
+		ed25519Data[i] = essenceBytes
+	}
+	_, ed25519Valid := batchverifier.VerifyED25519(ed25519PublicKeys, ed25519Data, ed25519Signatures)
+	for i, unlockBlockIndex := range ed25519Indices {
+		if ed25519Valid[i] {
+			verifiedUnlockBlocks[unlockBlockIndex] = NewSignatureUnlockBlock(newVerifiedSignature(unlockBlocks[unlockBlockIndex].(*SignatureUnlockBlock).Signature(), essenceBytes))
+		}
+	}
+
+	_, blsValid := batchverifier.VerifyBLSAggregated(essenceBytes, blsSignatures)
+	for i, unlockBlockIndex := range blsIndices {
+		if blsValid[i] {
+			verifiedUnlockBlocks[unlockBlockIndex] = NewSignatureUnlockBlock(newVerifiedSignature(unlockBlocks[unlockBlockIndex].(*SignatureUnlockBlock).Signature(), essenceBytes))
+		}
+	}
+
+	return verifiedUnlockBlocks
+}
+
 // SafeAddUint64 adds two uint64 values. It returns the result and a valid flag that indicates whether the addition is
 // valid without causing an overflow.
 func SafeAddUint64(a uint64, b uint64) (result uint64, valid bool) {
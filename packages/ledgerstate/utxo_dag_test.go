@@ -7,6 +7,7 @@ import (
 
 	"github.com/iotaledger/goshimmer/packages/database"
 
+	"github.com/iotaledger/hive.go/crypto/bls"
 	"github.com/iotaledger/hive.go/crypto/ed25519"
 	"github.com/iotaledger/hive.go/identity"
 	"github.com/iotaledger/hive.go/kvstore/mapdb"
@@ -772,6 +773,68 @@ func TestUnlockBlocksValid(t *testing.T) {
 	assert.False(t, UnlockBlocksValid(Outputs{input}, tx))
 }
 
+func TestUnlockBlocksValid_BatchVerification(t *testing.T) {
+	ed25519KeyPairs := make(map[OutputID]ed25519.KeyPair)
+	blsPrivateKeys := make(map[OutputID]bls.PrivateKey)
+	consumedOutputs := make(map[OutputID]Output)
+	for i := 0; i < 9; i++ {
+		var output *SigLockedSingleOutput
+		if i%3 == 0 {
+			privateKey := bls.PrivateKeyFromRandomness()
+			output = NewSigLockedSingleOutput(100, NewBLSAddress(privateKey.PublicKey().Bytes()))
+			output.SetID(NewOutputID(GenesisTransactionID, uint16(i)))
+			blsPrivateKeys[output.ID()] = privateKey
+		} else {
+			keyPair := ed25519.GenerateKeyPair()
+			output = NewSigLockedSingleOutput(100, NewED25519Address(keyPair.PublicKey))
+			output.SetID(NewOutputID(GenesisTransactionID, uint16(i)))
+			ed25519KeyPairs[output.ID()] = keyPair
+		}
+		consumedOutputs[output.ID()] = output
+	}
+
+	inputs := make([]Input, 0, len(consumedOutputs))
+	for outputID := range consumedOutputs {
+		inputs = append(inputs, NewUTXOInput(outputID))
+	}
+	essence := NewTransactionEssence(0, time.Now(), identity.ID{}, identity.ID{}, NewInputs(inputs...), NewOutputs(
+		NewSigLockedSingleOutput(900, createWallets(1)[0].address),
+	))
+
+	// sign the inputs in the (sorted) order of the essence
+	signUnlockBlocks := func(invalidInputIndex int) (unlockBlocks UnlockBlocks, orderedOutputs Outputs) {
+		for i, input := range essence.Inputs() {
+			outputID := input.(*UTXOInput).ReferencedOutputID()
+			orderedOutputs = append(orderedOutputs, consumedOutputs[outputID])
+
+			signedData := essence.Bytes()
+			if i == invalidInputIndex {
+				signedData = []byte("invalid")
+			}
+
+			if keyPair, isED25519 := ed25519KeyPairs[outputID]; isED25519 {
+				unlockBlocks = append(unlockBlocks, NewSignatureUnlockBlock(NewED25519Signature(keyPair.PublicKey, keyPair.PrivateKey.Sign(signedData))))
+				continue
+			}
+
+			signature, err := blsPrivateKeys[outputID].Sign(signedData)
+			require.NoError(t, err)
+			unlockBlocks = append(unlockBlocks, NewSignatureUnlockBlock(NewBLSSignature(signature)))
+		}
+
+		return unlockBlocks, orderedOutputs
+	}
+
+	unlockBlocks, orderedOutputs := signUnlockBlocks(-1)
+	assert.True(t, UnlockBlocksValid(orderedOutputs, NewTransaction(essence, unlockBlocks)))
+
+	// every single invalid signature needs to be detected
+	for invalidInputIndex := range essence.Inputs() {
+		unlockBlocks, orderedOutputs = signUnlockBlocks(invalidInputIndex)
+		assert.False(t, UnlockBlocksValid(orderedOutputs, NewTransaction(essence, unlockBlocks)), "invalid input %d", invalidInputIndex)
+	}
+}
+
 func TestAddressOutputMapping(t *testing.T) {
 	branchDAG, utxoDAG := setupDependencies(t)
 	defer branchDAG.Shutdown()
@@ -1,7 +1,6 @@
 package mana
 
 import (
-	"math"
 	"time"
 )
 
@@ -12,19 +11,28 @@ type AccessBaseMana struct {
 	LastUpdated        time.Time
 }
 
-func (a *AccessBaseMana) update(t time.Time) error {
+func (a *AccessBaseMana) update(t time.Time, model ManaModel) error {
 	if t.Before(a.LastUpdated) || t == a.LastUpdated {
 		// trying to do a time wise update to the past, that is not allowed
 		return ErrAlreadyUpdated
 	}
-	n := t.Sub(a.LastUpdated)
-	a.updateBM2(n)
-	a.updateEBM2(n)
+	a.updateMana(t.Sub(a.LastUpdated), model)
 	a.LastUpdated = t
 	return nil
 }
 
-func (a *AccessBaseMana) updateBM2(n time.Duration) {
+// updateMana updates BM2 and EBM2 by the duration `n` according to the given model.
+func (a *AccessBaseMana) updateMana(n time.Duration, model ManaModel) {
+	baseMana := a.BaseMana2
+	a.updateBM2(n, model)
+	// base mana that stopped updating doesn't contribute to the effective base mana anymore
+	if a.BaseMana2 == 0 {
+		baseMana = 0
+	}
+	a.updateEBM2(baseMana, n, model)
+}
+
+func (a *AccessBaseMana) updateBM2(n time.Duration, model ManaModel) {
 	// zero value doesn't need to be updated
 	if a.BaseMana2 == 0 {
 		return
@@ -34,67 +42,52 @@ func (a *AccessBaseMana) updateBM2(n time.Duration) {
 		a.BaseMana2 = 0
 		return
 	}
-	a.BaseMana2 *= math.Pow(math.E, -Decay*n.Seconds())
+	a.BaseMana2 = model.DecayBaseMana(a.BaseMana2, n)
 }
 
-func (a *AccessBaseMana) updateEBM2(n time.Duration) {
+// updateEBM2 updates EBM2, given the value of BM2 at the beginning of the duration `n`.
+func (a *AccessBaseMana) updateEBM2(baseMana float64, n time.Duration, model ManaModel) {
 	// zero value doesn't need to be updated
-	if a.BaseMana2 == 0 && a.EffectiveBaseMana2 == 0 {
+	if baseMana == 0 && a.EffectiveBaseMana2 == 0 {
 		return
 	}
 	// close to zero value is considered zero, stop future updates
-	if a.BaseMana2 == 0 && a.EffectiveBaseMana2 < DeltaStopUpdate {
+	if baseMana == 0 && a.EffectiveBaseMana2 < DeltaStopUpdate {
 		a.EffectiveBaseMana2 = 0
 		return
 	}
-
-	if emaCoeff2 != Decay {
-		a.EffectiveBaseMana2 = math.Pow(math.E, -emaCoeff2*n.Seconds())*a.EffectiveBaseMana2 +
-			(math.Pow(math.E, -Decay*n.Seconds())-math.Pow(math.E, -emaCoeff2*n.Seconds()))/
-				(emaCoeff2-Decay)*emaCoeff2/math.Pow(math.E, -Decay*n.Seconds())*a.BaseMana2
-	} else {
-		a.EffectiveBaseMana2 = math.Pow(math.E, -Decay*n.Seconds())*a.EffectiveBaseMana2 +
-			Decay*n.Seconds()*a.BaseMana2
-	}
+	a.EffectiveBaseMana2 = model.UpdateEffectiveBaseMana(a.EffectiveBaseMana2, baseMana, n)
 }
 
-func (a *AccessBaseMana) revoke(float64) error {
+func (a *AccessBaseMana) revoke(float64, time.Time, ManaModel) error {
 	panic("access mana cannot be revoked")
 }
 
-func (a *AccessBaseMana) pledge(tx *TxInfo) (pledged float64) {
+func (a *AccessBaseMana) pledge(tx *TxInfo, model ManaModel) (pledged float64) {
 	t := tx.TimeStamp
 
 	if t.After(a.LastUpdated) {
 		// regular update
-		n := t.Sub(a.LastUpdated)
 		// first, update BM2 and EBM2 until `t`
-		a.updateBM2(n)
-		a.updateEBM2(n)
+		a.updateMana(t.Sub(a.LastUpdated), model)
 		a.LastUpdated = t
 		// pending mana awarded, need to see how long funds sat
 		for _, input := range tx.InputInfos {
-			bm2Add := input.Amount * (1 - math.Pow(math.E, -Decay*(t.Sub(input.TimeStamp).Seconds())))
+			bm2Add := model.PendingMana(input.Amount, t.Sub(input.TimeStamp))
 			a.BaseMana2 += bm2Add
 			pledged += bm2Add
 		}
 	} else {
 		// past update
 		n := a.LastUpdated.Sub(t)
-		// update  BM2 at `t`
-		oldMana2 := a.BaseMana2
 		for _, input := range tx.InputInfos {
-			bm2Add := input.Amount * (1 - math.Pow(math.E, -Decay*(t.Sub(input.TimeStamp).Seconds()))) *
-				math.Pow(math.E, -Decay*n.Seconds())
+			// update BM2 at `t` and let it decay until `bm.LastUpdated`
+			pendingMana := model.PendingMana(input.Amount, t.Sub(input.TimeStamp))
+			bm2Add := model.DecayBaseMana(pendingMana, n)
 			a.BaseMana2 += bm2Add
 			pledged += bm2Add
-		}
-		// update EBM2 to `bm.LastUpdated`
-		if emaCoeff2 != Decay {
-			a.EffectiveBaseMana2 += (a.BaseMana2 - oldMana2) * emaCoeff2 * (math.Pow(math.E, -Decay*n.Seconds()) -
-				math.Pow(math.E, -emaCoeff2*n.Seconds())) / (emaCoeff2 - Decay) / math.Pow(math.E, -Decay*n.Seconds())
-		} else {
-			a.EffectiveBaseMana2 += (a.BaseMana2 - oldMana2) * Decay * n.Seconds()
+			// update EBM2 to `bm.LastUpdated`
+			a.EffectiveBaseMana2 += model.UpdateEffectiveBaseMana(0, pendingMana, n)
 		}
 	}
 	return
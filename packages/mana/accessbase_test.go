@@ -16,7 +16,7 @@ func TestUpdateBM2(t *testing.T) {
 		bm := AccessBaseMana{}
 
 		// 0 initial values, timely update should not change anything
-		bm.updateBM2(time.Hour)
+		bm.updateBM2(time.Hour, DefaultManaModel(AccessMana))
 		assert.Equal(t, 0.0, bm.BaseMana2)
 	})
 
@@ -25,7 +25,7 @@ func TestUpdateBM2(t *testing.T) {
 
 		// pledge BM2 at t = o
 		bm.BaseMana2 = 1.0
-		bm.updateBM2(time.Hour * 6)
+		bm.updateBM2(time.Hour*6, DefaultManaModel(AccessMana))
 		assert.InDelta(t, 0.5, bm.BaseMana2, delta)
 	})
 
@@ -36,7 +36,7 @@ func TestUpdateBM2(t *testing.T) {
 		bm.BaseMana2 = 1.0
 		// with emaCoeff1 = 0.00003209, half value should be reached within 6 hours
 		for i := 0; i < 6; i++ {
-			bm.updateBM2(time.Hour)
+			bm.updateBM2(time.Hour, DefaultManaModel(AccessMana))
 		}
 		assert.InDelta(t, 0.5, bm.BaseMana2, delta)
 	})
@@ -47,7 +47,7 @@ func TestUpdateEBM2CoeffEqual(t *testing.T) {
 		bm := AccessBaseMana{}
 
 		// 0 initial values, timely update should not change anything
-		bm.updateEBM2(time.Hour)
+		bm.updateMana(time.Hour, DefaultManaModel(AccessMana))
 		assert.Equal(t, 0.0, bm.EffectiveBaseMana2)
 	})
 
@@ -57,9 +57,7 @@ func TestUpdateEBM2CoeffEqual(t *testing.T) {
 		// first, let's calculate once on a 6 hour span
 		// pledge BM2 at t = o
 		bmBatch.BaseMana2 = 1.0
-		// updateEBM2 relies on an update baseMana2 value
-		bmBatch.updateBM2(time.Hour * 6)
-		bmBatch.updateEBM2(time.Hour * 6)
+		bmBatch.updateMana(time.Hour*6, DefaultManaModel(AccessMana))
 
 		bmInc := AccessBaseMana{}
 		// second, let's calculate the same but every hour
@@ -67,9 +65,7 @@ func TestUpdateEBM2CoeffEqual(t *testing.T) {
 		bmInc.BaseMana2 = 1.0
 		// with emaCoeff1 = 0.00003209, half value should be reached within 6 hours
 		for i := 0; i < 6; i++ {
-			// updateEBM2 relies on an update baseMana2 value
-			bmInc.updateBM2(time.Hour)
-			bmInc.updateEBM2(time.Hour)
+			bmInc.updateMana(time.Hour, DefaultManaModel(AccessMana))
 		}
 
 		// compare results of the two calculations
@@ -85,7 +81,7 @@ func TestUpdateTimeInPast_Access(t *testing.T) {
 		LastUpdated:        baseTime,
 	}
 	pastTime := baseTime.Add(time.Hour * -1)
-	err := bm.update(pastTime)
+	err := bm.update(pastTime, DefaultManaModel(AccessMana))
 	assert.Error(t, err)
 	assert.Equal(t, ErrAlreadyUpdated, err)
 }
@@ -99,7 +95,7 @@ func TestUpdate_Access(t *testing.T) {
 	}
 	updateTime := baseTime.Add(time.Hour * 6)
 
-	err := bm.update(updateTime)
+	err := bm.update(updateTime, DefaultManaModel(AccessMana))
 	assert.NoError(t, err)
 	// values are only valid for default coefficients of 0.00003209 and t = 6 hours
 	assert.InDelta(t, 0.5, bm.BaseMana2, delta)
@@ -115,7 +111,7 @@ func TestRevoke_Access(t *testing.T) {
 		LastUpdated:        baseTime,
 	}
 	assert.Panics(t, func() {
-		_ = bm.revoke(1.0)
+		_ = bm.revoke(1.0, baseTime, DefaultManaModel(AccessMana))
 	})
 }
 
@@ -154,7 +150,7 @@ func TestPledgeRegularOldFunds_Access(t *testing.T) {
 		},
 	}
 
-	bm2Pledged := bm.pledge(txInfo)
+	bm2Pledged := bm.pledge(txInfo, DefaultManaModel(AccessMana))
 
 	assert.InDelta(t, 10.0, bm2Pledged, delta)
 	// half of the original BM2 degraded away in 6 hours
@@ -196,7 +192,7 @@ func TestPledgeRegularHalfOldFunds_Access(t *testing.T) {
 		},
 	}
 
-	bm2Pledged := bm.pledge(txInfo)
+	bm2Pledged := bm.pledge(txInfo, DefaultManaModel(AccessMana))
 
 	assert.InDelta(t, 5.0, bm2Pledged, delta)
 	// half of the original BM2 degraded away in 6 hours
@@ -238,7 +234,7 @@ func TestPledgePastOldFunds_Access(t *testing.T) {
 		},
 	}
 
-	bm2Pledged := bm.pledge(txInfo)
+	bm2Pledged := bm.pledge(txInfo, DefaultManaModel(AccessMana))
 
 	// pledged at t=0, half of input amount is added to bm2
 	assert.InDelta(t, 5.0, bm2Pledged, delta)
@@ -14,6 +14,7 @@ import (
 // AccessBaseManaVector represents a base mana vector.
 type AccessBaseManaVector struct {
 	vector map[identity.ID]*AccessBaseMana
+	model  ManaModel
 	sync.RWMutex
 }
 
@@ -75,7 +76,7 @@ func (a *AccessBaseManaVector) Book(txInfo *TxInfo) {
 		// save it for proper event trigger
 		oldMana := *a.vector[pledgeNodeID]
 		// actually pledge and update
-		pledged := a.vector[pledgeNodeID].pledge(txInfo)
+		pledged := a.vector[pledgeNodeID].pledge(txInfo, a.model)
 		pledgeEvent = &PledgedEvent{
 			NodeID:        pledgeNodeID,
 			Amount:        pledged,
@@ -297,6 +298,11 @@ func (a *AccessBaseManaVector) RemoveZeroNodes() {
 	}
 }
 
+// Model returns the model that is used to calculate the mana values of the vector.
+func (a *AccessBaseManaVector) Model() ManaModel {
+	return a.model
+}
+
 var _ BaseManaVector = &AccessBaseManaVector{}
 
 //// Region Internal methods ////
@@ -307,7 +313,7 @@ func (a *AccessBaseManaVector) update(nodeID identity.ID, t time.Time) error {
 		return ErrNodeNotFoundInBaseManaVector
 	}
 	oldMana := *a.vector[nodeID]
-	if err := a.vector[nodeID].update(t); err != nil {
+	if err := a.vector[nodeID].update(t, a.model); err != nil {
 		return err
 	}
 	Events().Updated.Trigger(&UpdatedEvent{nodeID, &oldMana, a.vector[nodeID], a.Type()})
@@ -6,9 +6,9 @@ import (
 
 // BaseMana is an interface for a collection of base mana values of a single node.
 type BaseMana interface {
-	update(time.Time) error
-	revoke(float64) error
-	pledge(*TxInfo) float64
+	update(time.Time, ManaModel) error
+	revoke(float64, time.Time, ManaModel) error
+	pledge(*TxInfo, ManaModel) float64
 	BaseValue() float64
 	EffectiveValue() float64
 	LastUpdate() time.Time
@@ -41,18 +41,27 @@ type BaseManaVector interface {
 	FromPersistable(*PersistableBaseMana) error
 	// RemoveZeroNodes removes all zero mana nodes from the mana vector.
 	RemoveZeroNodes()
+	// Model returns the model that is used to calculate the mana values of the vector.
+	Model() ManaModel
 }
 
 // NewBaseManaVector creates and returns a new base mana vector for the specified type.
-func NewBaseManaVector(vectorType Type) (BaseManaVector, error) {
+// It uses the DefaultManaModel of the type, unless a model is specified.
+func NewBaseManaVector(vectorType Type, optionalManaModel ...ManaModel) (BaseManaVector, error) {
+	model := DefaultManaModel(vectorType)
+	if len(optionalManaModel) > 0 {
+		model = optionalManaModel[0]
+	}
 	switch vectorType {
 	case AccessMana:
 		return &AccessBaseManaVector{
 			vector: make(map[identity.ID]*AccessBaseMana),
+			model:  model,
 		}, nil
 	case ConsensusMana:
 		return &ConsensusBaseManaVector{
 			vector: make(map[identity.ID]*ConsensusBaseMana),
+			model:  model,
 		}, nil
 	default:
 		return nil, errors.Errorf("error while creating base mana vector with type %d: %w", vectorType, ErrUnknownManaType)
@@ -6,27 +6,48 @@ import (
 
 // ConsensusBaseMana holds information about the consensus base mana values of a single node.
 type ConsensusBaseMana struct {
-	BaseMana1 float64
+	BaseMana1   float64
+	LastUpdated time.Time
 }
 
-func (c *ConsensusBaseMana) update(now time.Time) error {
-	panic("not implemented")
+func (c *ConsensusBaseMana) update(t time.Time, model ManaModel) error {
+	if t.Before(c.LastUpdated) || t == c.LastUpdated {
+		// trying to do a time wise update to the past, that is not allowed
+		return ErrAlreadyUpdated
+	}
+	// base mana without a timestamp has never been updated, so there is nothing to decay yet
+	if !c.LastUpdated.IsZero() {
+		c.BaseMana1 = model.DecayBaseMana(c.BaseMana1, t.Sub(c.LastUpdated))
+	}
+	c.LastUpdated = t
+	return nil
 }
 
-func (c *ConsensusBaseMana) revoke(amount float64) error {
+func (c *ConsensusBaseMana) revoke(amount float64, t time.Time, model ManaModel) error {
 	//if c.BaseMana1-amount < 0.0 {
 	//	return ErrBaseManaNegative
 	//}
-	c.BaseMana1 -= amount
+	c.BaseMana1 -= c.decayedAmount(amount, t, model)
 	return nil
 }
 
-func (c *ConsensusBaseMana) pledge(tx *TxInfo) (pledged float64) {
-	pledged = tx.sumInputs()
+func (c *ConsensusBaseMana) pledge(tx *TxInfo, model ManaModel) (pledged float64) {
+	pledged = c.decayedAmount(tx.sumInputs(), tx.TimeStamp, model)
 	c.BaseMana1 += pledged
 	return pledged
 }
 
+// decayedAmount updates the base mana to `t` and returns how much of the `amount` booked at `t` is left at the time
+// the base mana was last updated.
+func (c *ConsensusBaseMana) decayedAmount(amount float64, t time.Time, model ManaModel) float64 {
+	if t.Before(c.LastUpdated) {
+		// past update
+		return model.DecayBaseMana(amount, c.LastUpdated.Sub(t))
+	}
+	_ = c.update(t, model)
+	return amount
+}
+
 // BaseValue returns the base mana value (BM1).
 func (c *ConsensusBaseMana) BaseValue() float64 {
 	return c.BaseMana1
@@ -39,7 +60,7 @@ func (c *ConsensusBaseMana) EffectiveValue() float64 {
 
 // LastUpdate returns the last update time.
 func (c *ConsensusBaseMana) LastUpdate() time.Time {
-	panic("not implemented")
+	return c.LastUpdated
 }
 
 var _ BaseMana = &ConsensusBaseMana{}
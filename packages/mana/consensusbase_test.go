@@ -12,7 +12,7 @@ func TestRevoke_Consensus(t *testing.T) {
 	bm := &ConsensusBaseMana{
 		BaseMana1: 1.0,
 	}
-	err := bm.revoke(1.0)
+	err := bm.revoke(1.0, time.Now(), DefaultManaModel(ConsensusMana))
 	assert.NoError(t, err)
 	// values are only valid for default coefficients of 0.00003209 and t = 6 hours
 	assert.Equal(t, 0.0, bm.BaseMana1)
@@ -22,7 +22,7 @@ func TestRevokeNegativeBalance_Consensus(t *testing.T) {
 	bm := &ConsensusBaseMana{
 		BaseMana1: 0.0,
 	}
-	err := bm.revoke(1.0)
+	err := bm.revoke(1.0, time.Now(), DefaultManaModel(ConsensusMana))
 	assert.NoError(t, err)
 	assert.EqualValues(t, -1, bm.BaseMana1)
 }
@@ -60,7 +60,7 @@ func TestPledgeAndUpdateRegularOldFunds_Consensus(t *testing.T) {
 		},
 	}
 
-	pledged := bm.pledge(_txInfo)
+	pledged := bm.pledge(_txInfo, DefaultManaModel(ConsensusMana))
 
 	assert.Equal(t, 10.0, pledged)
 	assert.Equal(t, 11.0, bm.BaseMana1)
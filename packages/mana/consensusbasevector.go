@@ -9,11 +9,14 @@ import (
 
 	"github.com/cockroachdb/errors"
 	"github.com/iotaledger/hive.go/identity"
+
+	"github.com/iotaledger/goshimmer/packages/ledgerstate"
 )
 
 // ConsensusBaseManaVector represents a base mana vector.
 type ConsensusBaseManaVector struct {
 	vector map[identity.ID]*ConsensusBaseMana
+	model  ManaModel
 	sync.RWMutex
 }
 
@@ -37,39 +40,42 @@ func (c *ConsensusBaseManaVector) Has(nodeID identity.ID) bool {
 	return exists
 }
 
-//// BuildPastBaseVector builds a consensus base mana vector from past events upto time `t`.
-//// `eventLogs` is expected to be sorted chronologically.
-//func (c *ConsensusBaseManaVector) BuildPastBaseVector(eventsLog []Event, t time.Time) error {
-//	if c.vector == nil {
-//		c.vector = make(map[identity.ID]*ConsensusBaseMana)
-//	}
-//	for _, _ev := range eventsLog {
-//		switch _ev.Type() {
-//		case EventTypePledge:
-//			ev := _ev.(*PledgedEvent)
-//			if ev.Time.After(t) {
-//				return nil
-//			}
-//			if _, exist := c.vector[ev.NodeID]; !exist {
-//				c.vector[ev.NodeID] = &ConsensusBaseMana{}
-//			}
-//			c.vector[ev.NodeID].pledge(txInfoFromPledgeEvent(ev))
-//		case EventTypeRevoke:
-//			ev := _ev.(*RevokedEvent)
-//			if ev.Time.After(t) {
-//				return nil
-//			}
-//			if _, exist := c.vector[ev.NodeID]; !exist {
-//				c.vector[ev.NodeID] = &ConsensusBaseMana{}
-//			}
-//			err := c.vector[ev.NodeID].revoke(ev.Amount, ev.Time)
-//			if err != nil {
-//				return err
-//			}
-//		}
-//	}
-//	return nil
-//}
+// BuildPastBaseVector builds a consensus base mana vector from past events upto time `t`.
+// `eventLogs` is expected to be sorted chronologically.
+func (c *ConsensusBaseManaVector) BuildPastBaseVector(eventsLog []Event, t time.Time) error {
+	c.Lock()
+	defer c.Unlock()
+	if c.vector == nil {
+		c.vector = make(map[identity.ID]*ConsensusBaseMana)
+	}
+	for _, _ev := range eventsLog {
+		switch _ev.Type() {
+		case EventTypePledge:
+			ev := _ev.(*PledgedEvent)
+			if ev.Time.After(t) {
+				return nil
+			}
+			if _, exist := c.vector[ev.NodeID]; !exist {
+				c.vector[ev.NodeID] = &ConsensusBaseMana{}
+			}
+			c.vector[ev.NodeID].pledge(txInfoFromPledgeEvent(ev), c.model)
+		case EventTypeRevoke:
+			ev := _ev.(*RevokedEvent)
+			if ev.Time.After(t) {
+				return nil
+			}
+			if _, exist := c.vector[ev.NodeID]; !exist {
+				c.vector[ev.NodeID] = &ConsensusBaseMana{}
+			}
+			if err := c.vector[ev.NodeID].revoke(ev.Amount, ev.Time, c.model); err != nil {
+				return errors.Errorf("failed to revoke %f mana from node %s: %w", ev.Amount, ev.NodeID.String(), err)
+			}
+		default:
+			return errors.Errorf("failed to build past consensus base mana vector: %w", ErrUnknownManaEvent)
+		}
+	}
+	return nil
+}
 
 func txInfoFromPledgeEvent(ev *PledgedEvent) *TxInfo {
 	return &TxInfo{
@@ -97,6 +103,23 @@ func (c *ConsensusBaseManaVector) LoadSnapshot(snapshot map[identity.ID]Snapshot
 	defer c.Unlock()
 
 	for nodeID, records := range snapshot {
+		if records.ConsensusMana != nil {
+			c.vector[nodeID] = &ConsensusBaseMana{
+				BaseMana1:   records.ConsensusMana.BaseMana,
+				LastUpdated: records.ConsensusMana.Timestamp,
+			}
+
+			// trigger event, the snapshotted mana is not bound to a transaction
+			Events().Pledged.Trigger(&PledgedEvent{
+				NodeID:        nodeID,
+				Amount:        records.ConsensusMana.BaseMana,
+				Time:          records.ConsensusMana.Timestamp,
+				ManaType:      c.Type(),
+				TransactionID: ledgerstate.GenesisTransactionID,
+			})
+			continue
+		}
+
 		var value float64
 		for _, record := range records.SortedTxSnapshot {
 			value += record.Value
@@ -138,7 +161,7 @@ func (c *ConsensusBaseManaVector) Book(txInfo *TxInfo) {
 			// save old mana
 			oldMana := *c.vector[pledgeNodeID]
 			// revoke BM1
-			err := c.vector[pledgeNodeID].revoke(inputInfo.Amount)
+			err := c.vector[pledgeNodeID].revoke(inputInfo.Amount, txInfo.TimeStamp, c.model)
 			if errors.Is(err, ErrBaseManaNegative) {
 				panic(fmt.Sprintf("Revoking %f base mana 1 from node %s results in negative balance", inputInfo.Amount, pledgeNodeID.String()))
 			}
@@ -155,7 +178,7 @@ func (c *ConsensusBaseManaVector) Book(txInfo *TxInfo) {
 		// save it for proper event trigger
 		oldMana := *c.vector[pledgeNodeID]
 		// actually pledge and update
-		pledged := c.vector[pledgeNodeID].pledge(txInfo)
+		pledged := c.vector[pledgeNodeID].pledge(txInfo, c.model)
 		pledgeEvents = append(pledgeEvents, &PledgedEvent{
 			NodeID:        pledgeNodeID,
 			Amount:        pledged,
@@ -197,8 +220,12 @@ func (c *ConsensusBaseManaVector) UpdateAll(t time.Time) error {
 func (c *ConsensusBaseManaVector) GetMana(nodeID identity.ID, optionalUpdateTime ...time.Time) (float64, time.Time, error) {
 	c.Lock()
 	defer c.Unlock()
-	mana, err := c.getMana(nodeID)
-	return mana, time.Now(), err
+	t := time.Now()
+	if len(optionalUpdateTime) > 0 {
+		t = optionalUpdateTime[0]
+	}
+	mana, err := c.getMana(nodeID, t)
+	return mana, t, err
 }
 
 // GetManaMap returns mana perception of the node.
@@ -206,10 +233,13 @@ func (c *ConsensusBaseManaVector) GetManaMap(optionalUpdateTime ...time.Time) (r
 	c.Lock()
 	defer c.Unlock()
 	t = time.Now()
+	if len(optionalUpdateTime) > 0 {
+		t = optionalUpdateTime[0]
+	}
 	res = make(map[identity.ID]float64)
 	for ID := range c.vector {
 		var mana float64
-		mana, err = c.getMana(ID)
+		mana, err = c.getMana(ID, t)
 		if err != nil {
 			return nil, t, err
 		}
@@ -229,7 +259,7 @@ func (c *ConsensusBaseManaVector) GetHighestManaNodes(n uint) (res []Node, t tim
 		defer c.Unlock()
 		for ID := range c.vector {
 			var mana float64
-			mana, err = c.getMana(ID)
+			mana, err = c.getMana(ID, t)
 			if err != nil {
 				return err
 			}
@@ -273,7 +303,7 @@ func (c *ConsensusBaseManaVector) GetHighestManaNodesFraction(p float64) (res []
 			}
 
 			var mana float64
-			mana, err = c.getMana(ID)
+			mana, err = c.getMana(ID, t)
 			if err != nil {
 				return err
 			}
@@ -334,9 +364,10 @@ func (c *ConsensusBaseManaVector) ToPersistables() []*PersistableBaseMana {
 	var result []*PersistableBaseMana
 	for nodeID, bm := range c.vector {
 		pbm := &PersistableBaseMana{
-			ManaType:   c.Type(),
-			BaseValues: []float64{bm.BaseValue()},
-			NodeID:     nodeID,
+			ManaType:    c.Type(),
+			BaseValues:  []float64{bm.BaseValue()},
+			LastUpdated: bm.LastUpdated,
+			NodeID:      nodeID,
 		}
 		result = append(result, pbm)
 	}
@@ -356,7 +387,8 @@ func (c *ConsensusBaseManaVector) FromPersistable(p *PersistableBaseMana) (err e
 	c.Lock()
 	defer c.Unlock()
 	c.vector[p.NodeID] = &ConsensusBaseMana{
-		BaseMana1: p.BaseValues[0],
+		BaseMana1:   p.BaseValues[0],
+		LastUpdated: p.LastUpdated,
 	}
 	return
 }
@@ -372,16 +404,24 @@ func (c *ConsensusBaseManaVector) RemoveZeroNodes() {
 	}
 }
 
+// Model returns the model that is used to calculate the mana values of the vector.
+func (c *ConsensusBaseManaVector) Model() ManaModel {
+	return c.model
+}
+
 var _ BaseManaVector = &ConsensusBaseManaVector{}
 
 //// Region Internal methods ////
 
-// getMana returns the consensus mana.
-func (c *ConsensusBaseManaVector) getMana(nodeID identity.ID) (float64, error) {
+// getMana returns the consensus mana at time `t`.
+func (c *ConsensusBaseManaVector) getMana(nodeID identity.ID, t time.Time) (float64, error) {
 	if _, exist := c.vector[nodeID]; !exist {
 		return 0.0, ErrNodeNotFoundInBaseManaVector
 	}
 
 	baseMana := c.vector[nodeID]
-	return baseMana.BaseValue(), nil
+	if baseMana.LastUpdated.IsZero() || !t.After(baseMana.LastUpdated) {
+		return baseMana.BaseValue(), nil
+	}
+	return c.model.DecayBaseMana(baseMana.BaseValue(), t.Sub(baseMana.LastUpdated)), nil
 }
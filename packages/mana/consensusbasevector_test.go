@@ -49,6 +49,30 @@ func TestConsensusBaseManaVector_Has(t *testing.T) {
 	assert.True(t, has)
 }
 
+func TestConsensusBaseManaVector_LoadSnapshot(t *testing.T) {
+	bmv, err := NewBaseManaVector(ConsensusMana)
+	assert.NoError(t, err)
+
+	snapshotTime := time.Now()
+	txNodeID, vectorNodeID := randNodeID(), randNodeID()
+	bmv.LoadSnapshot(map[identity.ID]SnapshotNode{
+		txNodeID: {
+			SortedTxSnapshot: SortedTxSnapshot{
+				{Value: 10, Timestamp: snapshotTime},
+				{Value: 5, Timestamp: snapshotTime},
+			},
+		},
+		vectorNodeID: {
+			// the consensus mana takes precedence over the transactions
+			SortedTxSnapshot: SortedTxSnapshot{{Value: 10, Timestamp: snapshotTime}},
+			ConsensusMana:    &ConsensusManaSnapshot{BaseMana: 7, Timestamp: snapshotTime},
+		},
+	})
+
+	assert.Equal(t, &ConsensusBaseMana{BaseMana1: 15}, bmv.(*ConsensusBaseManaVector).vector[txNodeID])
+	assert.Equal(t, &ConsensusBaseMana{BaseMana1: 7, LastUpdated: snapshotTime}, bmv.(*ConsensusBaseManaVector).vector[vectorNodeID])
+}
+
 func TestConsensusBaseManaVector_Book(t *testing.T) {
 	// hold information about which events triggered
 	var (
@@ -440,3 +464,35 @@ func TestConsensusBaseManaVector_ToAndFromPersistable(t *testing.T) {
 	}
 	assert.Equal(t, bmv.(*ConsensusBaseManaVector).vector, restoredBmv.(*ConsensusBaseManaVector).vector)
 }
+
+func TestConsensusBaseManaVector_BuildPastBaseVector(t *testing.T) {
+	bmv, err := NewBaseManaVector(ConsensusMana)
+	assert.NoError(t, err)
+	cbmv := bmv.(*ConsensusBaseManaVector)
+
+	baseTime := time.Now()
+	nodeA, nodeB := randNodeID(), randNodeID()
+	eventsLog := EventSlice{
+		&PledgedEvent{NodeID: nodeA, Amount: 10, Time: baseTime, ManaType: ConsensusMana},
+		&RevokedEvent{NodeID: nodeA, Amount: 4, Time: baseTime.Add(time.Minute), ManaType: ConsensusMana},
+		&PledgedEvent{NodeID: nodeB, Amount: 4, Time: baseTime.Add(time.Minute), ManaType: ConsensusMana},
+		&PledgedEvent{NodeID: nodeB, Amount: 100, Time: baseTime.Add(time.Hour), ManaType: ConsensusMana},
+	}
+	eventsLog.Sort()
+
+	assert.NoError(t, cbmv.BuildPastBaseVector(eventsLog, baseTime.Add(2*time.Minute)))
+	manaMap, _, err := cbmv.GetManaMap()
+	assert.NoError(t, err)
+	assert.Equal(t, NodeMap{nodeA: 6, nodeB: 4}, manaMap)
+
+	// a vector built from a checkpoint continues where the checkpoint left off
+	checkpoint, err := NewBaseManaVector(ConsensusMana)
+	assert.NoError(t, err)
+	for _, p := range cbmv.ToPersistables() {
+		assert.NoError(t, checkpoint.FromPersistable(p))
+	}
+	assert.NoError(t, checkpoint.(*ConsensusBaseManaVector).BuildPastBaseVector(eventsLog[3:], baseTime.Add(time.Hour)))
+	manaMap, _, err = checkpoint.GetManaMap()
+	assert.NoError(t, err)
+	assert.Equal(t, NodeMap{nodeA: 6, nodeB: 104}, manaMap)
+}
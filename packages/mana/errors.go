@@ -19,4 +19,20 @@ var (
 	ErrInvalidTargetManaType = errors.New("invalid target mana type")
 	// ErrUnknownManaEvent is returned if mana event type could not be identified.
 	ErrUnknownManaEvent = errors.New("unknown mana event")
+	// ErrUnknownManaModel is returned if the mana model could not be identified.
+	ErrUnknownManaModel = errors.New("unknown mana model")
+	// ErrInvalidManaModelParameter is returned if a mana model is created with an invalid parameter.
+	ErrInvalidManaModelParameter = errors.New("invalid mana model parameter")
+	// ErrUnknownPledgeMode is returned if the pledge mode of a pledge policy could not be identified.
+	ErrUnknownPledgeMode = errors.New("unknown pledge mode")
+	// ErrInvalidPledgePolicyParameter is returned if a pledge policy is created with an invalid parameter.
+	ErrInvalidPledgePolicyParameter = errors.New("invalid pledge policy parameter")
+	// ErrPledgeNodeNotAllowed is returned if the pledge policy does not accept the node as a pledge target.
+	ErrPledgeNodeNotAllowed = errors.New("node is not accepted as pledge target")
+	// ErrPledgeNodeBelowMinMana is returned if the node does not have the mana required to receive a pledge.
+	ErrPledgeNodeBelowMinMana = errors.New("node has less than the minimum mana to receive a pledge")
+	// ErrPledgeMaxShareExceeded is returned if the node owns more than the allowed share of the total mana.
+	ErrPledgeMaxShareExceeded = errors.New("node exceeds the maximum share of total mana")
+	// ErrPledgeRateLimitExceeded is returned if the pledger exceeded its pledge rate limit.
+	ErrPledgeRateLimitExceeded = errors.New("pledge rate limit exceeded")
 )
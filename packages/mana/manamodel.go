@@ -0,0 +1,213 @@
+package mana
+
+import (
+	"math"
+	"time"
+
+	"github.com/cockroachdb/errors"
+)
+
+const (
+	// EMAModelName is the name of the exponential moving average model.
+	EMAModelName = "ema"
+	// LinearDecayModelName is the name of the linear decay model.
+	LinearDecayModelName = "linear"
+	// NoDecayModelName is the name of the model without any decay.
+	NoDecayModelName = "none"
+)
+
+// region ManaModel ////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// ManaModel defines how the base mana of a node decays and how its effective base mana follows the base mana over time.
+// Every base mana vector uses its own model.
+type ManaModel interface {
+	// Name returns the name of the model.
+	Name() string
+	// PendingMana returns the base mana that `amount` tokens generate while they stay unspent for the duration `n`.
+	PendingMana(amount float64, n time.Duration) float64
+	// DecayBaseMana returns the base mana `baseMana` after it decayed for the duration `n`.
+	DecayBaseMana(baseMana float64, n time.Duration) float64
+	// UpdateEffectiveBaseMana returns the effective base mana after the duration `n`, given the effective base mana and
+	// the base mana at the beginning of the duration.
+	UpdateEffectiveBaseMana(effectiveBaseMana float64, baseMana float64, n time.Duration) float64
+}
+
+// ModelParameters contains the parameters of the built-in mana models.
+type ModelParameters struct {
+	// EMACoefficient is the coefficient of the moving average of the effective base mana, in 1/sec.
+	EMACoefficient float64
+	// Decay is the exponential decay (gamma) of the base mana, in 1/sec.
+	Decay float64
+	// LinearDecayRate is the amount of base mana that is lost per second in the linear decay model.
+	LinearDecayRate float64
+}
+
+// NewManaModel returns the built-in model with the given name.
+func NewManaModel(name string, parameters ModelParameters) (ManaModel, error) {
+	switch name {
+	case EMAModelName:
+		return NewEMAModel(parameters.EMACoefficient, parameters.Decay)
+	case LinearDecayModelName:
+		return NewLinearDecayModel(parameters.EMACoefficient, parameters.LinearDecayRate)
+	case NoDecayModelName:
+		return NewNoDecayModel(), nil
+	default:
+		return nil, errors.Errorf("failed to create mana model %s: %w", name, ErrUnknownManaModel)
+	}
+}
+
+// DefaultManaModel returns the model that is used by a base mana vector of the given type if no model is specified.
+func DefaultManaModel(vectorType Type) ManaModel {
+	if vectorType == AccessMana || vectorType == ResearchAccess {
+		return &EMAModel{emaCoefficient: emaCoeff2, decay: Decay}
+	}
+	return NewNoDecayModel()
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region EMAModel /////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// EMAModel is the model where the base mana decays exponentially and the effective base mana is its exponential moving
+// average.
+type EMAModel struct {
+	emaCoefficient float64
+	decay          float64
+}
+
+// NewEMAModel returns a new EMAModel with the given moving average coefficient and decay, both in 1/sec.
+func NewEMAModel(emaCoefficient float64, decay float64) (*EMAModel, error) {
+	if emaCoefficient <= 0.0 {
+		return nil, errors.Errorf("ema coefficient must be greater than 0, got %f: %w", emaCoefficient, ErrInvalidManaModelParameter)
+	}
+	if decay <= 0.0 {
+		return nil, errors.Errorf("decay must be greater than 0, got %f: %w", decay, ErrInvalidManaModelParameter)
+	}
+	return &EMAModel{
+		emaCoefficient: emaCoefficient,
+		decay:          decay,
+	}, nil
+}
+
+// Name returns the name of the model.
+func (e *EMAModel) Name() string {
+	return EMAModelName
+}
+
+// PendingMana returns the base mana that `amount` tokens generate while they stay unspent for the duration `n`.
+func (e *EMAModel) PendingMana(amount float64, n time.Duration) float64 {
+	return amount * (1 - math.Pow(math.E, -e.decay*n.Seconds()))
+}
+
+// DecayBaseMana returns the base mana `baseMana` after it decayed for the duration `n`.
+func (e *EMAModel) DecayBaseMana(baseMana float64, n time.Duration) float64 {
+	return baseMana * math.Pow(math.E, -e.decay*n.Seconds())
+}
+
+// UpdateEffectiveBaseMana returns the effective base mana after the duration `n`, given the effective base mana and
+// the base mana at the beginning of the duration.
+func (e *EMAModel) UpdateEffectiveBaseMana(effectiveBaseMana float64, baseMana float64, n time.Duration) float64 {
+	if e.emaCoefficient != e.decay {
+		return math.Pow(math.E, -e.emaCoefficient*n.Seconds())*effectiveBaseMana +
+			(math.Pow(math.E, -e.decay*n.Seconds())-math.Pow(math.E, -e.emaCoefficient*n.Seconds()))/
+				(e.emaCoefficient-e.decay)*e.emaCoefficient*baseMana
+	}
+	return math.Pow(math.E, -e.decay*n.Seconds()) * (effectiveBaseMana + e.decay*n.Seconds()*baseMana)
+}
+
+var _ ManaModel = &EMAModel{}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region LinearDecayModel /////////////////////////////////////////////////////////////////////////////////////////////
+
+// LinearDecayModel is the model where spent tokens generate their full value as base mana, which then decreases by a
+// constant rate until it reaches zero. The effective base mana is the exponential moving average of the base mana.
+type LinearDecayModel struct {
+	emaCoefficient float64
+	decayRate      float64
+}
+
+// NewLinearDecayModel returns a new LinearDecayModel with the given moving average coefficient in 1/sec and decay rate
+// in mana/sec.
+func NewLinearDecayModel(emaCoefficient float64, decayRate float64) (*LinearDecayModel, error) {
+	if emaCoefficient <= 0.0 {
+		return nil, errors.Errorf("ema coefficient must be greater than 0, got %f: %w", emaCoefficient, ErrInvalidManaModelParameter)
+	}
+	if decayRate < 0.0 {
+		return nil, errors.Errorf("linear decay rate must not be negative, got %f: %w", decayRate, ErrInvalidManaModelParameter)
+	}
+	return &LinearDecayModel{
+		emaCoefficient: emaCoefficient,
+		decayRate:      decayRate,
+	}, nil
+}
+
+// Name returns the name of the model.
+func (l *LinearDecayModel) Name() string {
+	return LinearDecayModelName
+}
+
+// PendingMana returns the base mana that `amount` tokens generate while they stay unspent for the duration `n`.
+func (l *LinearDecayModel) PendingMana(amount float64, _ time.Duration) float64 {
+	return amount
+}
+
+// DecayBaseMana returns the base mana `baseMana` after it decayed for the duration `n`.
+func (l *LinearDecayModel) DecayBaseMana(baseMana float64, n time.Duration) float64 {
+	return math.Max(0, baseMana-l.decayRate*n.Seconds())
+}
+
+// UpdateEffectiveBaseMana returns the effective base mana after the duration `n`, given the effective base mana and
+// the base mana at the beginning of the duration.
+func (l *LinearDecayModel) UpdateEffectiveBaseMana(effectiveBaseMana float64, baseMana float64, n time.Duration) float64 {
+	// the base mana only contributes to the moving average until it decayed to zero
+	m := n.Seconds()
+	if l.decayRate > 0 && baseMana/l.decayRate < m {
+		m = baseMana / l.decayRate
+	}
+	growth := math.Pow(math.E, l.emaCoefficient*m)
+
+	return math.Pow(math.E, -l.emaCoefficient*n.Seconds()) * (effectiveBaseMana + baseMana*(growth-1) -
+		l.decayRate*(m*growth-(growth-1)/l.emaCoefficient))
+}
+
+var _ ManaModel = &LinearDecayModel{}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region NoDecayModel /////////////////////////////////////////////////////////////////////////////////////////////////
+
+// NoDecayModel is the model where spent tokens generate their full value as base mana, which never decays. The
+// effective base mana always equals the base mana. It is mainly useful for testing.
+type NoDecayModel struct{}
+
+// NewNoDecayModel returns a new NoDecayModel.
+func NewNoDecayModel() *NoDecayModel {
+	return &NoDecayModel{}
+}
+
+// Name returns the name of the model.
+func (n *NoDecayModel) Name() string {
+	return NoDecayModelName
+}
+
+// PendingMana returns the base mana that `amount` tokens generate while they stay unspent for the duration `n`.
+func (n *NoDecayModel) PendingMana(amount float64, _ time.Duration) float64 {
+	return amount
+}
+
+// DecayBaseMana returns the base mana `baseMana` after it decayed for the duration `n`.
+func (n *NoDecayModel) DecayBaseMana(baseMana float64, _ time.Duration) float64 {
+	return baseMana
+}
+
+// UpdateEffectiveBaseMana returns the effective base mana after the duration `n`, given the effective base mana and
+// the base mana at the beginning of the duration.
+func (n *NoDecayModel) UpdateEffectiveBaseMana(_ float64, baseMana float64, _ time.Duration) float64 {
+	return baseMana
+}
+
+var _ ManaModel = &NoDecayModel{}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -0,0 +1,120 @@
+package mana
+
+import (
+	"testing"
+	"time"
+
+	"github.com/iotaledger/hive.go/identity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewManaModel(t *testing.T) {
+	parameters := ModelParameters{EMACoefficient: 0.00003209, Decay: 0.00003209, LinearDecayRate: 0.1}
+	for _, name := range []string{EMAModelName, LinearDecayModelName, NoDecayModelName} {
+		model, err := NewManaModel(name, parameters)
+		require.NoError(t, err)
+		assert.Equal(t, name, model.Name())
+	}
+
+	_, err := NewManaModel("unknown", parameters)
+	assert.ErrorIs(t, err, ErrUnknownManaModel)
+	_, err = NewManaModel(EMAModelName, ModelParameters{EMACoefficient: 0.00003209})
+	assert.ErrorIs(t, err, ErrInvalidManaModelParameter)
+	_, err = NewManaModel(LinearDecayModelName, ModelParameters{EMACoefficient: 0.00003209, LinearDecayRate: -1})
+	assert.ErrorIs(t, err, ErrInvalidManaModelParameter)
+}
+
+func TestManaModel_IncrementalUpdate(t *testing.T) {
+	emaModel, err := NewEMAModel(0.0001, 0.00003209)
+	require.NoError(t, err)
+	linearModel, err := NewLinearDecayModel(0.0001, 0.0001)
+	require.NoError(t, err)
+
+	for _, model := range []ManaModel{emaModel, linearModel, NewNoDecayModel()} {
+		t.Run(model.Name(), func(t *testing.T) {
+			batchBaseMana := model.DecayBaseMana(1.0, 6*time.Hour)
+			batchEffectiveBaseMana := model.UpdateEffectiveBaseMana(0, 1.0, 6*time.Hour)
+
+			baseMana, effectiveBaseMana := 1.0, 0.0
+			for i := 0; i < 6; i++ {
+				effectiveBaseMana = model.UpdateEffectiveBaseMana(effectiveBaseMana, baseMana, time.Hour)
+				baseMana = model.DecayBaseMana(baseMana, time.Hour)
+			}
+
+			assert.InDelta(t, batchBaseMana, baseMana, delta)
+			assert.InDelta(t, batchEffectiveBaseMana, effectiveBaseMana, delta)
+		})
+	}
+}
+
+func TestLinearDecayModel(t *testing.T) {
+	model, err := NewLinearDecayModel(0.001, 0.001)
+	require.NoError(t, err)
+
+	assert.Equal(t, 5.0, model.PendingMana(5.0, time.Hour))
+	assert.InDelta(t, 0.4, model.DecayBaseMana(1.0, 600*time.Second), 1e-9)
+	assert.Equal(t, 0.0, model.DecayBaseMana(1.0, 2000*time.Second))
+
+	// compare the closed form of the moving average with a numeric integration
+	effectiveBaseMana, baseMana := 0.0, 1.0
+	for i := 0; i < 20000; i++ {
+		effectiveBaseMana += 0.001 * (baseMana - effectiveBaseMana) * 0.1
+		baseMana = model.DecayBaseMana(baseMana, 100*time.Millisecond)
+	}
+	assert.InDelta(t, effectiveBaseMana, model.UpdateEffectiveBaseMana(0, 1.0, 2000*time.Second), delta)
+}
+
+func TestBaseManaVector_ManaModel(t *testing.T) {
+	baseTime := time.Now()
+	nodeID := randNodeID()
+	txInfo := &TxInfo{
+		TimeStamp:    baseTime,
+		TotalBalance: 10.0,
+		PledgeID:     map[Type]identity.ID{AccessMana: nodeID, ConsensusMana: nodeID},
+		InputInfos: []InputInfo{
+			{
+				TimeStamp: baseTime.Add(-time.Hour),
+				Amount:    10.0,
+				PledgeID:  map[Type]identity.ID{AccessMana: randNodeID(), ConsensusMana: randNodeID()},
+			},
+		},
+	}
+
+	t.Run("CASE: Default models", func(t *testing.T) {
+		accessVector, err := NewBaseManaVector(AccessMana)
+		require.NoError(t, err)
+		assert.Equal(t, EMAModelName, accessVector.Model().Name())
+
+		consensusVector, err := NewBaseManaVector(ConsensusMana)
+		require.NoError(t, err)
+		assert.Equal(t, NoDecayModelName, consensusVector.Model().Name())
+
+		consensusVector.Book(txInfo)
+		consensusMana, _, err := consensusVector.GetMana(nodeID, baseTime.Add(time.Hour))
+		require.NoError(t, err)
+		assert.Equal(t, 10.0, consensusMana)
+	})
+
+	t.Run("CASE: No decay access mana", func(t *testing.T) {
+		accessVector, err := NewBaseManaVector(AccessMana, NewNoDecayModel())
+		require.NoError(t, err)
+
+		accessVector.Book(txInfo)
+		accessMana, _, err := accessVector.GetMana(nodeID, baseTime.Add(time.Hour))
+		require.NoError(t, err)
+		assert.Equal(t, 10.0, accessMana)
+	})
+
+	t.Run("CASE: Linear decay consensus mana", func(t *testing.T) {
+		linearModel, err := NewLinearDecayModel(0.001, 0.001)
+		require.NoError(t, err)
+		consensusVector, err := NewBaseManaVector(ConsensusMana, linearModel)
+		require.NoError(t, err)
+
+		consensusVector.Book(txInfo)
+		consensusMana, _, err := consensusVector.GetMana(nodeID, baseTime.Add(time.Hour))
+		require.NoError(t, err)
+		assert.InDelta(t, 10.0-3.6, consensusMana, 1e-9)
+	})
+}
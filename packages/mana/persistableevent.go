@@ -6,6 +6,7 @@ import (
 	"strconv"
 	"time"
 
+	"github.com/cockroachdb/errors"
 	"github.com/iotaledger/hive.go/identity"
 	"github.com/iotaledger/hive.go/marshalutil"
 	"github.com/iotaledger/hive.go/objectstorage"
@@ -45,6 +46,41 @@ func (p *PersistableEvent) ToStringValues() []string {
 	return []string{_type, _nodeID, _fullNodeID, _amount, _time, _manaType, _txID, _inputID}
 }
 
+// PersistableEventFromStringValues parses a persistable event from the string values that were created with
+// ToStringValues, e.g. a row of a mana event log CSV.
+func PersistableEventFromStringValues(values []string) (p *PersistableEvent, err error) {
+	if len(values) != len(p.ToStringKeys()) {
+		return nil, errors.Errorf("expected %d values but got %d", len(p.ToStringKeys()), len(values))
+	}
+	p = &PersistableEvent{}
+	eventType, err := strconv.ParseUint(values[0], 10, 8)
+	if err != nil {
+		return nil, errors.Errorf("failed to parse event type %s: %w", values[0], err)
+	}
+	p.Type = byte(eventType)
+	if p.NodeID, err = IDFromStr(values[2]); err != nil {
+		return nil, errors.Errorf("failed to parse node ID %s: %w", values[2], err)
+	}
+	if p.Amount, err = strconv.ParseFloat(values[3], 64); err != nil {
+		return nil, errors.Errorf("failed to parse amount %s: %w", values[3], err)
+	}
+	unixTime, err := strconv.ParseInt(values[4], 10, 64)
+	if err != nil {
+		return nil, errors.Errorf("failed to parse time %s: %w", values[4], err)
+	}
+	p.Time = time.Unix(unixTime, 0)
+	if p.ManaType, err = TypeFromString(values[5]); err != nil {
+		return nil, errors.Errorf("failed to parse mana type %s: %w", values[5], err)
+	}
+	if p.TransactionID, err = ledgerstate.TransactionIDFromBase58(values[6]); err != nil {
+		return nil, errors.Errorf("failed to parse transaction ID %s: %w", values[6], err)
+	}
+	if p.InputID, err = ledgerstate.OutputIDFromBase58(values[7]); err != nil {
+		return nil, errors.Errorf("failed to parse input ID %s: %w", values[7], err)
+	}
+	return p, nil
+}
+
 // Bytes marshals the persistable event into a sequence of bytes.
 func (p *PersistableEvent) Bytes() []byte {
 	if bytes := p.bytes; bytes != nil {
@@ -61,3 +61,22 @@ func TestFromEventObjectStorage(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, ev.Bytes(), ev1.Bytes(), "should be equal")
 }
+
+func TestPersistableEventFromStringValues(t *testing.T) {
+	ev := &PersistableEvent{
+		Type:          EventTypeRevoke,
+		NodeID:        randNodeID(),
+		Amount:        100,
+		Time:          time.Unix(1614924295, 0),
+		ManaType:      ConsensusMana,
+		TransactionID: randomTxID(),
+		InputID:       ledgerstate.NewOutputID(randomTxID(), 1),
+	}
+
+	restoredEvent, err := PersistableEventFromStringValues(ev.ToStringValues())
+	assert.NoError(t, err)
+	assert.Equal(t, ev.Bytes(), restoredEvent.Bytes())
+
+	_, err = PersistableEventFromStringValues(ev.ToStringValues()[1:])
+	assert.Error(t, err)
+}
@@ -0,0 +1,304 @@
+package mana
+
+import (
+	"sync"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/iotaledger/hive.go/identity"
+	"github.com/iotaledger/hive.go/types"
+)
+
+const (
+	// AnyNodePledgeModeName is the name of the pledge mode that accepts every node.
+	AnyNodePledgeModeName = "any"
+	// AllowListPledgeModeName is the name of the pledge mode that only accepts the listed nodes.
+	AllowListPledgeModeName = "allowList"
+	// DenyListPledgeModeName is the name of the pledge mode that accepts every node except the listed ones.
+	DenyListPledgeModeName = "denyList"
+)
+
+// region PledgeMode ///////////////////////////////////////////////////////////////////////////////////////////////////
+
+// PledgeMode defines which nodes are accepted as pledge targets based on their identity.
+type PledgeMode uint8
+
+const (
+	// AnyNodePledgeMode accepts every node.
+	AnyNodePledgeMode PledgeMode = iota
+	// AllowListPledgeMode only accepts the listed nodes.
+	AllowListPledgeMode
+	// DenyListPledgeMode accepts every node except the listed ones.
+	DenyListPledgeMode
+)
+
+// PledgeModeFromString parses a string and returns the PledgeMode it defines.
+func PledgeModeFromString(name string) (PledgeMode, error) {
+	switch name {
+	case AnyNodePledgeModeName:
+		return AnyNodePledgeMode, nil
+	case AllowListPledgeModeName:
+		return AllowListPledgeMode, nil
+	case DenyListPledgeModeName:
+		return DenyListPledgeMode, nil
+	default:
+		return AnyNodePledgeMode, errors.Errorf("failed to parse pledge mode %s: %w", name, ErrUnknownPledgeMode)
+	}
+}
+
+// String returns a human readable version of the PledgeMode.
+func (p PledgeMode) String() string {
+	switch p {
+	case AnyNodePledgeMode:
+		return AnyNodePledgeModeName
+	case AllowListPledgeMode:
+		return AllowListPledgeModeName
+	case DenyListPledgeMode:
+		return DenyListPledgeModeName
+	default:
+		return "unknown"
+	}
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region PledgePolicy /////////////////////////////////////////////////////////////////////////////////////////////////
+
+// PledgeManaRetrieverFunc is a function type to retrieve the mana of a node and the total mana of all nodes.
+type PledgeManaRetrieverFunc func(nodeID identity.ID) (nodeMana float64, totalMana float64, err error)
+
+// PledgePolicyParameters contains the rules of a PledgePolicy. Zero values disable the mana and rate limit rules.
+type PledgePolicyParameters struct {
+	// Mode defines which nodes are accepted based on their identity.
+	Mode PledgeMode
+	// Nodes is the allow- or deny-list, depending on the Mode.
+	Nodes []identity.ID
+	// MinMana is the mana a node needs to have already to receive a pledge.
+	MinMana float64
+	// MaxShare is the maximum share of the total mana a node may own to receive a pledge.
+	MaxShare float64
+	// RateLimit is the maximum number of pledges a single pledger address may make within the RateLimitWindow.
+	RateLimit int
+	// RateLimitWindow is the time window of the RateLimit.
+	RateLimitWindow time.Duration
+}
+
+// PledgePolicy decides whether mana of a certain type may be pledged to a node. It combines identity based rules
+// (any node, allow-list, deny-list), mana based rules (minimum mana, maximum share of the total mana) and a rate limit
+// per pledger address.
+type PledgePolicy struct {
+	manaType      Type
+	parameters    PledgePolicyParameters
+	nodes         map[identity.ID]types.Empty
+	manaRetriever PledgeManaRetrieverFunc
+
+	pledges     map[string][]time.Time
+	lastCleanup time.Time
+	mutex       sync.Mutex
+}
+
+// NewPledgePolicy returns a new PledgePolicy for the given mana type. The manaRetriever is only called if a mana based
+// rule is enabled.
+func NewPledgePolicy(manaType Type, parameters PledgePolicyParameters, manaRetriever PledgeManaRetrieverFunc) (*PledgePolicy, error) {
+	if parameters.Mode > DenyListPledgeMode {
+		return nil, errors.Errorf("invalid pledge mode %d: %w", parameters.Mode, ErrUnknownPledgeMode)
+	}
+	if parameters.MinMana < 0 {
+		return nil, errors.Errorf("min mana must not be negative, got %f: %w", parameters.MinMana, ErrInvalidPledgePolicyParameter)
+	}
+	if parameters.MaxShare < 0 || parameters.MaxShare > 1 {
+		return nil, errors.Errorf("max share must be in [0,1], got %f: %w", parameters.MaxShare, ErrInvalidPledgePolicyParameter)
+	}
+	if parameters.RateLimit < 0 {
+		return nil, errors.Errorf("rate limit must not be negative, got %d: %w", parameters.RateLimit, ErrInvalidPledgePolicyParameter)
+	}
+	if parameters.RateLimit > 0 && parameters.RateLimitWindow <= 0 {
+		return nil, errors.Errorf("rate limit window must be positive, got %s: %w", parameters.RateLimitWindow, ErrInvalidPledgePolicyParameter)
+	}
+	if (parameters.MinMana > 0 || parameters.MaxShare > 0) && manaRetriever == nil {
+		return nil, errors.Errorf("mana based rules require a mana retriever: %w", ErrInvalidPledgePolicyParameter)
+	}
+
+	nodes := make(map[identity.ID]types.Empty, len(parameters.Nodes))
+	for _, nodeID := range parameters.Nodes {
+		nodes[nodeID] = types.Void
+	}
+	parameters.Nodes = append([]identity.ID{}, parameters.Nodes...)
+
+	return &PledgePolicy{
+		manaType:      manaType,
+		parameters:    parameters,
+		nodes:         nodes,
+		manaRetriever: manaRetriever,
+		pledges:       make(map[string][]time.Time),
+	}, nil
+}
+
+// Type returns the mana type the policy is applied to.
+func (p *PledgePolicy) Type() Type {
+	return p.manaType
+}
+
+// Parameters returns a copy of the rules of the policy.
+func (p *PledgePolicy) Parameters() PledgePolicyParameters {
+	parameters := p.parameters
+	parameters.Nodes = append([]identity.ID{}, p.parameters.Nodes...)
+	return parameters
+}
+
+// Check returns an error if mana must not be pledged to the given node by the given pledger at time t. An empty pledger
+// is not subject to the rate limit. Check does not count the pledge towards the rate limit, use Reserve for pledges that
+// are about to be issued.
+func (p *PledgePolicy) Check(nodeID identity.ID, pledger string, t time.Time) error {
+	if err := p.checkNode(nodeID); err != nil {
+		return err
+	}
+	if err := p.checkMana(nodeID); err != nil {
+		return err
+	}
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	return p.checkRateLimit(pledger, t)
+}
+
+// CheckLocal returns an error if the node must not pledge mana to itself, e.g. when refreshing its delegated mana. Only
+// the identity based rules and the minimum mana apply, because the node neither competes for a share of the total mana
+// with its own pledges nor is subject to the rate limit of external pledgers.
+func (p *PledgePolicy) CheckLocal(nodeID identity.ID) error {
+	if err := p.checkNode(nodeID); err != nil {
+		return err
+	}
+	if p.parameters.MinMana == 0 {
+		return nil
+	}
+
+	nodeMana, _, err := p.manaRetriever(nodeID)
+	if err != nil {
+		return errors.Errorf("failed to retrieve %s mana of node %s: %w", p.manaType, nodeID, err)
+	}
+	if nodeMana < p.parameters.MinMana {
+		return errors.Errorf("node %s has %f %s mana, less than the required %f: %w", nodeID, nodeMana, p.manaType, p.parameters.MinMana, ErrPledgeNodeBelowMinMana)
+	}
+	return nil
+}
+
+// Reserve checks the pledge and, if it is accepted, counts it towards the rate limit of the pledger within the same
+// critical section, so that concurrent pledges cannot exceed the rate limit together. The returned cancel function
+// removes the pledge from the rate limit again and must be called if the pledge was not issued.
+func (p *PledgePolicy) Reserve(nodeID identity.ID, pledger string, t time.Time) (cancel func(), err error) {
+	if err = p.checkNode(nodeID); err != nil {
+		return nil, err
+	}
+	if err = p.checkMana(nodeID); err != nil {
+		return nil, err
+	}
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if err = p.checkRateLimit(pledger, t); err != nil {
+		return nil, err
+	}
+	if p.parameters.RateLimit == 0 || pledger == "" {
+		return func() {}, nil
+	}
+	p.cleanup(t)
+	p.pledges[pledger] = append(p.recentPledges(pledger, t), t)
+
+	return func() { p.cancel(pledger, t) }, nil
+}
+
+// Evaluate checks the pledge and, if it is accepted, counts it towards the rate limit of the pledger.
+func (p *PledgePolicy) Evaluate(nodeID identity.ID, pledger string, t time.Time) error {
+	_, err := p.Reserve(nodeID, pledger, t)
+	return err
+}
+
+// cancel removes a reserved pledge of the pledger at time t.
+func (p *PledgePolicy) cancel(pledger string, t time.Time) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	pledges := p.pledges[pledger]
+	for i := len(pledges) - 1; i >= 0; i-- {
+		if pledges[i].Equal(t) {
+			p.pledges[pledger] = append(pledges[:i:i], pledges[i+1:]...)
+			return
+		}
+	}
+}
+
+// checkNode applies the identity based rules.
+func (p *PledgePolicy) checkNode(nodeID identity.ID) error {
+	_, listed := p.nodes[nodeID]
+	switch p.parameters.Mode {
+	case AllowListPledgeMode:
+		if !listed {
+			return errors.Errorf("node %s is not in the %s mana allow-list: %w", nodeID, p.manaType, ErrPledgeNodeNotAllowed)
+		}
+	case DenyListPledgeMode:
+		if listed {
+			return errors.Errorf("node %s is in the %s mana deny-list: %w", nodeID, p.manaType, ErrPledgeNodeNotAllowed)
+		}
+	}
+	return nil
+}
+
+// checkMana applies the mana based rules.
+func (p *PledgePolicy) checkMana(nodeID identity.ID) error {
+	if p.parameters.MinMana == 0 && p.parameters.MaxShare == 0 {
+		return nil
+	}
+
+	nodeMana, totalMana, err := p.manaRetriever(nodeID)
+	if err != nil {
+		return errors.Errorf("failed to retrieve %s mana of node %s: %w", p.manaType, nodeID, err)
+	}
+	if nodeMana < p.parameters.MinMana {
+		return errors.Errorf("node %s has %f %s mana, less than the required %f: %w", nodeID, nodeMana, p.manaType, p.parameters.MinMana, ErrPledgeNodeBelowMinMana)
+	}
+	// without any mana in the network no node can exceed its share
+	if p.parameters.MaxShare > 0 && totalMana > 0 && nodeMana/totalMana > p.parameters.MaxShare {
+		return errors.Errorf("node %s owns %f of the total %s mana, more than the allowed %f: %w", nodeID, nodeMana/totalMana, p.manaType, p.parameters.MaxShare, ErrPledgeMaxShareExceeded)
+	}
+	return nil
+}
+
+// checkRateLimit applies the rate limit of the pledger. It must be called while holding the mutex.
+func (p *PledgePolicy) checkRateLimit(pledger string, t time.Time) error {
+	if p.parameters.RateLimit == 0 || pledger == "" {
+		return nil
+	}
+	if len(p.recentPledges(pledger, t)) >= p.parameters.RateLimit {
+		return errors.Errorf("%s already pledged %s mana %d times within %s: %w", pledger, p.manaType, p.parameters.RateLimit, p.parameters.RateLimitWindow, ErrPledgeRateLimitExceeded)
+	}
+	return nil
+}
+
+// recentPledges returns the pledges of the pledger that are still within the rate limit window at time t. It must be
+// called while holding the mutex.
+func (p *PledgePolicy) recentPledges(pledger string, t time.Time) []time.Time {
+	pledges := p.pledges[pledger]
+	for len(pledges) > 0 && !pledges[0].After(t.Add(-p.parameters.RateLimitWindow)) {
+		pledges = pledges[1:]
+	}
+	return pledges
+}
+
+// cleanup removes the pledgers without any recent pledges, at most once per rate limit window. It must be called while
+// holding the mutex.
+func (p *PledgePolicy) cleanup(t time.Time) {
+	if t.Sub(p.lastCleanup) < p.parameters.RateLimitWindow {
+		return
+	}
+	for pledger := range p.pledges {
+		if len(p.recentPledges(pledger, t)) == 0 {
+			delete(p.pledges, pledger)
+		}
+	}
+	p.lastCleanup = t
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
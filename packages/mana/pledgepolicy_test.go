@@ -0,0 +1,127 @@
+package mana
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/iotaledger/hive.go/identity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPledgeModeFromString(t *testing.T) {
+	for _, mode := range []PledgeMode{AnyNodePledgeMode, AllowListPledgeMode, DenyListPledgeMode} {
+		parsed, err := PledgeModeFromString(mode.String())
+		require.NoError(t, err)
+		assert.Equal(t, mode, parsed)
+	}
+
+	_, err := PledgeModeFromString("unknown")
+	assert.ErrorIs(t, err, ErrUnknownPledgeMode)
+}
+
+func TestNewPledgePolicy(t *testing.T) {
+	_, err := NewPledgePolicy(AccessMana, PledgePolicyParameters{MaxShare: 1.5}, nil)
+	assert.ErrorIs(t, err, ErrInvalidPledgePolicyParameter)
+	_, err = NewPledgePolicy(AccessMana, PledgePolicyParameters{RateLimit: 1}, nil)
+	assert.ErrorIs(t, err, ErrInvalidPledgePolicyParameter)
+	_, err = NewPledgePolicy(AccessMana, PledgePolicyParameters{MinMana: 1}, nil)
+	assert.ErrorIs(t, err, ErrInvalidPledgePolicyParameter)
+	_, err = NewPledgePolicy(AccessMana, PledgePolicyParameters{Mode: 3}, nil)
+	assert.ErrorIs(t, err, ErrUnknownPledgeMode)
+}
+
+func TestPledgePolicy_Lists(t *testing.T) {
+	listed := randNodeID()
+	other := randNodeID()
+
+	anyPolicy, err := NewPledgePolicy(ConsensusMana, PledgePolicyParameters{}, nil)
+	require.NoError(t, err)
+	assert.NoError(t, anyPolicy.Check(listed, "", time.Now()))
+	assert.NoError(t, anyPolicy.Check(other, "", time.Now()))
+
+	allowPolicy, err := NewPledgePolicy(ConsensusMana, PledgePolicyParameters{Mode: AllowListPledgeMode, Nodes: []identity.ID{listed}}, nil)
+	require.NoError(t, err)
+	assert.NoError(t, allowPolicy.Check(listed, "", time.Now()))
+	assert.ErrorIs(t, allowPolicy.Check(other, "", time.Now()), ErrPledgeNodeNotAllowed)
+
+	denyPolicy, err := NewPledgePolicy(ConsensusMana, PledgePolicyParameters{Mode: DenyListPledgeMode, Nodes: []identity.ID{listed}}, nil)
+	require.NoError(t, err)
+	assert.ErrorIs(t, denyPolicy.Check(listed, "", time.Now()), ErrPledgeNodeNotAllowed)
+	assert.NoError(t, denyPolicy.Check(other, "", time.Now()))
+}
+
+func TestPledgePolicy_Mana(t *testing.T) {
+	rich := randNodeID()
+	poor := randNodeID()
+	manaMap := map[identity.ID]float64{rich: 60, poor: 5}
+	retriever := func(nodeID identity.ID) (float64, float64, error) {
+		return manaMap[nodeID], 100, nil
+	}
+
+	policy, err := NewPledgePolicy(ConsensusMana, PledgePolicyParameters{MinMana: 10, MaxShare: 0.5}, retriever)
+	require.NoError(t, err)
+	assert.ErrorIs(t, policy.Check(poor, "", time.Now()), ErrPledgeNodeBelowMinMana)
+	assert.ErrorIs(t, policy.Check(rich, "", time.Now()), ErrPledgeMaxShareExceeded)
+
+	manaMap[poor] = 40
+	assert.NoError(t, policy.Check(poor, "", time.Now()))
+
+	// the node's own pledges are not limited by its share of the total mana
+	assert.NoError(t, policy.CheckLocal(rich))
+	manaMap[poor] = 5
+	assert.ErrorIs(t, policy.CheckLocal(poor), ErrPledgeNodeBelowMinMana)
+}
+
+func TestPledgePolicy_RateLimit(t *testing.T) {
+	nodeID := randNodeID()
+	policy, err := NewPledgePolicy(AccessMana, PledgePolicyParameters{RateLimit: 2, RateLimitWindow: time.Minute}, nil)
+	require.NoError(t, err)
+
+	now := time.Now()
+	assert.NoError(t, policy.Evaluate(nodeID, "pledger", now))
+	assert.NoError(t, policy.Evaluate(nodeID, "pledger", now.Add(time.Second)))
+	assert.ErrorIs(t, policy.Evaluate(nodeID, "pledger", now.Add(2*time.Second)), ErrPledgeRateLimitExceeded)
+
+	// other pledgers and pledges without a pledger are not affected
+	assert.NoError(t, policy.Evaluate(nodeID, "other", now.Add(2*time.Second)))
+	assert.NoError(t, policy.Evaluate(nodeID, "", now.Add(2*time.Second)))
+
+	// pledges expire after the window
+	assert.NoError(t, policy.Evaluate(nodeID, "pledger", now.Add(time.Minute+time.Second)))
+}
+
+func TestPledgePolicy_Reserve(t *testing.T) {
+	nodeID := randNodeID()
+	policy, err := NewPledgePolicy(AccessMana, PledgePolicyParameters{RateLimit: 1, RateLimitWindow: time.Minute}, nil)
+	require.NoError(t, err)
+
+	// a cancelled reservation does not count towards the rate limit
+	now := time.Now()
+	cancel, err := policy.Reserve(nodeID, "pledger", now)
+	require.NoError(t, err)
+	_, err = policy.Reserve(nodeID, "pledger", now.Add(time.Second))
+	assert.ErrorIs(t, err, ErrPledgeRateLimitExceeded)
+	cancel()
+	_, err = policy.Reserve(nodeID, "pledger", now.Add(time.Second))
+	assert.NoError(t, err)
+
+	// concurrent reservations do not exceed the rate limit
+	var wg sync.WaitGroup
+	var mutex sync.Mutex
+	accepted := 0
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, reserveErr := policy.Reserve(nodeID, "concurrent", now); reserveErr == nil {
+				mutex.Lock()
+				accepted++
+				mutex.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, 1, accepted)
+}
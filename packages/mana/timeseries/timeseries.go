@@ -0,0 +1,326 @@
+// Package timeseries stores sampled mana values of nodes as OpenMetrics text files and allows to query them again.
+package timeseries
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/iotaledger/hive.go/identity"
+	"github.com/mr-tron/base58"
+
+	"github.com/iotaledger/goshimmer/packages/mana"
+)
+
+const (
+	// MetricName is the name of the OpenMetrics metric family holding the mana samples.
+	MetricName = "goshimmer_mana"
+
+	filePrefix = "mana-"
+	fileSuffix = ".om"
+	eofMarker  = "# EOF\n"
+)
+
+var (
+	// ErrInvalidSample is returned if a line of a time-series file can not be parsed.
+	ErrInvalidSample = errors.New("invalid mana sample")
+	// ErrInvalidWriterParameter is returned if a Writer is created with an invalid parameter.
+	ErrInvalidWriterParameter = errors.New("invalid time-series writer parameter")
+)
+
+// region Point ////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// Point is the mana of a node at a certain time.
+type Point struct {
+	Time  time.Time
+	Value float64
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region Writer ///////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// Writer appends mana samples to OpenMetrics text files in a directory. A new file is started once the current one
+// exceeds the maximum file size and the oldest files are removed to keep at most the maximum number of files.
+type Writer struct {
+	directory   string
+	maxFileSize int64
+	maxFiles    int
+
+	file     *os.File
+	fileSize int64
+	mutex    sync.Mutex
+}
+
+// NewWriter creates a new Writer that stores its files in the given directory.
+func NewWriter(directory string, maxFileSize int64, maxFiles int) (*Writer, error) {
+	if maxFileSize <= 0 {
+		return nil, errors.Errorf("max file size must be positive, got %d: %w", maxFileSize, ErrInvalidWriterParameter)
+	}
+	if maxFiles <= 0 {
+		return nil, errors.Errorf("max files must be positive, got %d: %w", maxFiles, ErrInvalidWriterParameter)
+	}
+	if err := os.MkdirAll(directory, 0o755); err != nil {
+		return nil, errors.Errorf("failed to create time-series directory %s: %w", directory, err)
+	}
+	return &Writer{
+		directory:   directory,
+		maxFileSize: maxFileSize,
+		maxFiles:    maxFiles,
+	}, nil
+}
+
+// Write appends the mana of all nodes in the given mana maps, sampled at time t.
+func (w *Writer) Write(t time.Time, manaMaps map[mana.Type]mana.NodeMap) error {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	if w.file == nil || w.fileSize >= w.maxFileSize {
+		if err := w.rotate(t); err != nil {
+			return err
+		}
+	}
+
+	var buffer bytes.Buffer
+	for _, manaType := range sortedTypes(manaMaps) {
+		for nodeID, value := range manaMaps[manaType] {
+			buffer.WriteString(formatSample(manaType, nodeID, value, t))
+		}
+	}
+	n, err := w.file.Write(buffer.Bytes())
+	w.fileSize += int64(n)
+	if err != nil {
+		return errors.Errorf("failed to write mana samples to %s: %w", w.file.Name(), err)
+	}
+	return nil
+}
+
+// Close terminates the current file.
+func (w *Writer) Close() error {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	return w.closeFile()
+}
+
+// rotate terminates the current file, starts a new one and removes the files that exceed the maximum number of files.
+// It must be called while holding the mutex.
+func (w *Writer) rotate(t time.Time) error {
+	if err := w.closeFile(); err != nil {
+		return err
+	}
+
+	path := filepath.Join(w.directory, fileName(t))
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o644)
+	if err != nil {
+		return errors.Errorf("failed to create time-series file %s: %w", path, err)
+	}
+	header := fmt.Sprintf("# TYPE %s gauge\n# HELP %s Base mana of a node.\n", MetricName, MetricName)
+	n, err := file.WriteString(header)
+	if err != nil {
+		_ = file.Close()
+		return errors.Errorf("failed to write header to %s: %w", path, err)
+	}
+	w.file = file
+	w.fileSize = int64(n)
+
+	files, err := listFiles(w.directory)
+	if err != nil {
+		return err
+	}
+	for len(files) > w.maxFiles {
+		if err := os.Remove(files[0].path); err != nil {
+			return errors.Errorf("failed to remove time-series file %s: %w", files[0].path, err)
+		}
+		files = files[1:]
+	}
+	return nil
+}
+
+// closeFile terminates and closes the current file. It must be called while holding the mutex.
+func (w *Writer) closeFile() error {
+	if w.file == nil {
+		return nil
+	}
+	defer func() { w.file = nil }()
+
+	if _, err := w.file.WriteString(eofMarker); err != nil {
+		_ = w.file.Close()
+		return errors.Errorf("failed to terminate time-series file %s: %w", w.file.Name(), err)
+	}
+	return w.file.Close()
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region Query ////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// Query returns the recorded mana of the node per mana type, for the samples taken within [from, to].
+func Query(directory string, nodeID identity.ID, from, to time.Time) (map[mana.Type][]Point, error) {
+	files, err := listFiles(directory)
+	if err != nil {
+		return nil, err
+	}
+
+	encodedNodeID := base58.Encode(nodeID.Bytes())
+	res := make(map[mana.Type][]Point)
+	for i, file := range files {
+		// files only contain samples taken before the next file was started
+		if file.start.After(to) || (i+1 < len(files) && files[i+1].start.Before(from)) {
+			continue
+		}
+		if err := readFile(file.path, func(manaType mana.Type, sampleNodeID string, point Point) {
+			if sampleNodeID != encodedNodeID || point.Time.Before(from) || point.Time.After(to) {
+				return
+			}
+			res[manaType] = append(res[manaType], point)
+		}); err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
+
+// readFile calls the consumer for every valid sample in the file.
+func readFile(path string, consumer func(manaType mana.Type, nodeID string, point Point)) error {
+	file, err := os.Open(path)
+	if err != nil {
+		// the file might have been removed by a rotation in the meantime
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return errors.Errorf("failed to open time-series file %s: %w", path, err)
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		// a partially written line is left behind if the node crashed while writing
+		manaType, nodeID, point, err := parseSample(line)
+		if err != nil {
+			continue
+		}
+		consumer(manaType, nodeID, point)
+	}
+	return scanner.Err()
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region utility functions ////////////////////////////////////////////////////////////////////////////////////////////
+
+type timeSeriesFile struct {
+	path  string
+	start time.Time
+}
+
+// listFiles returns the time-series files in the directory, sorted by the time they were started.
+func listFiles(directory string) ([]timeSeriesFile, error) {
+	entries, err := ioutil.ReadDir(directory)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, errors.Errorf("failed to list time-series directory %s: %w", directory, err)
+	}
+
+	var files []timeSeriesFile
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasPrefix(name, filePrefix) || !strings.HasSuffix(name, fileSuffix) {
+			continue
+		}
+		startMillis, err := strconv.ParseInt(strings.TrimSuffix(strings.TrimPrefix(name, filePrefix), fileSuffix), 10, 64)
+		if err != nil {
+			continue
+		}
+		files = append(files, timeSeriesFile{
+			path:  filepath.Join(directory, name),
+			start: time.Unix(0, startMillis*int64(time.Millisecond)),
+		})
+	}
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].start.Before(files[j].start)
+	})
+	return files, nil
+}
+
+func fileName(t time.Time) string {
+	return fmt.Sprintf("%s%015d%s", filePrefix, t.UnixNano()/int64(time.Millisecond), fileSuffix)
+}
+
+// formatSample returns the OpenMetrics text representation of a sample.
+func formatSample(manaType mana.Type, nodeID identity.ID, value float64, t time.Time) string {
+	millis := t.UnixNano() / int64(time.Millisecond)
+	return fmt.Sprintf("%s{type=\"%s\",nodeID=\"%s\"} %s %d.%03d\n", MetricName, manaType.String(), base58.Encode(nodeID.Bytes()),
+		strconv.FormatFloat(value, 'g', -1, 64), millis/1000, millis%1000)
+}
+
+// parseSample parses a sample in the format written by formatSample.
+func parseSample(line string) (manaType mana.Type, nodeID string, point Point, err error) {
+	labelsStart := strings.IndexByte(line, '{')
+	labelsEnd := strings.IndexByte(line, '}')
+	if labelsStart == -1 || labelsEnd < labelsStart || line[:labelsStart] != MetricName {
+		return manaType, "", point, errors.Errorf("malformed sample %q: %w", line, ErrInvalidSample)
+	}
+
+	for _, label := range strings.Split(line[labelsStart+1:labelsEnd], ",") {
+		keyValue := strings.SplitN(label, "=", 2)
+		if len(keyValue) != 2 {
+			return manaType, "", point, errors.Errorf("malformed label %q: %w", label, ErrInvalidSample)
+		}
+		switch value := strings.Trim(keyValue[1], "\""); keyValue[0] {
+		case "type":
+			if manaType, err = mana.TypeFromString(value); err != nil {
+				return manaType, "", point, errors.Errorf("malformed mana type %q: %w", value, ErrInvalidSample)
+			}
+		case "nodeID":
+			nodeID = value
+		}
+	}
+
+	fields := strings.Fields(line[labelsEnd+1:])
+	if len(fields) != 2 {
+		return manaType, "", point, errors.Errorf("malformed sample %q: %w", line, ErrInvalidSample)
+	}
+	if point.Value, err = strconv.ParseFloat(fields[0], 64); err != nil {
+		return manaType, "", point, errors.Errorf("malformed value %q: %w", fields[0], ErrInvalidSample)
+	}
+	timestamp, err := strconv.ParseFloat(fields[1], 64)
+	if err != nil {
+		return manaType, "", point, errors.Errorf("malformed timestamp %q: %w", fields[1], ErrInvalidSample)
+	}
+	point.Time = time.Unix(0, int64(math.Round(timestamp*1000))*int64(time.Millisecond))
+	return manaType, nodeID, point, nil
+}
+
+// sortedTypes returns the mana types of the maps that can be stored, in ascending order.
+func sortedTypes(manaMaps map[mana.Type]mana.NodeMap) (types []mana.Type) {
+	for manaType := range manaMaps {
+		// only types with a parsable name can be queried again
+		if parsed, err := mana.TypeFromString(manaType.String()); err != nil || parsed != manaType {
+			continue
+		}
+		types = append(types, manaType)
+	}
+	sort.Slice(types, func(i, j int) bool {
+		return types[i] < types[j]
+	})
+	return types
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
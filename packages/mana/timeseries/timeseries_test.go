@@ -0,0 +1,81 @@
+package timeseries
+
+import (
+	"io/ioutil"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/iotaledger/hive.go/identity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/goshimmer/packages/mana"
+)
+
+func TestWriter_Query(t *testing.T) {
+	directory := t.TempDir()
+	writer, err := NewWriter(directory, 1<<20, 10)
+	require.NoError(t, err)
+
+	nodeA := identity.GenerateIdentity().ID()
+	nodeB := identity.GenerateIdentity().ID()
+	start := time.Unix(1600000000, 123*int64(time.Millisecond))
+	for i := 0; i < 5; i++ {
+		require.NoError(t, writer.Write(start.Add(time.Duration(i)*time.Minute), map[mana.Type]mana.NodeMap{
+			mana.AccessMana:    {nodeA: float64(i), nodeB: 100},
+			mana.ConsensusMana: {nodeA: float64(10 * i)},
+			mana.WeightedMana:  {nodeA: 1},
+		}))
+	}
+	require.NoError(t, writer.Close())
+
+	history, err := Query(directory, nodeA, start.Add(time.Minute), start.Add(3*time.Minute))
+	require.NoError(t, err)
+	require.Len(t, history[mana.AccessMana], 3)
+	require.Len(t, history[mana.ConsensusMana], 3)
+	assert.Len(t, history[mana.WeightedMana], 3)
+	for i, point := range history[mana.ConsensusMana] {
+		assert.True(t, start.Add(time.Duration(i+1)*time.Minute).Equal(point.Time))
+		assert.Equal(t, float64(10*(i+1)), point.Value)
+	}
+}
+
+func TestWriter_Rotation(t *testing.T) {
+	directory := t.TempDir()
+	// every write exceeds the max file size, so each one ends up in its own file
+	writer, err := NewWriter(directory, 1, 3)
+	require.NoError(t, err)
+
+	nodeID := identity.GenerateIdentity().ID()
+	start := time.Unix(1600000000, 0)
+	for i := 0; i < 5; i++ {
+		require.NoError(t, writer.Write(start.Add(time.Duration(i)*time.Minute), map[mana.Type]mana.NodeMap{
+			mana.AccessMana: {nodeID: float64(i)},
+		}))
+	}
+	require.NoError(t, writer.Close())
+
+	entries, err := ioutil.ReadDir(directory)
+	require.NoError(t, err)
+	assert.Len(t, entries, 3)
+
+	// the oldest samples were removed with their files
+	history, err := Query(directory, nodeID, start, start.Add(time.Hour))
+	require.NoError(t, err)
+	require.Len(t, history[mana.AccessMana], 3)
+	assert.Equal(t, 2.0, history[mana.AccessMana][0].Value)
+}
+
+func TestParseSample(t *testing.T) {
+	nodeID := identity.GenerateIdentity().ID()
+	sampleTime := time.Unix(1600000000, 999*int64(time.Millisecond))
+	manaType, _, point, err := parseSample(strings.TrimSuffix(formatSample(mana.ConsensusMana, nodeID, 0.1, sampleTime), "\n"))
+	require.NoError(t, err)
+	assert.Equal(t, mana.ConsensusMana, manaType)
+	assert.Equal(t, 0.1, point.Value)
+	assert.True(t, sampleTime.Equal(point.Time))
+
+	_, _, _, err = parseSample("other_metric 1 2")
+	assert.ErrorIs(t, err, ErrInvalidSample)
+}
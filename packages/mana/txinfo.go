@@ -46,6 +46,9 @@ type InputInfo struct {
 type SnapshotNode struct {
 	AccessMana       AccessManaSnapshot
 	SortedTxSnapshot SortedTxSnapshot
+	// ConsensusMana is the consensus mana of the node. If it is nil, the consensus mana is derived from the
+	// SortedTxSnapshot.
+	ConsensusMana *ConsensusManaSnapshot
 }
 
 // AccessManaSnapshot defines the record for the aMana snapshot of one node
@@ -54,6 +57,12 @@ type AccessManaSnapshot struct {
 	Timestamp time.Time
 }
 
+// ConsensusManaSnapshot defines the record for the cMana snapshot of one node.
+type ConsensusManaSnapshot struct {
+	BaseMana  float64
+	Timestamp time.Time
+}
+
 // TxSnapshot defines the record of one transaction.
 type TxSnapshot struct {
 	Value     float64
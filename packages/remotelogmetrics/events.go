@@ -54,6 +54,10 @@ type FPCConflictRecord struct {
 	// ConflictCreationTime points to time when the context has been created
 	ConflictCreationTime time.Time `json:"conflictStart" bson:"conflictStart"`
 	Delta                int64     `json:"delta"`
+	// SamplingStrategy defines the strategy used to select the queried nodes in the round.
+	SamplingStrategy string `json:"samplingStrategy,omitempty" bson:"samplingStrategy,omitempty"`
+	// SelectedManaShare defines the share of the total mana held by the nodes queried in the round.
+	SelectedManaShare float64 `json:"selectedManaShare,omitempty" bson:"selectedManaShare,omitempty"`
 }
 
 // TransactionMetrics defines the transaction metrics record to sent be to remote logger.
@@ -472,14 +472,14 @@ func (a *ApprovalWeightManager) updateBranchWeight(branchID ledgerstate.BranchID
 		switch isAggregatedBranch := len(conflictBranchIDs) != 1; isAggregatedBranch {
 		case false:
 			a.tangle.Storage.BranchWeight(conflictBranchID, NewBranchWeight).Consume(func(branchWeight *BranchWeight) {
-				branchWeight.SetWeight(newBranchWeight)
+				a.setBranchWeight(branchWeight, newBranchWeight)
 
 				a.Events.BranchConfirmation.Set(conflictBranchID, newBranchWeight-a.weightOfHeaviestConflictingBranch(branchID))
 			})
 		default:
 			a.tangle.Storage.BranchWeight(conflictBranchID, NewBranchWeight).Consume(func(branchWeight *BranchWeight) {
 				if newBranchWeight > branchWeight.Weight() {
-					branchWeight.SetWeight(newBranchWeight)
+					a.setBranchWeight(branchWeight, newBranchWeight)
 
 					a.Events.BranchConfirmation.Set(conflictBranchID, newBranchWeight-a.weightOfHeaviestConflictingBranch(branchID))
 				}
@@ -488,6 +488,14 @@ func (a *ApprovalWeightManager) updateBranchWeight(branchID ledgerstate.BranchID
 	}
 }
 
+// setBranchWeight updates the weight of the given BranchWeight and records the reached approval weight milestones.
+func (a *ApprovalWeightManager) setBranchWeight(branchWeight *BranchWeight, weight float64) {
+	previousWeight := branchWeight.Weight()
+	if branchWeight.SetWeight(weight) {
+		a.tangle.LedgerState.ConflictTimeline.RecordApprovalWeight(branchWeight.BranchID(), previousWeight, weight)
+	}
+}
+
 func (a *ApprovalWeightManager) weightOfHeaviestConflictingBranch(branchID ledgerstate.BranchID) (weight float64) {
 	a.tangle.LedgerState.BranchDAG.ForEachConflictingBranchID(branchID, func(conflictingBranchID ledgerstate.BranchID) {
 		a.tangle.Storage.BranchWeight(conflictingBranchID).Consume(func(branchWeight *BranchWeight) {
@@ -527,7 +535,7 @@ func (a *ApprovalWeightManager) moveMarkerWeightToNewBranch(marker *markers.Mark
 		for conflictBranchID := range conflictBranchIDs {
 			a.tangle.Storage.BranchWeight(conflictBranchID, NewBranchWeight).Consume(func(b *BranchWeight) {
 				if newWeight > b.Weight() {
-					b.SetWeight(newWeight)
+					a.setBranchWeight(b, newWeight)
 				}
 			})
 		}
@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/cockroachdb/errors"
+	"github.com/iotaledger/hive.go/events"
 	"github.com/iotaledger/hive.go/types"
 
 	"github.com/iotaledger/goshimmer/packages/ledgerstate"
@@ -15,9 +16,12 @@ import (
 // LedgerState is a Tangle component that wraps the components of the ledgerstate package and makes them available at a
 // "single point of contact".
 type LedgerState struct {
-	tangle    *Tangle
-	BranchDAG *ledgerstate.BranchDAG
-	UTXODAG   ledgerstate.IUTXODAG
+	tangle             *Tangle
+	BranchDAG          *ledgerstate.BranchDAG
+	UTXODAG            ledgerstate.IUTXODAG
+	ColorSupplyManager *ledgerstate.ColorSupplyManager
+	AliasStateHistory  *ledgerstate.AliasStateHistory
+	ConflictTimeline   *ledgerstate.ConflictTimeline
 
 	totalSupply uint64
 }
@@ -25,15 +29,49 @@ type LedgerState struct {
 // NewLedgerState is the constructor of the LedgerState component.
 func NewLedgerState(tangle *Tangle) (ledgerState *LedgerState) {
 	branchDAG := ledgerstate.NewBranchDAG(tangle.Options.Store, tangle.Options.CacheTimeProvider)
+	utxoDAG := ledgerstate.NewUTXODAG(tangle.Options.Store, tangle.Options.CacheTimeProvider, branchDAG)
 	return &LedgerState{
-		tangle:    tangle,
-		BranchDAG: branchDAG,
-		UTXODAG:   ledgerstate.NewUTXODAG(tangle.Options.Store, tangle.Options.CacheTimeProvider, branchDAG),
+		tangle:             tangle,
+		BranchDAG:          branchDAG,
+		UTXODAG:            utxoDAG,
+		ColorSupplyManager: ledgerstate.NewColorSupplyManager(tangle.Options.Store, tangle.Options.CacheTimeProvider, utxoDAG),
+		AliasStateHistory:  ledgerstate.NewAliasStateHistory(tangle.Options.Store, tangle.Options.CacheTimeProvider, utxoDAG),
+		ConflictTimeline:   ledgerstate.NewConflictTimeline(tangle.Options.Store, tangle.Options.CacheTimeProvider, branchDAG),
 	}
 }
 
+// Setup sets up the behavior of the component by making it attach to the relevant events of other components.
+func (l *LedgerState) Setup() {
+	l.UTXODAG.Events().TransactionConfirmed.Attach(events.NewClosure(func(transactionID ledgerstate.TransactionID) {
+		if _, err := l.ColorSupplyManager.ApplyTransaction(transactionID); err != nil {
+			l.tangle.Events.Error.Trigger(errors.Errorf("failed to update ColorSupply of confirmed Transaction with %s: %w", transactionID, err))
+		}
+	}))
+	l.UTXODAG.Events().TransactionBranchIDUpdated.Attach(events.NewClosure(l.ColorSupplyManager.UpdateTransactionBranch))
+	l.BranchDAG.Events.BranchRejected.Attach(events.NewClosure(func(branchDAGEvent *ledgerstate.BranchDAGEvent) {
+		defer branchDAGEvent.Release()
+		branchID := branchDAGEvent.Branch.ID()
+		if _, err := l.ColorSupplyManager.RevertBranch(branchID); err != nil {
+			l.tangle.Events.Error.Trigger(errors.Errorf("failed to revert ColorSupply of Transactions in rejected Branch with %s: %w", branchID, err))
+		}
+	}))
+
+	l.BranchDAG.Events.ConflictMemberAdded.Attach(events.NewClosure(l.ConflictTimeline.RecordBranchCreated))
+	l.BranchDAG.Events.BranchConfirmed.Attach(events.NewClosure(func(branchDAGEvent *ledgerstate.BranchDAGEvent) {
+		defer branchDAGEvent.Release()
+		l.ConflictTimeline.RecordDecision(branchDAGEvent.Branch.ID(), ledgerstate.Confirmed)
+	}))
+	l.BranchDAG.Events.BranchRejected.Attach(events.NewClosure(func(branchDAGEvent *ledgerstate.BranchDAGEvent) {
+		defer branchDAGEvent.Release()
+		l.ConflictTimeline.RecordDecision(branchDAGEvent.Branch.ID(), ledgerstate.Rejected)
+	}))
+}
+
 // Shutdown shuts down the LedgerState and persists its state.
 func (l *LedgerState) Shutdown() {
+	l.ColorSupplyManager.Shutdown()
+	l.AliasStateHistory.Shutdown()
+	l.ConflictTimeline.Shutdown()
 	l.UTXODAG.Shutdown()
 	l.BranchDAG.Shutdown()
 }
@@ -118,9 +156,26 @@ func (l *LedgerState) BookTransaction(transaction *ledgerstate.Transaction, mess
 		return
 	}
 
+	if targetBranch != ledgerstate.InvalidBranchID {
+		if err = l.AliasStateHistory.IndexTransaction(transaction.ID()); err != nil {
+			err = errors.Errorf("failed to index AliasOutputs of Transaction with %s: %w", transaction.ID(), err)
+			return
+		}
+	}
+
 	return
 }
 
+// AliasStateRecords returns the indexed versions of the alias chain with the given AliasAddress.
+func (l *LedgerState) AliasStateRecords(aliasAddress *ledgerstate.AliasAddress) ledgerstate.AliasStateRecords {
+	return l.AliasStateHistory.AliasStateRecords(aliasAddress)
+}
+
+// ConflictTimelineEntries returns the recorded steps of the resolution of the Conflict with the given ConflictID.
+func (l *LedgerState) ConflictTimelineEntries(conflictID ledgerstate.ConflictID) ledgerstate.ConflictTimelineEntries {
+	return l.ConflictTimeline.ConflictTimelineEntries(conflictID)
+}
+
 // ConflictSet returns the list of transactionIDs conflicting with the given transactionID.
 func (l *LedgerState) ConflictSet(transactionID ledgerstate.TransactionID) (conflictSet ledgerstate.TransactionIDs) {
 	conflictIDs := make(ledgerstate.ConflictIDs)
@@ -165,6 +220,8 @@ func (l *LedgerState) BranchID(transactionID ledgerstate.TransactionID) (branchI
 // LoadSnapshot creates a set of outputs in the UTXO-DAG, that are forming the genesis for future transactions.
 func (l *LedgerState) LoadSnapshot(snapshot *ledgerstate.Snapshot) (err error) {
 	l.UTXODAG.LoadSnapshot(snapshot)
+	l.ColorSupplyManager.LoadSnapshot(snapshot)
+	l.AliasStateHistory.LoadSnapshot(snapshot)
 	// add attachment link between txs from snapshot and the genesis message (EmptyMessageID).
 	for txID, record := range snapshot.Transactions {
 		fmt.Println("... Loading snapshot transaction: ", txID, "#outputs=", len(record.Essence.Outputs()), record.UnspentOutputs)
@@ -243,6 +300,11 @@ func (l *LedgerState) SnapshotUTXO() (snapshot *ledgerstate.Snapshot) {
 	return snapshot
 }
 
+// ColorSupply returns the ColorSupply of the given Color.
+func (l *LedgerState) ColorSupply(color ledgerstate.Color) *ledgerstate.CachedColorSupply {
+	return l.ColorSupplyManager.ColorSupply(color)
+}
+
 // ReturnTransaction returns a specific transaction.
 func (l *LedgerState) ReturnTransaction(transactionID ledgerstate.TransactionID) (transaction *ledgerstate.Transaction) {
 	return l.UTXODAG.Transaction(transactionID)
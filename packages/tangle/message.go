@@ -392,13 +392,14 @@ func MessageFromObjectStorage(key []byte, data []byte) (result objectstorage.Sto
 
 // VerifySignature verifies the signature of the message.
 func (m *Message) VerifySignature() bool {
-	msgBytes := m.Bytes()
-	signature := m.Signature()
+	return m.issuerPublicKey.VerifySignature(m.signedContent(), m.Signature())
+}
 
-	contentLength := len(msgBytes) - len(signature)
-	content := msgBytes[:contentLength]
+// signedContent returns the bytes of the Message that are covered by its signature.
+func (m *Message) signedContent() []byte {
+	msgBytes := m.Bytes()
 
-	return m.issuerPublicKey.VerifySignature(content, signature)
+	return msgBytes[:len(msgBytes)-len(m.Signature())]
 }
 
 // ID returns the id of the message which is made up of the content id and parent1/parent2 ids.
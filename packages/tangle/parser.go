@@ -12,6 +12,7 @@ import (
 	"github.com/iotaledger/hive.go/events"
 	"github.com/iotaledger/hive.go/typeutils"
 
+	"github.com/iotaledger/goshimmer/packages/batchverifier"
 	"github.com/iotaledger/goshimmer/packages/ledgerstate"
 	"github.com/iotaledger/goshimmer/packages/pow"
 )
@@ -252,8 +253,11 @@ type MessageFilter interface {
 
 // region MessageSignatureFilter ///////////////////////////////////////////////////////////////////////////////////////
 
-// MessageSignatureFilter filters messages based on whether their signatures are valid.
+// MessageSignatureFilter filters messages based on whether their signatures are valid. The signatures of messages that
+// are filtered concurrently (i.e. when messages arrive in bursts) are verified in batches.
 type MessageSignatureFilter struct {
+	verifier *batchverifier.ED25519Verifier
+
 	onAcceptCallback func(msg *Message, peer *peer.Peer)
 	onRejectCallback func(msg *Message, err error, peer *peer.Peer)
 
@@ -263,13 +267,15 @@ type MessageSignatureFilter struct {
 
 // NewMessageSignatureFilter creates a new message signature filter.
 func NewMessageSignatureFilter() *MessageSignatureFilter {
-	return &MessageSignatureFilter{}
+	return &MessageSignatureFilter{
+		verifier: batchverifier.NewED25519Verifier(batchverifier.DefaultMaxBatchSize),
+	}
 }
 
 // Filter filters up on the given bytes and peer and calls the acceptance callback
 // if the input passes or the rejection callback if the input is rejected.
 func (f *MessageSignatureFilter) Filter(msg *Message, peer *peer.Peer) {
-	if msg.VerifySignature() {
+	if f.verifier.Verify(msg.IssuerPublicKey(), msg.signedContent(), msg.Signature()) {
 		f.getAcceptCallback()(msg, peer)
 		return
 	}
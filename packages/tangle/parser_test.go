@@ -3,11 +3,14 @@ package tangle
 import (
 	"context"
 	"strconv"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/cockroachdb/errors"
 	"github.com/iotaledger/hive.go/autopeering/peer"
+	"github.com/iotaledger/hive.go/crypto/ed25519"
 	"github.com/iotaledger/hive.go/events"
 	"github.com/iotaledger/hive.go/identity"
 	"github.com/iotaledger/hive.go/marshalutil"
@@ -16,6 +19,7 @@ import (
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
+	"github.com/iotaledger/goshimmer/packages/batchverifier"
 	"github.com/iotaledger/goshimmer/packages/ledgerstate"
 	"github.com/iotaledger/goshimmer/packages/pow"
 	"github.com/iotaledger/goshimmer/packages/tangle/payload"
@@ -112,6 +116,86 @@ func Test_isMessageAndTransactionTimestampsValid(t *testing.T) {
 	})
 }
 
+func TestMessageSignatureFilter_Filter(t *testing.T) {
+	const messageCount = 200
+
+	filter := NewMessageSignatureFilter()
+	var acceptedCount, rejectedCount int32
+	filter.OnAccept(func(msg *Message, peer *peer.Peer) {
+		atomic.AddInt32(&acceptedCount, 1)
+	})
+	filter.OnReject(func(msg *Message, err error, peer *peer.Peer) {
+		assert.True(t, errors.Is(err, ErrInvalidSignature))
+		atomic.AddInt32(&rejectedCount, 1)
+	})
+
+	messages := make([]*Message, messageCount)
+	for i := range messages {
+		if i%10 == 0 {
+			messages[i] = newTestDataMessage("invalid" + strconv.Itoa(i))
+			continue
+		}
+		messages[i] = newTestSignedDataMessage(ed25519.GenerateKeyPair(), "valid"+strconv.Itoa(i))
+	}
+
+	var wg sync.WaitGroup
+	for _, msg := range messages {
+		wg.Add(1)
+		go func(msg *Message) {
+			defer wg.Done()
+			filter.Filter(msg, testPeer)
+		}(msg)
+	}
+	wg.Wait()
+
+	assert.EqualValues(t, messageCount-messageCount/10, atomic.LoadInt32(&acceptedCount))
+	assert.EqualValues(t, messageCount/10, atomic.LoadInt32(&rejectedCount))
+}
+
+func BenchmarkMessageSignatureFilter_Individually(b *testing.B) {
+	messages := newTestSignedDataMessages(batchverifier.DefaultMaxBatchSize)
+
+	b.SetParallelism(batchverifier.DefaultMaxBatchSize)
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for i := 0; pb.Next(); i++ {
+			messages[i%len(messages)].VerifySignature()
+		}
+	})
+}
+
+func BenchmarkMessageSignatureFilter_Batched(b *testing.B) {
+	messages := newTestSignedDataMessages(batchverifier.DefaultMaxBatchSize)
+	filter := NewMessageSignatureFilter()
+	filter.OnAccept(func(msg *Message, peer *peer.Peer) {})
+	filter.OnReject(func(msg *Message, err error, peer *peer.Peer) {})
+
+	b.SetParallelism(batchverifier.DefaultMaxBatchSize)
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for i := 0; pb.Next(); i++ {
+			filter.Filter(messages[i%len(messages)], testPeer)
+		}
+	})
+}
+
+func newTestSignedDataMessages(count int) (messages []*Message) {
+	messages = make([]*Message, count)
+	for i := range messages {
+		messages[i] = newTestSignedDataMessage(ed25519.GenerateKeyPair(), "Test"+strconv.Itoa(i))
+	}
+
+	return messages
+}
+
+func newTestSignedDataMessage(keyPair ed25519.KeyPair, payloadString string) *Message {
+	issuingTime := time.Now()
+	sequenceNumber := nextSequenceNumber()
+	unsignedMessage := NewMessage([]MessageID{EmptyMessageID}, []MessageID{}, issuingTime, keyPair.PublicKey, sequenceNumber, payload.NewGenericDataPayload([]byte(payloadString)), 0, ed25519.Signature{})
+
+	return NewMessage([]MessageID{EmptyMessageID}, []MessageID{}, issuingTime, keyPair.PublicKey, sequenceNumber, payload.NewGenericDataPayload([]byte(payloadString)), 0, keyPair.PrivateKey.Sign(unsignedMessage.signedContent()))
+}
+
 func TestPowFilter_Filter(t *testing.T) {
 	filter := NewPowFilter(testWorker, testDifficulty)
 
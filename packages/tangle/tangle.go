@@ -114,6 +114,7 @@ func (t *Tangle) Configure(options ...Option) {
 // Setup sets up the data flow by connecting the different components (by calling their corresponding Setup method).
 func (t *Tangle) Setup() {
 	t.Storage.Setup()
+	t.LedgerState.Setup()
 	t.Solidifier.Setup()
 	t.Requester.Setup()
 	t.FIFOScheduler.Setup()
@@ -4,7 +4,6 @@ import (
 	"container/list"
 	"context"
 	"fmt"
-	"math"
 	"math/rand"
 	"sync"
 	"time"
@@ -108,8 +107,8 @@ func (f *FPC) Events() vote.Events {
 }
 
 // Round enqueues new items, sets opinions on active vote contexts, finalizes them and then
-// queries for opinions.
-func (f *FPC) Round(random float64, delayedRoundStart ...time.Duration) error {
+// queries for opinions. The queries are bound to the given dRNG round, which is the same for all nodes.
+func (f *FPC) Round(random float64, round uint32, delayedRoundStart ...time.Duration) error {
 	start := time.Now()
 	// enqueue new voting contexts
 	f.enqueue()
@@ -136,7 +135,7 @@ func (f *FPC) Round(random float64, delayedRoundStart ...time.Duration) error {
 		delay = delayedRoundStart[0]
 	}
 	// query for opinions on the current vote contexts
-	queriedOpinions, err := f.queryOpinions(delay)
+	queriedOpinions, sampleComposition, err := f.queryOpinions(round, delay)
 	if err == nil {
 		f.lastRoundCompletedSuccessfully = true
 		// execute a round executed event
@@ -145,6 +144,8 @@ func (f *FPC) Round(random float64, delayedRoundStart ...time.Duration) error {
 			RandUsed:           random,
 			ActiveVoteContexts: f.ctxs,
 			QueriedOpinions:    queriedOpinions,
+			SamplingStrategy:   f.samplingStrategy().Name(),
+			SampleComposition:  sampleComposition,
 		}
 		// TODO: add possibility to check whether an event handler is registered
 		// in order to prevent the collection of the round stats data if not needed
@@ -207,34 +208,36 @@ func (f *FPC) finalizeOpinions() {
 	}
 }
 
-// queries the opinions of QuerySampleSize amount of OpinionGivers.
-func (f *FPC) queryOpinions(delayedRoundStart ...time.Duration) ([]opinion.QueriedOpinions, error) {
+// queries the opinions of QuerySampleSize amount of OpinionGivers in the given dRNG round.
+func (f *FPC) queryOpinions(round uint32, delayedRoundStart ...time.Duration) ([]opinion.QueriedOpinions, vote.SampleComposition, error) {
 	conflictIDs, timestampIDs := f.voteContextIDs()
 
 	// nothing to vote on
 	if len(conflictIDs) == 0 && len(timestampIDs) == 0 {
-		return nil, nil
+		return nil, vote.SampleComposition{}, nil
 	}
 
 	opinionGivers, err := f.opinionGiverFunc()
 	if err != nil {
-		return nil, err
+		return nil, vote.SampleComposition{}, err
 	}
 
 	// nobody to query
 	if len(opinionGivers) == 0 {
-		return nil, ErrNoOpinionGiversAvailable
+		return nil, vote.SampleComposition{}, ErrNoOpinionGiversAvailable
 	}
 
 	// select a random subset of opinion givers to query.
 	// if the same opinion giver is selected multiple times, we query it only once
 	// but use its opinion N selected times.
-	opinionGiversToQuery, totalOpinionGiversMana := ManaBasedSampling(opinionGivers, f.paras.MaxQuerySampleSize, f.paras.QuerySampleSize, f.opinionGiverRng)
+	opinionGiversToQuery := f.samplingStrategy().Sample(opinionGivers, f.paras.MaxQuerySampleSize, f.paras.QuerySampleSize, f.opinionGiverRng)
+	totalOpinionGiversMana := TotalMana(opinionGivers)
+	composition := sampleComposition(opinionGivers, opinionGiversToQuery, totalOpinionGiversMana)
 
 	// get own mana and calculate total mana
 	ownMana, err := f.ownWeightRetrieverFunc()
 	if err != nil {
-		return nil, err
+		return nil, vote.SampleComposition{}, err
 	}
 	totalMana := totalOpinionGiversMana + ownMana
 
@@ -252,7 +255,7 @@ func (f *FPC) queryOpinions(delayedRoundStart ...time.Duration) ([]opinion.Queri
 		go func(opinionGiverToQuery opinion.OpinionGiver, selectedCount int) {
 			defer wg.Done()
 
-			queryCtx, cancel := context.WithTimeout(context.Background(), f.paras.QueryTimeout)
+			queryCtx, cancel := context.WithTimeout(opinion.ContextWithRound(context.Background(), round), f.paras.QueryTimeout)
 			defer cancel()
 
 			// delayedRoundStart gives the time that has elapsed since the start of the current round.
@@ -263,7 +266,7 @@ func (f *FPC) queryOpinions(delayedRoundStart ...time.Duration) ([]opinion.Queri
 			// query (both statements and P2P)
 			opinions, err := opinionGiverToQuery.Query(queryCtx, conflictIDs, timestampIDs, delay)
 			if err != nil || len(opinions) != len(conflictIDs)+len(timestampIDs) {
-				// ignore opinions
+				// ignore opinions, e.g. of opinion givers whose replies failed verification
 				return
 			}
 
@@ -297,7 +300,7 @@ func (f *FPC) queryOpinions(delayedRoundStart ...time.Duration) ([]opinion.Queri
 
 	f.computeLikeProportion(voteMap, ownMana, totalMana)
 
-	return allQueriedOpinions, nil
+	return allQueriedOpinions, composition, nil
 }
 
 func (f *FPC) computeLikeProportion(voteMap map[string]opinion.Opinions, ownMana, totalMana float64) {
@@ -377,52 +380,17 @@ func (f *FPC) biasTowardsOwnOpinion(voteCtx *vote.Context) float64 {
 	return eta
 }
 
-// SetOpinionGiverRng sets random number generator in the FPC instance
-func (f *FPC) SetOpinionGiverRng(rng *rand.Rand) {
-	f.opinionGiverRng = rng
-}
-
-// ManaBasedSampling returns list of OpinionGivers to query, weighted by consensus mana and corresponding total mana value.
-// If mana not available, fallback to uniform sampling
-// weighted random sampling based on https://eli.thegreenplace.net/2010/01/22/weighted-random-generation-in-python/
-func ManaBasedSampling(opinionGivers []opinion.OpinionGiver, maxQuerySampleSize, querySampleSize int, rng *rand.Rand) (map[opinion.OpinionGiver]int, float64) {
-	totalConsensusMana := 0.0
-	totals := make([]float64, 0, len(opinionGivers))
-
-	for i := 0; i < len(opinionGivers); i++ {
-		totalConsensusMana += opinionGivers[i].Mana()
-		totals = append(totals, totalConsensusMana)
-	}
-
-	// check if total mana is almost zero
-
-	if math.Abs(totalConsensusMana) <= toleranceTotalMana {
-		// fallback to uniform sampling
-		return UniformSampling(opinionGivers, maxQuerySampleSize, querySampleSize, rng), 0
+// samplingStrategy returns the configured SamplingStrategy and falls back to mana based sampling.
+func (f *FPC) samplingStrategy() SamplingStrategy {
+	if f.paras.SamplingStrategy == nil {
+		return ManaBasedSamplingStrategy{}
 	}
-
-	opinionGiversToQuery := map[opinion.OpinionGiver]int{}
-	for i := 0; i < maxQuerySampleSize && len(opinionGiversToQuery) < querySampleSize; i++ {
-		rnd := rng.Float64() * totalConsensusMana
-		for idx, v := range totals {
-			if rnd < v {
-				selected := opinionGivers[idx]
-				opinionGiversToQuery[selected]++
-				break
-			}
-		}
-	}
-	return opinionGiversToQuery, totalConsensusMana
+	return f.paras.SamplingStrategy
 }
 
-// UniformSampling returns list of OpinionGivers to query, sampled uniformly
-func UniformSampling(opinionGivers []opinion.OpinionGiver, maxQuerySampleSize, querySampleSize int, rng *rand.Rand) map[opinion.OpinionGiver]int {
-	opinionGiversToQuery := map[opinion.OpinionGiver]int{}
-	for i := 0; i < querySampleSize; i++ {
-		selected := opinionGivers[rng.Intn(len(opinionGivers))]
-		opinionGiversToQuery[selected]++
-	}
-	return opinionGiversToQuery
+// SetOpinionGiverRng sets random number generator in the FPC instance
+func (f *FPC) SetOpinionGiverRng(rng *rand.Rand) {
+	f.opinionGiverRng = rng
 }
 
 // create a voteMap for the stored conflicts and timestamps
@@ -86,6 +86,7 @@ type opiniongivermock struct {
 	id            identity.ID
 	roundsReplies []opinion.Opinions
 	roundIndex    int
+	queriedRounds []uint32
 	mana          float64
 }
 
@@ -98,7 +99,8 @@ func (ogm *opiniongivermock) ID() identity.ID {
 	return ogm.id
 }
 
-func (ogm *opiniongivermock) Query(_ context.Context, _, _ []string, _ ...time.Duration) (opinion.Opinions, error) {
+func (ogm *opiniongivermock) Query(ctx context.Context, _, _ []string, _ ...time.Duration) (opinion.Opinions, error) {
+	ogm.queriedRounds = append(ogm.queriedRounds, opinion.RoundFromContext(ctx))
 	if ogm.roundIndex >= len(ogm.roundsReplies) {
 		return ogm.roundsReplies[len(ogm.roundsReplies)-1], nil
 	}
@@ -143,11 +145,13 @@ func TestFPCFinalizedEvent(t *testing.T) {
 
 	// do 5 rounds of FPC -> 5 because the last one finalizes the vote
 	for i := 0; i < 5; i++ {
-		assert.NoError(t, voter.Round(0.5))
+		assert.NoError(t, voter.Round(0.5, uint32(100+i)))
 	}
 
 	require.NotNil(t, finalizedOpinion, "finalized event should have been fired")
 	assert.Equal(t, opinion.Like, *finalizedOpinion, "the final opinion should have been 'Like'")
+	// the queries are bound to their dRNG round, the last round doesn't query as the vote is finalized
+	assert.Equal(t, []uint32{100, 101, 102, 103}, opinionGiverMock.queriedRounds)
 }
 
 func TestFPCFailedEvent(t *testing.T) {
@@ -179,7 +183,7 @@ func TestFPCFailedEvent(t *testing.T) {
 	assert.NoError(t, voter.Vote(id, vote.ConflictType, opinion.Like))
 
 	for i := 0; i < 4; i++ {
-		assert.NoError(t, voter.Round(0.5))
+		assert.NoError(t, voter.Round(0.5, uint32(i)))
 	}
 
 	require.NotNil(t, failedOpinion, "failed event should have been fired")
@@ -225,7 +229,7 @@ func TestFPCVotingMultipleOpinionGivers(t *testing.T) {
 
 		var roundsDone int
 		for finalOpinion == nil {
-			assert.NoError(t, voter.Round(0.7))
+			assert.NoError(t, voter.Round(0.7, uint32(roundsDone)))
 			roundsDone++
 		}
 
@@ -350,7 +354,7 @@ func TestFPCVotingMultipleOpinionGiversWithMana(t *testing.T) {
 
 		var roundsDone int
 		for finalOpinion == nil {
-			assert.NoError(t, voter.Round(0.7))
+			assert.NoError(t, voter.Round(0.7, uint32(roundsDone)))
 			roundsDone++
 		}
 
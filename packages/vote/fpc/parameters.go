@@ -30,6 +30,8 @@ type Parameters struct {
 	QueryTimeout time.Duration
 	// MinOpinionsReceived defines the minimum amount of opinions to receive in order to consider an FPC round valid.
 	MinOpinionsReceived int
+	// SamplingStrategy selects the opinion givers to query on each round.
+	SamplingStrategy SamplingStrategy
 }
 
 // DefaultParameters returns the default parameters used in FPC.
@@ -48,6 +50,7 @@ func DefaultParameters() *Parameters {
 		TotalRoundsCoolingOffPeriod:         0,
 		MaxRoundsPerVoteContext:             100,
 		QueryTimeout:                        1500 * time.Millisecond,
+		SamplingStrategy:                    ManaBasedSamplingStrategy{},
 	}
 
 	return p
@@ -0,0 +1,252 @@
+package fpc
+
+import (
+	"bytes"
+	"math"
+	"math/rand"
+	"sort"
+
+	"github.com/cockroachdb/errors"
+
+	"github.com/iotaledger/goshimmer/packages/vote"
+	"github.com/iotaledger/goshimmer/packages/vote/opinion"
+)
+
+const (
+	// ManaBasedSamplingName is the name of the mana weighted sampling with replacement.
+	ManaBasedSamplingName = "mana"
+	// ManaBasedSamplingWithoutReplacementName is the name of the mana weighted sampling without replacement.
+	ManaBasedSamplingWithoutReplacementName = "manaWithoutReplacement"
+	// UniformSamplingName is the name of the uniform sampling.
+	UniformSamplingName = "uniform"
+	// TopKSamplingName is the name of the sampling that queries the highest mana opinion givers plus a random tail.
+	TopKSamplingName = "topK"
+)
+
+// ErrInvalidSamplingStrategy is returned if an unknown or misconfigured sampling strategy is requested.
+var ErrInvalidSamplingStrategy = errors.New("invalid sampling strategy")
+
+// region SamplingStrategy /////////////////////////////////////////////////////////////////////////////////////////////
+
+// SamplingStrategy selects the opinion givers that are queried in a round.
+type SamplingStrategy interface {
+	// Name returns the name of the strategy.
+	Name() string
+	// Sample returns the opinion givers to query, together with how often each of them was selected.
+	Sample(opinionGivers []opinion.OpinionGiver, maxQuerySampleSize, querySampleSize int, rng *rand.Rand) map[opinion.OpinionGiver]int
+}
+
+// SamplingStrategyFromString returns the SamplingStrategy with the given name. The topK parameter is only used by the
+// top-k sampling.
+func SamplingStrategyFromString(name string, topK int) (SamplingStrategy, error) {
+	switch name {
+	case ManaBasedSamplingName:
+		return ManaBasedSamplingStrategy{}, nil
+	case ManaBasedSamplingWithoutReplacementName:
+		return ManaBasedSamplingWithoutReplacementStrategy{}, nil
+	case UniformSamplingName:
+		return UniformSamplingStrategy{}, nil
+	case TopKSamplingName:
+		if topK < 0 {
+			return nil, errors.Errorf("top-k sampling requires a non-negative k, got %d: %w", topK, ErrInvalidSamplingStrategy)
+		}
+		return TopKSamplingStrategy{TopK: topK}, nil
+	default:
+		return nil, errors.Errorf("failed to parse sampling strategy %s: %w", name, ErrInvalidSamplingStrategy)
+	}
+}
+
+// ManaBasedSamplingStrategy samples the opinion givers weighted by consensus mana with replacement, until
+// querySampleSize distinct opinion givers or maxQuerySampleSize selections are reached.
+type ManaBasedSamplingStrategy struct{}
+
+// Name returns the name of the strategy.
+func (ManaBasedSamplingStrategy) Name() string {
+	return ManaBasedSamplingName
+}
+
+// Sample returns the opinion givers to query, together with how often each of them was selected.
+func (ManaBasedSamplingStrategy) Sample(opinionGivers []opinion.OpinionGiver, maxQuerySampleSize, querySampleSize int, rng *rand.Rand) map[opinion.OpinionGiver]int {
+	opinionGiversToQuery, _ := ManaBasedSampling(opinionGivers, maxQuerySampleSize, querySampleSize, rng)
+	return opinionGiversToQuery
+}
+
+// ManaBasedSamplingWithoutReplacementStrategy samples querySampleSize distinct opinion givers weighted by consensus
+// mana, so that every opinion giver is counted at most once.
+type ManaBasedSamplingWithoutReplacementStrategy struct{}
+
+// Name returns the name of the strategy.
+func (ManaBasedSamplingWithoutReplacementStrategy) Name() string {
+	return ManaBasedSamplingWithoutReplacementName
+}
+
+// Sample returns the opinion givers to query, together with how often each of them was selected.
+func (ManaBasedSamplingWithoutReplacementStrategy) Sample(opinionGivers []opinion.OpinionGiver, _, querySampleSize int, rng *rand.Rand) map[opinion.OpinionGiver]int {
+	return ManaBasedSamplingWithoutReplacement(opinionGivers, querySampleSize, rng)
+}
+
+// UniformSamplingStrategy samples querySampleSize opinion givers uniformly with replacement, ignoring their mana.
+type UniformSamplingStrategy struct{}
+
+// Name returns the name of the strategy.
+func (UniformSamplingStrategy) Name() string {
+	return UniformSamplingName
+}
+
+// Sample returns the opinion givers to query, together with how often each of them was selected.
+func (UniformSamplingStrategy) Sample(opinionGivers []opinion.OpinionGiver, maxQuerySampleSize, querySampleSize int, rng *rand.Rand) map[opinion.OpinionGiver]int {
+	return UniformSampling(opinionGivers, maxQuerySampleSize, querySampleSize, rng)
+}
+
+// TopKSamplingStrategy always queries the TopK opinion givers with the highest consensus mana and fills the remaining
+// sample uniformly with the other opinion givers, so that an attacker can neither eclipse a node from the high mana
+// nodes nor predict the whole sample.
+type TopKSamplingStrategy struct {
+	TopK int
+}
+
+// Name returns the name of the strategy.
+func (TopKSamplingStrategy) Name() string {
+	return TopKSamplingName
+}
+
+// Sample returns the opinion givers to query, together with how often each of them was selected.
+func (t TopKSamplingStrategy) Sample(opinionGivers []opinion.OpinionGiver, maxQuerySampleSize, querySampleSize int, rng *rand.Rand) map[opinion.OpinionGiver]int {
+	sorted := make([]opinion.OpinionGiver, len(opinionGivers))
+	copy(sorted, opinionGivers)
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].Mana() != sorted[j].Mana() {
+			return sorted[i].Mana() > sorted[j].Mana()
+		}
+		return bytes.Compare(sorted[i].ID().Bytes(), sorted[j].ID().Bytes()) < 0
+	})
+
+	topK := t.TopK
+	if topK > querySampleSize {
+		topK = querySampleSize
+	}
+	if topK > len(sorted) {
+		topK = len(sorted)
+	}
+
+	opinionGiversToQuery := map[opinion.OpinionGiver]int{}
+	for _, opinionGiver := range sorted[:topK] {
+		opinionGiversToQuery[opinionGiver] = 1
+	}
+	if tail := sorted[topK:]; len(tail) > 0 && querySampleSize > topK {
+		for opinionGiver, selectedCount := range UniformSampling(tail, maxQuerySampleSize-topK, querySampleSize-topK, rng) {
+			opinionGiversToQuery[opinionGiver] = selectedCount
+		}
+	}
+	return opinionGiversToQuery
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region sampling functions ///////////////////////////////////////////////////////////////////////////////////////////
+
+// ManaBasedSampling returns list of OpinionGivers to query, weighted by consensus mana and corresponding total mana value.
+// If mana not available, fallback to uniform sampling
+// weighted random sampling based on https://eli.thegreenplace.net/2010/01/22/weighted-random-generation-in-python/
+func ManaBasedSampling(opinionGivers []opinion.OpinionGiver, maxQuerySampleSize, querySampleSize int, rng *rand.Rand) (map[opinion.OpinionGiver]int, float64) {
+	totalConsensusMana := 0.0
+	totals := make([]float64, 0, len(opinionGivers))
+
+	for i := 0; i < len(opinionGivers); i++ {
+		totalConsensusMana += opinionGivers[i].Mana()
+		totals = append(totals, totalConsensusMana)
+	}
+
+	// check if total mana is almost zero
+
+	if math.Abs(totalConsensusMana) <= toleranceTotalMana {
+		// fallback to uniform sampling
+		return UniformSampling(opinionGivers, maxQuerySampleSize, querySampleSize, rng), 0
+	}
+
+	opinionGiversToQuery := map[opinion.OpinionGiver]int{}
+	for i := 0; i < maxQuerySampleSize && len(opinionGiversToQuery) < querySampleSize; i++ {
+		rnd := rng.Float64() * totalConsensusMana
+		for idx, v := range totals {
+			if rnd < v {
+				selected := opinionGivers[idx]
+				opinionGiversToQuery[selected]++
+				break
+			}
+		}
+	}
+	return opinionGiversToQuery, totalConsensusMana
+}
+
+// UniformSampling returns list of OpinionGivers to query, sampled uniformly
+func UniformSampling(opinionGivers []opinion.OpinionGiver, maxQuerySampleSize, querySampleSize int, rng *rand.Rand) map[opinion.OpinionGiver]int {
+	opinionGiversToQuery := map[opinion.OpinionGiver]int{}
+	for i := 0; i < querySampleSize; i++ {
+		selected := opinionGivers[rng.Intn(len(opinionGivers))]
+		opinionGiversToQuery[selected]++
+	}
+	return opinionGiversToQuery
+}
+
+// ManaBasedSamplingWithoutReplacement returns querySampleSize distinct OpinionGivers to query, weighted by consensus
+// mana. If mana not available, fallback to uniform sampling without replacement.
+// weighted random sampling based on https://doi.org/10.1016/j.ipl.2005.11.003
+func ManaBasedSamplingWithoutReplacement(opinionGivers []opinion.OpinionGiver, querySampleSize int, rng *rand.Rand) map[opinion.OpinionGiver]int {
+	// every opinion giver gets the key u^(1/mana) and the ones with the largest keys are selected. The keys are compared
+	// in log-space, i.e. log(u)/mana, as u^(1/mana) rounds to 1 for large mana values.
+	keys := make([]float64, len(opinionGivers))
+	indices := make([]int, len(opinionGivers))
+	useMana := math.Abs(TotalMana(opinionGivers)) > toleranceTotalMana
+	for i, opinionGiver := range opinionGivers {
+		indices[i] = i
+		// u is drawn from (0,1] so that its logarithm is finite
+		u := 1 - rng.Float64()
+		switch {
+		case !useMana:
+			keys[i] = math.Log(u)
+		case opinionGiver.Mana() > 0:
+			keys[i] = math.Log(u) / opinionGiver.Mana()
+		default:
+			keys[i] = math.Inf(-1)
+		}
+	}
+	sort.Slice(indices, func(i, j int) bool {
+		return keys[indices[i]] > keys[indices[j]]
+	})
+
+	opinionGiversToQuery := map[opinion.OpinionGiver]int{}
+	for _, idx := range indices {
+		if len(opinionGiversToQuery) >= querySampleSize || math.IsInf(keys[idx], -1) {
+			break
+		}
+		opinionGiversToQuery[opinionGivers[idx]] = 1
+	}
+	return opinionGiversToQuery
+}
+
+// TotalMana returns the sum of the consensus mana of the given OpinionGivers.
+func TotalMana(opinionGivers []opinion.OpinionGiver) (totalMana float64) {
+	for _, opinionGiver := range opinionGivers {
+		totalMana += opinionGiver.Mana()
+	}
+	return totalMana
+}
+
+// sampleComposition describes the selected opinion givers.
+func sampleComposition(opinionGivers []opinion.OpinionGiver, opinionGiversToQuery map[opinion.OpinionGiver]int, totalMana float64) vote.SampleComposition {
+	composition := vote.SampleComposition{
+		OpinionGivers: len(opinionGivers),
+		Selected:      len(opinionGiversToQuery),
+	}
+	var selectedMana float64
+	for opinionGiver, selectedCount := range opinionGiversToQuery {
+		composition.Selections += selectedCount
+		selectedMana += opinionGiver.Mana()
+	}
+	if totalMana > toleranceTotalMana {
+		composition.SelectedManaShare = selectedMana / totalMana
+	}
+	return composition
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -0,0 +1,84 @@
+package fpc_test
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/cockroachdb/errors"
+	"github.com/iotaledger/hive.go/identity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/goshimmer/packages/vote/fpc"
+	"github.com/iotaledger/goshimmer/packages/vote/opinion"
+)
+
+func TestSamplingStrategyFromString(t *testing.T) {
+	for _, name := range []string{fpc.ManaBasedSamplingName, fpc.ManaBasedSamplingWithoutReplacementName, fpc.UniformSamplingName, fpc.TopKSamplingName} {
+		strategy, err := fpc.SamplingStrategyFromString(name, 5)
+		require.NoError(t, err)
+		assert.Equal(t, name, strategy.Name())
+	}
+
+	_, err := fpc.SamplingStrategyFromString("unknown", 5)
+	assert.True(t, errors.Is(err, fpc.ErrInvalidSamplingStrategy))
+	_, err = fpc.SamplingStrategyFromString(fpc.TopKSamplingName, -1)
+	assert.True(t, errors.Is(err, fpc.ErrInvalidSamplingStrategy))
+}
+
+func TestManaBasedSamplingWithoutReplacement(t *testing.T) {
+	// half of the opinion givers has no mana and must never be selected
+	opinionGivers := make([]opinion.OpinionGiver, 40)
+	for i := 0; i < len(opinionGivers); i++ {
+		opinionGivers[i] = &opiniongivermock{mana: float64(i % 2), id: identity.GenerateIdentity().ID()}
+	}
+
+	opinionGiversToQuery := fpc.ManaBasedSamplingWithoutReplacementStrategy{}.Sample(opinionGivers, 100, 10, rand.New(rand.NewSource(42)))
+	assert.Len(t, opinionGiversToQuery, 10)
+	for opinionGiver, selectedCount := range opinionGiversToQuery {
+		assert.Equal(t, 1, selectedCount)
+		assert.Equal(t, float64(1), opinionGiver.Mana())
+	}
+
+	// with fewer opinion givers with mana than the sample size, only those are selected
+	opinionGiversToQuery = fpc.ManaBasedSamplingWithoutReplacementStrategy{}.Sample(opinionGivers, 100, 30, rand.New(rand.NewSource(42)))
+	assert.Len(t, opinionGiversToQuery, 20)
+}
+
+func TestManaBasedSamplingWithoutReplacement_LargeMana(t *testing.T) {
+	// with mana in the order of the total supply the selection must still be proportional to the mana
+	richOpinionGiver := &opiniongivermock{mana: 1e15, id: identity.GenerateIdentity().ID()}
+	poorOpinionGiver := &opiniongivermock{mana: 1e14, id: identity.GenerateIdentity().ID()}
+	opinionGivers := []opinion.OpinionGiver{richOpinionGiver, poorOpinionGiver}
+
+	const samples = 100000
+	rng := rand.New(rand.NewSource(42))
+	richSelected := 0
+	for i := 0; i < samples; i++ {
+		opinionGiversToQuery := fpc.ManaBasedSamplingWithoutReplacementStrategy{}.Sample(opinionGivers, 100, 1, rng)
+		require.Len(t, opinionGiversToQuery, 1)
+		if opinionGiversToQuery[richOpinionGiver] == 1 {
+			richSelected++
+		}
+	}
+	assert.InDelta(t, 10.0/11.0, float64(richSelected)/samples, 0.005)
+}
+
+func TestTopKSampling(t *testing.T) {
+	opinionGivers := make([]opinion.OpinionGiver, 30)
+	for i := 0; i < len(opinionGivers); i++ {
+		opinionGivers[i] = &opiniongivermock{mana: float64(i), id: identity.GenerateIdentity().ID()}
+	}
+
+	opinionGiversToQuery := fpc.TopKSamplingStrategy{TopK: 5}.Sample(opinionGivers, 100, 21, rand.New(rand.NewSource(42)))
+	sumVotes := 0
+	for _, v := range opinionGiversToQuery {
+		sumVotes += v
+	}
+	assert.Equal(t, 21, sumVotes)
+
+	// the opinion givers with the highest mana are always selected exactly once
+	for i := 25; i < 30; i++ {
+		assert.Equal(t, 1, opinionGiversToQuery[opinionGivers[i]])
+	}
+}
@@ -0,0 +1,72 @@
+package simulation
+
+import (
+	"math"
+
+	"github.com/cockroachdb/errors"
+)
+
+const (
+	// UniformManaDistributionName is the name of the distribution that assigns the same mana to every node.
+	UniformManaDistributionName = "uniform"
+	// ZipfManaDistributionName is the name of the distribution that assigns mana following Zipf's law.
+	ZipfManaDistributionName = "zipf"
+)
+
+// ErrUnknownManaDistribution is returned if a mana distribution with an unknown name is requested.
+var ErrUnknownManaDistribution = errors.New("unknown mana distribution")
+
+// ManaDistribution returns the mana of every node, given the number of nodes.
+type ManaDistribution func(nodes int) []float64
+
+// UniformManaDistribution assigns the same mana to every node.
+func UniformManaDistribution(nodes int) []float64 {
+	manas := make([]float64, nodes)
+	for i := range manas {
+		manas[i] = 1
+	}
+	return manas
+}
+
+// ZipfManaDistribution returns a ManaDistribution that assigns the mana of the i-th node proportional to 1/i^s.
+func ZipfManaDistribution(s float64) ManaDistribution {
+	return func(nodes int) []float64 {
+		manas := make([]float64, nodes)
+		for i := range manas {
+			manas[i] = 1 / math.Pow(float64(i+1), s)
+		}
+		return manas
+	}
+}
+
+// ManaDistributionFromString returns the ManaDistribution with the given name. The zipfS parameter is only used by the
+// Zipf distribution.
+func ManaDistributionFromString(name string, zipfS float64) (ManaDistribution, error) {
+	switch name {
+	case UniformManaDistributionName:
+		return UniformManaDistribution, nil
+	case ZipfManaDistributionName:
+		return ZipfManaDistribution(zipfS), nil
+	default:
+		return nil, errors.Errorf("failed to parse mana distribution %s: %w", name, ErrUnknownManaDistribution)
+	}
+}
+
+// assignMana returns the mana of the honest nodes followed by the mana of the adversary nodes. The honest mana follows
+// the distribution, while the adversary nodes share the given fraction of the total mana equally.
+func assignMana(distribution ManaDistribution, honestNodes, adversaryNodes int, adversaryManaShare float64) []float64 {
+	manas := distribution(honestNodes)
+	var honestMana float64
+	for _, m := range manas {
+		honestMana += m
+	}
+
+	if adversaryNodes == 0 {
+		return manas
+	}
+	adversaryMana := honestMana * adversaryManaShare / (1 - adversaryManaShare)
+	for i := 0; i < adversaryNodes; i++ {
+		manas = append(manas, adversaryMana/float64(adversaryNodes))
+	}
+	return manas
+}
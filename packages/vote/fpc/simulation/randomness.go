@@ -0,0 +1,71 @@
+package simulation
+
+import (
+	"math/rand"
+
+	"github.com/cockroachdb/errors"
+)
+
+const (
+	// UniformRandomnessName is the name of the randomness source that draws uniformly random numbers.
+	UniformRandomnessName = "uniform"
+	// FixedRandomnessName is the name of the randomness source that always returns the same number.
+	FixedRandomnessName = "fixed"
+	// DRNGRandomnessName is the name of the randomness source that models a dRNG with missing beacons.
+	DRNGRandomnessName = "drng"
+)
+
+// ErrUnknownRandomnessSource is returned if a randomness source with an unknown name is requested.
+var ErrUnknownRandomnessSource = errors.New("unknown randomness source")
+
+// RandomnessSource provides the random number that all honest nodes use in a round.
+type RandomnessSource interface {
+	// Next returns the random number of the given round.
+	Next(round int, rng *rand.Rand) float64
+}
+
+// RandomnessSourceFromString returns the RandomnessSource with the given name. The defaultRandomness is used by the
+// fixed source and by the dRNG source if a beacon is missing, which happens with the given failureRate.
+func RandomnessSourceFromString(name string, defaultRandomness, failureRate float64) (RandomnessSource, error) {
+	switch name {
+	case UniformRandomnessName:
+		return UniformRandomness{}, nil
+	case FixedRandomnessName:
+		return FixedRandomness(defaultRandomness), nil
+	case DRNGRandomnessName:
+		return DRNGRandomness{FailureRate: failureRate, DefaultRandomness: defaultRandomness}, nil
+	default:
+		return nil, errors.Errorf("failed to parse randomness source %s: %w", name, ErrUnknownRandomnessSource)
+	}
+}
+
+// UniformRandomness draws a uniformly random number in every round, like a dRNG that never misses a beacon.
+type UniformRandomness struct{}
+
+// Next returns the random number of the given round.
+func (UniformRandomness) Next(_ int, rng *rand.Rand) float64 {
+	return rng.Float64()
+}
+
+// FixedRandomness returns the same number in every round, like a node that never receives a dRNG beacon.
+type FixedRandomness float64
+
+// Next returns the random number of the given round.
+func (f FixedRandomness) Next(int, *rand.Rand) float64 {
+	return float64(f)
+}
+
+// DRNGRandomness models a dRNG whose beacon is missing with the given rate, in which case the nodes fall back to the
+// default randomness.
+type DRNGRandomness struct {
+	FailureRate       float64
+	DefaultRandomness float64
+}
+
+// Next returns the random number of the given round.
+func (d DRNGRandomness) Next(_ int, rng *rand.Rand) float64 {
+	if rng.Float64() < d.FailureRate {
+		return d.DefaultRandomness
+	}
+	return rng.Float64()
+}
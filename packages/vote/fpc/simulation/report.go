@@ -0,0 +1,113 @@
+package simulation
+
+import (
+	"sort"
+)
+
+// region RunResult ////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// RunResult is the outcome of a single simulated vote.
+type RunResult struct {
+	// Unanimous is true if all honest nodes started with the same opinion.
+	Unanimous bool
+	// Agreement is true if all honest nodes that finalized, finalized the same opinion.
+	Agreement bool
+	// Integrity is true if no honest node finalized an opinion different from the unanimous initial opinion.
+	Integrity bool
+	// Terminated is true if all honest nodes finalized.
+	Terminated bool
+	// Rounds contains the number of rounds every finalized honest node needed.
+	Rounds []int
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region Report ///////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// Report aggregates the results of all runs of a Simulation.
+type Report struct {
+	// Runs is the number of simulated runs.
+	Runs int
+	// UnanimousRuns is the number of runs in which all honest nodes started with the same opinion.
+	UnanimousRuns int
+	// AgreementFailures is the number of runs in which honest nodes finalized different opinions.
+	AgreementFailures int
+	// IntegrityFailures is the number of unanimous runs in which an honest node finalized the other opinion.
+	IntegrityFailures int
+	// TerminationFailures is the number of runs in which at least one honest node did not finalize.
+	TerminationFailures int
+
+	rounds []int
+}
+
+// AgreementFailureRate returns the share of runs in which honest nodes finalized different opinions.
+func (r *Report) AgreementFailureRate() float64 {
+	return rate(r.AgreementFailures, r.Runs)
+}
+
+// IntegrityFailureRate returns the share of unanimous runs in which an honest node finalized the other opinion.
+func (r *Report) IntegrityFailureRate() float64 {
+	return rate(r.IntegrityFailures, r.UnanimousRuns)
+}
+
+// TerminationFailureRate returns the share of runs in which at least one honest node did not finalize.
+func (r *Report) TerminationFailureRate() float64 {
+	return rate(r.TerminationFailures, r.Runs)
+}
+
+// MeanRoundsToFinalization returns the mean number of rounds an honest node needed to finalize.
+func (r *Report) MeanRoundsToFinalization() float64 {
+	if len(r.rounds) == 0 {
+		return 0
+	}
+	var sum int
+	for _, rounds := range r.rounds {
+		sum += rounds
+	}
+	return float64(sum) / float64(len(r.rounds))
+}
+
+// RoundsToFinalizationQuantile returns the number of rounds within which the given share q of the honest nodes
+// finalized.
+func (r *Report) RoundsToFinalizationQuantile(q float64) int {
+	if len(r.rounds) == 0 {
+		return 0
+	}
+	index := int(q * float64(len(r.rounds)-1))
+	if index < 0 {
+		index = 0
+	}
+	if index >= len(r.rounds) {
+		index = len(r.rounds) - 1
+	}
+	return r.rounds[index]
+}
+
+// add adds the result of a run to the report.
+func (r *Report) add(result *RunResult) {
+	r.Runs++
+	if result.Unanimous {
+		r.UnanimousRuns++
+		if !result.Integrity {
+			r.IntegrityFailures++
+		}
+	}
+	if !result.Agreement {
+		r.AgreementFailures++
+	}
+	if !result.Terminated {
+		r.TerminationFailures++
+	}
+
+	r.rounds = append(r.rounds, result.Rounds...)
+	sort.Ints(r.rounds)
+}
+
+func rate(failures, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+	return float64(failures) / float64(total)
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
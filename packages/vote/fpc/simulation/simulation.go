@@ -0,0 +1,388 @@
+// Package simulation runs many FPC instances in a single process over an in-memory network of opinion givers, in order
+// to measure how the FPC parameters perform against adversaries, unreliable networks and missing randomness.
+package simulation
+
+import (
+	"context"
+	"math/rand"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/iotaledger/hive.go/events"
+	"github.com/iotaledger/hive.go/identity"
+
+	"github.com/iotaledger/goshimmer/packages/vote"
+	"github.com/iotaledger/goshimmer/packages/vote/fpc"
+	"github.com/iotaledger/goshimmer/packages/vote/opinion"
+)
+
+// voteID is the ID of the conflict that is voted on in every run.
+const voteID = "simulated-conflict"
+
+var (
+	// ErrInvalidParameter is returned if a simulation is created with invalid parameters.
+	ErrInvalidParameter = errors.New("invalid simulation parameter")
+	// ErrQueryDropped is returned by an opinion giver whose reply got lost.
+	ErrQueryDropped = errors.New("query dropped")
+)
+
+// region Parameters ///////////////////////////////////////////////////////////////////////////////////////////////////
+
+// Parameters defines the setup of a Simulation.
+type Parameters struct {
+	// HonestNodes is the number of nodes that follow the FPC protocol.
+	HonestNodes int
+	// AdversaryNodes is the number of nodes that reply according to the Strategy.
+	AdversaryNodes int
+	// AdversaryManaShare is the share of the total mana that is owned by the adversary nodes.
+	AdversaryManaShare float64
+	// Strategy defines how the adversary nodes reply to queries.
+	Strategy Strategy
+	// ManaDistribution defines the mana of the honest nodes.
+	ManaDistribution ManaDistribution
+	// InitialLikeProportion is the proportion of honest nodes that initially like the conflict.
+	InitialLikeProportion float64
+	// DropRate is the probability that a query is not answered.
+	DropRate float64
+	// DelayRate is the probability that the reply of an honest node is delayed, so that it still contains the
+	// opinion of the previous round.
+	DelayRate float64
+	// Randomness provides the random number of every round.
+	Randomness RandomnessSource
+	// FPC contains the parameters of the FPC instances of the honest nodes.
+	FPC *fpc.Parameters
+	// Runs is the number of independent votes that are simulated.
+	Runs int
+	// Seed is the seed of the random number generator.
+	Seed int64
+}
+
+// DefaultParameters returns the parameters of a network of 100 honest nodes with uniform mana and without adversary.
+func DefaultParameters() *Parameters {
+	return &Parameters{
+		HonestNodes:           100,
+		Strategy:              AlwaysDislikeStrategy{},
+		ManaDistribution:      UniformManaDistribution,
+		InitialLikeProportion: 0.5,
+		Randomness:            UniformRandomness{},
+		FPC:                   fpc.DefaultParameters(),
+		Runs:                  100,
+		Seed:                  time.Now().UnixNano(),
+	}
+}
+
+// validate returns an error if the parameters can not be simulated.
+func (p *Parameters) validate() error {
+	if p.HonestNodes < 2 {
+		return errors.Errorf("at least two honest nodes are required, got %d: %w", p.HonestNodes, ErrInvalidParameter)
+	}
+	if p.AdversaryNodes < 0 {
+		return errors.Errorf("adversary nodes must not be negative, got %d: %w", p.AdversaryNodes, ErrInvalidParameter)
+	}
+	if p.AdversaryNodes > 0 && (p.AdversaryManaShare <= 0 || p.AdversaryManaShare >= 1) {
+		return errors.Errorf("adversary mana share must be in (0,1), got %f: %w", p.AdversaryManaShare, ErrInvalidParameter)
+	}
+	if p.AdversaryNodes > 0 && p.Strategy == nil {
+		return errors.Errorf("adversary nodes require a strategy: %w", ErrInvalidParameter)
+	}
+	for name, probability := range map[string]float64{
+		"initial like proportion": p.InitialLikeProportion,
+		"drop rate":               p.DropRate,
+		"delay rate":              p.DelayRate,
+	} {
+		if probability < 0 || probability > 1 {
+			return errors.Errorf("%s must be in [0,1], got %f: %w", name, probability, ErrInvalidParameter)
+		}
+	}
+	if p.ManaDistribution == nil || p.Randomness == nil || p.FPC == nil {
+		return errors.Errorf("mana distribution, randomness source and FPC parameters are required: %w", ErrInvalidParameter)
+	}
+	if p.Runs <= 0 {
+		return errors.Errorf("runs must be positive, got %d: %w", p.Runs, ErrInvalidParameter)
+	}
+	return nil
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region Simulation ///////////////////////////////////////////////////////////////////////////////////////////////////
+
+// Simulation runs votes of honest FPC instances against adversary nodes.
+type Simulation struct {
+	parameters *Parameters
+	rng        *rand.Rand
+}
+
+// New creates a new Simulation with the given parameters.
+func New(parameters *Parameters) (*Simulation, error) {
+	if err := parameters.validate(); err != nil {
+		return nil, err
+	}
+	return &Simulation{
+		parameters: parameters,
+		rng:        rand.New(rand.NewSource(parameters.Seed)),
+	}, nil
+}
+
+// Run simulates all runs and returns the aggregated results.
+func (s *Simulation) Run() (*Report, error) {
+	report := &Report{}
+	for i := 0; i < s.parameters.Runs; i++ {
+		result, err := s.runOnce()
+		if err != nil {
+			return nil, errors.Errorf("failed to simulate run %d: %w", i, err)
+		}
+		report.add(result)
+	}
+	return report, nil
+}
+
+// runOnce simulates a single vote until all honest nodes finalized or failed.
+func (s *Simulation) runOnce() (*RunResult, error) {
+	n := newNetwork(s.parameters, s.rng)
+	for _, node := range n.honest {
+		if err := node.voter.Vote(voteID, vote.ConflictType, node.initialOpinion); err != nil {
+			return nil, err
+		}
+	}
+
+	// the vote context fails after MaxRoundsPerVoteContext, so every node is done at the latest one round later
+	for round := 0; round <= s.parameters.FPC.MaxRoundsPerVoteContext && !n.done(); round++ {
+		n.startRound(round)
+		random := s.parameters.Randomness.Next(round, s.rng)
+		for _, node := range n.honest {
+			if node.done {
+				continue
+			}
+			if err := node.voter.Round(random, uint32(round)); err != nil {
+				return nil, errors.Errorf("failed to execute round %d of node %d: %w", round, node.index, err)
+			}
+		}
+	}
+	return n.result(), nil
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region network //////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// network contains the nodes of a single run and the view of the honest opinions that queries are answered from.
+type network struct {
+	parameters *Parameters
+	manas      []float64
+	honest     []*honestNode
+
+	round    int
+	current  *RoundView
+	previous *RoundView
+}
+
+// honestNode is a node that runs an FPC instance.
+type honestNode struct {
+	index          int
+	voter          *fpc.FPC
+	initialOpinion opinion.Opinion
+
+	done         bool
+	finalized    bool
+	finalOpinion opinion.Opinion
+	finalRound   int
+}
+
+func newNetwork(parameters *Parameters, rng *rand.Rand) *network {
+	n := &network{
+		parameters: parameters,
+		manas:      assignMana(parameters.ManaDistribution, parameters.HonestNodes, parameters.AdversaryNodes, parameters.AdversaryManaShare),
+	}
+
+	// a random subset of the honest nodes initially likes the conflict
+	likers := int(parameters.InitialLikeProportion*float64(parameters.HonestNodes) + 0.5)
+	for position, index := range rng.Perm(parameters.HonestNodes) {
+		node := &honestNode{
+			index:          index,
+			initialOpinion: opinion.Dislike,
+		}
+		if position < likers {
+			node.initialOpinion = opinion.Like
+		}
+		n.honest = append(n.honest, node)
+	}
+
+	identities := make([]identity.ID, len(n.manas))
+	for i := range identities {
+		rng.Read(identities[i][:])
+	}
+	for _, node := range n.honest {
+		node := node
+		givers := make([]opinion.OpinionGiver, 0, len(n.manas)-1)
+		for responder := range n.manas {
+			if responder == node.index {
+				continue
+			}
+			givers = append(givers, &opinionGiver{
+				network:   n,
+				querier:   node.index,
+				responder: responder,
+				id:        identities[responder],
+				rng:       rand.New(rand.NewSource(rng.Int63())),
+			})
+		}
+
+		node.voter = fpc.New(func() ([]opinion.OpinionGiver, error) {
+			return givers, nil
+		}, func() (float64, error) {
+			return n.manas[node.index], nil
+		}, parameters.FPC)
+		node.voter.SetOpinionGiverRng(rand.New(rand.NewSource(rng.Int63())))
+		node.voter.Events().Finalized.Attach(events.NewClosure(func(ev *vote.OpinionEvent) {
+			node.finish(ev.Opinion, n.round, true)
+		}))
+		node.voter.Events().Failed.Attach(events.NewClosure(func(ev *vote.OpinionEvent) {
+			node.finish(ev.Opinion, n.round, false)
+		}))
+	}
+	return n
+}
+
+// startRound takes the view of the honest opinions that all queries of the round are answered from.
+func (n *network) startRound(round int) {
+	view := &RoundView{
+		Round:    round,
+		opinions: make(map[int]opinion.Opinion, len(n.honest)),
+	}
+	var likedMana, totalMana float64
+	for _, node := range n.honest {
+		o := node.opinion()
+		view.opinions[node.index] = o
+		totalMana += n.manas[node.index]
+		if o == opinion.Like {
+			likedMana += n.manas[node.index]
+		}
+	}
+	if totalMana > 0 {
+		view.likeProportion = likedMana / totalMana
+	}
+
+	n.round = round
+	n.previous = n.current
+	n.current = view
+}
+
+// done returns true if all honest nodes finalized or failed.
+func (n *network) done() bool {
+	for _, node := range n.honest {
+		if !node.done {
+			return false
+		}
+	}
+	return true
+}
+
+// isAdversary returns true if the node with the given index is an adversary.
+func (n *network) isAdversary(index int) bool {
+	return index >= n.parameters.HonestNodes
+}
+
+// result evaluates the outcome of the run.
+func (n *network) result() *RunResult {
+	result := &RunResult{
+		Unanimous:  true,
+		Agreement:  true,
+		Integrity:  true,
+		Terminated: true,
+	}
+	var finalOpinion opinion.Opinion
+	for _, node := range n.honest {
+		if node.initialOpinion != n.honest[0].initialOpinion {
+			result.Unanimous = false
+		}
+		if !node.finalized {
+			result.Terminated = false
+			continue
+		}
+		result.Rounds = append(result.Rounds, node.finalRound+1)
+		if finalOpinion == 0 {
+			finalOpinion = node.finalOpinion
+		}
+		if node.finalOpinion != finalOpinion {
+			result.Agreement = false
+		}
+	}
+	if result.Unanimous {
+		for _, node := range n.honest {
+			if node.finalized && node.finalOpinion != node.initialOpinion {
+				result.Integrity = false
+			}
+		}
+	}
+	return result
+}
+
+// opinion returns the opinion the node replies to queries.
+func (h *honestNode) opinion() opinion.Opinion {
+	if h.done {
+		return h.finalOpinion
+	}
+	o, err := h.voter.IntermediateOpinion(voteID)
+	if err != nil {
+		// the vote context is not active before the first round
+		return h.initialOpinion
+	}
+	return o
+}
+
+func (h *honestNode) finish(finalOpinion opinion.Opinion, round int, finalized bool) {
+	h.done = true
+	h.finalized = finalized
+	h.finalOpinion = finalOpinion
+	h.finalRound = round
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region opinionGiver /////////////////////////////////////////////////////////////////////////////////////////////////
+
+// opinionGiver is the view of a single querier on a single responder. Every opinionGiver has its own random number
+// generator, as FPC queries the opinion givers concurrently.
+type opinionGiver struct {
+	network   *network
+	querier   int
+	responder int
+	id        identity.ID
+	rng       *rand.Rand
+}
+
+// Query returns the opinion of the responder for every given ID.
+func (o *opinionGiver) Query(_ context.Context, conflictIDs, timestampIDs []string, _ ...time.Duration) (opinion.Opinions, error) {
+	if o.rng.Float64() < o.network.parameters.DropRate {
+		return nil, ErrQueryDropped
+	}
+
+	var reply opinion.Opinion
+	switch {
+	case o.network.isAdversary(o.responder):
+		reply = o.network.parameters.Strategy.Opinion(o.network.current, o.querier, o.rng)
+	case o.network.previous != nil && o.rng.Float64() < o.network.parameters.DelayRate:
+		reply = o.network.previous.Opinion(o.responder)
+	default:
+		reply = o.network.current.Opinion(o.responder)
+	}
+
+	opinions := make(opinion.Opinions, len(conflictIDs)+len(timestampIDs))
+	for i := range opinions {
+		opinions[i] = reply
+	}
+	return opinions, nil
+}
+
+// ID returns the identifier of the responder.
+func (o *opinionGiver) ID() identity.ID {
+	return o.id
+}
+
+// Mana returns the consensus mana of the responder.
+func (o *opinionGiver) Mana() float64 {
+	return o.network.manas[o.responder]
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -0,0 +1,105 @@
+package simulation
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/cockroachdb/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/goshimmer/packages/vote/opinion"
+)
+
+func TestSimulation_Honest(t *testing.T) {
+	params := DefaultParameters()
+	params.HonestNodes = 20
+	params.InitialLikeProportion = 1
+	params.Runs = 5
+	params.Seed = 42
+
+	simulation, err := New(params)
+	require.NoError(t, err)
+	report, err := simulation.Run()
+	require.NoError(t, err)
+
+	assert.Equal(t, 5, report.Runs)
+	assert.Equal(t, 5, report.UnanimousRuns)
+	assert.Zero(t, report.AgreementFailureRate())
+	assert.Zero(t, report.IntegrityFailureRate())
+	assert.Zero(t, report.TerminationFailureRate())
+	assert.GreaterOrEqual(t, report.MeanRoundsToFinalization(), float64(params.FPC.TotalRoundsFinalization))
+}
+
+func TestSimulation_Adversary(t *testing.T) {
+	for _, name := range []string{AlwaysDislikeStrategyName, RandomStrategyName, CautiousStrategyName, SplittingStrategyName} {
+		strategy, err := StrategyFromString(name)
+		require.NoError(t, err)
+
+		params := DefaultParameters()
+		params.HonestNodes = 15
+		params.AdversaryNodes = 5
+		params.AdversaryManaShare = 0.1
+		params.Strategy = strategy
+		params.DropRate = 0.1
+		params.DelayRate = 0.1
+		params.Randomness = DRNGRandomness{FailureRate: 0.2, DefaultRandomness: 0.5}
+		params.Runs = 2
+		params.Seed = 42
+
+		simulation, err := New(params)
+		require.NoError(t, err)
+		report, err := simulation.Run()
+		require.NoError(t, err, name)
+		assert.Equal(t, 2, report.Runs, name)
+	}
+}
+
+func TestNew_InvalidParameters(t *testing.T) {
+	params := DefaultParameters()
+	params.AdversaryNodes = 1
+	params.AdversaryManaShare = 1.5
+	_, err := New(params)
+	assert.True(t, errors.Is(err, ErrInvalidParameter))
+
+	params = DefaultParameters()
+	params.DropRate = -0.1
+	_, err = New(params)
+	assert.True(t, errors.Is(err, ErrInvalidParameter))
+}
+
+func TestFromString(t *testing.T) {
+	_, err := StrategyFromString("unknown")
+	assert.True(t, errors.Is(err, ErrUnknownStrategy))
+	_, err = ManaDistributionFromString("unknown", 1)
+	assert.True(t, errors.Is(err, ErrUnknownManaDistribution))
+	_, err = RandomnessSourceFromString("unknown", 0.5, 0)
+	assert.True(t, errors.Is(err, ErrUnknownRandomnessSource))
+
+	randomness, err := RandomnessSourceFromString(FixedRandomnessName, 0.3, 0)
+	require.NoError(t, err)
+	assert.Equal(t, 0.3, randomness.Next(0, rand.New(rand.NewSource(0))))
+}
+
+func TestAssignMana(t *testing.T) {
+	manas := assignMana(UniformManaDistribution, 3, 2, 0.25)
+	require.Len(t, manas, 5)
+	assert.Equal(t, []float64{1, 1, 1, 0.5, 0.5}, manas)
+
+	zipf, err := ManaDistributionFromString(ZipfManaDistributionName, 1)
+	require.NoError(t, err)
+	assert.Equal(t, []float64{1, 0.5}, zipf(2))
+}
+
+func TestSplittingStrategy(t *testing.T) {
+	view := &RoundView{
+		opinions:       map[int]opinion.Opinion{0: opinion.Like, 1: opinion.Dislike},
+		likeProportion: 0.5,
+	}
+	strategy := SplittingStrategy{}
+	assert.Equal(t, opinion.Like, strategy.Opinion(view, 0, nil))
+	assert.Equal(t, opinion.Dislike, strategy.Opinion(view, 1, nil))
+
+	view.likeProportion = 0.9
+	assert.Equal(t, opinion.Dislike, strategy.Opinion(view, 0, nil))
+}
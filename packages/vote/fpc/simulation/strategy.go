@@ -0,0 +1,154 @@
+package simulation
+
+import (
+	"math/rand"
+
+	"github.com/cockroachdb/errors"
+
+	"github.com/iotaledger/goshimmer/packages/vote/opinion"
+)
+
+const (
+	// AlwaysDislikeStrategyName is the name of the AlwaysDislikeStrategy.
+	AlwaysDislikeStrategyName = "dislike"
+	// RandomStrategyName is the name of the RandomStrategy.
+	RandomStrategyName = "random"
+	// CautiousStrategyName is the name of the CautiousStrategy.
+	CautiousStrategyName = "cautious"
+	// SplittingStrategyName is the name of the SplittingStrategy.
+	SplittingStrategyName = "splitting"
+
+	// splittingBand is the distance from the threshold within which the SplittingStrategy keeps the honest nodes split.
+	splittingBand = 0.2
+)
+
+// ErrUnknownStrategy is returned if an adversary strategy with an unknown name is requested.
+var ErrUnknownStrategy = errors.New("unknown adversary strategy")
+
+// region RoundView ////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// RoundView is the knowledge an adversary has about the honest nodes at the start of a round.
+type RoundView struct {
+	// Round is the number of the round, starting at 0.
+	Round int
+	// opinions contains the opinions of the honest nodes, indexed by node.
+	opinions map[int]opinion.Opinion
+	// likeProportion is the mana weighted proportion of honest nodes that like the vote object.
+	likeProportion float64
+}
+
+// Opinion returns the opinion of the given honest node at the start of the round.
+func (r *RoundView) Opinion(node int) opinion.Opinion {
+	o, exists := r.opinions[node]
+	if !exists {
+		return opinion.Unknown
+	}
+	return o
+}
+
+// LikeProportion returns the mana weighted proportion of honest nodes that like the vote object.
+func (r *RoundView) LikeProportion() float64 {
+	return r.likeProportion
+}
+
+// MinorityOpinion returns the opinion that is held by less than half of the honest mana.
+func (r *RoundView) MinorityOpinion() opinion.Opinion {
+	if r.likeProportion >= 0.5 {
+		return opinion.Dislike
+	}
+	return opinion.Like
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region Strategy /////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// Strategy defines how an adversary node replies to the queries of honest nodes.
+type Strategy interface {
+	// Name returns the name of the strategy.
+	Name() string
+	// Opinion returns the opinion the adversary replies to the given querier.
+	Opinion(view *RoundView, querier int, rng *rand.Rand) opinion.Opinion
+}
+
+// StrategyFromString returns the Strategy with the given name.
+func StrategyFromString(name string) (Strategy, error) {
+	switch name {
+	case AlwaysDislikeStrategyName:
+		return AlwaysDislikeStrategy{}, nil
+	case RandomStrategyName:
+		return RandomStrategy{}, nil
+	case CautiousStrategyName:
+		return CautiousStrategy{}, nil
+	case SplittingStrategyName:
+		return SplittingStrategy{}, nil
+	default:
+		return nil, errors.Errorf("failed to parse strategy %s: %w", name, ErrUnknownStrategy)
+	}
+}
+
+// AlwaysDislikeStrategy dislikes everything, no matter what the honest nodes think.
+type AlwaysDislikeStrategy struct{}
+
+// Name returns the name of the strategy.
+func (AlwaysDislikeStrategy) Name() string {
+	return AlwaysDislikeStrategyName
+}
+
+// Opinion returns the opinion the adversary replies to the given querier.
+func (AlwaysDislikeStrategy) Opinion(*RoundView, int, *rand.Rand) opinion.Opinion {
+	return opinion.Dislike
+}
+
+// RandomStrategy replies with a random opinion to every query.
+type RandomStrategy struct{}
+
+// Name returns the name of the strategy.
+func (RandomStrategy) Name() string {
+	return RandomStrategyName
+}
+
+// Opinion returns the opinion the adversary replies to the given querier.
+func (RandomStrategy) Opinion(_ *RoundView, _ int, rng *rand.Rand) opinion.Opinion {
+	if rng.Intn(2) == 0 {
+		return opinion.Like
+	}
+	return opinion.Dislike
+}
+
+// CautiousStrategy replies the same opinion to all queriers of a round. It always picks the opinion of the honest
+// minority to drag the mean opinion towards the threshold.
+type CautiousStrategy struct{}
+
+// Name returns the name of the strategy.
+func (CautiousStrategy) Name() string {
+	return CautiousStrategyName
+}
+
+// Opinion returns the opinion the adversary replies to the given querier.
+func (CautiousStrategy) Opinion(view *RoundView, _ int, _ *rand.Rand) opinion.Opinion {
+	return view.MinorityOpinion()
+}
+
+// SplittingStrategy replies different opinions to different queriers (berserk adversary). As long as the observed mean
+// opinion is close to the threshold, it confirms every querier in its own opinion to keep the honest nodes split.
+// Otherwise, it behaves like the CautiousStrategy to drag the mean back towards the threshold.
+type SplittingStrategy struct{}
+
+// Name returns the name of the strategy.
+func (SplittingStrategy) Name() string {
+	return SplittingStrategyName
+}
+
+// Opinion returns the opinion the adversary replies to the given querier.
+func (SplittingStrategy) Opinion(view *RoundView, querier int, _ *rand.Rand) opinion.Opinion {
+	if view.likeProportion < 0.5-splittingBand || view.likeProportion > 0.5+splittingBand {
+		return view.MinorityOpinion()
+	}
+	if querierOpinion := view.Opinion(querier); querierOpinion != opinion.Unknown {
+		return querierOpinion
+	}
+	return view.MinorityOpinion()
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -5,7 +5,10 @@ import (
 	"net"
 
 	"github.com/iotaledger/hive.go/events"
+	"github.com/iotaledger/hive.go/identity"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/proto"
 
 	"github.com/iotaledger/goshimmer/packages/metrics"
@@ -17,13 +20,19 @@ import (
 // If there's no opinion, the function should return Unknown.
 type OpinionRetriever func(id string, objectType vote.ObjectType) opinion.Opinion
 
-// New creates a new VoterServer.
-func New(voter vote.Voter, opnRetriever OpinionRetriever, bindAddr string, netRxEvent, netTxEvent, queryReceivedEvent *events.Event) *VoterServer {
+// RoundRetriever retrieves the latest dRNG round known to the node, which binds the queries to a network-wide round.
+type RoundRetriever func() uint32
+
+// New creates a new VoterServer which signs its replies with the given local identity and only answers queries of the
+// current dRNG round given by the round retriever. The passed in server options can be used to e.g. enable TLS.
+func New(voter vote.Voter, opnRetriever OpinionRetriever, roundRetriever RoundRetriever, bindAddr string, localIdentity *identity.LocalIdentity, netRxEvent, netTxEvent, queryReceivedEvent *events.Event, opts ...grpc.ServerOption) *VoterServer {
 	return &VoterServer{
 		voter:              voter,
 		opnRetriever:       opnRetriever,
+		roundRetriever:     roundRetriever,
 		bindAddr:           bindAddr,
-		grpcServer:         grpc.NewServer(),
+		localIdentity:      localIdentity,
+		grpcServer:         grpc.NewServer(opts...),
 		netRxEvent:         netRxEvent,
 		netTxEvent:         netTxEvent,
 		queryReceivedEvent: queryReceivedEvent,
@@ -34,7 +43,9 @@ func New(voter vote.Voter, opnRetriever OpinionRetriever, bindAddr string, netRx
 type VoterServer struct {
 	voter              vote.Voter
 	opnRetriever       OpinionRetriever
+	roundRetriever     RoundRetriever
 	bindAddr           string
+	localIdentity      *identity.LocalIdentity
 	grpcServer         *grpc.Server
 	netRxEvent         *events.Event
 	netTxEvent         *events.Event
@@ -42,10 +53,22 @@ type VoterServer struct {
 	UnimplementedVoterQueryServer
 }
 
-// Opinion replies the query request with an opinion and triggers the events.
+// Opinion replies the query request with a signed opinion and triggers the events.
 func (vs *VoterServer) Opinion(ctx context.Context, req *QueryRequest) (*QueryReply, error) {
+	if req.Version != ProtocolVersion {
+		return nil, status.Errorf(codes.FailedPrecondition, "%s: %d", ErrUnsupportedVersion, req.Version)
+	}
+	if len(req.Nonce) != NonceSize {
+		return nil, status.Error(codes.InvalidArgument, ErrInvalidNonce.Error())
+	}
+	if currentRound := vs.roundRetriever(); !isCurrentRound(req.Round, currentRound) {
+		return nil, status.Errorf(codes.FailedPrecondition, "%s: %d instead of %d", ErrInvalidRound, req.Round, currentRound)
+	}
+
 	reply := &QueryReply{
 		Opinion: make([]int32, len(req.ConflictIDs)+len(req.TimestampIDs)),
+		Version: ProtocolVersion,
+		Round:   req.Round,
 	}
 	for i, id := range req.ConflictIDs {
 		// check whether there's an ongoing vote
@@ -65,6 +88,7 @@ func (vs *VoterServer) Opinion(ctx context.Context, req *QueryRequest) (*QueryRe
 		}
 		reply.Opinion[i+len(req.ConflictIDs)] = int32(vs.opnRetriever(id, vote.TimestampType))
 	}
+	SignReply(vs.localIdentity, req, reply)
 
 	if vs.netRxEvent != nil {
 		vs.netRxEvent.Trigger(uint64(proto.Size(req)))
@@ -79,6 +103,15 @@ func (vs *VoterServer) Opinion(ctx context.Context, req *QueryRequest) (*QueryRe
 	return reply, nil
 }
 
+// isCurrentRound checks whether the queried round is at most MaxRoundDifference rounds away from the current round, as
+// the nodes receive the beacon that starts a round at slightly different times.
+func isCurrentRound(round, currentRound uint32) bool {
+	if round > currentRound {
+		return round-currentRound <= MaxRoundDifference
+	}
+	return currentRound-round <= MaxRoundDifference
+}
+
 // Run starts the voting server.
 func (vs *VoterServer) Run() error {
 	listener, err := net.Listen("tcp", vs.bindAddr)
@@ -0,0 +1,154 @@
+package net_test
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/iotaledger/hive.go/crypto/ed25519"
+	"github.com/iotaledger/hive.go/identity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/status"
+
+	"github.com/iotaledger/goshimmer/packages/vote"
+	"github.com/iotaledger/goshimmer/packages/vote/fpc"
+	votenet "github.com/iotaledger/goshimmer/packages/vote/net"
+	"github.com/iotaledger/goshimmer/packages/vote/opinion"
+)
+
+func opinionRetriever(id string, _ vote.ObjectType) opinion.Opinion {
+	if id == "liked" {
+		return opinion.Like
+	}
+	return opinion.Dislike
+}
+
+func currentRound(round uint32) votenet.RoundRetriever {
+	return func() uint32 { return round }
+}
+
+func newLocalIdentity(t *testing.T) (*identity.LocalIdentity, ed25519.PrivateKey) {
+	publicKey, privateKey, err := ed25519.GenerateKey()
+	require.NoError(t, err)
+	return identity.NewLocalIdentity(publicKey, privateKey), privateKey
+}
+
+func TestVerifyReply(t *testing.T) {
+	localIdentity, _ := newLocalIdentity(t)
+	server := votenet.New(fpc.New(nil, nil), opinionRetriever, currentRound(3), "", localIdentity, nil, nil, nil)
+
+	req, err := votenet.NewQueryRequest(3, []string{"liked"}, []string{"disliked"})
+	require.NoError(t, err)
+	reply, err := server.Opinion(context.Background(), req)
+	require.NoError(t, err)
+	assert.Equal(t, []int32{int32(opinion.Like), int32(opinion.Dislike)}, reply.Opinion)
+	assert.NoError(t, votenet.VerifyReply(localIdentity.PublicKey(), req, reply))
+
+	// the reply was signed by a different node
+	assert.ErrorIs(t, votenet.VerifyReply(identity.GenerateIdentity().PublicKey(), req, reply), votenet.ErrInvalidSignature)
+
+	// the reply was tampered with
+	reply.Opinion[1] = int32(opinion.Like)
+	assert.ErrorIs(t, votenet.VerifyReply(localIdentity.PublicKey(), req, reply), votenet.ErrInvalidSignature)
+	reply.Opinion[1] = int32(opinion.Dislike)
+
+	// the reply is replayed for another query of the same round
+	otherReq, err := votenet.NewQueryRequest(3, []string{"liked"}, []string{"disliked"})
+	require.NoError(t, err)
+	assert.ErrorIs(t, votenet.VerifyReply(localIdentity.PublicKey(), otherReq, reply), votenet.ErrInvalidSignature)
+
+	// the reply is replayed in another round
+	otherReq.Round = 4
+	assert.ErrorIs(t, votenet.VerifyReply(localIdentity.PublicKey(), otherReq, reply), votenet.ErrRoundMismatch)
+
+	// queries with an unsupported version are rejected
+	req.Version = votenet.ProtocolVersion + 1
+	_, err = server.Opinion(context.Background(), req)
+	assert.Error(t, err)
+}
+
+func TestVoterServer_Round(t *testing.T) {
+	localIdentity, _ := newLocalIdentity(t)
+	server := votenet.New(fpc.New(nil, nil), opinionRetriever, currentRound(10), "", localIdentity, nil, nil, nil)
+
+	query := func(round uint32) error {
+		req, err := votenet.NewQueryRequest(round, []string{"liked"}, nil)
+		require.NoError(t, err)
+		_, err = server.Opinion(context.Background(), req)
+		return err
+	}
+
+	// queries of the current round and its neighbors are answered
+	assert.NoError(t, query(9))
+	assert.NoError(t, query(10))
+	assert.NoError(t, query(11))
+
+	// queries of other rounds are rejected
+	for _, round := range []uint32{0, 8, 12, 100} {
+		err := query(round)
+		require.Error(t, err)
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	}
+}
+
+func TestVoterServer_TLS(t *testing.T) {
+	localIdentity, privateKey := newLocalIdentity(t)
+	address := startTLSServer(t, localIdentity, privateKey)
+
+	req, reply, err := queryTLS(t, address, localIdentity.PublicKey())
+	require.NoError(t, err)
+	assert.NoError(t, votenet.VerifyReply(localIdentity.PublicKey(), req, reply))
+
+	// the certificate of the server does not belong to the expected node
+	_, _, err = queryTLS(t, address, identity.GenerateIdentity().PublicKey())
+	assert.Error(t, err)
+}
+
+func TestVoterServer_TLSWrongServerKey(t *testing.T) {
+	localIdentity, _ := newLocalIdentity(t)
+	// the server presents a certificate of a key other than its node identity
+	_, impostorKey := newLocalIdentity(t)
+	address := startTLSServer(t, localIdentity, impostorKey)
+
+	_, reply, err := queryTLS(t, address, localIdentity.PublicKey())
+	require.Error(t, err)
+	assert.Nil(t, reply)
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+	assert.Contains(t, err.Error(), votenet.ErrUnexpectedCertificate.Error())
+}
+
+// startTLSServer starts a VoterServer of the given identity that uses a certificate of the given private key and
+// returns its address.
+func startTLSServer(t *testing.T, localIdentity *identity.LocalIdentity, privateKey ed25519.PrivateKey) string {
+	serverTLSConfig, err := votenet.NewServerTLSConfig(privateKey)
+	require.NoError(t, err)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	grpcServer := grpc.NewServer(grpc.Creds(credentials.NewTLS(serverTLSConfig)))
+	votenet.RegisterVoterQueryServer(grpcServer, votenet.New(fpc.New(nil, nil), opinionRetriever, currentRound(1), "", localIdentity, nil, nil, nil))
+	go func() { _ = grpcServer.Serve(listener) }()
+	t.Cleanup(grpcServer.Stop)
+
+	return listener.Addr().String()
+}
+
+// queryTLS queries the VoterServer at the given address and expects its certificate to use the given public key.
+func queryTLS(t *testing.T, address string, publicKey ed25519.PublicKey) (*votenet.QueryRequest, *votenet.QueryReply, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	conn, err := grpc.DialContext(ctx, address, grpc.WithTransportCredentials(credentials.NewTLS(votenet.NewClientTLSConfig(publicKey))))
+	require.NoError(t, err)
+	defer conn.Close()
+
+	req, err := votenet.NewQueryRequest(1, []string{"liked"}, nil)
+	require.NoError(t, err)
+	reply, err := votenet.NewVoterQueryClient(conn).Opinion(ctx, req)
+	return req, reply, err
+}
@@ -0,0 +1,114 @@
+package net
+
+import (
+	"crypto/rand"
+
+	"github.com/cockroachdb/errors"
+	"github.com/iotaledger/hive.go/crypto/ed25519"
+	"github.com/iotaledger/hive.go/identity"
+	"github.com/iotaledger/hive.go/marshalutil"
+)
+
+const (
+	// ProtocolVersion is the version of the FPC query protocol.
+	ProtocolVersion uint32 = 1
+
+	// NonceSize is the size of the random nonce that binds a reply to its query.
+	NonceSize = 32
+
+	// MaxRoundDifference is the maximum number of dRNG rounds by which the round of a query may differ from the current
+	// round of the queried node.
+	MaxRoundDifference uint32 = 1
+)
+
+// replySignaturePrefix is prepended to the signed bytes of a reply, so that a querier can not obtain a signature of the
+// node identity over arbitrary data that is valid in any other context.
+var replySignaturePrefix = []byte("goshimmer fpc query reply:")
+
+var (
+	// ErrUnsupportedVersion is returned if a query or reply uses an unsupported protocol version.
+	ErrUnsupportedVersion = errors.New("unsupported FPC query protocol version")
+	// ErrInvalidNonce is returned if a query does not contain a nonce of the expected size.
+	ErrInvalidNonce = errors.New("invalid query nonce")
+	// ErrInvalidRound is returned if a query does not belong to the current dRNG round of the queried node.
+	ErrInvalidRound = errors.New("query round is not the current dRNG round")
+	// ErrRoundMismatch is returned if a reply does not belong to the round of the query.
+	ErrRoundMismatch = errors.New("reply round does not match the queried round")
+	// ErrOpinionCountMismatch is returned if a reply does not contain an opinion for every queried ID.
+	ErrOpinionCountMismatch = errors.New("reply does not contain an opinion for every queried ID")
+	// ErrInvalidSignature is returned if the signature of a reply is invalid.
+	ErrInvalidSignature = errors.New("invalid reply signature")
+)
+
+// NewQueryRequest creates a new QueryRequest for the given dRNG round with a random nonce.
+func NewQueryRequest(round uint32, conflictIDs, timestampIDs []string) (*QueryRequest, error) {
+	nonce := make([]byte, NonceSize)
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, errors.Errorf("failed to generate nonce: %w", err)
+	}
+
+	return &QueryRequest{
+		ConflictIDs:  conflictIDs,
+		TimestampIDs: timestampIDs,
+		Version:      ProtocolVersion,
+		Round:        round,
+		Nonce:        nonce,
+	}, nil
+}
+
+// SignReply signs the reply to the given request with the given local identity.
+func SignReply(localIdentity *identity.LocalIdentity, req *QueryRequest, reply *QueryReply) {
+	reply.Signature = localIdentity.Sign(signingBytes(req, reply)).Bytes()
+}
+
+// VerifyReply checks that the reply answers the given request and that it was signed by the owner of the given public
+// key.
+func VerifyReply(publicKey ed25519.PublicKey, req *QueryRequest, reply *QueryReply) error {
+	if reply.GetVersion() != ProtocolVersion {
+		return errors.Errorf("reply has version %d: %w", reply.GetVersion(), ErrUnsupportedVersion)
+	}
+	if reply.GetRound() != req.GetRound() {
+		return errors.Errorf("reply has round %d instead of %d: %w", reply.GetRound(), req.GetRound(), ErrRoundMismatch)
+	}
+	if len(reply.GetOpinion()) != len(req.GetConflictIDs())+len(req.GetTimestampIDs()) {
+		return errors.Errorf("reply contains %d opinions: %w", len(reply.GetOpinion()), ErrOpinionCountMismatch)
+	}
+
+	signature, _, err := ed25519.SignatureFromBytes(reply.GetSignature())
+	if err != nil {
+		return errors.Errorf("failed to parse signature: %v: %w", err, ErrInvalidSignature)
+	}
+	if !publicKey.VerifySignature(signingBytes(req, reply), signature) {
+		return ErrInvalidSignature
+	}
+
+	return nil
+}
+
+// signingBytes returns the bytes that are covered by the signature of a reply. They start with a fixed context string and
+// contain the queried IDs and the nonce of the request, so that a reply can't be replayed for a different query.
+func signingBytes(req *QueryRequest, reply *QueryReply) []byte {
+	marshalUtil := marshalutil.New().
+		WriteBytes(replySignaturePrefix).
+		WriteUint32(reply.GetVersion()).
+		WriteUint32(reply.GetRound()).
+		WriteUint32(uint32(len(req.GetNonce()))).
+		WriteBytes(req.GetNonce())
+
+	writeIDs(marshalUtil, req.GetConflictIDs())
+	writeIDs(marshalUtil, req.GetTimestampIDs())
+
+	marshalUtil.WriteUint32(uint32(len(reply.GetOpinion())))
+	for _, opn := range reply.GetOpinion() {
+		marshalUtil.WriteInt32(opn)
+	}
+
+	return marshalUtil.Bytes()
+}
+
+func writeIDs(marshalUtil *marshalutil.MarshalUtil, ids []string) {
+	marshalUtil.WriteUint32(uint32(len(ids)))
+	for _, id := range ids {
+		marshalUtil.WriteUint32(uint32(len(id))).WriteBytes([]byte(id))
+	}
+}
@@ -0,0 +1,25 @@
+package net
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/iotaledger/hive.go/identity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSigningBytes_Prefix(t *testing.T) {
+	localIdentity := identity.GenerateLocalIdentity()
+	req, err := NewQueryRequest(3, []string{"liked"}, []string{"disliked"})
+	require.NoError(t, err)
+	reply := &QueryReply{Version: ProtocolVersion, Round: 3, Opinion: []int32{1, 2}}
+	SignReply(localIdentity, req, reply)
+	require.NoError(t, VerifyReply(localIdentity.PublicKey(), req, reply))
+
+	// a signature of the same bytes without the context string is rejected
+	message := signingBytes(req, reply)
+	require.True(t, bytes.HasPrefix(message, replySignaturePrefix))
+	reply.Signature = localIdentity.Sign(message[len(replySignaturePrefix):]).Bytes()
+	assert.ErrorIs(t, VerifyReply(localIdentity.PublicKey(), req, reply), ErrInvalidSignature)
+}
@@ -0,0 +1,69 @@
+package net
+
+import (
+	"bytes"
+	stded25519 "crypto/ed25519"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/iotaledger/hive.go/crypto/ed25519"
+)
+
+// certificateValidity is the validity period of the self-signed certificate of the FPC service.
+const certificateValidity = 10 * 365 * 24 * time.Hour
+
+// ErrUnexpectedCertificate is returned if the certificate of the FPC service does not belong to the queried node.
+var ErrUnexpectedCertificate = errors.New("certificate does not belong to the queried node")
+
+// NewServerTLSConfig creates a TLS configuration for the FPC service that uses a self-signed certificate with the given
+// private key of the node identity.
+func NewServerTLSConfig(privateKey ed25519.PrivateKey) (*tls.Config, error) {
+	key := stded25519.PrivateKey(privateKey.Bytes())
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: privateKey.Public().String()},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(certificateValidity),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+
+	certificate, err := x509.CreateCertificate(rand.Reader, template, template, key.Public(), key)
+	if err != nil {
+		return nil, errors.Errorf("failed to create certificate: %w", err)
+	}
+
+	return &tls.Config{
+		Certificates: []tls.Certificate{{Certificate: [][]byte{certificate}, PrivateKey: key}},
+		MinVersion:   tls.VersionTLS13,
+	}, nil
+}
+
+// NewClientTLSConfig creates a TLS configuration to query the FPC service of the node with the given public key. The
+// certificate presented by the service is accepted only if it uses the identity key of that node.
+func NewClientTLSConfig(publicKey ed25519.PublicKey) *tls.Config {
+	return &tls.Config{
+		MinVersion: tls.VersionTLS13,
+		// the certificate is self-signed, so it is verified against the node identity instead of a CA
+		InsecureSkipVerify: true, //nolint:gosec
+		VerifyPeerCertificate: func(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+			if len(rawCerts) == 0 {
+				return errors.Errorf("no certificate presented: %w", ErrUnexpectedCertificate)
+			}
+			certificate, err := x509.ParseCertificate(rawCerts[0])
+			if err != nil {
+				return errors.Errorf("failed to parse certificate: %w", err)
+			}
+			certificateKey, ok := certificate.PublicKey.(stded25519.PublicKey)
+			if !ok || !bytes.Equal(certificateKey, publicKey.Bytes()) {
+				return ErrUnexpectedCertificate
+			}
+			return nil
+		},
+	}
+}
@@ -17,6 +17,20 @@ type OpinionGiver interface {
 	Mana() float64
 }
 
+type roundContextKey struct{}
+
+// ContextWithRound returns a copy of the given context that carries the FPC round in which a query is performed, so that
+// an OpinionGiver can bind its query to that round.
+func ContextWithRound(ctx context.Context, round uint32) context.Context {
+	return context.WithValue(ctx, roundContextKey{}, round)
+}
+
+// RoundFromContext returns the FPC round carried by the given context or 0 if the context does not carry a round.
+func RoundFromContext(ctx context.Context) uint32 {
+	round, _ := ctx.Value(roundContextKey{}).(uint32)
+	return round
+}
+
 // QueriedOpinions represents queried opinions from a given opinion giver.
 type QueriedOpinions struct {
 	// The ID of the opinion giver.
@@ -0,0 +1,174 @@
+package statement
+
+import (
+	"github.com/iotaledger/goshimmer/packages/ledgerstate"
+	"github.com/iotaledger/goshimmer/packages/tangle"
+)
+
+const (
+	// headerSize is an upper bound for the bytes of a Statement that are not used by its records.
+	headerSize = 2*4 + 2 + 10 + 2 + 2*5
+
+	// recordOverhead is an upper bound for the bytes of a record in addition to its ID.
+	recordOverhead = 1 + 2
+)
+
+// region Encoder //////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// Encoder turns the opinions that a node holds in every FPC round into Statements. Every checkpointInterval
+// Statements a checkpoint Statement with all opinions is created, in between delta Statements only contain the
+// opinions that changed since the previous Statement. Statements that would exceed the maximum size are split into
+// several Statements with consecutive sequence numbers. The Encoder is not safe for concurrent use.
+type Encoder struct {
+	checkpointInterval        int
+	maxSize                   int
+	sequenceNumber            uint64
+	statementsSinceCheckpoint int
+	conflicts                 map[ledgerstate.TransactionID]Opinion
+	timestamps                map[tangle.MessageID]Opinion
+}
+
+// NewEncoder returns a new Encoder that creates a checkpoint every checkpointInterval Statements and Statements of at
+// most maxSize bytes.
+func NewEncoder(checkpointInterval int, maxSize int) *Encoder {
+	return &Encoder{
+		checkpointInterval:        checkpointInterval,
+		maxSize:                   maxSize,
+		statementsSinceCheckpoint: checkpointInterval,
+		conflicts:                 make(map[ledgerstate.TransactionID]Opinion),
+		timestamps:                make(map[tangle.MessageID]Opinion),
+	}
+}
+
+// Encode returns the Statements that communicate the given opinions of the current round. At least one Statement is
+// returned, so that nodes without active votes still signal that they are alive.
+func (e *Encoder) Encode(conflicts Conflicts, timestamps Timestamps) (statements []*Statement) {
+	checkpoint := e.statementsSinceCheckpoint >= e.checkpointInterval
+	roundsElapsed := e.roundsElapsed(conflicts, timestamps)
+
+	changedConflicts := make(Conflicts, 0, len(conflicts))
+	currentConflicts := make(map[ledgerstate.TransactionID]Opinion, len(conflicts))
+	for _, conflict := range conflicts {
+		currentConflicts[conflict.ID] = conflict.Opinion
+		if previous, exists := e.conflicts[conflict.ID]; checkpoint || !exists || !unchanged(previous, conflict.Opinion, roundsElapsed) {
+			changedConflicts = append(changedConflicts, conflict)
+		}
+	}
+	changedTimestamps := make(Timestamps, 0, len(timestamps))
+	currentTimestamps := make(map[tangle.MessageID]Opinion, len(timestamps))
+	for _, timestamp := range timestamps {
+		currentTimestamps[timestamp.ID] = timestamp.Opinion
+		if previous, exists := e.timestamps[timestamp.ID]; checkpoint || !exists || !unchanged(previous, timestamp.Opinion, roundsElapsed) {
+			changedTimestamps = append(changedTimestamps, timestamp)
+		}
+	}
+
+	var removedConflicts []ledgerstate.TransactionID
+	var removedTimestamps []tangle.MessageID
+	if !checkpoint {
+		for conflictID := range e.conflicts {
+			if _, exists := currentConflicts[conflictID]; !exists {
+				removedConflicts = append(removedConflicts, conflictID)
+			}
+		}
+		for messageID := range e.timestamps {
+			if _, exists := currentTimestamps[messageID]; !exists {
+				removedTimestamps = append(removedTimestamps, messageID)
+			}
+		}
+	}
+
+	statements = e.split(checkpoint, roundsElapsed, changedConflicts, changedTimestamps, removedConflicts, removedTimestamps)
+
+	e.conflicts = currentConflicts
+	e.timestamps = currentTimestamps
+	if checkpoint {
+		e.statementsSinceCheckpoint = 0
+	}
+	e.statementsSinceCheckpoint += len(statements)
+
+	return statements
+}
+
+// ForceCheckpoint makes the next Statement a checkpoint. It is used if a Statement could not be issued, as the other
+// nodes miss its opinions until they receive the next checkpoint.
+func (e *Encoder) ForceCheckpoint() {
+	e.statementsSinceCheckpoint = e.checkpointInterval
+}
+
+// split distributes the given opinions over as many Statements as needed to not exceed the maximum size. Only the
+// first Statement is a checkpoint and advances the unchanged opinions.
+func (e *Encoder) split(checkpoint bool, roundsElapsed uint8, conflicts Conflicts, timestamps Timestamps, removedConflicts []ledgerstate.TransactionID, removedTimestamps []tangle.MessageID) (statements []*Statement) {
+	var partConflicts Conflicts
+	var partTimestamps Timestamps
+	var partRemovedConflicts []ledgerstate.TransactionID
+	var partRemovedTimestamps []tangle.MessageID
+	size := headerSize
+
+	flush := func() {
+		var statement *Statement
+		if checkpoint && len(statements) == 0 {
+			statement = NewCheckpoint(e.sequenceNumber, partConflicts, partTimestamps)
+		} else {
+			statement = NewDelta(e.sequenceNumber, roundsElapsed, partConflicts, partTimestamps, partRemovedConflicts, partRemovedTimestamps)
+		}
+		statements = append(statements, statement)
+		e.sequenceNumber++
+
+		// the rounds only elapse once, the following parts belong to the same round
+		roundsElapsed = 0
+		partConflicts, partTimestamps, partRemovedConflicts, partRemovedTimestamps = Conflicts{}, Timestamps{}, nil, nil
+		size = headerSize
+	}
+	reserve := func(recordSize int) {
+		if size+recordSize > e.maxSize {
+			flush()
+		}
+		size += recordSize
+	}
+
+	partConflicts, partTimestamps = Conflicts{}, Timestamps{}
+	for _, conflict := range conflicts {
+		reserve(ledgerstate.TransactionIDLength + recordOverhead)
+		partConflicts = append(partConflicts, conflict)
+	}
+	for _, conflictID := range removedConflicts {
+		reserve(ledgerstate.TransactionIDLength + recordOverhead)
+		partRemovedConflicts = append(partRemovedConflicts, conflictID)
+	}
+	for _, timestamp := range timestamps {
+		reserve(tangle.MessageIDLength + recordOverhead)
+		partTimestamps = append(partTimestamps, timestamp)
+	}
+	for _, messageID := range removedTimestamps {
+		reserve(tangle.MessageIDLength + recordOverhead)
+		partRemovedTimestamps = append(partRemovedTimestamps, messageID)
+	}
+	flush()
+
+	return statements
+}
+
+// roundsElapsed returns the number of rounds that passed since the previous Statement. All active votes advance by
+// one round per FPC round, so it is derived from the first opinion that was part of the previous Statement as well.
+func (e *Encoder) roundsElapsed(conflicts Conflicts, timestamps Timestamps) uint8 {
+	for _, conflict := range conflicts {
+		if previous, exists := e.conflicts[conflict.ID]; exists {
+			return conflict.Round - previous.Round
+		}
+	}
+	for _, timestamp := range timestamps {
+		if previous, exists := e.timestamps[timestamp.ID]; exists {
+			return timestamp.Round - previous.Round
+		}
+	}
+
+	return 0
+}
+
+// unchanged returns true if the current opinion can be derived from the previous one by advancing its round.
+func unchanged(previous, current Opinion, roundsElapsed uint8) bool {
+	return previous.Value == current.Value && previous.Round+roundsElapsed == current.Round
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -0,0 +1,149 @@
+package statement
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cockroachdb/errors"
+	"github.com/iotaledger/hive.go/identity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/goshimmer/packages/ledgerstate"
+	"github.com/iotaledger/goshimmer/packages/tangle"
+	"github.com/iotaledger/goshimmer/packages/vote/opinion"
+)
+
+func TestEncoder(t *testing.T) {
+	encoder := NewEncoder(3, 1000)
+	view := NewRegistry().NodeView(identity.GenerateIdentity().ID())
+
+	txA, err := ledgerstate.TransactionIDFromRandomness()
+	require.NoError(t, err)
+	txB, err := ledgerstate.TransactionIDFromRandomness()
+	require.NoError(t, err)
+	msgA := tangle.EmptyMessageID
+
+	encodeAndProcess := func(conflicts Conflicts, timestamps Timestamps) []*Statement {
+		statements := encoder.Encode(conflicts, timestamps)
+		for _, s := range statements {
+			parsed, _, err := FromBytes(s.Bytes())
+			require.NoError(t, err)
+			require.NoError(t, view.ProcessStatement(parsed))
+		}
+		assert.True(t, view.Complete())
+		assert.ElementsMatch(t, conflicts, view.ActiveConflicts())
+		assert.ElementsMatch(t, timestamps, view.ActiveTimestamps())
+
+		return statements
+	}
+
+	// the first statement is a checkpoint
+	statements := encodeAndProcess(Conflicts{{txA, Opinion{opinion.Like, 1}}}, Timestamps{{msgA, Opinion{opinion.Dislike, 1}}})
+	require.Len(t, statements, 1)
+	assert.True(t, statements[0].Checkpoint)
+
+	// unchanged opinions are not included
+	statements = encodeAndProcess(Conflicts{{txA, Opinion{opinion.Like, 2}}}, Timestamps{{msgA, Opinion{opinion.Dislike, 2}}})
+	require.Len(t, statements, 1)
+	assert.False(t, statements[0].Checkpoint)
+	assert.EqualValues(t, 1, statements[0].RoundsElapsed)
+	assert.Empty(t, statements[0].Conflicts)
+	assert.Empty(t, statements[0].Timestamps)
+	assert.Equal(t, Opinions{{opinion.Like, 1}, {opinion.Like, 2}}, view.ConflictOpinion(txA))
+
+	// changed, new and removed opinions are included
+	statements = encodeAndProcess(Conflicts{{txA, Opinion{opinion.Dislike, 3}}, {txB, Opinion{opinion.Like, 1}}}, Timestamps{})
+	require.Len(t, statements, 1)
+	assert.Len(t, statements[0].Conflicts, 2)
+	assert.Equal(t, []tangle.MessageID{msgA}, statements[0].RemovedTimestamps)
+
+	// a checkpoint is created after checkpointInterval statements
+	statements = encodeAndProcess(Conflicts{{txA, Opinion{opinion.Dislike, 4}}, {txB, Opinion{opinion.Like, 2}}}, Timestamps{})
+	require.Len(t, statements, 1)
+	assert.True(t, statements[0].Checkpoint)
+	assert.Len(t, statements[0].Conflicts, 2)
+}
+
+func TestEncoder_Split(t *testing.T) {
+	encoder := NewEncoder(10, headerSize+2*(ledgerstate.TransactionIDLength+recordOverhead))
+	view := NewRegistry().NodeView(identity.GenerateIdentity().ID())
+
+	conflicts := make(Conflicts, 5)
+	for i := range conflicts {
+		transactionID, err := ledgerstate.TransactionIDFromRandomness()
+		require.NoError(t, err)
+		conflicts[i] = Conflict{transactionID, Opinion{opinion.Like, 1}}
+	}
+
+	statements := encoder.Encode(conflicts, Timestamps{})
+	require.Len(t, statements, 3)
+	assert.True(t, statements[0].Checkpoint)
+	for i, s := range statements {
+		assert.EqualValues(t, i, s.SequenceNumber)
+		assert.LessOrEqual(t, len(s.Bytes()), encoder.maxSize)
+		require.NoError(t, view.ProcessStatement(s))
+	}
+	assert.ElementsMatch(t, conflicts, view.ActiveConflicts())
+}
+
+func TestEncoder_ForceCheckpoint(t *testing.T) {
+	encoder := NewEncoder(10, 1000)
+	view := NewRegistry().NodeView(identity.GenerateIdentity().ID())
+	txA, err := ledgerstate.TransactionIDFromRandomness()
+	require.NoError(t, err)
+
+	for _, s := range encoder.Encode(Conflicts{{txA, Opinion{opinion.Like, 1}}}, Timestamps{}) {
+		require.NoError(t, view.ProcessStatement(s))
+	}
+	opinions, err := view.Query(context.Background(), []string{txA.Base58()}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, opinion.Opinions{opinion.Like}, opinions)
+
+	// the statement with the changed opinion could not be issued, so the view is incomplete and can not be queried
+	encoder.Encode(Conflicts{{txA, Opinion{opinion.Dislike, 2}}}, Timestamps{})
+	for _, s := range encoder.Encode(Conflicts{{txA, Opinion{opinion.Dislike, 3}}}, Timestamps{}) {
+		assert.ErrorIs(t, view.ProcessStatement(s), ErrMissingStatements)
+	}
+	_, err = view.Query(context.Background(), []string{txA.Base58()}, nil)
+	assert.ErrorIs(t, err, ErrMissingStatements)
+
+	// the forced checkpoint restores the view
+	encoder.ForceCheckpoint()
+	statements := encoder.Encode(Conflicts{{txA, Opinion{opinion.Dislike, 4}}}, Timestamps{})
+	require.Len(t, statements, 1)
+	assert.True(t, statements[0].Checkpoint)
+	require.NoError(t, view.ProcessStatement(statements[0]))
+	opinions, err = view.Query(context.Background(), []string{txA.Base58()}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, opinion.Opinions{opinion.Dislike}, opinions)
+}
+
+func TestView_ProcessStatement(t *testing.T) {
+	view := NewRegistry().NodeView(identity.GenerateIdentity().ID())
+
+	txA, err := ledgerstate.TransactionIDFromRandomness()
+	require.NoError(t, err)
+
+	require.NoError(t, view.ProcessStatement(NewCheckpoint(5, Conflicts{{txA, Opinion{opinion.Like, 1}}}, Timestamps{})))
+	assert.True(t, view.Complete())
+
+	// stale statements are ignored
+	err = view.ProcessStatement(NewDelta(5, 1, Conflicts{{txA, Opinion{opinion.Dislike, 2}}}, Timestamps{}, nil, nil))
+	assert.True(t, errors.Is(err, ErrStaleStatement))
+	assert.Equal(t, Opinions{{opinion.Like, 1}}, view.ConflictOpinion(txA))
+
+	// missed statements make the view incomplete until the next checkpoint
+	err = view.ProcessStatement(NewDelta(7, 2, Conflicts{}, Timestamps{}, nil, nil))
+	assert.True(t, errors.Is(err, ErrMissingStatements))
+	assert.False(t, view.Complete())
+	assert.Equal(t, Conflicts{{txA, Opinion{opinion.Like, 3}}}, view.ActiveConflicts())
+
+	require.NoError(t, view.ProcessStatement(NewCheckpoint(8, Conflicts{}, Timestamps{})))
+	assert.True(t, view.Complete())
+	assert.Empty(t, view.ActiveConflicts())
+
+	// a checkpoint with sequence number 0 signals a restart of the node
+	require.NoError(t, view.ProcessStatement(NewCheckpoint(0, Conflicts{{txA, Opinion{opinion.Like, 1}}}, Timestamps{})))
+	assert.Len(t, view.ActiveConflicts(), 1)
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/cockroachdb/errors"
 	"github.com/iotaledger/hive.go/cerrors"
+	"github.com/iotaledger/hive.go/identity"
 	"github.com/iotaledger/hive.go/marshalutil"
 	"github.com/iotaledger/hive.go/stringify"
 
@@ -118,4 +119,30 @@ func (o Opinions) Finalized(l int) bool {
 	return true
 }
 
+// MajorityOpinion returns the opinion of the last round that is held by most of the given nodes. It returns
+// opinion.Unknown if there is no strict majority between liking and disliking.
+func MajorityOpinion(nodesOpinions map[identity.ID]Opinions) opinion.Opinion {
+	likes, dislikes := 0, 0
+	for _, opinions := range nodesOpinions {
+		if len(opinions) == 0 {
+			continue
+		}
+		switch opinions.Last().Value {
+		case opinion.Like:
+			likes++
+		case opinion.Dislike:
+			dislikes++
+		}
+	}
+
+	switch {
+	case likes > dislikes:
+		return opinion.Like
+	case dislikes > likes:
+		return opinion.Dislike
+	default:
+		return opinion.Unknown
+	}
+}
+
 // endregion /////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -1,7 +1,6 @@
 package statement
 
 import (
-	"fmt"
 	"sync"
 
 	"github.com/cockroachdb/errors"
@@ -9,6 +8,8 @@ import (
 	"github.com/iotaledger/hive.go/marshalutil"
 	"github.com/iotaledger/hive.go/stringify"
 
+	"github.com/iotaledger/goshimmer/packages/ledgerstate"
+	"github.com/iotaledger/goshimmer/packages/tangle"
 	"github.com/iotaledger/goshimmer/packages/tangle/payload"
 )
 
@@ -35,20 +36,43 @@ func init() {
 	})
 }
 
-// Statement defines a Statement payload.
+// ErrUnsupportedVersion is returned when parsing a Statement that was encoded with an unknown version.
+var ErrUnsupportedVersion = errors.New("unsupported statement version")
+
+const (
+	// Version defines the version of the encoding of Statements.
+	Version uint8 = 1
+
+	// flagCheckpoint marks a Statement as a checkpoint.
+	flagCheckpoint byte = 1 << 0
+)
+
+// Statement defines a Statement payload. A checkpoint Statement contains the opinions about all conflicts and
+// timestamps the node is voting on, while a delta Statement only contains the opinions that changed since the
+// previous Statement of the node and the conflicts and timestamps the node stopped voting on. The opinions that did
+// not change advanced by RoundsElapsed rounds.
 type Statement struct {
-	ConflictsCount  uint32
-	Conflicts       Conflicts
-	TimestampsCount uint32
-	Timestamps      Timestamps
+	Version           uint8
+	Checkpoint        bool
+	SequenceNumber    uint64
+	RoundsElapsed     uint8
+	ConflictsCount    uint32
+	Conflicts         Conflicts
+	TimestampsCount   uint32
+	Timestamps        Timestamps
+	RemovedConflicts  []ledgerstate.TransactionID
+	RemovedTimestamps []tangle.MessageID
 
 	bytes      []byte
 	bytesMutex sync.RWMutex
 }
 
-// New creates a new Statement payload.
-func New(conflicts Conflicts, timestamps Timestamps) *Statement {
+// NewCheckpoint creates a new checkpoint Statement payload containing all the given opinions.
+func NewCheckpoint(sequenceNumber uint64, conflicts Conflicts, timestamps Timestamps) *Statement {
 	return &Statement{
+		Version:         Version,
+		Checkpoint:      true,
+		SequenceNumber:  sequenceNumber,
 		ConflictsCount:  uint32(len(conflicts)),
 		Conflicts:       conflicts,
 		TimestampsCount: uint32(len(timestamps)),
@@ -56,6 +80,22 @@ func New(conflicts Conflicts, timestamps Timestamps) *Statement {
 	}
 }
 
+// NewDelta creates a new delta Statement payload containing the opinions that changed and the conflicts and timestamps
+// that were removed since the previous Statement.
+func NewDelta(sequenceNumber uint64, roundsElapsed uint8, conflicts Conflicts, timestamps Timestamps, removedConflicts []ledgerstate.TransactionID, removedTimestamps []tangle.MessageID) *Statement {
+	return &Statement{
+		Version:           Version,
+		SequenceNumber:    sequenceNumber,
+		RoundsElapsed:     roundsElapsed,
+		ConflictsCount:    uint32(len(conflicts)),
+		Conflicts:         conflicts,
+		TimestampsCount:   uint32(len(timestamps)),
+		Timestamps:        timestamps,
+		RemovedConflicts:  removedConflicts,
+		RemovedTimestamps: removedTimestamps,
+	}
+}
+
 // FromBytes unmarshals a Statement Payload from a sequence of bytes.
 func FromBytes(bytes []byte) (statement *Statement, consumedBytes int, err error) {
 	marshalUtil := marshalutil.New(bytes)
@@ -97,42 +137,87 @@ func Parse(marshalUtil *marshalutil.MarshalUtil) (statement *Statement, err erro
 		return
 	}
 
-	// parse conflicts
-	if statement.ConflictsCount, err = marshalUtil.ReadUint32(); err != nil {
-		err = errors.Errorf("failed to parse conflicts len of statement payload: %w", err)
+	if statement.Version, err = marshalUtil.ReadUint8(); err != nil {
+		err = errors.Errorf("failed to parse version of statement payload: %w", err)
+		return
+	}
+	if statement.Version != Version {
+		err = errors.Errorf("failed to parse statement payload with version %d: %w", statement.Version, ErrUnsupportedVersion)
 		return
 	}
 
-	parsedBytes := marshalUtil.ReadOffset() - 8 // skip the payload size and type
-	if uint32(parsedBytes)+(statement.ConflictsCount*ConflictLength) > payloadSize {
-		err = fmt.Errorf("failed to parse statement payload: number of conflicts overflowing: %w", err)
+	flags, err := marshalUtil.ReadByte()
+	if err != nil {
+		err = errors.Errorf("failed to parse flags of statement payload: %w", err)
 		return
 	}
+	statement.Checkpoint = flags&flagCheckpoint != 0
 
-	if statement.Conflicts, err = ConflictsFromMarshalUtil(marshalUtil, statement.ConflictsCount); err != nil {
-		err = errors.Errorf("failed to parse conflicts from statement payload: %w", err)
+	if statement.SequenceNumber, err = readUvarint(marshalUtil); err != nil {
+		err = errors.Errorf("failed to parse sequence number of statement payload: %w", err)
 		return
 	}
 
-	// parse timestamps
-	if statement.TimestampsCount, err = marshalUtil.ReadUint32(); err != nil {
-		err = errors.Errorf("failed to parse timestamps len of statement payload: %w", err)
+	roundsElapsed, err := readUvarint(marshalUtil)
+	if err != nil {
+		err = errors.Errorf("failed to parse elapsed rounds of statement payload: %w", err)
+		return
+	}
+	if roundsElapsed > 0xff {
+		err = errors.Errorf("elapsed rounds (%d) of statement payload overflowing: %w", roundsElapsed, cerrors.ErrParseBytesFailed)
 		return
 	}
+	statement.RoundsElapsed = uint8(roundsElapsed)
 
-	parsedBytes = marshalUtil.ReadOffset() - 8 // skip the payload size and type
-	if uint32(parsedBytes)+statement.TimestampsCount*TimestampLength > payloadSize {
-		err = fmt.Errorf("failed to parse statement payload: number of timestamps overflowing: %w", err)
+	// parse conflicts
+	conflictRecords, err := readRecords(marshalUtil, ledgerstate.TransactionIDLength)
+	if err != nil {
+		err = errors.Errorf("failed to parse conflicts from statement payload: %w", err)
 		return
 	}
+	statement.Conflicts = Conflicts{}
+	for _, r := range conflictRecords {
+		conflictID, _, idErr := ledgerstate.TransactionIDFromBytes(r.id)
+		if idErr != nil {
+			err = errors.Errorf("failed to parse conflict ID from statement payload: %w", idErr)
+			return
+		}
+		if r.removed {
+			statement.RemovedConflicts = append(statement.RemovedConflicts, conflictID)
+			continue
+		}
+		statement.Conflicts = append(statement.Conflicts, Conflict{ID: conflictID, Opinion: r.opinion})
+	}
+	statement.ConflictsCount = uint32(len(statement.Conflicts))
 
-	if statement.Timestamps, err = TimestampsFromMarshalUtil(marshalUtil, statement.TimestampsCount); err != nil {
+	// parse timestamps
+	timestampRecords, err := readRecords(marshalUtil, tangle.MessageIDLength)
+	if err != nil {
 		err = errors.Errorf("failed to parse timestamps from statement payload: %w", err)
 		return
 	}
+	statement.Timestamps = Timestamps{}
+	for _, r := range timestampRecords {
+		messageID, _, idErr := tangle.MessageIDFromBytes(r.id)
+		if idErr != nil {
+			err = errors.Errorf("failed to parse message ID from statement payload: %w", idErr)
+			return
+		}
+		if r.removed {
+			statement.RemovedTimestamps = append(statement.RemovedTimestamps, messageID)
+			continue
+		}
+		statement.Timestamps = append(statement.Timestamps, Timestamp{ID: messageID, Opinion: r.opinion})
+	}
+	statement.TimestampsCount = uint32(len(statement.Timestamps))
+
+	if statement.Checkpoint && (len(statement.RemovedConflicts) > 0 || len(statement.RemovedTimestamps) > 0) {
+		err = errors.Errorf("checkpoint statement payload must not contain removed opinions: %w", cerrors.ErrParseBytesFailed)
+		return
+	}
 
 	// return the number of bytes we processed
-	parsedBytes = marshalUtil.ReadOffset() - readStartOffset
+	parsedBytes := marshalUtil.ReadOffset() - readStartOffset
 	if parsedBytes != int(payloadSize)+4 { // skip the payload size
 		err = errors.Errorf("parsed bytes (%d) did not match expected size (%d): %w", parsedBytes, payloadSize, cerrors.ErrParseBytesFailed)
 		return
@@ -162,12 +247,34 @@ func (s *Statement) Bytes() (bytes []byte) {
 		return
 	}
 
-	payloadBytes := marshalutil.New().
-		WriteUint32(s.ConflictsCount).
-		Write(s.Conflicts).
-		WriteUint32(s.TimestampsCount).
-		Write(s.Timestamps).
-		Bytes()
+	var flags byte
+	if s.Checkpoint {
+		flags |= flagCheckpoint
+	}
+
+	conflictRecords := make([]record, 0, len(s.Conflicts)+len(s.RemovedConflicts))
+	for _, conflict := range s.Conflicts {
+		conflictRecords = append(conflictRecords, record{id: conflict.ID.Bytes(), opinion: conflict.Opinion})
+	}
+	for _, conflictID := range s.RemovedConflicts {
+		conflictRecords = append(conflictRecords, record{id: conflictID.Bytes(), removed: true})
+	}
+	timestampRecords := make([]record, 0, len(s.Timestamps)+len(s.RemovedTimestamps))
+	for _, timestamp := range s.Timestamps {
+		timestampRecords = append(timestampRecords, record{id: timestamp.ID.Bytes(), opinion: timestamp.Opinion})
+	}
+	for _, messageID := range s.RemovedTimestamps {
+		timestampRecords = append(timestampRecords, record{id: messageID.Bytes(), removed: true})
+	}
+
+	payloadMarshalUtil := marshalutil.New().
+		WriteUint8(s.Version).
+		WriteByte(flags)
+	writeUvarint(payloadMarshalUtil, s.SequenceNumber)
+	writeUvarint(payloadMarshalUtil, uint64(s.RoundsElapsed))
+	writeRecords(payloadMarshalUtil, conflictRecords)
+	writeRecords(payloadMarshalUtil, timestampRecords)
+	payloadBytes := payloadMarshalUtil.Bytes()
 
 	payloadBytesLength := len(payloadBytes)
 
@@ -181,10 +288,16 @@ func (s *Statement) Bytes() (bytes []byte) {
 
 func (s *Statement) String() string {
 	return stringify.Struct("Payload",
+		stringify.StructField("version", s.Version),
+		stringify.StructField("checkpoint", s.Checkpoint),
+		stringify.StructField("sequenceNumber", s.SequenceNumber),
+		stringify.StructField("roundsElapsed", s.RoundsElapsed),
 		stringify.StructField("conflictsLen", s.ConflictsCount),
 		stringify.StructField("conflicts", s.Conflicts),
 		stringify.StructField("timestampsLen", s.TimestampsCount),
 		stringify.StructField("timestamps", s.Timestamps),
+		stringify.StructField("removedConflicts", s.RemovedConflicts),
+		stringify.StructField("removedTimestamps", s.RemovedTimestamps),
 	)
 }
 
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/cockroachdb/errors"
 	"github.com/iotaledger/hive.go/marshalutil"
 	"github.com/stretchr/testify/require"
 
@@ -25,13 +26,13 @@ func dummyPayload(t *testing.T) *Statement {
 		{tangle.EmptyMessageID, Opinion{opinion.Like, 1}},
 		{tangle.EmptyMessageID, Opinion{opinion.Dislike, 2}},
 	}
-	return New(conflicts, timestamps)
+	return NewCheckpoint(0, conflicts, timestamps)
 }
 
 func emptyPayload() *Statement {
 	conflicts := []Conflict{}
 	timestamps := []Timestamp{}
-	return New(conflicts, timestamps)
+	return NewDelta(1, 1, conflicts, timestamps, nil, nil)
 }
 
 func TestPayloadFromMarshalUtil(t *testing.T) {
@@ -46,10 +47,53 @@ func TestPayloadFromMarshalUtil(t *testing.T) {
 	require.EqualValues(t, 2, parsedPayload.TimestampsCount)
 	require.Equal(t, payload.Conflicts, parsedPayload.Conflicts)
 	require.Equal(t, payload.Timestamps, parsedPayload.Timestamps)
+	require.True(t, parsedPayload.Checkpoint)
 
 	fmt.Println(parsedPayload)
 }
 
+func TestDeltaPayloadFromMarshalUtil(t *testing.T) {
+	txA, err := ledgerstate.TransactionIDFromRandomness()
+	require.NoError(t, err)
+	txB, err := ledgerstate.TransactionIDFromRandomness()
+	require.NoError(t, err)
+
+	payload := NewDelta(300, 2,
+		Conflicts{{txA, Opinion{opinion.Dislike, 200}}},
+		Timestamps{{tangle.EmptyMessageID, Opinion{opinion.Unknown, 3}}},
+		[]ledgerstate.TransactionID{txB},
+		[]tangle.MessageID{tangle.EmptyMessageID},
+	)
+
+	parsedPayload, _, err := FromBytes(payload.Bytes())
+	require.NoError(t, err)
+
+	require.False(t, parsedPayload.Checkpoint)
+	require.EqualValues(t, 300, parsedPayload.SequenceNumber)
+	require.EqualValues(t, 2, parsedPayload.RoundsElapsed)
+	require.Equal(t, payload.Conflicts, parsedPayload.Conflicts)
+	require.Equal(t, payload.Timestamps, parsedPayload.Timestamps)
+	require.Equal(t, payload.RemovedConflicts, parsedPayload.RemovedConflicts)
+	require.Equal(t, payload.RemovedTimestamps, parsedPayload.RemovedTimestamps)
+
+}
+
+func TestPayloadUnsupportedVersion(t *testing.T) {
+	payload := dummyPayload(t)
+	payload.Version = Version + 1
+
+	_, _, err := FromBytes(payload.Bytes())
+	require.True(t, errors.Is(err, ErrUnsupportedVersion))
+}
+
+func TestCheckpointWithRemovedOpinions(t *testing.T) {
+	payload := dummyPayload(t)
+	payload.RemovedTimestamps = []tangle.MessageID{tangle.EmptyMessageID}
+
+	_, _, err := FromBytes(payload.Bytes())
+	require.Error(t, err)
+}
+
 func TestEmptyPayloadFromMarshalUtil(t *testing.T) {
 	payload := emptyPayload()
 	bytes := payload.Bytes()
@@ -0,0 +1,150 @@
+package statement
+
+import (
+	"encoding/binary"
+
+	"github.com/cockroachdb/errors"
+	"github.com/iotaledger/hive.go/cerrors"
+	"github.com/iotaledger/hive.go/marshalutil"
+
+	"github.com/iotaledger/goshimmer/packages/vote/opinion"
+)
+
+// region record ///////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// The opinion codes of a record. Every code is bitpacked into 2 bits.
+const (
+	codeRemoved byte = iota
+	codeLike
+	codeDislike
+	codeUnknown
+
+	codeBits          = 2
+	codesPerByte      = 8 / codeBits
+	codeMask     byte = 1<<codeBits - 1
+)
+
+// record is the compact representation of an opinion about a conflict or timestamp in a Statement. A removed record
+// signals that the node stopped voting on the conflict or timestamp and does not carry an opinion.
+type record struct {
+	id      []byte
+	removed bool
+	opinion Opinion
+}
+
+// writeRecords writes the given records into the marshalUtil: the number of records as a varint followed by the IDs,
+// the bitpacked opinion codes and the rounds of the records that were not removed as varints.
+func writeRecords(marshalUtil *marshalutil.MarshalUtil, records []record) {
+	writeUvarint(marshalUtil, uint64(len(records)))
+	for _, r := range records {
+		marshalUtil.WriteBytes(r.id)
+	}
+
+	codes := make([]byte, (len(records)+codesPerByte-1)/codesPerByte)
+	for i, r := range records {
+		codes[i/codesPerByte] |= recordCode(r) << (uint(i%codesPerByte) * codeBits)
+	}
+	marshalUtil.WriteBytes(codes)
+
+	for _, r := range records {
+		if !r.removed {
+			writeUvarint(marshalUtil, uint64(r.opinion.Round))
+		}
+	}
+}
+
+// readRecords reads records with IDs of the given length from the marshalUtil.
+func readRecords(marshalUtil *marshalutil.MarshalUtil, idLength int) (records []record, err error) {
+	count, err := readUvarint(marshalUtil)
+	if err != nil {
+		return nil, errors.Errorf("failed to parse number of records: %w", err)
+	}
+	// every record needs at least the bytes of its ID, which prevents huge allocations for malformed counts
+	if count > uint64(len(marshalUtil.Bytes())-marshalUtil.ReadOffset())/uint64(idLength) {
+		return nil, errors.Errorf("number of records (%d) overflowing: %w", count, cerrors.ErrParseBytesFailed)
+	}
+
+	records = make([]record, count)
+	for i := range records {
+		if records[i].id, err = marshalUtil.ReadBytes(idLength); err != nil {
+			return nil, errors.Errorf("failed to parse ID of record: %w", err)
+		}
+	}
+
+	codes, err := marshalUtil.ReadBytes((len(records) + codesPerByte - 1) / codesPerByte)
+	if err != nil {
+		return nil, errors.Errorf("failed to parse opinion codes of records: %w", err)
+	}
+	for i := range records {
+		switch code := codes[i/codesPerByte] >> (uint(i%codesPerByte) * codeBits) & codeMask; code {
+		case codeRemoved:
+			records[i].removed = true
+		case codeLike:
+			records[i].opinion.Value = opinion.Like
+		case codeDislike:
+			records[i].opinion.Value = opinion.Dislike
+		default:
+			records[i].opinion.Value = opinion.Unknown
+		}
+	}
+
+	for i := range records {
+		if records[i].removed {
+			continue
+		}
+		round, err := readUvarint(marshalUtil)
+		if err != nil {
+			return nil, errors.Errorf("failed to parse round of record: %w", err)
+		}
+		if round > 0xff {
+			return nil, errors.Errorf("round (%d) of record overflowing: %w", round, cerrors.ErrParseBytesFailed)
+		}
+		records[i].opinion.Round = uint8(round)
+	}
+
+	return records, nil
+}
+
+// recordCode returns the opinion code of the given record.
+func recordCode(r record) byte {
+	if r.removed {
+		return codeRemoved
+	}
+
+	switch r.opinion.Value {
+	case opinion.Like:
+		return codeLike
+	case opinion.Dislike:
+		return codeDislike
+	default:
+		return codeUnknown
+	}
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region varint ///////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// writeUvarint writes the given value as a varint into the marshalUtil.
+func writeUvarint(marshalUtil *marshalutil.MarshalUtil, value uint64) {
+	buffer := make([]byte, binary.MaxVarintLen64)
+	marshalUtil.WriteBytes(buffer[:binary.PutUvarint(buffer, value)])
+}
+
+// readUvarint reads a varint from the marshalUtil.
+func readUvarint(marshalUtil *marshalutil.MarshalUtil) (value uint64, err error) {
+	result, err := marshalUtil.Parse(func(data []byte) (interface{}, int, error) {
+		value, consumedBytes := binary.Uvarint(data)
+		if consumedBytes <= 0 {
+			return nil, 0, errors.Errorf("failed to parse varint: %w", cerrors.ErrParseBytesFailed)
+		}
+		return value, consumedBytes, nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return result.(uint64), nil
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -5,6 +5,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/cockroachdb/errors"
 	"github.com/iotaledger/hive.go/identity"
 
 	"github.com/iotaledger/goshimmer/packages/clock"
@@ -13,12 +14,23 @@ import (
 	"github.com/iotaledger/goshimmer/packages/vote/opinion"
 )
 
+var (
+	// ErrStaleStatement is returned if a Statement is not newer than the latest Statement processed for the node.
+	ErrStaleStatement = errors.New("stale statement")
+
+	// ErrMissingStatements is returned if Statements of the node were missed, so that its View is incomplete until
+	// the next checkpoint.
+	ErrMissingStatements = errors.New("missing statements")
+)
+
 // region Registry /////////////////////////////////////////////////////////////////////////////////////////////////////
 
 // Registry holds the opinions of all the nodes.
 type Registry struct {
 	nodesView map[identity.ID]*View
 	mu        sync.RWMutex
+	storage   *Storage
+	retention time.Duration
 }
 
 // NewRegistry returns a new registry.
@@ -28,6 +40,87 @@ func NewRegistry() *Registry {
 	}
 }
 
+// NewPersistentRegistry returns a new registry that persists the processed Statements in the given Storage for the
+// given retention window. The views of the nodes are restored from the Statements that are already stored.
+func NewPersistentRegistry(storage *Storage, retention time.Duration) *Registry {
+	r := NewRegistry()
+	r.storage = storage
+	r.retention = retention
+
+	storage.DeleteNodeStatementsBefore(clock.SyncedTime().Add(-retention))
+	for nodeID, nodeStatements := range storage.AllNodeStatements() {
+		view := r.NodeView(nodeID)
+		for _, nodeStatement := range nodeStatements {
+			// missed Statements were already reported when the Statement was processed for the first time
+			_ = view.processStatement(nodeStatement.Statement(), nodeStatement.ReceivedTime())
+			view.UpdateLastStatementReceivedTime(nodeStatement.ReceivedTime())
+		}
+	}
+
+	return r
+}
+
+// ProcessStatement adds the opinions of the given Statement to the view of the node that issued it and persists the
+// Statement together with the local time at which it was received if the registry is backed by a Storage. Stale
+// Statements are neither processed nor persisted.
+func (r *Registry) ProcessStatement(nodeID identity.ID, messageID tangle.MessageID, receivedTime time.Time, statement *Statement) error {
+	err := r.NodeView(nodeID).ProcessStatement(statement)
+	if errors.Is(err, ErrStaleStatement) {
+		return err
+	}
+
+	if r.storage != nil {
+		r.storage.StoreNodeStatement(NewNodeStatement(nodeID, messageID, receivedTime, statement))
+	}
+
+	return err
+}
+
+// NodeStatements returns the persisted Statements of the given node ordered by their received time. It returns nil if
+// the registry is not backed by a Storage.
+func (r *Registry) NodeStatements(nodeID identity.ID) NodeStatements {
+	if r.storage == nil {
+		return nil
+	}
+
+	return r.storage.NodeStatements(nodeID)
+}
+
+// ConflictOpinions returns the opinion histories of all the nodes that stated an opinion about the given conflict.
+func (r *Registry) ConflictOpinions(id ledgerstate.TransactionID) map[identity.ID]Opinions {
+	nodesOpinions := make(map[identity.ID]Opinions)
+	for _, v := range r.NodesView() {
+		v.cMutex.RLock()
+		if entry, ok := v.Conflicts[id]; ok {
+			nodesOpinions[v.NodeID] = append(Opinions{}, entry.Opinions...)
+		}
+		v.cMutex.RUnlock()
+	}
+
+	return nodesOpinions
+}
+
+// TimestampOpinions returns the opinion histories of all the nodes that stated an opinion about the given timestamp.
+func (r *Registry) TimestampOpinions(id tangle.MessageID) map[identity.ID]Opinions {
+	nodesOpinions := make(map[identity.ID]Opinions)
+	for _, v := range r.NodesView() {
+		v.tMutex.RLock()
+		if entry, ok := v.Timestamps[id]; ok {
+			nodesOpinions[v.NodeID] = append(Opinions{}, entry.Opinions...)
+		}
+		v.tMutex.RUnlock()
+	}
+
+	return nodesOpinions
+}
+
+// Shutdown shuts down the registry and persists its state.
+func (r *Registry) Shutdown() {
+	if r.storage != nil {
+		r.storage.Shutdown()
+	}
+}
+
 // NodeView returns the view of the given node, and adds a new view if not present.
 func (r *Registry) NodeView(id identity.ID) *View {
 	r.mu.Lock()
@@ -35,9 +128,11 @@ func (r *Registry) NodeView(id identity.ID) *View {
 
 	if _, ok := r.nodesView[id]; !ok {
 		r.nodesView[id] = &View{
-			NodeID:     id,
-			Conflicts:  make(map[ledgerstate.TransactionID]Entry),
-			Timestamps: make(map[tangle.MessageID]Entry),
+			NodeID:           id,
+			Conflicts:        make(map[ledgerstate.TransactionID]Entry),
+			Timestamps:       make(map[tangle.MessageID]Entry),
+			activeConflicts:  make(map[ledgerstate.TransactionID]Opinion),
+			activeTimestamps: make(map[tangle.MessageID]Opinion),
 		}
 	}
 
@@ -58,16 +153,22 @@ func (r *Registry) NodesView() []*View {
 	return views
 }
 
-// Clean deletes all the entries older than the given duration d.
+// Clean deletes all the entries older than the given duration d and the persisted Statements that are older than the
+// retention window.
 func (r *Registry) Clean(d time.Duration) {
 	now := clock.SyncedTime()
 
+	if r.storage != nil {
+		r.storage.DeleteNodeStatementsBefore(now.Add(-r.retention))
+	}
+
 	for _, v := range r.NodesView() {
 		v.cMutex.Lock()
 		// loop over the conflicts
 		for id, c := range v.Conflicts {
 			if c.Timestamp.Add(d).Before(now) {
 				delete(v.Conflicts, id)
+				delete(v.activeConflicts, id)
 			}
 		}
 		v.cMutex.Unlock()
@@ -77,6 +178,7 @@ func (r *Registry) Clean(d time.Duration) {
 		for id, t := range v.Timestamps {
 			if t.Timestamp.Add(d).Before(now) {
 				delete(v.Timestamps, id)
+				delete(v.activeTimestamps, id)
 			}
 		}
 		v.tMutex.Unlock()
@@ -97,15 +199,182 @@ type Entry struct {
 
 // region View /////////////////////////////////////////////////////////////////////////////////////////////////////
 
-// View holds the node's opinion about conflicts and timestamps.
+// View holds the node's opinion about conflicts and timestamps. The active opinions are the ones of the latest
+// Statement of the node, reconstructed from its checkpoint and delta Statements.
 type View struct {
 	NodeID                         identity.ID
 	Conflicts                      map[ledgerstate.TransactionID]Entry
+	activeConflicts                map[ledgerstate.TransactionID]Opinion
 	cMutex                         sync.RWMutex
 	Timestamps                     map[tangle.MessageID]Entry
+	activeTimestamps               map[tangle.MessageID]Opinion
 	tMutex                         sync.RWMutex
 	LastStatementReceivedTimestamp time.Time
 	lstMutex                       sync.RWMutex
+	sequenceNumber                 uint64
+	statementProcessed             bool
+	complete                       bool
+	sMutex                         sync.RWMutex
+}
+
+// ProcessStatement adds the opinions of the given Statement to the view. A checkpoint replaces the active opinions,
+// while a delta updates the changed opinions, removes the ones the node stopped voting on and advances the unchanged
+// ones by the elapsed rounds. Stale Statements are ignored. If Statements were missed, the delta is applied anyway
+// but ErrMissingStatements is returned and the view stays incomplete until the next checkpoint.
+func (v *View) ProcessStatement(statement *Statement) (err error) {
+	return v.processStatement(statement, clock.SyncedTime())
+}
+
+// processStatement processes the given Statement and records its opinions at the given time.
+func (v *View) processStatement(statement *Statement, now time.Time) (err error) {
+	v.sMutex.Lock()
+	defer v.sMutex.Unlock()
+
+	// a checkpoint with sequence number 0 is issued after a restart of the node
+	restarted := statement.Checkpoint && statement.SequenceNumber == 0
+	if v.statementProcessed && statement.SequenceNumber <= v.sequenceNumber && !restarted {
+		return errors.Errorf("statement %d is not newer than statement %d: %w", statement.SequenceNumber, v.sequenceNumber, ErrStaleStatement)
+	}
+
+	switch {
+	case statement.Checkpoint:
+		v.complete = true
+	case !v.statementProcessed || statement.SequenceNumber != v.sequenceNumber+1:
+		v.complete = false
+		err = errors.Errorf("received statement %d after statement %d: %w", statement.SequenceNumber, v.sequenceNumber, ErrMissingStatements)
+	}
+	v.statementProcessed = true
+	v.sequenceNumber = statement.SequenceNumber
+
+	v.applyConflicts(statement, now)
+	v.applyTimestamps(statement, now)
+
+	return err
+}
+
+// Complete returns true if the active opinions of the view are the ones of the latest Statement of the node, i.e.,
+// no Statements were missed since the latest checkpoint.
+func (v *View) Complete() bool {
+	v.sMutex.RLock()
+	defer v.sMutex.RUnlock()
+
+	return v.complete
+}
+
+// ActiveConflicts returns the reconstructed opinions about the conflicts of the latest Statement of the node.
+func (v *View) ActiveConflicts() Conflicts {
+	v.cMutex.RLock()
+	defer v.cMutex.RUnlock()
+
+	conflicts := make(Conflicts, 0, len(v.activeConflicts))
+	for id, o := range v.activeConflicts {
+		conflicts = append(conflicts, Conflict{ID: id, Opinion: o})
+	}
+
+	return conflicts
+}
+
+// ActiveTimestamps returns the reconstructed opinions about the timestamps of the latest Statement of the node.
+func (v *View) ActiveTimestamps() Timestamps {
+	v.tMutex.RLock()
+	defer v.tMutex.RUnlock()
+
+	timestamps := make(Timestamps, 0, len(v.activeTimestamps))
+	for id, o := range v.activeTimestamps {
+		timestamps = append(timestamps, Timestamp{ID: id, Opinion: o})
+	}
+
+	return timestamps
+}
+
+func (v *View) applyConflicts(statement *Statement, now time.Time) {
+	v.cMutex.Lock()
+	defer v.cMutex.Unlock()
+
+	if statement.Checkpoint {
+		v.activeConflicts = make(map[ledgerstate.TransactionID]Opinion, len(statement.Conflicts))
+	}
+
+	if statement.RoundsElapsed > 0 {
+		changed := make(map[ledgerstate.TransactionID]bool, len(statement.Conflicts))
+		for _, c := range statement.Conflicts {
+			changed[c.ID] = true
+		}
+		for id, o := range v.activeConflicts {
+			if !changed[id] {
+				v.recordConflictOpinion(id, Opinion{Value: o.Value, Round: o.Round + statement.RoundsElapsed}, now)
+			}
+		}
+	}
+
+	for _, c := range statement.Conflicts {
+		v.recordConflictOpinion(c.ID, c.Opinion, now)
+	}
+	for _, id := range statement.RemovedConflicts {
+		delete(v.activeConflicts, id)
+	}
+}
+
+func (v *View) applyTimestamps(statement *Statement, now time.Time) {
+	v.tMutex.Lock()
+	defer v.tMutex.Unlock()
+
+	if statement.Checkpoint {
+		v.activeTimestamps = make(map[tangle.MessageID]Opinion, len(statement.Timestamps))
+	}
+
+	if statement.RoundsElapsed > 0 {
+		changed := make(map[tangle.MessageID]bool, len(statement.Timestamps))
+		for _, t := range statement.Timestamps {
+			changed[t.ID] = true
+		}
+		for id, o := range v.activeTimestamps {
+			if !changed[id] {
+				v.recordTimestampOpinion(id, Opinion{Value: o.Value, Round: o.Round + statement.RoundsElapsed}, now)
+			}
+		}
+	}
+
+	for _, t := range statement.Timestamps {
+		v.recordTimestampOpinion(t.ID, t.Opinion, now)
+	}
+	for _, id := range statement.RemovedTimestamps {
+		delete(v.activeTimestamps, id)
+	}
+}
+
+// recordConflictOpinion sets the active opinion about the given conflict and adds it to its history. An opinion of the
+// same round as the latest one replaces it. The caller needs to hold the cMutex.
+func (v *View) recordConflictOpinion(id ledgerstate.TransactionID, o Opinion, now time.Time) {
+	v.activeConflicts[id] = o
+
+	entry, exists := v.Conflicts[id]
+	if !exists {
+		entry.Timestamp = now
+	}
+	if last := len(entry.Opinions) - 1; last >= 0 && entry.Opinions[last].Round == o.Round {
+		entry.Opinions[last] = o
+	} else {
+		entry.Opinions = append(entry.Opinions, o)
+	}
+	v.Conflicts[id] = entry
+}
+
+// recordTimestampOpinion sets the active opinion about the given timestamp and adds it to its history. An opinion of
+// the same round as the latest one replaces it. The caller needs to hold the tMutex.
+func (v *View) recordTimestampOpinion(id tangle.MessageID, o Opinion, now time.Time) {
+	v.activeTimestamps[id] = o
+
+	entry, exists := v.Timestamps[id]
+	if !exists {
+		entry.Timestamp = now
+	}
+	if last := len(entry.Opinions) - 1; last >= 0 && entry.Opinions[last].Round == o.Round {
+		entry.Opinions[last] = o
+	} else {
+		entry.Opinions = append(entry.Opinions, o)
+	}
+	v.Timestamps[id] = entry
 }
 
 // AddConflict appends the given conflict to the given view.
@@ -216,8 +485,13 @@ func (v *View) TimestampOpinion(id tangle.MessageID) Opinions {
 	return v.Timestamps[id].Opinions
 }
 
-// Query retrieves the opinions about the given conflicts and timestamps.
+// Query retrieves the opinions about the given conflicts and timestamps. It fails with ErrMissingStatements if the view
+// is incomplete, as its opinions might be outdated.
 func (v *View) Query(ctx context.Context, conflictIDs []string, timestampIDs []string) (opinion.Opinions, error) {
+	if !v.Complete() {
+		return nil, errors.Errorf("view of node %s is incomplete: %w", v.NodeID, ErrMissingStatements)
+	}
+
 	answer := opinion.Opinions{}
 	for _, id := range conflictIDs {
 		ID, err := ledgerstate.TransactionIDFromBase58(id)
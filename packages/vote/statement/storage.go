@@ -0,0 +1,327 @@
+package statement
+
+import (
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/iotaledger/hive.go/byteutils"
+	"github.com/iotaledger/hive.go/cerrors"
+	"github.com/iotaledger/hive.go/identity"
+	"github.com/iotaledger/hive.go/kvstore"
+	"github.com/iotaledger/hive.go/marshalutil"
+	"github.com/iotaledger/hive.go/objectstorage"
+	"github.com/iotaledger/hive.go/stringify"
+
+	"github.com/iotaledger/goshimmer/packages/database"
+	"github.com/iotaledger/goshimmer/packages/tangle"
+)
+
+const (
+	// PrefixNodeStatement defines the storage prefix for the NodeStatements.
+	PrefixNodeStatement byte = iota
+
+	nodeStatementCacheTime = 10 * time.Second
+)
+
+// region Storage //////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// Storage persists the Statements that were received from other nodes, so that the Registry can be restored after a
+// restart and the history of the stated opinions can be inspected.
+type Storage struct {
+	nodeStatementStorage *objectstorage.ObjectStorage
+	shutdownOnce         sync.Once
+}
+
+// NewStorage is the constructor of the Storage.
+func NewStorage(store kvstore.KVStore, cacheProvider *database.CacheTimeProvider) *Storage {
+	osFactory := objectstorage.NewFactory(store, database.PrefixStatement)
+
+	return &Storage{
+		nodeStatementStorage: osFactory.New(PrefixNodeStatement, NodeStatementFromObjectStorage, cacheProvider.CacheTime(nodeStatementCacheTime), NodeStatementKeyPartition, objectstorage.LeakDetectionEnabled(false)),
+	}
+}
+
+// StoreNodeStatement stores the given NodeStatement and returns true if it was not stored before.
+func (s *Storage) StoreNodeStatement(nodeStatement *NodeStatement) (stored bool) {
+	cachedNodeStatement, stored := s.nodeStatementStorage.StoreIfAbsent(nodeStatement)
+	if stored {
+		cachedNodeStatement.Release()
+	}
+
+	return stored
+}
+
+// NodeStatements returns the stored NodeStatements of the given node ordered by their received time.
+func (s *Storage) NodeStatements(nodeID identity.ID) (nodeStatements NodeStatements) {
+	s.nodeStatementStorage.ForEach(func(key []byte, cachedObject objectstorage.CachedObject) bool {
+		(&CachedNodeStatement{CachedObject: cachedObject}).Consume(func(nodeStatement *NodeStatement) {
+			nodeStatements = append(nodeStatements, nodeStatement)
+		})
+		return true
+	}, objectstorage.WithIteratorPrefix(nodeID.Bytes()))
+	nodeStatements.sort()
+
+	return nodeStatements
+}
+
+// AllNodeStatements returns the stored NodeStatements of all nodes ordered by their received time.
+func (s *Storage) AllNodeStatements() map[identity.ID]NodeStatements {
+	nodeStatements := make(map[identity.ID]NodeStatements)
+	s.nodeStatementStorage.ForEach(func(key []byte, cachedObject objectstorage.CachedObject) bool {
+		(&CachedNodeStatement{CachedObject: cachedObject}).Consume(func(nodeStatement *NodeStatement) {
+			nodeStatements[nodeStatement.NodeID()] = append(nodeStatements[nodeStatement.NodeID()], nodeStatement)
+		})
+		return true
+	})
+	for _, statements := range nodeStatements {
+		statements.sort()
+	}
+
+	return nodeStatements
+}
+
+// DeleteNodeStatementsBefore deletes all the NodeStatements that were received before the given time and returns the
+// amount of deleted NodeStatements.
+func (s *Storage) DeleteNodeStatementsBefore(t time.Time) (deleted int) {
+	s.nodeStatementStorage.ForEach(func(key []byte, cachedObject objectstorage.CachedObject) bool {
+		(&CachedNodeStatement{CachedObject: cachedObject}).Consume(func(nodeStatement *NodeStatement) {
+			if nodeStatement.ReceivedTime().Before(t) {
+				nodeStatement.Delete()
+				deleted++
+			}
+		})
+		return true
+	})
+
+	return deleted
+}
+
+// Shutdown shuts down the Storage and persists its state.
+func (s *Storage) Shutdown() {
+	s.shutdownOnce.Do(func() {
+		s.nodeStatementStorage.Shutdown()
+	})
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region NodeStatement ////////////////////////////////////////////////////////////////////////////////////////////////
+
+// NodeStatementKeyPartition defines the partition of the storage key of the NodeStatement model.
+var NodeStatementKeyPartition = objectstorage.PartitionKey(identity.IDLength, marshalutil.Int64Size, marshalutil.Uint64Size)
+
+// NodeStatement is the persisted version of a Statement that was issued by a node together with the message that
+// contained it. It is ordered and retained by the local time at which the message was received, as the issuing time of
+// the message is chosen by its issuer.
+type NodeStatement struct {
+	nodeID       identity.ID
+	receivedTime time.Time
+	messageID    tangle.MessageID
+	statement    *Statement
+
+	objectstorage.StorableObjectFlags
+}
+
+// NewNodeStatement is the constructor of a NodeStatement.
+func NewNodeStatement(nodeID identity.ID, messageID tangle.MessageID, receivedTime time.Time, statement *Statement) *NodeStatement {
+	return &NodeStatement{
+		nodeID:       nodeID,
+		receivedTime: receivedTime,
+		messageID:    messageID,
+		statement:    statement,
+	}
+}
+
+// NodeStatementFromBytes unmarshals a NodeStatement from a sequence of bytes.
+func NodeStatementFromBytes(bytes []byte) (nodeStatement *NodeStatement, consumedBytes int, err error) {
+	marshalUtil := marshalutil.New(bytes)
+	if nodeStatement, err = NodeStatementFromMarshalUtil(marshalUtil); err != nil {
+		err = errors.Errorf("failed to parse NodeStatement from MarshalUtil: %w", err)
+		return
+	}
+	consumedBytes = marshalUtil.ReadOffset()
+
+	return
+}
+
+// NodeStatementFromMarshalUtil unmarshals a NodeStatement using a MarshalUtil (for easier unmarshaling).
+func NodeStatementFromMarshalUtil(marshalUtil *marshalutil.MarshalUtil) (nodeStatement *NodeStatement, err error) {
+	nodeStatement = &NodeStatement{}
+	nodeIDBytes, err := marshalUtil.ReadBytes(identity.IDLength)
+	if err != nil {
+		err = errors.Errorf("failed to parse node ID (%v): %w", err, cerrors.ErrParseBytesFailed)
+		return
+	}
+	copy(nodeStatement.nodeID[:], nodeIDBytes)
+	receivedTime, err := marshalUtil.ReadInt64()
+	if err != nil {
+		err = errors.Errorf("failed to parse received time (%v): %w", err, cerrors.ErrParseBytesFailed)
+		return
+	}
+	nodeStatement.receivedTime = time.Unix(0, receivedTime)
+	// the sequence number is part of the key to order Statements with the same received time, it is restored from the
+	// Statement itself
+	if _, err = marshalUtil.ReadUint64(); err != nil {
+		err = errors.Errorf("failed to parse sequence number (%v): %w", err, cerrors.ErrParseBytesFailed)
+		return
+	}
+	if nodeStatement.messageID, err = tangle.MessageIDFromMarshalUtil(marshalUtil); err != nil {
+		err = errors.Errorf("failed to parse MessageID from MarshalUtil: %w", err)
+		return
+	}
+	if nodeStatement.statement, err = Parse(marshalUtil); err != nil {
+		err = errors.Errorf("failed to parse Statement from MarshalUtil: %w", err)
+		return
+	}
+
+	return
+}
+
+// NodeStatementFromObjectStorage restores a NodeStatement object that was stored in the ObjectStorage.
+func NodeStatementFromObjectStorage(key []byte, data []byte) (nodeStatement objectstorage.StorableObject, err error) {
+	if nodeStatement, _, err = NodeStatementFromBytes(byteutils.ConcatBytes(key, data)); err != nil {
+		err = errors.Errorf("failed to parse NodeStatement from bytes: %w", err)
+		return
+	}
+
+	return
+}
+
+// NodeID returns the identifier of the node that issued the Statement.
+func (n *NodeStatement) NodeID() identity.ID {
+	return n.nodeID
+}
+
+// ReceivedTime returns the local time at which the message that contained the Statement was received.
+func (n *NodeStatement) ReceivedTime() time.Time {
+	return n.receivedTime
+}
+
+// MessageID returns the identifier of the message that contained the Statement.
+func (n *NodeStatement) MessageID() tangle.MessageID {
+	return n.messageID
+}
+
+// Statement returns the Statement that was issued by the node.
+func (n *NodeStatement) Statement() *Statement {
+	return n.statement
+}
+
+// Bytes returns a marshaled version of the NodeStatement.
+func (n *NodeStatement) Bytes() []byte {
+	return byteutils.ConcatBytes(n.ObjectStorageKey(), n.ObjectStorageValue())
+}
+
+// String returns a human readable version of the NodeStatement.
+func (n *NodeStatement) String() string {
+	return stringify.Struct("NodeStatement",
+		stringify.StructField("nodeID", n.NodeID()),
+		stringify.StructField("receivedTime", n.ReceivedTime()),
+		stringify.StructField("messageID", n.MessageID()),
+		stringify.StructField("statement", n.Statement()),
+	)
+}
+
+// Update is disabled and panics if it ever gets called - it is required to match the StorableObject interface.
+func (n *NodeStatement) Update(objectstorage.StorableObject) {
+	panic("updates disabled")
+}
+
+// ObjectStorageKey returns the key that is used to store the object in the database. It is required to match the
+// StorableObject interface.
+func (n *NodeStatement) ObjectStorageKey() []byte {
+	return marshalutil.New(identity.IDLength + marshalutil.Int64Size + marshalutil.Uint64Size).
+		WriteBytes(n.nodeID.Bytes()).
+		WriteInt64(n.receivedTime.UnixNano()).
+		WriteUint64(n.statement.SequenceNumber).
+		Bytes()
+}
+
+// ObjectStorageValue marshals the NodeStatement into a sequence of bytes. The node ID, the received time and the
+// sequence number are not serialized here as they are only used as a key in the ObjectStorage.
+func (n *NodeStatement) ObjectStorageValue() []byte {
+	return marshalutil.New().
+		Write(n.messageID).
+		WriteBytes(n.statement.Bytes()).
+		Bytes()
+}
+
+// code contract (make sure the type implements all required methods)
+var _ objectstorage.StorableObject = &NodeStatement{}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region NodeStatements ///////////////////////////////////////////////////////////////////////////////////////////////
+
+// NodeStatements represents a collection of NodeStatements.
+type NodeStatements []*NodeStatement
+
+// String returns a human readable version of the NodeStatements.
+func (n NodeStatements) String() string {
+	structBuilder := stringify.StructBuilder("NodeStatements")
+	for i, nodeStatement := range n {
+		structBuilder.AddField(stringify.StructField(strconv.Itoa(i), nodeStatement))
+	}
+
+	return structBuilder.String()
+}
+
+// sort orders the NodeStatements by their received time and sequence number.
+func (n NodeStatements) sort() {
+	sort.Slice(n, func(i, j int) bool {
+		if !n[i].receivedTime.Equal(n[j].receivedTime) {
+			return n[i].receivedTime.Before(n[j].receivedTime)
+		}
+		return n[i].statement.SequenceNumber < n[j].statement.SequenceNumber
+	})
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region CachedNodeStatement //////////////////////////////////////////////////////////////////////////////////////////
+
+// CachedNodeStatement is a wrapper for the generic CachedObject returned by the object storage that overrides the
+// accessor methods with a type-casted one.
+type CachedNodeStatement struct {
+	objectstorage.CachedObject
+}
+
+// Retain marks this CachedObject to still be in use by the program.
+func (c *CachedNodeStatement) Retain() *CachedNodeStatement {
+	return &CachedNodeStatement{c.CachedObject.Retain()}
+}
+
+// Unwrap is the type-casted equivalent of Get. It returns nil if the object does not exist.
+func (c *CachedNodeStatement) Unwrap() *NodeStatement {
+	untypedObject := c.Get()
+	if untypedObject == nil {
+		return nil
+	}
+
+	typedObject := untypedObject.(*NodeStatement)
+	if typedObject == nil || typedObject.IsDeleted() {
+		return nil
+	}
+
+	return typedObject
+}
+
+// Consume unwraps the CachedObject and passes a type-casted version to the consumer. It automatically releases the
+// object when the consumer finishes and returns true of there was at least one object that was consumed.
+func (c *CachedNodeStatement) Consume(consumer func(nodeStatement *NodeStatement), forceRelease ...bool) (consumed bool) {
+	return c.CachedObject.Consume(func(object objectstorage.StorableObject) {
+		consumer(object.(*NodeStatement))
+	}, forceRelease...)
+}
+
+// String returns a human readable version of the CachedNodeStatement.
+func (c *CachedNodeStatement) String() string {
+	return stringify.Struct("CachedNodeStatement",
+		stringify.StructField("CachedObject", c.Unwrap()),
+	)
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
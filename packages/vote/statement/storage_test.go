@@ -0,0 +1,81 @@
+package statement
+
+import (
+	"testing"
+	"time"
+
+	"github.com/iotaledger/hive.go/identity"
+	"github.com/iotaledger/hive.go/kvstore/mapdb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/goshimmer/packages/database"
+	"github.com/iotaledger/goshimmer/packages/ledgerstate"
+	"github.com/iotaledger/goshimmer/packages/tangle"
+	"github.com/iotaledger/goshimmer/packages/vote/opinion"
+)
+
+func TestNodeStatement_Bytes(t *testing.T) {
+	txA, err := ledgerstate.TransactionIDFromRandomness()
+	require.NoError(t, err)
+
+	nodeStatement := NewNodeStatement(identity.GenerateIdentity().ID(), tangle.EmptyMessageID, time.Now(),
+		NewDelta(3, 1, Conflicts{{txA, Opinion{opinion.Like, 4}}}, Timestamps{}, nil, []tangle.MessageID{tangle.EmptyMessageID}))
+
+	restoredNodeStatement, consumedBytes, err := NodeStatementFromBytes(nodeStatement.Bytes())
+	require.NoError(t, err)
+	assert.Equal(t, len(nodeStatement.Bytes()), consumedBytes)
+	assert.Equal(t, nodeStatement.Bytes(), restoredNodeStatement.Bytes())
+	assert.Equal(t, nodeStatement.NodeID(), restoredNodeStatement.NodeID())
+	assert.True(t, nodeStatement.ReceivedTime().Equal(restoredNodeStatement.ReceivedTime()))
+	assert.Equal(t, nodeStatement.Statement().Conflicts, restoredNodeStatement.Statement().Conflicts)
+}
+
+func TestPersistentRegistry(t *testing.T) {
+	store := mapdb.NewMapDB()
+	nodeID := identity.GenerateIdentity().ID()
+	txA, err := ledgerstate.TransactionIDFromRandomness()
+	require.NoError(t, err)
+
+	registry := NewPersistentRegistry(NewStorage(store, database.NewCacheTimeProvider(0)), time.Hour)
+	receivedTime := time.Now()
+	require.NoError(t, registry.ProcessStatement(nodeID, tangle.EmptyMessageID, receivedTime, NewCheckpoint(0, Conflicts{{txA, Opinion{opinion.Like, 1}}}, Timestamps{})))
+	require.NoError(t, registry.ProcessStatement(nodeID, tangle.EmptyMessageID, receivedTime.Add(time.Second), NewDelta(1, 1, Conflicts{}, Timestamps{}, nil, nil)))
+
+	// stale statements are not persisted
+	assert.Error(t, registry.ProcessStatement(nodeID, tangle.EmptyMessageID, receivedTime.Add(2*time.Second), NewDelta(1, 1, Conflicts{}, Timestamps{}, nil, nil)))
+	assert.Len(t, registry.NodeStatements(nodeID), 2)
+	registry.Shutdown()
+
+	// the views are restored after a restart
+	registry = NewPersistentRegistry(NewStorage(store, database.NewCacheTimeProvider(0)), time.Hour)
+	nodeStatements := registry.NodeStatements(nodeID)
+	require.Len(t, nodeStatements, 2)
+	assert.EqualValues(t, 0, nodeStatements[0].Statement().SequenceNumber)
+	assert.EqualValues(t, 1, nodeStatements[1].Statement().SequenceNumber)
+
+	assert.True(t, registry.NodeView(nodeID).Complete())
+	assert.Equal(t, Conflicts{{txA, Opinion{opinion.Like, 2}}}, registry.NodeView(nodeID).ActiveConflicts())
+	assert.Equal(t, map[identity.ID]Opinions{nodeID: {{opinion.Like, 1}, {opinion.Like, 2}}}, registry.ConflictOpinions(txA))
+
+	// statements older than the retention window are deleted
+	registry.Shutdown()
+	registry = NewPersistentRegistry(NewStorage(store, database.NewCacheTimeProvider(0)), time.Since(receivedTime.Add(time.Second/2)))
+	assert.Len(t, registry.NodeStatements(nodeID), 1)
+	registry.Shutdown()
+}
+
+func TestMajorityOpinion(t *testing.T) {
+	nodeA, nodeB, nodeC := identity.GenerateIdentity().ID(), identity.GenerateIdentity().ID(), identity.GenerateIdentity().ID()
+
+	assert.Equal(t, opinion.Unknown, MajorityOpinion(map[identity.ID]Opinions{}))
+	assert.Equal(t, opinion.Like, MajorityOpinion(map[identity.ID]Opinions{
+		nodeA: {{opinion.Dislike, 1}, {opinion.Like, 2}},
+		nodeB: {{opinion.Like, 1}},
+		nodeC: {{opinion.Dislike, 1}},
+	}))
+	assert.Equal(t, opinion.Unknown, MajorityOpinion(map[identity.ID]Opinions{
+		nodeA: {{opinion.Like, 1}},
+		nodeB: {{opinion.Dislike, 1}},
+	}))
+}
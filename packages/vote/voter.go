@@ -26,8 +26,8 @@ type Voter interface {
 // were generated in a decentralized fashion.
 type DRNGRoundBasedVoter interface {
 	Voter
-	// Round starts a new round.
-	Round(rand float64, delayedRoundStart ...time.Duration) error
+	// Round starts a new round using the given random number of the given dRNG round.
+	Round(rand float64, round uint32, delayedRoundStart ...time.Duration) error
 }
 
 // Events defines events which happen on a Voter.
@@ -55,6 +55,22 @@ type RoundStats struct {
 	ActiveVoteContexts map[string]*Context `json:"active_vote_contexts"`
 	// The opinions which were queried during the round per opinion giver.
 	QueriedOpinions []opinion.QueriedOpinions `json:"queried_opinions"`
+	// The name of the strategy used to select the opinion givers to query.
+	SamplingStrategy string `json:"sampling_strategy"`
+	// The composition of the sample of opinion givers queried during the round.
+	SampleComposition SampleComposition `json:"sample_composition"`
+}
+
+// SampleComposition describes the opinion givers that were selected to be queried in a round.
+type SampleComposition struct {
+	// The amount of opinion givers that were available for sampling.
+	OpinionGivers int `json:"opinion_givers"`
+	// The amount of distinct opinion givers that were selected.
+	Selected int `json:"selected"`
+	// The amount of selections, counting opinion givers that were selected multiple times.
+	Selections int `json:"selections"`
+	// The share of the total mana of the available opinion givers that is held by the selected ones.
+	SelectedManaShare float64 `json:"selected_mana_share"`
 }
 
 // OpinionEvent is the struct containing data to be passed around with Finalized and Failed events.
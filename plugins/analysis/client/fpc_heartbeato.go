@@ -38,6 +38,8 @@ func onRoundExecuted(roundStats *vote.RoundStats) {
 			Duration:           roundStats.Duration,
 			RandUsed:           roundStats.RandUsed,
 			ActiveVoteContexts: chunk,
+			SamplingStrategy:   roundStats.SamplingStrategy,
+			SampleComposition:  roundStats.SampleComposition,
 		}
 
 		hb := &packet.FPCHeartbeat{
@@ -15,6 +15,7 @@ import (
 	"github.com/iotaledger/goshimmer/plugins/messagelayer"
 	ledgerstateAPI "github.com/iotaledger/goshimmer/plugins/webapi/ledgerstate"
 	manaAPI "github.com/iotaledger/goshimmer/plugins/webapi/mana"
+	statementAPI "github.com/iotaledger/goshimmer/plugins/webapi/statement"
 )
 
 // ExplorerMessage defines the struct of the ExplorerMessage.
@@ -175,6 +176,9 @@ func setupExplorerRoutes(routeGroup *echo.Group) {
 	routeGroup.GET("/branch/:branchID", ledgerstateAPI.GetBranch)
 	routeGroup.GET("/branch/:branchID/children", ledgerstateAPI.GetBranchChildren)
 	routeGroup.GET("/branch/:branchID/conflicts", ledgerstateAPI.GetBranchConflicts)
+	routeGroup.GET("/conflict/:conflictID/timeline", ledgerstateAPI.GetConflictTimeline)
+	routeGroup.GET("/statements/conflict/:transactionID", statementAPI.GetConflictOpinions)
+	routeGroup.GET("/statements/timestamp/:messageID", statementAPI.GetTimestampOpinions)
 	routeGroup.POST("/chat", chat.SendChatMessage)
 
 	routeGroup.GET("/search/:search", func(c echo.Context) error {
@@ -223,7 +227,7 @@ func findMessage(messageID tangle.MessageID) (explorerMsg *ExplorerMessage, err
 }
 
 func findAddress(strAddress string) (*ExplorerAddress, error) {
-	address, err := ledgerstate.AddressFromBase58EncodedString(strAddress)
+	address, err := ledgerstate.AddressFromString(strAddress)
 	if err != nil {
 		return nil, fmt.Errorf("%w: address %s", ErrNotFound, strAddress)
 	}
@@ -92,6 +92,7 @@ export class ExplorerBranchQueryResult extends React.Component<Props, any> {
                             {branchConflicts && <ListGroup>
                                 {branchConflicts.conflicts.map((c,i) => <div key={i}>
                                     OutputID: <a href={`/explorer/output/${c.outputID.base58}`}>{c.outputID.base58}</a>
+                                    {' '}(<a href={`/explorer/conflict/${c.outputID.base58}`}>timeline</a>)
                                     <ListGroup className={"mb-2"}>
                                         {c.branchIDs.map((b,j) => <ListGroup.Item key={j}>
                                             <a href={`/explorer/branch/${b}`}>{resolveBase58BranchID(b)}</a>
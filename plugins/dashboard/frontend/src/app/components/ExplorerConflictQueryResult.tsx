@@ -0,0 +1,109 @@
+import * as React from 'react';
+import Container from "react-bootstrap/Container";
+import NodeStore from "app/stores/NodeStore";
+import { inject, observer } from "mobx-react";
+import ExplorerStore, {ConflictTimelineEntry} from "app/stores/ExplorerStore";
+import ListGroup from "react-bootstrap/ListGroup";
+import Badge from "react-bootstrap/Badge";
+import Table from "react-bootstrap/Table";
+import {resolveBase58BranchID} from "app/utils/branch";
+
+
+interface Props {
+    nodeStore?: NodeStore;
+    explorerStore?: ExplorerStore;
+    match?: {
+        params: {
+            id: string,
+        }
+    }
+}
+
+@inject("nodeStore")
+@inject("explorerStore")
+@observer
+export class ExplorerConflictQueryResult extends React.Component<Props, any> {
+    componentDidMount() {
+        this.props.explorerStore.getConflictTimeline(this.props.match.params.id);
+    }
+
+    componentWillUnmount() {
+        this.props.explorerStore.reset();
+    }
+    render() {
+        let {id} = this.props.match.params;
+        let { query_err, conflictTimeline } = this.props.explorerStore;
+
+        if (query_err) {
+            return (
+                <Container>
+                    <h4>Conflict not found - 404</h4>
+                    <span>{id}</span>
+                </Container>
+            );
+        }
+        let renderOpinion = (liked: boolean) => {
+            return liked ? <Badge variant="success">like</Badge> : <Badge variant="danger">dislike</Badge>
+        }
+        let renderDetails = (entry: ConflictTimelineEntry) => {
+            switch (entry.type) {
+                case "BranchCreated":
+                    return <span>member created</span>
+                case "Opinion":
+                    return <span>FCoB {renderOpinion(entry.liked)} with level of knowledge {entry.levelOfKnowledge || 0}</span>
+                case "VotingRound":
+                    return <span>FPC round {entry.round}: {renderOpinion(entry.liked)} ({((entry.proportionLiked || 0) * 100).toFixed(2)}% of queried nodes liked)</span>
+                case "VotingFinalized":
+                    return <span>FPC finalized as {renderOpinion(entry.liked)} after {entry.round || 0} rounds</span>
+                case "VotingFailed":
+                    return <span>FPC failed after {entry.round || 0} rounds, last opinion {renderOpinion(entry.liked)}</span>
+                case "ApprovalWeight":
+                    return <span>approval weight reached {((entry.approvalWeight || 0) * 100).toFixed(2)}%</span>
+                case "Decision":
+                    return entry.liked ? <Badge variant="success">confirmed</Badge> : <Badge variant="danger">rejected</Badge>
+            }
+            return <span>{entry.type}</span>
+        }
+        let renderDecision = (branchID: string) => {
+            let decision = conflictTimeline.timeline.find(entry => entry.type === "Decision" && entry.branchID === branchID);
+            if (!decision) {
+                return <Badge variant="warning">pending</Badge>
+            }
+            return <span>
+                {decision.liked ? <Badge variant="success">confirmed</Badge> : <Badge variant="danger">rejected</Badge>}
+                {' '}at {new Date(decision.timestamp / 1000000).toLocaleString()}
+            </span>
+        }
+        return (
+            <Container>
+                <h4>Conflict</h4>
+                {conflictTimeline && <ListGroup className={"mb-2"}>
+                    <ListGroup.Item>ID: <a href={`/explorer/output/${conflictTimeline.conflictID}`}>{conflictTimeline.conflictID}</a></ListGroup.Item>
+                    <ListGroup.Item>Members:
+                        <ListGroup>
+                            {conflictTimeline.branchIDs.map((b,i) => <ListGroup.Item key={i}>
+                                <a href={`/explorer/branch/${b}`}>{resolveBase58BranchID(b)}</a> {renderDecision(b)}
+                            </ListGroup.Item>)}
+                        </ListGroup>
+                    </ListGroup.Item>
+                </ListGroup>}
+                {conflictTimeline && <Table striped hover size="sm">
+                    <thead>
+                        <tr>
+                            <th>Time</th>
+                            <th>Branch</th>
+                            <th>Step</th>
+                        </tr>
+                    </thead>
+                    <tbody>
+                        {conflictTimeline.timeline.map((entry, i) => <tr key={i}>
+                            <td>{new Date(entry.timestamp / 1000000).toLocaleString()}</td>
+                            <td><a href={`/explorer/branch/${entry.branchID}`}>{resolveBase58BranchID(entry.branchID)}</a></td>
+                            <td>{renderDetails(entry)}</td>
+                        </tr>)}
+                    </tbody>
+                </Table>}
+            </Container>
+        )
+    }
+}
@@ -133,6 +133,9 @@ export class ExplorerMessageQueryResult extends React.Component<Props, any> {
                                     <ListGroup.Item>
                                         Solid: {msg.solid ? 'Yes' : 'No'}
                                     </ListGroup.Item>
+                                    <ListGroup.Item>
+                                        Timestamp Statements: <Link to={`/explorer/opinions/timestamp/${id}`}>stated opinions</Link>
+                                    </ListGroup.Item>
                                     <ListGroup.Item>
                                         Scheduled: {msg.scheduled ? 'Yes' : 'No'}
                                     </ListGroup.Item>
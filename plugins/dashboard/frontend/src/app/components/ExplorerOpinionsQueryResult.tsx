@@ -0,0 +1,86 @@
+import * as React from 'react';
+import Container from "react-bootstrap/Container";
+import NodeStore from "app/stores/NodeStore";
+import { inject, observer } from "mobx-react";
+import ExplorerStore, {NodeOpinions} from "app/stores/ExplorerStore";
+import ListGroup from "react-bootstrap/ListGroup";
+import Badge from "react-bootstrap/Badge";
+import Table from "react-bootstrap/Table";
+
+
+interface Props {
+    nodeStore?: NodeStore;
+    explorerStore?: ExplorerStore;
+    match?: {
+        params: {
+            type: string,
+            id: string,
+        }
+    }
+}
+
+@inject("nodeStore")
+@inject("explorerStore")
+@observer
+export class ExplorerOpinionsQueryResult extends React.Component<Props, any> {
+    componentDidMount() {
+        this.props.explorerStore.getNodesOpinions(this.props.match.params.type, this.props.match.params.id);
+    }
+
+    componentWillUnmount() {
+        this.props.explorerStore.reset();
+    }
+    render() {
+        let {type, id} = this.props.match.params;
+        let { query_err, nodesOpinions } = this.props.explorerStore;
+
+        if (query_err) {
+            return (
+                <Container>
+                    <h4>No statements found - 404</h4>
+                    <span>{id}</span>
+                </Container>
+            );
+        }
+        let renderOpinion = (value: string) => {
+            switch (value) {
+                case "Like":
+                    return <Badge variant="success">like</Badge>
+                case "Dislike":
+                    return <Badge variant="danger">dislike</Badge>
+            }
+            return <Badge variant="secondary">unknown</Badge>
+        }
+        let renderHistory = (node: NodeOpinions) => {
+            return node.opinions.map((o, i) => <span key={i}>{o.round}: {renderOpinion(o.value)}{' '}</span>)
+        }
+        let link = type === "conflict" ? `/explorer/transaction/${id}` : `/explorer/message/${id}`;
+        let diverging = nodesOpinions ? nodesOpinions.nodes.filter(node => node.diverging).length : 0;
+        return (
+            <Container>
+                <h4>Stated opinions</h4>
+                {nodesOpinions && <ListGroup className={"mb-2"}>
+                    <ListGroup.Item>{type === "conflict" ? "Transaction" : "Timestamp of message"}: <a href={link}>{id}</a></ListGroup.Item>
+                    <ListGroup.Item>Majority: {renderOpinion(nodesOpinions.majority)}</ListGroup.Item>
+                    <ListGroup.Item>Nodes: {nodesOpinions.nodes.length} ({diverging} diverging from the majority)</ListGroup.Item>
+                </ListGroup>}
+                {nodesOpinions && <Table striped hover size="sm">
+                    <thead>
+                        <tr>
+                            <th>Node</th>
+                            <th>Last opinion</th>
+                            <th>History (round: opinion)</th>
+                        </tr>
+                    </thead>
+                    <tbody>
+                        {nodesOpinions.nodes.map((node, i) => <tr key={i} className={node.diverging ? "table-warning" : ""}>
+                            <td title={node.nodeID}>{node.shortNodeID} {node.diverging && <Badge variant="warning">diverging</Badge>}</td>
+                            <td>{renderOpinion(node.last)}</td>
+                            <td>{renderHistory(node)}</td>
+                        </tr>)}
+                    </tbody>
+                </Table>}
+            </Container>
+        )
+    }
+}
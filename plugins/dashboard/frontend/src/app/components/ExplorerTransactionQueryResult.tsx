@@ -3,6 +3,7 @@ import Container from "react-bootstrap/Container";
 import { ExplorerTransaction } from "app/components/ExplorerTransaction"
 import { ExplorerTransactionMetadata } from "app/components/ExplorerTransactionMetadata"
 import { ExplorerTransactionAttachments } from "app/components/ExplorerTransactionAttachments"
+import { Link } from "react-router-dom";
 
 
 interface Props {
@@ -21,6 +22,7 @@ export class ExplorerTransactionQueryResult extends React.Component<Props, any>
                 <ExplorerTransaction txId={id}/>
                 <ExplorerTransactionMetadata txId={id}/>
                 <ExplorerTransactionAttachments txId={id}/>
+                <Link to={`/explorer/opinions/conflict/${id}`}>Opinions stated by other nodes</Link>
             </Container>
         )
     }
@@ -21,6 +21,8 @@ import {Mana} from "app/components/Mana";
 import {ExplorerTransactionQueryResult} from "app/components/ExplorerTransactionQueryResult";
 import {ExplorerOutputQueryResult} from "app/components/ExplorerOutputQueryResult";
 import {ExplorerBranchQueryResult} from "app/components/ExplorerBranchQueryResult";
+import {ExplorerConflictQueryResult} from "app/components/ExplorerConflictQueryResult";
+import {ExplorerOpinionsQueryResult} from "app/components/ExplorerOpinionsQueryResult";
 
 interface Props {
     history: any;
@@ -98,6 +100,8 @@ export class Root extends React.Component<Props, any> {
                     <Route exact path="/explorer/transaction/:id" component={ExplorerTransactionQueryResult}/>
                     <Route exact path="/explorer/output/:id" component={ExplorerOutputQueryResult}/>
                     <Route exact path="/explorer/branch/:id" component={ExplorerBranchQueryResult}/>
+                    <Route exact path="/explorer/conflict/:id" component={ExplorerConflictQueryResult}/>
+                    <Route exact path="/explorer/opinions/:type/:id" component={ExplorerOpinionsQueryResult}/>
                     <Route exact path="/explorer/404/:search" component={Explorer404}/>
                     <Route exact path="/drng" component={Drng}/>
                     <Route exact path="/chat" component={Chat}/>
@@ -125,6 +125,42 @@ class BranchConflicts {
     conflicts: Array<BranchConflict>
 }
 
+export class ConflictTimelineEntry {
+    timestamp: number;
+    type: string;
+    branchID: string;
+    liked: boolean;
+    levelOfKnowledge: number;
+    round: number;
+    proportionLiked: number;
+    approvalWeight: number;
+}
+
+class ConflictTimeline {
+    conflictID: string;
+    branchIDs: Array<string>;
+    timeline: Array<ConflictTimelineEntry>;
+}
+
+class RoundOpinion {
+    value: string;
+    round: number;
+}
+
+export class NodeOpinions {
+    nodeID: string;
+    shortNodeID: string;
+    opinions: Array<RoundOpinion>;
+    last: string;
+    diverging: boolean;
+}
+
+class NodesOpinions {
+    id: string;
+    majority: string;
+    nodes: Array<NodeOpinions>;
+}
+
 export class InclusionState {
 	liked: boolean;
 	rejected: boolean;
@@ -167,6 +203,8 @@ export class ExplorerStore {
     @observable branch: Branch = null;
     @observable branchChildren: BranchChildren = null;
     @observable branchConflicts: BranchConflicts = null;
+    @observable conflictTimeline: ConflictTimeline = null;
+    @observable nodesOpinions: NodesOpinions = null;
 
     // loading
     @observable query_loading: boolean = false;
@@ -434,6 +472,42 @@ export class ExplorerStore {
         }
     }
 
+    getConflictTimeline = async (id: string) => {
+        try {
+            let res = await fetch(`/api/conflict/${id}/timeline`)
+            if (res.status === 404) {
+                this.updateQueryError(QueryError.NotFound);
+                return;
+            }
+            if (res.status === 400) {
+                this.updateQueryError(QueryError.BadRequest);
+                return;
+            }
+            let conflictTimeline: ConflictTimeline = await res.json()
+            this.updateConflictTimeline(conflictTimeline)
+        } catch (err) {
+            this.updateQueryError(err);
+        }
+    }
+
+    getNodesOpinions = async (type: string, id: string) => {
+        try {
+            let res = await fetch(`/api/statements/${type}/${id}`)
+            if (res.status === 404) {
+                this.updateQueryError(QueryError.NotFound);
+                return;
+            }
+            if (res.status === 400) {
+                this.updateQueryError(QueryError.BadRequest);
+                return;
+            }
+            let nodesOpinions: NodesOpinions = await res.json()
+            this.updateNodesOpinions(nodesOpinions)
+        } catch (err) {
+            this.updateQueryError(err);
+        }
+    }
+
     @action
     reset = () => {
         this.msg = null;
@@ -449,6 +523,8 @@ export class ExplorerStore {
         this.branch = null;
         this.branchChildren = null;
         this.branchConflicts = null;
+        this.conflictTimeline = null;
+        this.nodesOpinions = null;
     };
 
     @action
@@ -508,6 +584,16 @@ export class ExplorerStore {
         this.branchConflicts = conflicts;
     }
 
+    @action
+    updateConflictTimeline = (conflictTimeline: ConflictTimeline) => {
+        this.conflictTimeline = conflictTimeline;
+    }
+
+    @action
+    updateNodesOpinions = (nodesOpinions: NodesOpinions) => {
+        this.nodesOpinions = nodesOpinions;
+    }
+
     @action
     updateMessage = (msg: Message) => {
         this.msg = msg;
@@ -9,7 +9,6 @@ import (
 	"github.com/gorilla/websocket"
 	"github.com/iotaledger/hive.go/daemon"
 	"github.com/iotaledger/hive.go/events"
-	"github.com/iotaledger/hive.go/identity"
 	"github.com/iotaledger/hive.go/workerpool"
 	"github.com/mr-tron/base58"
 
@@ -202,26 +201,10 @@ func sendManaRevoke(ev *mana.RevokedEvent) {
 
 // region Websocket message sending handlers (initial data)
 func sendAllowedManaPledge(ws *websocket.Conn) error {
-	allowedAccess := manaPlugin.GetAllowedPledgeNodes(mana.AccessMana)
-	allowedConsensus := manaPlugin.GetAllowedPledgeNodes(mana.ConsensusMana)
-
-	wsmsgData := &AllowedPledgeIDsMsgData{}
-	wsmsgData.Access.Enabled = allowedAccess.IsFilterEnabled
-	allowedAccess.Allowed.ForEach(func(element interface{}) {
-		ID := element.(identity.ID)
-		wsmsgData.Access.AllowedNodeIDs = append(wsmsgData.Access.AllowedNodeIDs, AllowedNodeStr{
-			ShortID: ID.String(),
-			FullID:  base58.Encode(ID.Bytes()),
-		})
-	})
-	wsmsgData.Consensus.Enabled = allowedConsensus.IsFilterEnabled
-	allowedConsensus.Allowed.ForEach(func(element interface{}) {
-		ID := element.(identity.ID)
-		wsmsgData.Consensus.AllowedNodeIDs = append(wsmsgData.Consensus.AllowedNodeIDs, AllowedNodeStr{
-			ShortID: ID.String(),
-			FullID:  base58.Encode(ID.Bytes()),
-		})
-	})
+	wsmsgData := &AllowedPledgeIDsMsgData{
+		Access:    pledgeIDFilter(manaPlugin.GetPledgePolicy(mana.AccessMana)),
+		Consensus: pledgeIDFilter(manaPlugin.GetPledgePolicy(mana.ConsensusMana)),
+	}
 
 	if err := sendJSON(ws, &wsmsg{
 		Type: MsgTypeManaAllowedPledge,
@@ -232,6 +215,22 @@ func sendAllowedManaPledge(ws *websocket.Conn) error {
 	return nil
 }
 
+// pledgeIDFilter converts a pledge policy to the filter shown in the dashboard, which only lists allowed nodes.
+func pledgeIDFilter(policy *mana.PledgePolicy) (filter PledgeIDFilter) {
+	parameters := policy.Parameters()
+	if parameters.Mode != mana.AllowListPledgeMode {
+		return
+	}
+	filter.Enabled = true
+	for _, ID := range parameters.Nodes {
+		filter.AllowedNodeIDs = append(filter.AllowedNodeIDs, AllowedNodeStr{
+			ShortID: ID.String(),
+			FullID:  base58.Encode(ID.Bytes()),
+		})
+	}
+	return
+}
+
 // endregion
 
 // region Websocket message data structs
@@ -9,6 +9,8 @@ import (
 	"github.com/mr-tron/base58/base58"
 
 	"github.com/iotaledger/goshimmer/packages/drng"
+	"github.com/iotaledger/goshimmer/plugins/config"
+	"github.com/iotaledger/goshimmer/plugins/database"
 )
 
 const (
@@ -26,88 +28,38 @@ const (
 var ErrParsingCommitteeMember = errors.New("cannot parse committee member")
 
 func configureDRNG() *drng.DRNG {
-	c := make(map[uint32][]drng.Option)
-
-	// Pollen dRNG configuration
-	// parse identities of the committee members
-	committeeMembers, err := parseCommitteeMembers(Parameters.Pollen.CommitteeMembers)
-	if err != nil {
-		plugin.LogWarnf("Invalid Pollen committee members: %s", err)
-	}
-
-	// parse distributed public key of the committee
-	dpk, err := parseDistributedPublicKey(Parameters.Pollen.DistributedPubKey)
-	if err != nil {
-		plugin.LogWarn(err)
-	}
-
-	// configure Pollen committee
-	pollenConf := &drng.Committee{
-		InstanceID:    Pollen,
-		Threshold:     uint8(Parameters.Pollen.Threshold),
-		DistributedPK: dpk,
-		Identities:    committeeMembers,
-	}
-
-	if len(committeeMembers) > 0 {
-		c[pollenConf.InstanceID] = []drng.Option{drng.SetCommittee(pollenConf)}
-	}
-
-	// X-Team dRNG configuration
-	// parse identities of the x-team committee members
-	committeeMembers, err = parseCommitteeMembers(Parameters.XTeam.CommitteeMembers)
+	instances, err := LoadInstanceParameters(config.Node())
 	if err != nil {
-		plugin.LogWarnf("Invalid X-Team committee members: %s", err)
+		plugin.LogFatalf("Invalid dRNG configuration: %s", err)
 	}
 
-	// parse distributed public key of the committee
-	dpk, err = parseDistributedPublicKey(Parameters.XTeam.DistributedPubKey)
-	if err != nil {
-		plugin.LogWarn(err)
-	}
-
-	// configure X-Team committee
-	xTeamConf := &drng.Committee{
-		InstanceID:    XTeam,
-		Threshold:     uint8(Parameters.XTeam.Threshold),
-		DistributedPK: dpk,
-		Identities:    committeeMembers,
-	}
+	c := make(map[uint32][]drng.Option)
+	for _, instance := range instances {
+		instanceConfig, err := instance.InstanceConfig()
+		if err != nil {
+			plugin.LogWarnf("Invalid dRNG instance %d: %s", instance.InstanceID, err)
+			continue
+		}
+		if _, exists := c[instanceConfig.InstanceID]; exists {
+			plugin.LogWarnf("Invalid dRNG instance %d: instanceID is used more than once", instanceConfig.InstanceID)
+			continue
+		}
 
-	if len(committeeMembers) > 0 {
-		c[xTeamConf.InstanceID] = []drng.Option{drng.SetCommittee(xTeamConf)}
+		c[instanceConfig.InstanceID] = []drng.Option{drng.SetCommittee(instanceConfig.Committee())}
 	}
 
-	// Custom dRNG configuration
-	// parse identities of the x-team committee members
-	committeeMembers, err = parseCommitteeMembers(Parameters.Custom.CommitteeMembers)
-	if err != nil {
-		plugin.LogWarn("Invalid custom committee members: %s", err)
-	}
+	d := drng.New(c)
+	d.SetBeaconHistory(drng.NewBeaconHistory(database.Store(), database.CacheTimeProvider()))
 
-	// parse distributed public key of the committee
-	dpk, err = parseDistributedPublicKey(Parameters.Custom.DistributedPubKey)
-	if err != nil {
-		plugin.LogWarn(err)
-	}
-
-	// configure Custom committee
-	customConf := &drng.Committee{
-		InstanceID:    uint32(Parameters.Custom.InstanceID),
-		Threshold:     uint8(Parameters.Custom.Threshold),
-		DistributedPK: dpk,
-		Identities:    committeeMembers,
-	}
+	return d
+}
 
-	if len(committeeMembers) > 0 {
-		if customConf.InstanceID != Pollen && customConf.InstanceID != XTeam {
-			c[customConf.InstanceID] = []drng.Option{drng.SetCommittee(customConf)}
-		} else {
-			plugin.LogWarnf("Invalid Custom dRNG instanceID: %d, must be different than both Pollen and X-Team dRNG instance IDs (%d - %d)", customConf.InstanceID, pollenConf.InstanceID, xTeamConf.InstanceID)
-		}
+// LoadInstance validates the given configuration and loads the instance at runtime.
+func LoadInstance(instanceConfig *InstanceConfig) (*drng.State, error) {
+	if err := instanceConfig.Validate(); err != nil {
+		return nil, err
 	}
-
-	return drng.New(c)
+	return Instance().LoadInstance(drng.SetCommittee(instanceConfig.Committee()))
 }
 
 // Instance returns the DRNG instance.
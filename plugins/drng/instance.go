@@ -0,0 +1,155 @@
+package drng
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/iotaledger/hive.go/configuration"
+	"github.com/iotaledger/hive.go/crypto/ed25519"
+
+	"github.com/iotaledger/goshimmer/packages/drng"
+)
+
+// ErrInvalidInstanceConfig is returned for an invalid configuration of a dRNG instance.
+var ErrInvalidInstanceConfig = errors.New("invalid drng instance configuration")
+
+// InstanceConfig is the configuration of a dRNG instance.
+type InstanceConfig struct {
+	// InstanceID is the identifier of the instance.
+	InstanceID uint32
+	// Threshold is the number of committee members required to produce a beacon.
+	Threshold uint8
+	// RoundInterval is the expected time between two beacons (zero if unknown).
+	RoundInterval time.Duration
+	// DistributedPubKey is the distributed public key of the committee (empty if unknown).
+	DistributedPubKey []byte
+	// CommitteeMembers are the identities of the committee members.
+	CommitteeMembers []ed25519.PublicKey
+}
+
+// InstanceParameters defines the configuration of a dRNG instance as it is given in the node configuration.
+type InstanceParameters struct {
+	// InstanceID is the identifier of the instance.
+	InstanceID uint32 `json:"instanceId" koanf:"instanceId"`
+	// Threshold is the number of committee members required to produce a beacon.
+	Threshold uint8 `json:"threshold" koanf:"threshold"`
+	// RoundInterval is the expected time between two beacons, e.g. "10s" (optional).
+	RoundInterval string `json:"roundInterval,omitempty" koanf:"roundInterval"`
+	// DistributedPubKey is the hex encoded distributed public key of the committee (optional).
+	DistributedPubKey string `json:"distributedPubKey,omitempty" koanf:"distributedPubKey"`
+	// CommitteeMembers are the base58 encoded identities of the committee members.
+	CommitteeMembers []string `json:"committeeMembers" koanf:"committeeMembers"`
+}
+
+// InstanceConfig parses and validates the InstanceConfig defined by the InstanceParameters.
+func (p *InstanceParameters) InstanceConfig() (config *InstanceConfig, err error) {
+	config = &InstanceConfig{
+		InstanceID: p.InstanceID,
+		Threshold:  p.Threshold,
+	}
+	if p.RoundInterval != "" {
+		if config.RoundInterval, err = time.ParseDuration(p.RoundInterval); err != nil {
+			return nil, errors.Errorf("failed to parse round interval of instance %d (%v): %w", p.InstanceID, err, ErrInvalidInstanceConfig)
+		}
+	}
+	if config.DistributedPubKey, err = parseDistributedPublicKey(p.DistributedPubKey); err != nil {
+		return nil, errors.Errorf("failed to parse distributed public key of instance %d (%v): %w", p.InstanceID, err, ErrInvalidInstanceConfig)
+	}
+	if config.CommitteeMembers, err = parseCommitteeMembers(p.CommitteeMembers); err != nil {
+		return nil, errors.Errorf("failed to parse committee members of instance %d (%v): %w", p.InstanceID, err, ErrInvalidInstanceConfig)
+	}
+	if err = config.Validate(); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
+// LoadInstanceParameters reads the configured dRNG instances from the given configuration. In a config file the
+// instances are given as a list of objects, while command line flags and environment variables contain the same list
+// encoded as JSON.
+func LoadInstanceParameters(conf *configuration.Configuration) (instances []*InstanceParameters, err error) {
+	for _, deprecatedKey := range deprecatedInstanceKeys {
+		if conf.Exists(deprecatedKey) {
+			return nil, errors.Errorf("%s is no longer supported, configure the instance in %s instead: %w", deprecatedKey, CfgInstances, ErrInvalidInstanceConfig)
+		}
+	}
+
+	switch value := conf.Get(CfgInstances).(type) {
+	case nil:
+		return nil, nil
+	case string:
+		if strings.TrimSpace(value) == "" {
+			return nil, nil
+		}
+		if err = json.Unmarshal([]byte(value), &instances); err != nil {
+			return nil, errors.Errorf("failed to parse %s as JSON list (%v): %w", CfgInstances, err, ErrInvalidInstanceConfig)
+		}
+	default:
+		if err = conf.Unmarshal(CfgInstances, &instances); err != nil {
+			return nil, errors.Errorf("failed to parse %s (%v): %w", CfgInstances, err, ErrInvalidInstanceConfig)
+		}
+	}
+	return instances, nil
+}
+
+// InstancesFlag returns the value of the instances flag that configures the given instances.
+func InstancesFlag(configs ...*InstanceConfig) string {
+	instances := make([]*InstanceParameters, len(configs))
+	for i, config := range configs {
+		instances[i] = config.Parameters()
+	}
+	// marshaling a slice of structs with string and integer fields can not fail
+	flag, _ := json.Marshal(instances)
+	return string(flag)
+}
+
+// Validate checks that the committee of the instance is able to produce beacons.
+func (c *InstanceConfig) Validate() error {
+	if len(c.CommitteeMembers) == 0 {
+		return errors.Errorf("instance %d has no committee members: %w", c.InstanceID, ErrInvalidInstanceConfig)
+	}
+	if c.Threshold == 0 || int(c.Threshold) > len(c.CommitteeMembers) {
+		return errors.Errorf("threshold %d of instance %d not in [1,%d]: %w", c.Threshold, c.InstanceID, len(c.CommitteeMembers), ErrInvalidInstanceConfig)
+	}
+	if c.RoundInterval < 0 {
+		return errors.Errorf("negative round interval of instance %d: %w", c.InstanceID, ErrInvalidInstanceConfig)
+	}
+	if len(c.DistributedPubKey) != 0 && len(c.DistributedPubKey) != drng.PublicKeySize {
+		return errors.Errorf("distributed public key of instance %d has length %d instead of %d: %w", c.InstanceID, len(c.DistributedPubKey), drng.PublicKeySize, ErrInvalidInstanceConfig)
+	}
+	return nil
+}
+
+// Committee returns the committee of the instance.
+func (c *InstanceConfig) Committee() *drng.Committee {
+	return &drng.Committee{
+		InstanceID:    c.InstanceID,
+		Threshold:     c.Threshold,
+		DistributedPK: c.DistributedPubKey,
+		Identities:    c.CommitteeMembers,
+		RoundInterval: c.RoundInterval,
+	}
+}
+
+// Parameters returns the InstanceParameters that define the InstanceConfig.
+func (c *InstanceConfig) Parameters() *InstanceParameters {
+	parameters := &InstanceParameters{
+		InstanceID:       c.InstanceID,
+		Threshold:        c.Threshold,
+		CommitteeMembers: make([]string, len(c.CommitteeMembers)),
+	}
+	if c.RoundInterval != 0 {
+		parameters.RoundInterval = c.RoundInterval.String()
+	}
+	if len(c.DistributedPubKey) != 0 {
+		parameters.DistributedPubKey = hex.EncodeToString(c.DistributedPubKey)
+	}
+	for i, member := range c.CommitteeMembers {
+		parameters.CommitteeMembers[i] = member.String()
+	}
+
+	return parameters
+}
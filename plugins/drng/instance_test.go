@@ -0,0 +1,104 @@
+package drng
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/iotaledger/hive.go/configuration"
+	"github.com/iotaledger/hive.go/crypto/ed25519"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/goshimmer/packages/drng"
+)
+
+func TestInstanceParameters(t *testing.T) {
+	members := []ed25519.PublicKey{ed25519.GenerateKeyPair().PublicKey, ed25519.GenerateKeyPair().PublicKey, ed25519.GenerateKeyPair().PublicKey}
+	instanceConfig := &InstanceConfig{
+		InstanceID:        7,
+		Threshold:         2,
+		RoundInterval:     10 * time.Second,
+		DistributedPubKey: make([]byte, drng.PublicKeySize),
+		CommitteeMembers:  members,
+	}
+
+	parsed, err := instanceConfig.Parameters().InstanceConfig()
+	require.NoError(t, err)
+	assert.Equal(t, instanceConfig, parsed)
+	assert.Equal(t, &drng.Committee{
+		InstanceID:    7,
+		Threshold:     2,
+		Identities:    members,
+		DistributedPK: make([]byte, drng.PublicKeySize),
+		RoundInterval: 10 * time.Second,
+	}, parsed.Committee())
+
+	// the round interval and the distributed public key are optional
+	parsed, err = (&InstanceParameters{InstanceID: 9999, Threshold: 1, CommitteeMembers: []string{members[0].String()}}).InstanceConfig()
+	require.NoError(t, err)
+	assert.Equal(t, &InstanceConfig{InstanceID: 9999, Threshold: 1, CommitteeMembers: members[:1]}, parsed)
+
+	for _, invalid := range []*InstanceParameters{
+		{InstanceID: 1, Threshold: 2, CommitteeMembers: []string{members[0].String()}},
+		{InstanceID: 1, Threshold: 0, CommitteeMembers: []string{members[0].String()}},
+		{InstanceID: 1, Threshold: 1},
+		{InstanceID: 1, Threshold: 1, DistributedPubKey: "00", CommitteeMembers: []string{members[0].String()}},
+		{InstanceID: 1, Threshold: 1, RoundInterval: "10", CommitteeMembers: []string{members[0].String()}},
+		{InstanceID: 1, Threshold: 1, CommitteeMembers: []string{"invalid"}},
+	} {
+		_, err = invalid.InstanceConfig()
+		assert.ErrorIs(t, err, ErrInvalidInstanceConfig, invalid)
+	}
+}
+
+func TestLoadInstanceParameters(t *testing.T) {
+	member := ed25519.GenerateKeyPair().PublicKey
+	expected := []*InstanceParameters{
+		{InstanceID: 1, Threshold: 1, RoundInterval: "10s", CommitteeMembers: []string{member.String()}},
+		{InstanceID: 2, Threshold: 1, CommitteeMembers: []string{member.String()}},
+	}
+
+	// a config file lists the instances as objects
+	conf := loadTestConfig(t, `{"drng": {"instances": [
+		{"instanceId": 1, "threshold": 1, "roundInterval": "10s", "committeeMembers": ["`+member.String()+`"]},
+		{"instanceId": 2, "threshold": 1, "committeeMembers": ["`+member.String()+`"]}
+	]}}`)
+	instances, err := LoadInstanceParameters(conf)
+	require.NoError(t, err)
+	assert.Equal(t, expected, instances)
+
+	// the flag contains the same list encoded as JSON
+	conf = configuration.New()
+	require.NoError(t, conf.Set(CfgInstances, InstancesFlag(
+		&InstanceConfig{InstanceID: 1, Threshold: 1, RoundInterval: 10 * time.Second, CommitteeMembers: []ed25519.PublicKey{member}},
+		&InstanceConfig{InstanceID: 2, Threshold: 1, CommitteeMembers: []ed25519.PublicKey{member}},
+	)))
+	instances, err = LoadInstanceParameters(conf)
+	require.NoError(t, err)
+	assert.Equal(t, expected, instances)
+
+	instances, err = LoadInstanceParameters(configuration.New())
+	require.NoError(t, err)
+	assert.Empty(t, instances)
+
+	conf = configuration.New()
+	require.NoError(t, conf.Set(CfgInstances, "instanceId=1;threshold=1"))
+	_, err = LoadInstanceParameters(conf)
+	assert.ErrorIs(t, err, ErrInvalidInstanceConfig)
+
+	// the configuration of the removed fixed instances is rejected instead of being ignored
+	conf = loadTestConfig(t, `{"drng": {"pollen": {"instanceId": 1, "threshold": 3}}}`)
+	_, err = LoadInstanceParameters(conf)
+	assert.ErrorIs(t, err, ErrInvalidInstanceConfig)
+}
+
+func loadTestConfig(t *testing.T, content string) *configuration.Configuration {
+	path := filepath.Join(t.TempDir(), "config.json")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+
+	conf := configuration.New()
+	require.NoError(t, conf.LoadFile(path))
+	return conf
+}
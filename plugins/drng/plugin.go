@@ -64,16 +64,20 @@ func run(*node.Plugin) {
 						plugin.LogDebug(err)
 						return
 					}
-					if err := instance.Dispatch(msg.IssuerPublicKey(), msg.IssuingTime(), parsedPayload); err != nil {
+					if err := instance.Dispatch(msg.ID(), msg.IssuerPublicKey(), msg.IssuingTime(), parsedPayload); err != nil {
 						// TODO: handle error
 						plugin.LogDebug(err)
 						return
 					}
-					plugin.LogDebug("New randomness: ", instance.State[parsedPayload.InstanceID].Randomness())
+					if state := instance.LoadState(parsedPayload.InstanceID); state != nil {
+						plugin.LogDebug("New randomness: ", state.Randomness())
+					}
 				})
 			}
 		}
 
+		instance.BeaconHistory().Shutdown()
+
 		plugin.LogInfof("Stopping %s ... done", "dRNG-plugin")
 	}, shutdown.PriorityFPC); err != nil {
 		plugin.Panicf("Failed to start as daemon: %s", err)
@@ -81,12 +85,12 @@ func run(*node.Plugin) {
 }
 
 func configureEvents() {
-	// skip the event configuration if no committee has been configured.
-	if len(Instance().State) == 0 {
-		return
-	}
-
 	messagelayer.Tangle().ConsensusManager.Events.MessageOpinionFormed.Attach(events.NewClosure(func(messageID tangle.MessageID) {
+		// skip the messages if no committee has been configured or loaded.
+		if Instance().InstanceCount() == 0 {
+			return
+		}
+
 		select {
 		case inbox <- messageID:
 		default:
@@ -95,6 +99,27 @@ func configureEvents() {
 
 	messagelayer.SetDRNGState(Instance().LoadState(messagelayer.FPCParameters.DRNGInstanceID))
 
+	// the instance used by FPC can be loaded and unloaded at runtime.
+	Instance().Events.InstanceLoaded.Attach(events.NewClosure(func(state *drng.State) {
+		plugin.LogInfof("Loaded dRNG instance %d", state.Committee().InstanceID)
+		if state.Committee().InstanceID == messagelayer.FPCParameters.DRNGInstanceID {
+			messagelayer.SetDRNGState(state)
+		}
+	}))
+	Instance().Events.InstanceUnloaded.Attach(events.NewClosure(func(state *drng.State) {
+		plugin.LogInfof("Unloaded dRNG instance %d", state.Committee().InstanceID)
+		if state.Committee().InstanceID == messagelayer.FPCParameters.DRNGInstanceID {
+			messagelayer.SetDRNGState(nil)
+		}
+	}))
+
+	Instance().Events.MissingRounds.Attach(events.NewClosure(func(gap *drng.GapEvent) {
+		plugin.LogWarnf("Missing %d rounds of dRNG instance %d: %d to %d", gap.MissingRounds(), gap.InstanceID, gap.FirstRound, gap.LastRound)
+	}))
+	Instance().Events.ChainMismatch.Attach(events.NewClosure(func(beacon *drng.Beacon) {
+		plugin.LogWarnf("Beacon of round %d of dRNG instance %d does not match the previous round", beacon.Round(), beacon.InstanceID())
+	}))
+
 	// Section to update the randomness for the dRNG ticker used by FPC.
 	Instance().Events.Randomness.Attach(events.NewClosure(func(state *drng.State) {
 		if state.Committee().InstanceID == messagelayer.FPCParameters.DRNGInstanceID {
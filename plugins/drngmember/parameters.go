@@ -0,0 +1,19 @@
+package drngmember
+
+import (
+	"github.com/iotaledger/hive.go/configuration"
+)
+
+// ParametersDefinition contains the definition of configuration parameters used by the drngmember plugin.
+type ParametersDefinition struct {
+	// InstanceID defines the instance ID of the dRNG committee the node is a member of. The committee produces a
+	// collective beacon every round interval of the instance.
+	InstanceID int `name:"instanceId" default:"9999" usage:"instance ID of the drng committee the node is a member of"`
+}
+
+// Parameters contains the configuration parameters of the drngmember plugin.
+var Parameters = &ParametersDefinition{}
+
+func init() {
+	configuration.BindParameters(Parameters, "drngMember")
+}
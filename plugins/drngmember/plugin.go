@@ -0,0 +1,227 @@
+package drngmember
+
+import (
+	"sync"
+	"time"
+
+	"github.com/iotaledger/hive.go/crypto/ed25519"
+	"github.com/iotaledger/hive.go/daemon"
+	"github.com/iotaledger/hive.go/events"
+	"github.com/iotaledger/hive.go/logger"
+	"github.com/iotaledger/hive.go/marshalutil"
+	"github.com/iotaledger/hive.go/node"
+
+	"github.com/iotaledger/goshimmer/packages/clock"
+	"github.com/iotaledger/goshimmer/packages/drng"
+	"github.com/iotaledger/goshimmer/packages/shutdown"
+	"github.com/iotaledger/goshimmer/packages/tangle"
+	"github.com/iotaledger/goshimmer/packages/tangle/payload"
+	"github.com/iotaledger/goshimmer/plugins/autopeering/local"
+	"github.com/iotaledger/goshimmer/plugins/database"
+	drngplugin "github.com/iotaledger/goshimmer/plugins/drng"
+	"github.com/iotaledger/goshimmer/plugins/messagelayer"
+)
+
+// PluginName is the name of the dRNG member plugin.
+const PluginName = "DRNGMember"
+
+// tickInterval is the interval in which the member is advanced to the current time.
+const tickInterval = time.Second
+
+var (
+	// plugin is the plugin instance of the dRNG member plugin.
+	plugin *node.Plugin
+	once   sync.Once
+	log    *logger.Logger
+	member *drng.Member
+
+	// inbox holds the received payloads of the committee until the member processes them. It only contains payloads
+	// issued by committee members, so that its size is bounded by the traffic of the committee and no payload of the
+	// distributed key generation is lost under load.
+	inbox       []*committeePayload
+	inboxMutex  sync.Mutex
+	inboxSignal = make(chan struct{}, 1)
+)
+
+// committeePayload is a dRNG payload of the committee together with its issuer.
+type committeePayload struct {
+	issuer  ed25519.PublicKey
+	payload *drng.Payload
+}
+
+// Plugin gets the plugin instance.
+func Plugin() *node.Plugin {
+	once.Do(func() {
+		plugin = node.NewPlugin(PluginName, node.Disabled, configure, run)
+	})
+	return plugin
+}
+
+func configure(*node.Plugin) {
+	log = logger.NewLogger(PluginName)
+
+	instanceID := uint32(Parameters.InstanceID)
+	state := drngplugin.Instance().LoadState(instanceID)
+	if state == nil {
+		log.Fatalf("no drng committee with instance ID %d configured", instanceID)
+	}
+
+	committee := state.Committee()
+	if committee.RoundInterval <= 0 {
+		log.Fatalf("drng committee %d has no round interval", instanceID)
+	}
+
+	var err error
+	member, err = drng.NewMember(instanceID, committee.Identities, int(committee.Threshold), local.GetInstance().PublicKey(), committee.RoundInterval, issuePayload)
+	if err != nil {
+		log.Fatalf("failed to join drng committee %d: %s", instanceID, err)
+	}
+
+	keyShareStorage := drng.NewKeyShareStorage(database.Store())
+	keyShare, err := keyShareStorage.Load(instanceID)
+	if err != nil {
+		log.Fatalf("failed to load key share of drng committee %d: %s", instanceID, err)
+	}
+	if keyShare != nil {
+		if err = member.RestoreKeyShare(keyShare); err != nil {
+			log.Warnf("discarding key share of drng committee %d: %s", instanceID, err)
+		} else {
+			log.Infof("restored key share of drng committee %d", instanceID)
+		}
+	}
+	if member.DistributedPublicKey() == nil {
+		restoreDKGState(keyShareStorage, instanceID)
+	}
+
+	member.Events.DealCreated.Attach(events.NewClosure(func(*drng.DKGDeal) {
+		storeDKGState(keyShareStorage, instanceID)
+	}))
+	member.Events.KeyGenerationCompleted.Attach(events.NewClosure(func(dpk []byte) {
+		log.Infof("distributed key generation of drng committee %d completed", instanceID)
+		keyShare, err := member.KeyShare()
+		if err == nil {
+			err = keyShareStorage.Store(keyShare)
+		}
+		if err == nil {
+			err = keyShareStorage.DeleteDKGState(instanceID)
+		}
+		if err != nil {
+			log.Errorf("failed to persist key share of drng committee %d: %s", instanceID, err)
+		}
+	}))
+	member.Events.Beacon.Attach(events.NewClosure(func(beacon *drng.CollectiveBeaconPayload) {
+		log.Debugf("recovered collective beacon of round %d", beacon.Round)
+	}))
+	member.Events.Error.Attach(events.NewClosure(func(err error) {
+		log.Warn(err)
+	}))
+
+	messagelayer.Tangle().Storage.Events.MessageStored.Attach(events.NewClosure(func(messageID tangle.MessageID) {
+		messagelayer.Tangle().Storage.Message(messageID).Consume(func(msg *tangle.Message) {
+			receivePayload(committee, msg)
+		})
+	}))
+}
+
+func run(*node.Plugin) {
+	if err := daemon.BackgroundWorker(PluginName, func(shutdownSignal <-chan struct{}) {
+		ticker := time.NewTicker(tickInterval)
+		defer ticker.Stop()
+	L:
+		for {
+			select {
+			case <-shutdownSignal:
+				break L
+			case <-ticker.C:
+				member.Tick(clock.SyncedTime())
+			case <-inboxSignal:
+				for _, received := range takeInbox() {
+					if err := member.HandlePayload(received.issuer, received.payload); err != nil {
+						log.Debug(err)
+					}
+				}
+			}
+		}
+		log.Infof("Stopping %s ... done", PluginName)
+	}, shutdown.PriorityFPC); err != nil {
+		log.Panicf("Failed to start as daemon: %s", err)
+	}
+}
+
+// restoreDKGState continues the interrupted key generation of the member, or persists the state of a new one before the
+// member announces its DKG key, as the other members keep the first announced key.
+func restoreDKGState(keyShareStorage *drng.KeyShareStorage, instanceID uint32) {
+	state, err := keyShareStorage.LoadDKGState(instanceID)
+	if err != nil {
+		log.Fatalf("failed to load DKG state of drng committee %d: %s", instanceID, err)
+	}
+	if state != nil {
+		if err = member.RestoreDKGState(state); err == nil {
+			log.Infof("continuing distributed key generation of drng committee %d", instanceID)
+			return
+		}
+		log.Warnf("discarding DKG state of drng committee %d: %s", instanceID, err)
+	}
+	storeDKGState(keyShareStorage, instanceID)
+}
+
+// storeDKGState persists the current state of the key generation of the member.
+func storeDKGState(keyShareStorage *drng.KeyShareStorage, instanceID uint32) {
+	state, err := member.DKGState()
+	if err == nil && state != nil {
+		err = keyShareStorage.StoreDKGState(state)
+	}
+	if err != nil {
+		log.Errorf("failed to persist DKG state of drng committee %d: %s", instanceID, err)
+	}
+}
+
+// receivePayload adds the dRNG payload of the given message to the inbox if it was issued by a member of the committee.
+func receivePayload(committee drng.Committee, msg *tangle.Message) {
+	if msg.Payload().Type() != drng.PayloadType || !isCommitteeMember(committee, msg.IssuerPublicKey()) {
+		return
+	}
+	parsedPayload, err := drng.PayloadFromMarshalUtil(marshalutil.New(msg.Payload().Bytes()))
+	if err != nil {
+		log.Debug(err)
+		return
+	}
+	if parsedPayload.InstanceID != committee.InstanceID {
+		return
+	}
+
+	inboxMutex.Lock()
+	inbox = append(inbox, &committeePayload{issuer: msg.IssuerPublicKey(), payload: parsedPayload})
+	inboxMutex.Unlock()
+
+	select {
+	case inboxSignal <- struct{}{}:
+	default:
+		// the member is already signaled and processes the whole inbox
+	}
+}
+
+// takeInbox removes and returns all payloads of the inbox.
+func takeInbox() (received []*committeePayload) {
+	inboxMutex.Lock()
+	defer inboxMutex.Unlock()
+
+	received, inbox = inbox, nil
+	return received
+}
+
+// isCommitteeMember returns true if the given identity is a member of the committee.
+func isCommitteeMember(committee drng.Committee, identity ed25519.PublicKey) bool {
+	for _, member := range committee.Identities {
+		if member == identity {
+			return true
+		}
+	}
+	return false
+}
+
+// issuePayload issues the given payload of the member in a new message.
+func issuePayload(p payload.Payload) error {
+	_, err := messagelayer.Tangle().IssuePayload(p)
+	return err
+}
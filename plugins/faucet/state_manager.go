@@ -190,6 +190,28 @@ func (s *StateManager) FulFillFundingRequest(requestMsg *tangle.Message) (m *tan
 
 	faucetReq := requestMsg.Payload().(*faucet.Request)
 
+	// pledge mana to requester, unless the request defines the nodes to pledge to
+	emptyID := identity.ID{}
+	accessManaPledgeID := identity.NewID(requestMsg.IssuerPublicKey())
+	consensusManaPledgeID := identity.NewID(requestMsg.IssuerPublicKey())
+	if faucetReq.AccessManaPledgeID() != emptyID {
+		accessManaPledgeID = faucetReq.AccessManaPledgeID()
+	}
+	if faucetReq.ConsensusManaPledgeID() != emptyID {
+		consensusManaPledgeID = faucetReq.ConsensusManaPledgeID()
+	}
+	// check the pledges before a funding output is taken, they only count if the funding transaction is issued
+	cancelPledge, err := messagelayer.ReserveManaPledge(accessManaPledgeID, consensusManaPledgeID, faucetReq.Address())
+	if err != nil {
+		err = errors.Errorf("funding request for address %s violates the mana pledge policy: %w", faucetReq.Address().Base58(), err)
+		return
+	}
+	defer func() {
+		if err != nil {
+			cancelPledge()
+		}
+	}()
+
 	// get an output that we can spend
 	fundingOutput, fErr := s.getFundingOutput()
 	// we don't have funding outputs
@@ -213,17 +235,7 @@ func (s *StateManager) FulFillFundingRequest(requestMsg *tangle.Message) (m *tan
 		return
 	}
 
-	// prepare funding tx, pledge mana to requester
-	emptyID := identity.ID{}
-	accessManaPledgeID := identity.NewID(requestMsg.IssuerPublicKey())
-	consensusManaPledgeID := identity.NewID(requestMsg.IssuerPublicKey())
-	if faucetReq.AccessManaPledgeID() != emptyID {
-		accessManaPledgeID = faucetReq.AccessManaPledgeID()
-	}
-	if faucetReq.ConsensusManaPledgeID() != emptyID {
-		consensusManaPledgeID = faucetReq.ConsensusManaPledgeID()
-	}
-
+	// prepare funding tx
 	tx := s.prepareFaucetTransaction(faucetReq.Address(), fundingOutput, accessManaPledgeID, consensusManaPledgeID)
 
 	// issue funding request
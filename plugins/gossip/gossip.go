@@ -71,7 +71,12 @@ func start(shutdownSignal <-chan struct{}) {
 	}
 	defer listener.Close()
 
-	srv := server.ServeTCP(lPeer, listener, Plugin().Logger())
+	var opts []server.ServerOption
+	if Parameters.Plaintext {
+		Plugin().LogWarn("Gossip encryption is disabled")
+		opts = append(opts, server.WithPlaintext())
+	}
+	srv := server.ServeTCP(lPeer, listener, Plugin().Logger(), opts...)
 	defer srv.Close()
 
 	mgr.Start(srv)
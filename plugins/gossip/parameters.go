@@ -8,6 +8,9 @@ import (
 type ParametersDefinition struct {
 	// NetworkVersion defines the config flag of the network version.
 	Port int `default:"14666" usage:"tcp port for gossip connection"`
+
+	// Plaintext defines whether the gossip connections are not encrypted.
+	Plaintext bool `default:"false" usage:"disable the encryption of gossip connections for debugging; only connects to neighbors that disable it too"`
 }
 
 // Parameters contains the configuration parameters of the gossip plugin.
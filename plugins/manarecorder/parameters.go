@@ -0,0 +1,26 @@
+package manarecorder
+
+import (
+	"time"
+
+	"github.com/iotaledger/hive.go/configuration"
+)
+
+// ParametersDefinition contains the definition of the parameters used by the manarecorder plugin.
+type ParametersDefinition struct {
+	// Directory defines the directory to store the mana time-series files in.
+	Directory string `default:"manahistory" usage:"directory to store the mana time-series files in"`
+	// Interval defines the interval between two mana samples.
+	Interval time.Duration `default:"1m" usage:"interval between two mana samples"`
+	// MaxFileSize defines the size in bytes after which a new time-series file is started.
+	MaxFileSize int64 `default:"10485760" usage:"size in bytes after which a new time-series file is started"`
+	// MaxFiles defines the number of time-series files to keep.
+	MaxFiles int `default:"10" usage:"number of time-series files to keep"`
+}
+
+// Parameters contains the configuration used by the manarecorder plugin.
+var Parameters = &ParametersDefinition{}
+
+func init() {
+	configuration.BindParameters(Parameters, "manarecorder")
+}
@@ -0,0 +1,94 @@
+package manarecorder
+
+import (
+	"sync"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/iotaledger/hive.go/daemon"
+	"github.com/iotaledger/hive.go/identity"
+	"github.com/iotaledger/hive.go/logger"
+	"github.com/iotaledger/hive.go/node"
+
+	"github.com/iotaledger/goshimmer/packages/mana"
+	"github.com/iotaledger/goshimmer/packages/mana/timeseries"
+	"github.com/iotaledger/goshimmer/packages/shutdown"
+	"github.com/iotaledger/goshimmer/plugins/messagelayer"
+)
+
+const (
+	// PluginName is the name of the mana recorder plugin.
+	PluginName = "ManaRecorder"
+)
+
+var (
+	// ErrRecorderDisabled is returned if the mana history is queried while the plugin is disabled.
+	ErrRecorderDisabled = errors.New("mana recorder is disabled")
+
+	plugin *node.Plugin
+	once   sync.Once
+	log    *logger.Logger
+	writer *timeseries.Writer
+)
+
+// Plugin gets the plugin instance.
+func Plugin() *node.Plugin {
+	once.Do(func() {
+		plugin = node.NewPlugin(PluginName, node.Disabled, configure, run)
+	})
+	return plugin
+}
+
+func configure(*node.Plugin) {
+	log = logger.NewLogger(PluginName)
+
+	var err error
+	writer, err = timeseries.NewWriter(Parameters.Directory, Parameters.MaxFileSize, Parameters.MaxFiles)
+	if err != nil {
+		log.Panicf("failed to create mana time-series writer: %s", err)
+	}
+}
+
+func run(*node.Plugin) {
+	if err := daemon.BackgroundWorker(PluginName, func(shutdownSignal <-chan struct{}) {
+		ticker := time.NewTicker(Parameters.Interval)
+		defer ticker.Stop()
+	L:
+		for {
+			select {
+			case <-shutdownSignal:
+				break L
+			case <-ticker.C:
+				record()
+			}
+		}
+		log.Infof("Stopping %s ...", PluginName)
+		if err := writer.Close(); err != nil {
+			log.Errorf("error closing mana time-series file: %s", err)
+		}
+		log.Infof("Stopping %s ... done", PluginName)
+	}, shutdown.PriorityMana); err != nil {
+		log.Panicf("Failed to start as daemon: %s", err)
+	}
+}
+
+// record samples the mana of all nodes and appends it to the time-series.
+func record() {
+	t := time.Now()
+	manaMaps, err := messagelayer.GetAllManaMaps(t)
+	if err != nil {
+		log.Warnf("error retrieving mana maps: %s", err)
+		return
+	}
+	if err := writer.Write(t, manaMaps); err != nil {
+		log.Errorf("error writing mana time-series: %s", err)
+	}
+}
+
+// History returns the recorded mana of the node per mana type, for the samples taken within [from, to].
+func History(nodeID identity.ID, from, to time.Time) (map[mana.Type][]timeseries.Point, error) {
+	if node.IsSkipped(Plugin()) {
+		return nil, ErrRecorderDisabled
+	}
+	return timeseries.Query(Parameters.Directory, nodeID, from, to)
+}
@@ -14,6 +14,7 @@ type DelegationReceiver struct {
 	*wallet
 	delegatedFunds map[ledgerstate.Color]uint64
 	delFundsMutex  sync.RWMutex
+	registry       *DelegationRegistry
 	sync.RWMutex
 
 	// utility to be able to filter based on the same timestamp
@@ -35,6 +36,9 @@ func (d *DelegationReceiver) Scan() []*ledgerstate.AliasOutput {
 		scanResult[i] = output.Clone().(*ledgerstate.AliasOutput)
 	}
 	d.updateDelegatedFunds(scanResult)
+	if err := d.registry.Update(scanResult, d.localTimeNow); err != nil {
+		plugin.LogErrorf("couldn't update delegation records: %s", err)
+	}
 	return scanResult
 }
 
@@ -63,6 +67,11 @@ func (d *DelegationReceiver) TotalDelegatedFunds() uint64 {
 	return total
 }
 
+// Registry returns the registry holding the records of the delegations to the node.
+func (d *DelegationReceiver) Registry() *DelegationRegistry {
+	return d.registry
+}
+
 // Address returns the receive address of the delegation receiver.
 func (d *DelegationReceiver) Address() ledgerstate.Address {
 	return d.address
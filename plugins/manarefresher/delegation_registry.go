@@ -0,0 +1,329 @@
+package manarefresher
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/iotaledger/hive.go/kvstore"
+	"github.com/iotaledger/hive.go/marshalutil"
+
+	"github.com/iotaledger/goshimmer/packages/ledgerstate"
+)
+
+// maxRefreshHistory is the number of refreshes that are kept per delegation.
+const maxRefreshHistory = 100
+
+// region DelegationRecord /////////////////////////////////////////////////////////////////////////////////////////////
+
+// DelegationRecord contains the accounting information of a single delegation to the node.
+type DelegationRecord struct {
+	// DelegationID is the alias address that identifies the delegation.
+	DelegationID ledgerstate.AliasAddress
+	// Delegator is the governing address of the delegated alias, which can reclaim the funds.
+	Delegator ledgerstate.Address
+	// OutputID is the ID of the latest known output of the delegated alias.
+	OutputID ledgerstate.OutputID
+	// Amount is the amount of delegated funds.
+	Amount uint64
+	// Timelock is the time until which the funds are delegated, zero if there is no timelock.
+	Timelock time.Time
+	// FirstSeen is the time the delegation was first seen by the node.
+	FirstSeen time.Time
+	// PledgedAccessMana is the access mana that refreshing the delegation pledged to the node in total.
+	PledgedAccessMana float64
+	// PledgedConsensusMana is the consensus mana that the delegation currently pledges to the node because of its
+	// refreshes. A refresh revokes the consensus mana of the delegated funds and pledges it again, so it is not summed up.
+	PledgedConsensusMana float64
+	// Refreshes contains the most recent refreshes of the delegation.
+	Refreshes []DelegationRefresh
+
+	active   bool
+	reminded bool
+}
+
+// DelegationRefresh contains the information of a single refresh of a delegation.
+type DelegationRefresh struct {
+	// Time is the time of the refresh.
+	Time time.Time
+	// TransactionID is the ID of the refreshing transaction.
+	TransactionID ledgerstate.TransactionID
+	// AccessMana is the access mana that the refresh pledged to the node.
+	AccessMana float64
+	// ConsensusMana is the net change of the consensus mana pledged to the node by the refresh.
+	ConsensusMana float64
+}
+
+// DelegationRecordFromMarshalUtil unmarshals a DelegationRecord using a MarshalUtil (for easier unmarshaling).
+func DelegationRecordFromMarshalUtil(marshalUtil *marshalutil.MarshalUtil) (record *DelegationRecord, err error) {
+	record = &DelegationRecord{}
+	delegationID, err := ledgerstate.AliasAddressFromMarshalUtil(marshalUtil)
+	if err != nil {
+		return nil, errors.Errorf("failed to parse delegation ID: %w", err)
+	}
+	record.DelegationID = *delegationID
+	if record.Delegator, err = ledgerstate.AddressFromMarshalUtil(marshalUtil); err != nil {
+		return nil, errors.Errorf("failed to parse delegator: %w", err)
+	}
+	if record.OutputID, err = ledgerstate.OutputIDFromMarshalUtil(marshalUtil); err != nil {
+		return nil, errors.Errorf("failed to parse output ID: %w", err)
+	}
+	if record.Amount, err = marshalUtil.ReadUint64(); err != nil {
+		return nil, errors.Errorf("failed to parse amount: %w", err)
+	}
+	if record.Timelock, err = marshalUtil.ReadTime(); err != nil {
+		return nil, errors.Errorf("failed to parse timelock: %w", err)
+	}
+	if record.FirstSeen, err = marshalUtil.ReadTime(); err != nil {
+		return nil, errors.Errorf("failed to parse first seen time: %w", err)
+	}
+	if record.PledgedAccessMana, err = marshalUtil.ReadFloat64(); err != nil {
+		return nil, errors.Errorf("failed to parse pledged access mana: %w", err)
+	}
+	if record.PledgedConsensusMana, err = marshalUtil.ReadFloat64(); err != nil {
+		return nil, errors.Errorf("failed to parse pledged consensus mana: %w", err)
+	}
+	if record.active, err = marshalUtil.ReadBool(); err != nil {
+		return nil, errors.Errorf("failed to parse active flag: %w", err)
+	}
+	if record.reminded, err = marshalUtil.ReadBool(); err != nil {
+		return nil, errors.Errorf("failed to parse reminded flag: %w", err)
+	}
+	refreshesCount, err := marshalUtil.ReadUint8()
+	if err != nil {
+		return nil, errors.Errorf("failed to parse refreshes count: %w", err)
+	}
+	for i := 0; i < int(refreshesCount); i++ {
+		refresh := DelegationRefresh{}
+		if refresh.Time, err = marshalUtil.ReadTime(); err != nil {
+			return nil, errors.Errorf("failed to parse refresh time: %w", err)
+		}
+		if refresh.TransactionID, err = ledgerstate.TransactionIDFromMarshalUtil(marshalUtil); err != nil {
+			return nil, errors.Errorf("failed to parse refresh transaction ID: %w", err)
+		}
+		if refresh.AccessMana, err = marshalUtil.ReadFloat64(); err != nil {
+			return nil, errors.Errorf("failed to parse refresh access mana: %w", err)
+		}
+		if refresh.ConsensusMana, err = marshalUtil.ReadFloat64(); err != nil {
+			return nil, errors.Errorf("failed to parse refresh consensus mana: %w", err)
+		}
+		record.Refreshes = append(record.Refreshes, refresh)
+	}
+
+	return record, nil
+}
+
+// Bytes returns a marshaled version of the DelegationRecord.
+func (d *DelegationRecord) Bytes() []byte {
+	marshalUtil := marshalutil.New().
+		Write(&d.DelegationID).
+		Write(d.Delegator).
+		Write(d.OutputID).
+		WriteUint64(d.Amount).
+		WriteTime(d.Timelock).
+		WriteTime(d.FirstSeen).
+		WriteFloat64(d.PledgedAccessMana).
+		WriteFloat64(d.PledgedConsensusMana).
+		WriteBool(d.active).
+		WriteBool(d.reminded).
+		WriteUint8(uint8(len(d.Refreshes)))
+	for _, refresh := range d.Refreshes {
+		marshalUtil.
+			WriteTime(refresh.Time).
+			Write(refresh.TransactionID).
+			WriteFloat64(refresh.AccessMana).
+			WriteFloat64(refresh.ConsensusMana)
+	}
+
+	return marshalUtil.Bytes()
+}
+
+// TimelockExpired returns true if the delegation has a timelock that passed at time t.
+func (d *DelegationRecord) TimelockExpired(t time.Time) bool {
+	return !d.Timelock.IsZero() && !d.Timelock.After(t)
+}
+
+// clone returns a copy of the record that can be handed out without holding the lock of the registry.
+func (d *DelegationRecord) clone() *DelegationRecord {
+	cloned := *d
+	cloned.Refreshes = append([]DelegationRefresh{}, d.Refreshes...)
+	return &cloned
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region DelegationRegistry ///////////////////////////////////////////////////////////////////////////////////////////
+
+// DelegationRegistry keeps a DelegationRecord for every delegation to the node. Records of delegations that are not
+// delegated anymore are removed, once the delegator was reminded to reclaim them. The records are persisted in the
+// given store, so that the refresh history and the reminders survive a restart of the node.
+type DelegationRegistry struct {
+	records map[ledgerstate.AliasAddress]*DelegationRecord
+	store   kvstore.KVStore
+	mutex   sync.RWMutex
+}
+
+// NewDelegationRegistry creates a new DelegationRegistry that loads and persists its records in the given store.
+func NewDelegationRegistry(store kvstore.KVStore) (*DelegationRegistry, error) {
+	d := &DelegationRegistry{
+		records: make(map[ledgerstate.AliasAddress]*DelegationRecord),
+		store:   store,
+	}
+
+	var err error
+	if iterErr := store.Iterate(kvstore.EmptyPrefix, func(_ kvstore.Key, value kvstore.Value) bool {
+		var record *DelegationRecord
+		if record, err = DelegationRecordFromMarshalUtil(marshalutil.New(value)); err != nil {
+			return false
+		}
+		d.records[record.DelegationID] = record
+		return true
+	}); iterErr != nil {
+		return nil, errors.Errorf("failed to load delegation records: %w", iterErr)
+	}
+	if err != nil {
+		return nil, errors.Errorf("failed to load delegation records: %w", err)
+	}
+
+	return d, nil
+}
+
+// Update updates the records with the delegated outputs found by a scan at time t.
+func (d *DelegationRegistry) Update(delegatedOutputs []*ledgerstate.AliasOutput, t time.Time) error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	batch := d.store.Batched()
+
+	for _, record := range d.records {
+		record.active = false
+	}
+	for _, alias := range delegatedOutputs {
+		record, exists := d.records[*alias.GetAliasAddress()]
+		if !exists {
+			record = &DelegationRecord{
+				DelegationID: *alias.GetAliasAddress(),
+				FirstSeen:    t,
+			}
+			d.records[record.DelegationID] = record
+		}
+		record.Delegator = alias.GetGoverningAddress()
+		record.OutputID = alias.ID()
+		record.Amount = aliasAmount(alias)
+		record.Timelock = alias.DelegationTimelock()
+		record.active = true
+	}
+	for delegationID, record := range d.records {
+		if !record.active && (record.Timelock.IsZero() || record.reminded) {
+			delete(d.records, delegationID)
+			if err := batch.Delete(delegationID.Bytes()); err != nil {
+				batch.Cancel()
+				return errors.Errorf("failed to delete delegation record: %w", err)
+			}
+			continue
+		}
+		if err := batch.Set(delegationID.Bytes(), record.Bytes()); err != nil {
+			batch.Cancel()
+			return errors.Errorf("failed to store delegation record: %w", err)
+		}
+	}
+
+	if err := batch.Commit(); err != nil {
+		return errors.Errorf("failed to store delegation records: %w", err)
+	}
+	return nil
+}
+
+// RecordRefresh adds a refresh of the delegated alias by the given transaction to its record. The refresh pledges the
+// consensus mana of the delegated funds to the node, which it previously pledged already if it was refreshed before.
+func (d *DelegationRegistry) RecordRefresh(alias *ledgerstate.AliasOutput, transactionID ledgerstate.TransactionID, accessMana float64, t time.Time) error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	record, exists := d.records[*alias.GetAliasAddress()]
+	if !exists {
+		return nil
+	}
+	consensusMana := float64(aliasAmount(alias))
+	refresh := DelegationRefresh{
+		Time:          t,
+		TransactionID: transactionID,
+		AccessMana:    accessMana,
+		ConsensusMana: consensusMana - record.PledgedConsensusMana,
+	}
+	record.PledgedAccessMana += refresh.AccessMana
+	record.PledgedConsensusMana = consensusMana
+	record.Refreshes = append(record.Refreshes, refresh)
+	if len(record.Refreshes) > maxRefreshHistory {
+		record.Refreshes = record.Refreshes[len(record.Refreshes)-maxRefreshHistory:]
+	}
+
+	return d.storeRecord(record)
+}
+
+// ExpiredTimelocks returns the records whose timelock passed at time t and that were not returned before.
+func (d *DelegationRegistry) ExpiredTimelocks(t time.Time) (expired []*DelegationRecord, err error) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	for _, record := range d.records {
+		if record.reminded || !record.TimelockExpired(t) {
+			continue
+		}
+		record.reminded = true
+		if err = d.storeRecord(record); err != nil {
+			return expired, err
+		}
+		expired = append(expired, record.clone())
+	}
+	return expired, nil
+}
+
+// Record returns a copy of the record of the given delegation.
+func (d *DelegationRegistry) Record(delegationID ledgerstate.AliasAddress) (record *DelegationRecord, exists bool) {
+	d.mutex.RLock()
+	defer d.mutex.RUnlock()
+
+	if record, exists = d.records[delegationID]; !exists {
+		return nil, false
+	}
+	return record.clone(), true
+}
+
+// Records returns copies of the records of the given delegator, or of all delegations if delegator is nil, sorted by
+// the time they were first seen.
+func (d *DelegationRegistry) Records(delegator ledgerstate.Address) (records []*DelegationRecord) {
+	d.mutex.RLock()
+	defer d.mutex.RUnlock()
+
+	for _, record := range d.records {
+		if delegator != nil && !record.Delegator.Equals(delegator) {
+			continue
+		}
+		records = append(records, record.clone())
+	}
+	sort.Slice(records, func(i, j int) bool {
+		return records[i].FirstSeen.Before(records[j].FirstSeen)
+	})
+	return records
+}
+
+// storeRecord persists the given record.
+func (d *DelegationRegistry) storeRecord(record *DelegationRecord) error {
+	if err := d.store.Set(record.DelegationID.Bytes(), record.Bytes()); err != nil {
+		return errors.Errorf("failed to store delegation record %s: %w", record.DelegationID.Base58(), err)
+	}
+	return nil
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// aliasAmount returns the sum of the balances of the alias.
+func aliasAmount(alias *ledgerstate.AliasOutput) (amount uint64) {
+	alias.Balances().ForEach(func(color ledgerstate.Color, balance uint64) bool {
+		amount += balance
+		return true
+	})
+	return amount
+}
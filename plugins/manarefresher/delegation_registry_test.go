@@ -0,0 +1,144 @@
+package manarefresher
+
+import (
+	"testing"
+	"time"
+
+	"github.com/iotaledger/hive.go/crypto/ed25519"
+	"github.com/iotaledger/hive.go/kvstore"
+	"github.com/iotaledger/hive.go/kvstore/mapdb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/goshimmer/packages/ledgerstate"
+)
+
+func TestDelegationRegistry_Update(t *testing.T) {
+	registry := newTestRegistry(t, mapdb.NewMapDB())
+	now := time.Now()
+	withoutTimelock := newDelegatedAlias(t, time.Time{})
+	withTimelock := newDelegatedAlias(t, now.Add(time.Hour))
+
+	require.NoError(t, registry.Update([]*ledgerstate.AliasOutput{withoutTimelock, withTimelock}, now))
+	assert.Len(t, registry.Records(nil), 2)
+	record, exists := registry.Record(*withoutTimelock.GetAliasAddress())
+	require.True(t, exists)
+	assert.Equal(t, now, record.FirstSeen)
+	assert.Equal(t, uint64(ledgerstate.DustThresholdAliasOutputIOTA), record.Amount)
+
+	// the first seen time is kept for known delegations
+	require.NoError(t, registry.Update([]*ledgerstate.AliasOutput{withoutTimelock, withTimelock}, now.Add(time.Minute)))
+	record, _ = registry.Record(*withoutTimelock.GetAliasAddress())
+	assert.Equal(t, now, record.FirstSeen)
+
+	// delegations without a timelock are removed as soon as they are gone, the ones with a timelock only once the
+	// delegator was reminded to reclaim them
+	require.NoError(t, registry.Update(nil, now.Add(2*time.Minute)))
+	_, exists = registry.Record(*withoutTimelock.GetAliasAddress())
+	assert.False(t, exists)
+	_, exists = registry.Record(*withTimelock.GetAliasAddress())
+	assert.True(t, exists)
+
+	require.Len(t, expiredTimelocks(t, registry, now.Add(2*time.Hour)), 1)
+	require.NoError(t, registry.Update(nil, now.Add(2*time.Hour)))
+	assert.Empty(t, registry.Records(nil))
+}
+
+func TestDelegationRegistry_ExpiredTimelocks(t *testing.T) {
+	registry := newTestRegistry(t, mapdb.NewMapDB())
+	now := time.Now()
+	alias := newDelegatedAlias(t, now.Add(time.Hour))
+	require.NoError(t, registry.Update([]*ledgerstate.AliasOutput{alias, newDelegatedAlias(t, time.Time{})}, now))
+
+	assert.Empty(t, expiredTimelocks(t, registry, now))
+
+	// every expired delegation is only reminded once
+	expired := expiredTimelocks(t, registry, now.Add(time.Hour))
+	require.Len(t, expired, 1)
+	assert.Equal(t, *alias.GetAliasAddress(), expired[0].DelegationID)
+	assert.True(t, expired[0].TimelockExpired(now.Add(time.Hour)))
+	assert.Empty(t, expiredTimelocks(t, registry, now.Add(2*time.Hour)))
+
+	// the reminded delegation is kept as long as it is still delegated
+	require.NoError(t, registry.Update([]*ledgerstate.AliasOutput{alias}, now.Add(2*time.Hour)))
+	_, exists := registry.Record(*alias.GetAliasAddress())
+	assert.True(t, exists)
+	assert.Empty(t, expiredTimelocks(t, registry, now.Add(3*time.Hour)))
+}
+
+func TestDelegationRegistry_RecordRefresh(t *testing.T) {
+	registry := newTestRegistry(t, mapdb.NewMapDB())
+	now := time.Now()
+	alias := newDelegatedAlias(t, time.Time{})
+
+	// refreshes of unknown delegations are ignored
+	require.NoError(t, registry.RecordRefresh(alias, ledgerstate.TransactionID{1}, 1, now))
+	assert.Empty(t, registry.Records(nil))
+
+	require.NoError(t, registry.Update([]*ledgerstate.AliasOutput{alias}, now))
+	for i := 0; i < maxRefreshHistory+10; i++ {
+		require.NoError(t, registry.RecordRefresh(alias, ledgerstate.TransactionID{byte(i)}, 2, now.Add(time.Duration(i)*time.Minute)))
+	}
+
+	record, exists := registry.Record(*alias.GetAliasAddress())
+	require.True(t, exists)
+	assert.Equal(t, float64(2*(maxRefreshHistory+10)), record.PledgedAccessMana)
+	// a refresh revokes the consensus mana of the delegated funds and pledges it again, so only the first one counts
+	assert.Equal(t, float64(ledgerstate.DustThresholdAliasOutputIOTA), record.PledgedConsensusMana)
+	assert.Equal(t, 0.0, record.Refreshes[0].ConsensusMana)
+
+	// only the most recent refreshes are kept
+	require.Len(t, record.Refreshes, maxRefreshHistory)
+	assert.Equal(t, ledgerstate.TransactionID{10}, record.Refreshes[0].TransactionID)
+	assert.Equal(t, now.Add(time.Duration(maxRefreshHistory+9)*time.Minute), record.Refreshes[maxRefreshHistory-1].Time)
+
+	// the returned record is a copy
+	record.Refreshes[0].AccessMana = 100
+	record, _ = registry.Record(*alias.GetAliasAddress())
+	assert.Equal(t, 2.0, record.Refreshes[0].AccessMana)
+}
+
+func TestDelegationRegistry_Restart(t *testing.T) {
+	store := mapdb.NewMapDB()
+	registry := newTestRegistry(t, store)
+	// strip the monotonic clock reading, which is not persisted
+	now := time.Now().Round(0)
+	alias := newDelegatedAlias(t, now.Add(time.Hour))
+	require.NoError(t, registry.Update([]*ledgerstate.AliasOutput{alias, newDelegatedAlias(t, time.Time{})}, now))
+	require.NoError(t, registry.RecordRefresh(alias, ledgerstate.TransactionID{1}, 2, now))
+	require.Len(t, expiredTimelocks(t, registry, now.Add(time.Hour)), 1)
+
+	// the records are restored with their refreshes and the reminder does not fire again
+	restarted := newTestRegistry(t, store)
+	assert.ElementsMatch(t, registry.Records(nil), restarted.Records(nil))
+	assert.Empty(t, expiredTimelocks(t, restarted, now.Add(2*time.Hour)))
+
+	// removed records are not restored
+	require.NoError(t, restarted.Update(nil, now.Add(2*time.Hour)))
+	assert.Empty(t, newTestRegistry(t, store).Records(nil))
+}
+
+func newTestRegistry(t *testing.T, store kvstore.KVStore) *DelegationRegistry {
+	registry, err := NewDelegationRegistry(store)
+	require.NoError(t, err)
+	return registry
+}
+
+func expiredTimelocks(t *testing.T, registry *DelegationRegistry, now time.Time) []*DelegationRecord {
+	expired, err := registry.ExpiredTimelocks(now)
+	require.NoError(t, err)
+	return expired
+}
+
+func newDelegatedAlias(t *testing.T, timelock time.Time) *ledgerstate.AliasOutput {
+	keyPair := ed25519.GenerateKeyPair()
+	alias, err := ledgerstate.NewAliasOutputMint(map[ledgerstate.Color]uint64{ledgerstate.ColorIOTA: ledgerstate.DustThresholdAliasOutputIOTA}, ledgerstate.NewED25519Address(keyPair.PublicKey))
+	require.NoError(t, err)
+	alias.SetIsDelegated(true)
+	alias.SetGoverningAddress(ledgerstate.NewED25519Address(ed25519.GenerateKeyPair().PublicKey))
+	if !timelock.IsZero() {
+		require.NoError(t, alias.SetDelegationTimelock(timelock))
+	}
+	alias.SetAliasAddress(ledgerstate.NewAliasAddress(keyPair.PublicKey.Bytes()))
+	return alias
+}
@@ -0,0 +1,20 @@
+package manarefresher
+
+import (
+	"github.com/iotaledger/hive.go/events"
+)
+
+// Events defines the events of the plugin.
+var Events = pluginEvents{
+	// DelegationTimelockExpired triggers when the timelock of a delegation passed.
+	DelegationTimelockExpired: events.NewEvent(delegationRecordEventCaller),
+}
+
+type pluginEvents struct {
+	// Fired when the timelock of a delegation passed and the delegator can reclaim the funds.
+	DelegationTimelockExpired *events.Event
+}
+
+func delegationRecordEventCaller(handler interface{}, params ...interface{}) {
+	handler.(func(*DelegationRecord))(params[0].(*DelegationRecord))
+}
@@ -7,11 +7,15 @@ import (
 
 	"github.com/cockroachdb/errors"
 	"github.com/iotaledger/hive.go/daemon"
+	"github.com/iotaledger/hive.go/events"
 	"github.com/iotaledger/hive.go/node"
 
+	"github.com/iotaledger/goshimmer/packages/clock"
+	databasePkg "github.com/iotaledger/goshimmer/packages/database"
 	"github.com/iotaledger/goshimmer/packages/ledgerstate"
 	"github.com/iotaledger/goshimmer/packages/shutdown"
 	"github.com/iotaledger/goshimmer/plugins/autopeering/local"
+	"github.com/iotaledger/goshimmer/plugins/database"
 )
 
 var (
@@ -24,6 +28,9 @@ var (
 // minRefreshInterval is the minimum refresh interval allowed for delegated outputs.
 const minRefreshInterval = 1 // minutes
 
+// timelockCheckInterval is the interval in which the delegation timelocks are checked for expiry.
+const timelockCheckInterval = time.Minute
+
 // Plugin gets the plugin instance.
 func Plugin() *node.Plugin {
 	once.Do(func() {
@@ -40,17 +47,27 @@ func configure(_ *node.Plugin) {
 		panic(errors.Wrap(err, "couldn't load private key of node identity"))
 	}
 	localWallet := newWalletFromPrivateKey(nodeIDPrivateKey)
-	refresher = NewRefresher(localWallet, &DelegationReceiver{wallet: localWallet})
+	registry, err := NewDelegationRegistry(database.StoreRealm([]byte{databasePkg.PrefixManaRefresher}))
+	if err != nil {
+		panic(errors.Wrap(err, "couldn't load delegation records"))
+	}
+	refresher = NewRefresher(localWallet, &DelegationReceiver{wallet: localWallet, registry: registry})
 
 	if Parameters.RefreshInterval < minRefreshInterval {
 		panic(fmt.Sprintf("manarefresh interval of %d minutes is too small, minimum is %d minutes", Parameters.RefreshInterval, minRefreshInterval))
 	}
+
+	Events.DelegationTimelockExpired.Attach(events.NewClosure(func(record *DelegationRecord) {
+		plugin.LogInfof("timelock of delegation %s expired, %s can reclaim %d tokens", record.DelegationID.Base58(), record.Delegator.Base58(), record.Amount)
+	}))
 }
 
 func run(_ *node.Plugin) {
 	if err := daemon.BackgroundWorker("ManaRefresher-plugin", func(shutdownSignal <-chan struct{}) {
 		ticker := time.NewTicker(time.Duration(Parameters.RefreshInterval) * time.Minute)
 		defer ticker.Stop()
+		timelockTicker := time.NewTicker(timelockCheckInterval)
+		defer timelockTicker.Stop()
 		for {
 			select {
 			case <-shutdownSignal:
@@ -61,6 +78,15 @@ func run(_ *node.Plugin) {
 				if err != nil {
 					plugin.LogErrorf("couldn't refresh mana: %w", err)
 				}
+
+			case <-timelockTicker.C:
+				expired, err := refresher.receiver.Registry().ExpiredTimelocks(clock.SyncedTime())
+				if err != nil {
+					plugin.LogErrorf("couldn't persist delegation reminders: %s", err)
+				}
+				for _, record := range expired {
+					Events.DelegationTimelockExpired.Trigger(record)
+				}
 			}
 		}
 	}, shutdown.PriorityManaRefresher); err != nil {
@@ -86,6 +112,31 @@ func TotalDelegatedFunds() (amount uint64) {
 	return refresher.receiver.TotalDelegatedFunds()
 }
 
+// DelegationRecords returns the records of the delegations of the given delegator, or of all delegations to the node if
+// delegator is nil.
+func DelegationRecords(delegator ledgerstate.Address) ([]*DelegationRecord, error) {
+	if refresher == nil {
+		return nil, errors.Errorf("manarefresher plugin is not running, node doesn't process delegated outputs")
+	}
+	// need to scan to get the most recent
+	_ = refresher.receiver.Scan()
+	return refresher.receiver.Registry().Records(delegator), nil
+}
+
+// DelegationRecordByID returns the record of the given delegation.
+func DelegationRecordByID(delegationID *ledgerstate.AliasAddress) (*DelegationRecord, error) {
+	if refresher == nil {
+		return nil, errors.Errorf("manarefresher plugin is not running, node doesn't process delegated outputs")
+	}
+	// need to scan to get the most recent
+	_ = refresher.receiver.Scan()
+	record, exists := refresher.receiver.Registry().Record(*delegationID)
+	if !exists {
+		return nil, errors.Errorf("delegation %s is not known to the node", delegationID.Base58())
+	}
+	return record, nil
+}
+
 // DelegatedOutputs returns all confirmed, unspent outputs that are delegated to the node.
 func DelegatedOutputs() (delegated ledgerstate.Outputs, err error) {
 	if refresher == nil {
@@ -52,10 +52,20 @@ func (r *Refresher) Refresh() (err error) {
 		if err != nil {
 			return
 		}
+		// the pending mana has to be determined before the outputs are spent
+		accessMana := make([]float64, len(consumedChunk))
+		for k, alias := range consumedChunk {
+			accessMana[k], _ = messagelayer.PendingManaOnOutput(alias.ID())
+		}
 		err = r.sendTransaction(tx)
 		if err != nil {
 			return
 		}
+		for k, alias := range consumedChunk {
+			if recordErr := r.receiver.Registry().RecordRefresh(alias, tx.ID(), accessMana[k], tx.Essence().Timestamp()); recordErr != nil {
+				plugin.LogErrorf("couldn't record refresh of delegation %s: %s", alias.GetAliasAddress().Base58(), recordErr)
+			}
+		}
 	}
 	return nil
 }
@@ -94,6 +104,11 @@ func (r *Refresher) prepareRefreshingTransaction(toBeRefreshed []*ledgerstate.Al
 		return nil, errors.Errorf("transaction timestamp is older than MaxReattachmentTime (%s) and cannot be issued", tangle.MaxReattachmentTimeMin)
 	}
 
+	// check the pledges to the node itself against the pledge policies
+	if err = messagelayer.CheckLocalManaPledge(essence.AccessPledgeID(), essence.ConsensusPledgeID()); err != nil {
+		return nil, errors.Errorf("refreshing transaction violates the mana pledge policy: %w", err)
+	}
+
 	return tx, nil
 }
 
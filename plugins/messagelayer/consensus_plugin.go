@@ -16,6 +16,7 @@ import (
 	"github.com/iotaledger/hive.go/identity"
 	"github.com/iotaledger/hive.go/node"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/protobuf/proto"
 
 	clockPkg "github.com/iotaledger/goshimmer/packages/clock"
@@ -34,6 +35,7 @@ import (
 	"github.com/iotaledger/goshimmer/packages/vote/statement"
 	"github.com/iotaledger/goshimmer/plugins/autopeering/discovery"
 	"github.com/iotaledger/goshimmer/plugins/autopeering/local"
+	"github.com/iotaledger/goshimmer/plugins/database"
 )
 
 // region Plugin ///////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -47,6 +49,8 @@ var (
 	voterServer         *votenet.VoterServer
 	registry            *statement.Registry
 	registryOnce        sync.Once
+	statementEncoder    *statement.Encoder
+	encoderOnce         sync.Once
 	dRNGState           *drng.State
 	dRNGStateMutex      sync.RWMutex
 	dRNGTicker          *drng.Ticker
@@ -77,6 +81,13 @@ func configureConsensusPlugin(plugin *node.Plugin) {
 			plugin.LogWarnf("FPC vote: %s", err)
 		}
 	}))
+	ConsensusMechanism().Events.TimestampVote.Attach(events.NewClosure(func(id string, initOpn opinion.Opinion) {
+		if err := Voter().Vote(id, vote.TimestampType, initOpn); err != nil {
+			// the message would never get an opinion otherwise, so we settle on the local opinion
+			plugin.LogWarnf("FPC timestamp vote: %s - using the local opinion '%s'", err, initOpn)
+			ConsensusMechanism().ProcessVote(&vote.OpinionEvent{ID: id, Opinion: initOpn, Ctx: vote.Context{Type: vote.TimestampType}})
+		}
+	}))
 	ConsensusMechanism().Events.Error.Attach(events.NewClosure(func(err error) {
 		plugin.LogErrorf("FCOB error: %s", err)
 	}))
@@ -92,7 +103,12 @@ func runConsensusPlugin(plugin *node.Plugin) {
 // Voter returns the DRNGRoundBasedVoter instance used by the FPC plugin.
 func Voter() vote.DRNGRoundBasedVoter {
 	voterOnce.Do(func() {
-		voter = fpc.New(OpinionGiverFunc, OwnManaRetriever)
+		parameters := fpc.DefaultParameters()
+		// an invalid sampling strategy is reported when the plugin is configured
+		if samplingStrategy, err := fpc.SamplingStrategyFromString(FPCParameters.SamplingStrategy, FPCParameters.SamplingTopK); err == nil {
+			parameters.SamplingStrategy = samplingStrategy
+		}
+		voter = fpc.New(OpinionGiverFunc, OwnManaRetriever, parameters)
 	})
 	return voter
 }
@@ -100,12 +116,28 @@ func Voter() vote.DRNGRoundBasedVoter {
 // Registry returns the registry.
 func Registry() *statement.Registry {
 	registryOnce.Do(func() {
-		registry = statement.NewRegistry()
+		storage := statement.NewStorage(database.Store(), database.CacheTimeProvider())
+		registry = statement.NewPersistentRegistry(storage, time.Duration(StatementParameters.Retention)*time.Minute)
 	})
 	return registry
 }
 
+// StatementEncoder returns the encoder that turns the opinions of the node into statements.
+func StatementEncoder() *statement.Encoder {
+	encoderOnce.Do(func() {
+		maxSize := payload.MaxSize
+		statementEncoder = statement.NewEncoder(StatementParameters.CheckpointInterval, int(maxPayloadRatio*float64(maxSize)))
+	})
+	return statementEncoder
+}
+
 func configureFPC(plugin *node.Plugin) {
+	samplingStrategy, err := fpc.SamplingStrategyFromString(FPCParameters.SamplingStrategy, FPCParameters.SamplingTopK)
+	if err != nil {
+		plugin.LogFatalf("invalid FPC sampling strategy: %s", err)
+	}
+	plugin.LogInfof("using %s sampling for FPC queries", samplingStrategy.Name())
+
 	if FPCParameters.Listen {
 		lPeer := local.GetInstance()
 		_, portStr, err := net.SplitHostPort(FPCParameters.BindAddress)
@@ -126,6 +158,8 @@ func configureFPC(plugin *node.Plugin) {
 		if StatementParameters.WriteStatement && checkEnoughMana(local.GetInstance().ID(), StatementParameters.WriteManaThreshold) {
 			makeStatement(roundStats, broadcastStatement)
 		}
+		recordVotingRounds(roundStats)
+
 		peersQueried := len(roundStats.QueriedOpinions)
 		voteContextsCount := len(roundStats.ActiveVoteContexts)
 		plugin.LogDebugf("executed round with rand %0.4f for %d vote contexts on %d peers, took %v", roundStats.RandUsed, voteContextsCount, peersQueried, roundStats.Duration)
@@ -134,14 +168,24 @@ func configureFPC(plugin *node.Plugin) {
 	Voter().Events().Finalized.Attach(events.NewClosure(ConsensusMechanism().ProcessVote))
 	Voter().Events().Finalized.Attach(events.NewClosure(func(ev *vote.OpinionEvent) {
 		if ev.Ctx.Type == vote.ConflictType {
+			recordVotingFinished(ev, true)
 			plugin.LogInfof("FPC finalized for transaction with id '%s' - final opinion: '%s'", ev.ID, ev.Opinion)
 		}
+		if ev.Ctx.Type == vote.TimestampType {
+			plugin.LogInfof("FPC finalized for timestamp of message with id '%s' - final opinion: '%s'", ev.ID, ev.Opinion)
+		}
 	}))
 
 	Voter().Events().Failed.Attach(events.NewClosure(func(ev *vote.OpinionEvent) {
 		if ev.Ctx.Type == vote.ConflictType {
+			recordVotingFinished(ev, false)
 			plugin.LogWarnf("FPC failed for transaction with id '%s' - last opinion: '%s'", ev.ID, ev.Opinion)
 		}
+		if ev.Ctx.Type == vote.TimestampType {
+			// the message would never get an opinion otherwise, so we settle on the last opinion of the vote
+			ConsensusMechanism().ProcessVote(ev)
+			plugin.LogWarnf("FPC failed for timestamp of message with id '%s' - last opinion: '%s'", ev.ID, ev.Opinion)
+		}
 	}))
 }
 
@@ -152,10 +196,25 @@ func runFPC(plugin *node.Plugin) {
 		if err := daemon.BackgroundWorker(ServerWorkerName, func(shutdownSignal <-chan struct{}) {
 			stopped := make(chan struct{})
 			bindAddr := FPCParameters.BindAddress
-			voterServer = votenet.New(Voter(), OpinionRetriever, bindAddr,
+			var opts []grpc.ServerOption
+			if FPCParameters.TLS {
+				privateKey, err := local.GetInstance().Database().LocalPrivateKey()
+				if err != nil {
+					plugin.LogErrorf("Failed to load the private key of the node: %s", err)
+					return
+				}
+				tlsConfig, err := votenet.NewServerTLSConfig(privateKey)
+				if err != nil {
+					plugin.LogErrorf("Failed to create the TLS configuration: %s", err)
+					return
+				}
+				opts = append(opts, grpc.Creds(credentials.NewTLS(tlsConfig)))
+			}
+			voterServer = votenet.New(Voter(), OpinionRetriever, currentDRNGRound, bindAddr, local.GetInstance().LocalIdentity(),
 				metrics.Events().FPCInboundBytes,
 				metrics.Events().FPCOutboundBytes,
 				metrics.Events().QueryReceived,
+				opts...,
 			)
 
 			go func() {
@@ -184,6 +243,11 @@ func runFPC(plugin *node.Plugin) {
 		plugin.LogInfof("Started FPC round initiator")
 		defer plugin.LogInfof("Stopped FPC round initiator")
 
+		// votes on timestamps that were still running when the node was shut down are not persisted by the voter
+		if requeued := ConsensusMechanism().RequeueTimestampVotes(); requeued > 0 {
+			plugin.LogInfof("Re-queued %d pending FPC timestamp votes", requeued)
+		}
+
 		dRNGTickerMutex.Lock()
 		dRNGTicker = drng.NewTicker(DRNGState, FPCParameters.RoundInterval, FPCParameters.DefaultRandomness, FPCParameters.AwaitOffset)
 		dRNGTickerMutex.Unlock()
@@ -192,7 +256,7 @@ func runFPC(plugin *node.Plugin) {
 		for {
 			select {
 			case r := <-dRNGTicker.C():
-				if err := voter.Round(r, dRNGTicker.DelayedRoundStart()); err != nil {
+				if err := voter.Round(r, uint32(dRNGTicker.Round()), dRNGTicker.DelayedRoundStart()); err != nil {
 					plugin.LogWarnf("unable to execute FPC round: %s", err)
 				}
 			case <-shutdownSignal:
@@ -221,6 +285,40 @@ func runFPC(plugin *node.Plugin) {
 	}, shutdown.PriorityFPC); err != nil {
 		plugin.Panicf("Failed to start as daemon: %s", err)
 	}
+
+	// the statement storage is shut down together with the tangle, after all FPC workers that write to it have stopped
+	if err := daemon.BackgroundWorker("StatementStorage", func(shutdownSignal <-chan struct{}) {
+		<-shutdownSignal
+		Registry().Shutdown()
+	}, shutdown.PriorityTangle); err != nil {
+		plugin.Panicf("Failed to start as daemon: %s", err)
+	}
+}
+
+// recordVotingRounds records the executed round of every conflict that is being voted on in the ConflictTimeline.
+func recordVotingRounds(roundStats *vote.RoundStats) {
+	for id, voteContext := range roundStats.ActiveVoteContexts {
+		if voteContext.Type != vote.ConflictType {
+			continue
+		}
+
+		transactionID, err := ledgerstate.TransactionIDFromBase58(id)
+		if err != nil {
+			continue
+		}
+
+		Tangle().LedgerState.ConflictTimeline.RecordVotingRound(ledgerstate.NewBranchID(transactionID), uint32(voteContext.Rounds), voteContext.LastOpinion() == opinion.Like, voteContext.ProportionLiked)
+	}
+}
+
+// recordVotingFinished records the outcome of the voting on a conflict in the ConflictTimeline.
+func recordVotingFinished(ev *vote.OpinionEvent, finalized bool) {
+	transactionID, err := ledgerstate.TransactionIDFromBase58(ev.ID)
+	if err != nil {
+		return
+	}
+
+	Tangle().LedgerState.ConflictTimeline.RecordVotingFinished(ledgerstate.NewBranchID(transactionID), uint32(ev.Ctx.Rounds), ev.Opinion == opinion.Like, finalized)
 }
 
 // endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -344,14 +442,19 @@ type PeerOpinionGiver struct {
 	p *peer.Peer
 }
 
-// Query queries another node for its opinion.
+// Query queries another node for its opinion in the round carried by the context. Replies that are not signed by the
+// queried node or that do not belong to the query are rejected.
 func (pog *PeerOpinionGiver) Query(ctx context.Context, conflictIDs, timestampIDs []string, _ ...time.Duration) (opinion.Opinions, error) {
 	if pog == nil {
 		return nil, fmt.Errorf("unable to query opinions, PeerOpinionGiver is nil")
 	}
 
 	var opts []grpc.DialOption
-	opts = append(opts, grpc.WithInsecure())
+	if FPCParameters.TLS {
+		opts = append(opts, grpc.WithTransportCredentials(credentials.NewTLS(votenet.NewClientTLSConfig(pog.p.PublicKey()))))
+	} else {
+		opts = append(opts, grpc.WithInsecure())
+	}
 
 	// connect to the FPC service
 	conn, err := grpc.Dial(pog.Address(), opts...)
@@ -366,19 +469,25 @@ func (pog *PeerOpinionGiver) Query(ctx context.Context, conflictIDs, timestampID
 	}()
 
 	client := votenet.NewVoterQueryClient(conn)
-	query := &votenet.QueryRequest{ConflictIDs: conflictIDs, TimestampIDs: timestampIDs}
+	query, err := votenet.NewQueryRequest(opinion.RoundFromContext(ctx), conflictIDs, timestampIDs)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create query: %w", err)
+	}
 	reply, err := client.Opinion(ctx, query)
 	if err != nil {
-		metrics.Events().QueryReplyError.Trigger(&metrics.QueryReplyErrorEvent{
-			ID:           pog.p.ID().String(),
-			OpinionCount: len(conflictIDs) + len(timestampIDs),
-		})
+		pog.triggerQueryReplyError(len(conflictIDs) + len(timestampIDs))
 		return nil, fmt.Errorf("unable to query opinions: %w", err)
 	}
 
 	metrics.Events().FPCInboundBytes.Trigger(uint64(proto.Size(reply)))
 	metrics.Events().FPCOutboundBytes.Trigger(uint64(proto.Size(query)))
 
+	// a node whose reply fails the verification is treated as not answering
+	if err = votenet.VerifyReply(pog.p.PublicKey(), query, reply); err != nil {
+		pog.triggerQueryReplyError(len(conflictIDs) + len(timestampIDs))
+		return nil, fmt.Errorf("invalid reply from %s: %w", pog.p.ID(), err)
+	}
+
 	// convert int32s in reply to opinions
 	opinions := make(opinion.Opinions, len(reply.Opinion))
 	for i, intOpn := range reply.Opinion {
@@ -388,6 +497,13 @@ func (pog *PeerOpinionGiver) Query(ctx context.Context, conflictIDs, timestampID
 	return opinions, err
 }
 
+func (pog *PeerOpinionGiver) triggerQueryReplyError(opinionCount int) {
+	metrics.Events().QueryReplyError.Trigger(&metrics.QueryReplyErrorEvent{
+		ID:           pog.p.ID().String(),
+		OpinionCount: opinionCount,
+	})
+}
+
 // ID returns the identifier of the underlying Peer.
 func (pog *PeerOpinionGiver) ID() identity.ID {
 	return pog.p.ID()
@@ -421,8 +537,19 @@ func OwnManaRetriever() (float64, error) {
 func OpinionRetriever(id string, objectType vote.ObjectType) opinion.Opinion {
 	switch objectType {
 	case vote.TimestampType:
-		// TODO: implement
-		return opinion.Like
+		messageID, err := tangle.NewMessageID(id)
+		if err != nil {
+			plugin.LogErrorf("received invalid vote request for timestamp of message '%s'", id)
+
+			return opinion.Unknown
+		}
+
+		timestampOpinion := opinion.Unknown
+		ConsensusMechanism().Storage.TimestampOpinion(messageID).Consume(func(t *fcob.TimestampOpinion) {
+			timestampOpinion = t.Value
+		})
+
+		return timestampOpinion
 	default: // conflict type
 		transactionID, err := ledgerstate.TransactionIDFromBase58(id)
 		if err != nil {
@@ -469,7 +596,7 @@ func checkEnoughMana(id identity.ID, threshold float64) bool {
 	return enoughMana
 }
 
-func makeStatement(roundStats *vote.RoundStats, broadcastFunc func(conflicts statement.Conflicts, timestamps statement.Timestamps)) {
+func makeStatement(roundStats *vote.RoundStats, broadcastFunc func(statement *statement.Statement) error) {
 	timestamps := statement.Timestamps{}
 	conflicts := statement.Conflicts{}
 
@@ -490,28 +617,17 @@ func makeStatement(roundStats *vote.RoundStats, broadcastFunc func(conflicts sta
 			}
 			conflicts = append(conflicts, conflictStatement)
 		}
-		conflicts, timestamps = handleStatement(conflicts, timestamps, broadcastFunc)
 	}
 
-	if len(conflicts)+len(timestamps) >= 0 {
-		broadcastFunc(conflicts, timestamps)
-	}
-}
-
-// handleStatement limits the size of statements if size exceeds max capacity
-func handleStatement(conflicts statement.Conflicts, timestamps statement.Timestamps,
-	broadcastFunc func(conflicts statement.Conflicts, timestamps statement.Timestamps)) (statement.Conflicts, statement.Timestamps) {
-	if hasStatementExceededMaxSize(conflicts, timestamps) {
-		broadcastFunc(conflicts, timestamps)
-		timestamps = statement.Timestamps{}
-		conflicts = statement.Conflicts{}
+	// the encoder only includes the opinions that changed since the previous statement and splits large statements
+	for _, s := range StatementEncoder().Encode(conflicts, timestamps) {
+		if err := broadcastFunc(s); err != nil {
+			plugin.LogWarnf("error issuing statement: %s", err)
+			// the other nodes missed the statement, so the next one has to contain all opinions again
+			StatementEncoder().ForceCheckpoint()
+			return
+		}
 	}
-	return conflicts, timestamps
-}
-
-func hasStatementExceededMaxSize(conflicts statement.Conflicts, timestamps statement.Timestamps) bool {
-	maxSize := payload.MaxSize
-	return (len(conflicts)*statement.ConflictLength + len(timestamps)*statement.TimestampLength) >= int(maxPayloadRatio*float64(maxSize))
 }
 
 func makeConflictStatement(id string, v *vote.Context) (statement.Conflict, error) {
@@ -547,17 +663,24 @@ func makeTimeStampStatement(id string, v *vote.Context) (statement.Timestamp, er
 }
 
 // broadcastStatement broadcasts a statement via communication layer.
-func broadcastStatement(conflicts statement.Conflicts, timestamps statement.Timestamps) {
-	msg, err := Tangle().IssuePayload(statement.New(conflicts, timestamps))
+func broadcastStatement(statementPayload *statement.Statement) error {
+	msg, err := Tangle().IssuePayload(statementPayload)
 	if err != nil {
-		plugin.LogWarnf("error issuing statement: %s", err)
-		return
+		return err
 	}
 
 	plugin.LogDebugf("issued statement %s", msg.ID())
+	return nil
 }
 
 func readStatement(messageID tangle.MessageID) {
+	// the statements are retained by the local time at which they were received, as the issuing time is chosen by the
+	// issuer of the message
+	receivedTime := clockPkg.SyncedTime()
+	Tangle().Storage.MessageMetadata(messageID).Consume(func(messageMetadata *tangle.MessageMetadata) {
+		receivedTime = messageMetadata.ReceivedTime()
+	})
+
 	Tangle().Storage.Message(messageID).Consume(func(msg *tangle.Message) {
 		messagePayload := msg.Payload()
 		if messagePayload.Type() != statement.StatementType {
@@ -580,13 +703,14 @@ func readStatement(messageID tangle.MessageID) {
 			return
 		}
 
-		issuerRegistry := Registry().NodeView(issuerID)
-
-		issuerRegistry.AddConflicts(statementPayload.Conflicts)
-
-		issuerRegistry.AddTimestamps(statementPayload.Timestamps)
+		if err := Registry().ProcessStatement(issuerID, msg.ID(), receivedTime, statementPayload); err != nil {
+			plugin.LogDebugf("statement of %s: %s", issuerID, err)
+			if errors.Is(err, statement.ErrStaleStatement) {
+				return
+			}
+		}
 
-		issuerRegistry.UpdateLastStatementReceivedTime(clockPkg.SyncedTime())
+		Registry().NodeView(issuerID).UpdateLastStatementReceivedTime(clockPkg.SyncedTime())
 		Tangle().ConsensusManager.Events.StatementProcessed.Trigger(msg)
 	})
 }
@@ -606,3 +730,11 @@ func DRNGState() *drng.State {
 	defer dRNGStateMutex.RUnlock()
 	return dRNGState
 }
+
+// currentDRNGRound returns the round of the latest dRNG randomness or 0 if no randomness was received yet.
+func currentDRNGRound() uint32 {
+	if state := DRNGState(); state != nil {
+		return uint32(state.Randomness().Round)
+	}
+	return 0
+}
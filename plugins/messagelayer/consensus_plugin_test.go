@@ -11,13 +11,13 @@ import (
 	"github.com/iotaledger/goshimmer/packages/vote/statement"
 )
 
-func MockBroadcastStatement(conflicts statement.Conflicts, timestamps statement.Timestamps) {
-	statementPayload := statement.New(conflicts, timestamps)
+func MockBroadcastStatement(statementPayload *statement.Statement) error {
 	payloadLen := len(statementPayload.Bytes())
 	if payloadLen > payload.MaxSize {
 		err := fmt.Errorf("maximum payload size of %d bytes exceeded", payloadLen)
 		panic(err)
 	}
+	return nil
 }
 
 func TestMakeStatement(t *testing.T) {
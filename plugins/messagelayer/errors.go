@@ -4,3 +4,6 @@ import "github.com/cockroachdb/errors"
 
 // ErrQueryNotAllowed is returned when the node is not synced and mana debug mode is disabled.
 var ErrQueryNotAllowed = errors.New("mana query not allowed, node is not synced, debug mode disabled")
+
+// ErrPastConsensusManaNotRetained is returned when past consensus mana is requested for a time before the retention window.
+var ErrPastConsensusManaNotRetained = errors.New("past consensus mana is not retained for the requested time")
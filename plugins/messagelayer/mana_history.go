@@ -0,0 +1,172 @@
+package messagelayer
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/iotaledger/goshimmer/packages/mana"
+)
+
+// region consensusHistory /////////////////////////////////////////////////////////////////////////////////////////////
+
+// consensusHistory keeps the logged consensus mana events ordered by time and caches checkpoints of the consensus base
+// mana vector at multiples of the checkpoint interval, so that past consensus mana is rebuilt from the closest
+// checkpoint instead of replaying the whole event log on every query.
+type consensusHistory struct {
+	interval    time.Duration
+	events      mana.EventSlice
+	checkpoints []*consensusCheckpoint
+	mutex       sync.Mutex
+}
+
+// consensusCheckpoint is a snapshot of the consensus base mana vector that contains all logged events up to its time.
+type consensusCheckpoint struct {
+	time         time.Time
+	persistables []*mana.PersistableBaseMana
+}
+
+// newConsensusHistory creates an empty consensusHistory that caches a checkpoint every interval.
+func newConsensusHistory(interval time.Duration) *consensusHistory {
+	return &consensusHistory{
+		interval: interval,
+	}
+}
+
+// load adds the given events that were read from the event log storage.
+func (h *consensusHistory) load(events mana.EventSlice) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	h.events = append(h.events, events...)
+	h.events.Sort()
+	h.checkpoints = nil
+}
+
+// add adds a newly logged event and drops all cached checkpoints that should have contained it.
+func (h *consensusHistory) add(ev mana.Event) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	h.events = append(h.events, ev)
+	if len(h.events) > 1 && !h.events[len(h.events)-2].Timestamp().Before(ev.Timestamp()) {
+		h.events.Sort()
+	}
+
+	valid := sort.Search(len(h.checkpoints), func(i int) bool {
+		return !h.checkpoints[i].time.Before(ev.Timestamp())
+	})
+	h.checkpoints = h.checkpoints[:valid]
+}
+
+// prune removes all events that were compacted into the stored checkpoint at time `t` and all cached checkpoints
+// before it.
+func (h *consensusHistory) prune(t time.Time) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	retainedEvents := make(mana.EventSlice, 0, len(h.events))
+	for _, ev := range h.events {
+		if ev.Timestamp().After(t) {
+			retainedEvents = append(retainedEvents, ev)
+		}
+	}
+	h.events = retainedEvents
+
+	firstRetained := sort.Search(len(h.checkpoints), func(i int) bool {
+		return !h.checkpoints[i].time.Before(t)
+	})
+	h.checkpoints = h.checkpoints[firstRetained:]
+}
+
+// vector builds the consensus base mana vector at time `t`. `base` holds the stored checkpoint taken at `baseTime`,
+// which contains none of the events in the history. It returns the vector together with the events that were applied
+// on top of the checkpoint that it was built from.
+func (h *consensusHistory) vector(base *mana.ConsensusBaseManaVector, baseTime time.Time, t time.Time) (*mana.ConsensusBaseManaVector, mana.EventSlice, error) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	cbmv, appliedUntil := base, time.Time{}
+	aligned := t
+	if h.interval > 0 {
+		aligned = t.Truncate(h.interval)
+	}
+	if checkpoint := h.checkpointBefore(aligned); checkpoint != nil {
+		baseManaVector, err := mana.NewBaseManaVector(mana.ConsensusMana, base.Model())
+		if err != nil {
+			return nil, nil, err
+		}
+		cbmv = baseManaVector.(*mana.ConsensusBaseManaVector)
+		for _, p := range checkpoint.persistables {
+			if err = cbmv.FromPersistable(p); err != nil {
+				return nil, nil, err
+			}
+		}
+		appliedUntil = checkpoint.time
+	}
+
+	if h.interval > 0 && !aligned.Equal(appliedUntil) && !aligned.Before(baseTime) {
+		if err := cbmv.BuildPastBaseVector(h.eventsBetween(appliedUntil, aligned), aligned); err != nil {
+			return nil, nil, err
+		}
+		h.storeCheckpoint(&consensusCheckpoint{
+			time:         aligned,
+			persistables: cbmv.ToPersistables(),
+		})
+		appliedUntil = aligned
+	}
+
+	events := h.eventsBetween(appliedUntil, t)
+	if err := cbmv.BuildPastBaseVector(events, t); err != nil {
+		return nil, nil, err
+	}
+
+	return cbmv, events, nil
+}
+
+// eventsBetween returns the events in (from, to]. A zero `from` includes all events up to `to`.
+func (h *consensusHistory) eventsBetween(from, to time.Time) mana.EventSlice {
+	start := 0
+	if !from.IsZero() {
+		start = sort.Search(len(h.events), func(i int) bool {
+			return h.events[i].Timestamp().After(from)
+		})
+	}
+	end := sort.Search(len(h.events), func(i int) bool {
+		return h.events[i].Timestamp().After(to)
+	})
+	if start >= end {
+		return nil
+	}
+
+	return append(mana.EventSlice(nil), h.events[start:end]...)
+}
+
+// checkpointBefore returns the youngest cached checkpoint that is not younger than `t`.
+func (h *consensusHistory) checkpointBefore(t time.Time) *consensusCheckpoint {
+	index := sort.Search(len(h.checkpoints), func(i int) bool {
+		return h.checkpoints[i].time.After(t)
+	})
+	if index == 0 {
+		return nil
+	}
+
+	return h.checkpoints[index-1]
+}
+
+// storeCheckpoint adds the checkpoint while keeping the cached checkpoints ordered by time.
+func (h *consensusHistory) storeCheckpoint(checkpoint *consensusCheckpoint) {
+	index := sort.Search(len(h.checkpoints), func(i int) bool {
+		return !h.checkpoints[i].time.Before(checkpoint.time)
+	})
+	if index < len(h.checkpoints) && h.checkpoints[index].time.Equal(checkpoint.time) {
+		h.checkpoints[index] = checkpoint
+		return
+	}
+
+	h.checkpoints = append(h.checkpoints, nil)
+	copy(h.checkpoints[index+1:], h.checkpoints[index:])
+	h.checkpoints[index] = checkpoint
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
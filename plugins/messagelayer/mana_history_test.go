@@ -0,0 +1,77 @@
+package messagelayer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/iotaledger/hive.go/identity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/goshimmer/packages/mana"
+)
+
+func TestConsensusHistory(t *testing.T) {
+	nodeA, nodeB := identity.GenerateIdentity().ID(), identity.GenerateIdentity().ID()
+	start := time.Now().Truncate(time.Hour).Add(-10 * time.Hour)
+	history := newConsensusHistory(time.Hour)
+
+	var events mana.EventSlice
+	for i := 0; i < 10; i++ {
+		events = append(events, &mana.PledgedEvent{
+			NodeID:   nodeA,
+			Amount:   10,
+			Time:     start.Add(time.Duration(i) * time.Hour).Add(time.Minute),
+			ManaType: mana.ConsensusMana,
+		})
+	}
+	history.load(events)
+
+	queryTime := start.Add(5*time.Hour + 30*time.Minute)
+	assertConsensusMana(t, history, queryTime, nodeA, 60)
+	require.Len(t, history.checkpoints, 1)
+	assert.Equal(t, start.Add(5*time.Hour), history.checkpoints[0].time)
+
+	// the cached checkpoint is used for younger queries and only the events after it are applied
+	vector, applied, err := history.vector(emptyConsensusVector(t), time.Time{}, start.Add(5*time.Hour+30*time.Minute))
+	require.NoError(t, err)
+	assert.Len(t, applied, 1)
+	assertVectorMana(t, vector, queryTime, nodeA, 60)
+	assertConsensusMana(t, history, start.Add(8*time.Hour), nodeA, 80)
+	require.Len(t, history.checkpoints, 2)
+
+	// a late event invalidates all checkpoints that should have contained it
+	history.add(&mana.PledgedEvent{
+		NodeID:   nodeB,
+		Amount:   5,
+		Time:     start.Add(6 * time.Hour),
+		ManaType: mana.ConsensusMana,
+	})
+	require.Len(t, history.checkpoints, 1)
+	assertConsensusMana(t, history, start.Add(8*time.Hour), nodeB, 5)
+	assertConsensusMana(t, history, queryTime, nodeB, 0)
+
+	// compacted events and older checkpoints are dropped
+	history.prune(start.Add(7 * time.Hour))
+	assert.Len(t, history.events, 3)
+	require.Len(t, history.checkpoints, 1)
+	assert.Equal(t, start.Add(8*time.Hour), history.checkpoints[0].time)
+}
+
+func emptyConsensusVector(t *testing.T) *mana.ConsensusBaseManaVector {
+	baseManaVector, err := mana.NewBaseManaVector(mana.ConsensusMana, mana.NewNoDecayModel())
+	require.NoError(t, err)
+	return baseManaVector.(*mana.ConsensusBaseManaVector)
+}
+
+func assertConsensusMana(t *testing.T, history *consensusHistory, queryTime time.Time, nodeID identity.ID, expected float64) {
+	vector, _, err := history.vector(emptyConsensusVector(t), time.Time{}, queryTime)
+	require.NoError(t, err)
+	assertVectorMana(t, vector, queryTime, nodeID, expected)
+}
+
+func assertVectorMana(t *testing.T, vector *mana.ConsensusBaseManaVector, queryTime time.Time, nodeID identity.ID, expected float64) {
+	manaMap, _, err := vector.GetManaMap(queryTime)
+	require.NoError(t, err)
+	assert.InDelta(t, expected, manaMap[nodeID], 1e-9)
+}
@@ -1,20 +1,19 @@
 package messagelayer
 
 import (
-	"fmt"
-	"math"
 	"os"
 	"sort"
 	"sync"
 	"time"
 
+	"github.com/cockroachdb/errors"
 	"github.com/iotaledger/hive.go/daemon"
-	"github.com/iotaledger/hive.go/datastructure/set"
 	"github.com/iotaledger/hive.go/events"
 	"github.com/iotaledger/hive.go/identity"
 	"github.com/iotaledger/hive.go/logger"
 	"github.com/iotaledger/hive.go/node"
 	"github.com/iotaledger/hive.go/objectstorage"
+	"go.uber.org/atomic"
 
 	db_pkg "github.com/iotaledger/goshimmer/packages/database"
 	"github.com/iotaledger/goshimmer/packages/gossip"
@@ -30,27 +29,32 @@ import (
 const (
 	// PluginName is the name of the mana plugin.
 	PluginName = "Mana"
-
-	// maxConsensusEventsInStorage = 110000
-	// slidingEventsInterval       = 10000 // 10% of maxConsensusEventsInStorage
 )
 
 var (
 	// manaPlugin is the plugin instance of the mana plugin.
-	manaPlugin         *node.Plugin
-	once               sync.Once
-	manaLogger         *logger.Logger
-	baseManaVectors    map[mana.Type]mana.BaseManaVector
-	osFactory          *objectstorage.Factory
-	storages           map[mana.Type]*objectstorage.ObjectStorage
-	allowedPledgeNodes map[mana.Type]AllowedPledge
-	// consensusBaseManaPastVectorStorage         *objectstorage.ObjectStorage
-	// consensusBaseManaPastVectorMetadataStorage *objectstorage.ObjectStorage
-	// consensusEventsLogStorage                  *objectstorage.ObjectStorage
-	// consensusEventsLogsStorageSize             atomic.Uint32
+	manaPlugin      *node.Plugin
+	once            sync.Once
+	manaLogger      *logger.Logger
+	baseManaVectors map[mana.Type]mana.BaseManaVector
+	osFactory       *objectstorage.Factory
+	storages        map[mana.Type]*objectstorage.ObjectStorage
+	pledgePolicies  map[mana.Type]*mana.PledgePolicy
+
+	consensusBaseManaPastVectorStorage         *objectstorage.ObjectStorage
+	consensusBaseManaPastVectorMetadataStorage *objectstorage.ObjectStorage
+	consensusEventsLogStorage                  *objectstorage.ObjectStorage
+	consensusEventsLogsStorageSize             atomic.Uint32
+	// latestConsensusEventTime holds the UnixNano timestamp of the youngest logged consensus mana event.
+	latestConsensusEventTime atomic.Int64
+	// consensusHistoryMutex prevents queries from seeing a half written checkpoint during compaction.
+	consensusHistoryMutex sync.RWMutex
+	// pastConsensusHistory indexes the logged consensus events and caches checkpoints for past consensus mana queries.
+	pastConsensusHistory *consensusHistory
+
 	onTransactionConfirmedClosure *events.Closure
-	// onPledgeEventClosure          *events.Closure
-	// onRevokeEventClosure          *events.Closure
+	onPledgeEventClosure          *events.Closure
+	onRevokeEventClosure          *events.Closure
 	// debuggingEnabled              bool
 )
 
@@ -66,13 +70,16 @@ func configureManaPlugin(*node.Plugin) {
 	manaLogger = logger.NewLogger(PluginName)
 
 	onTransactionConfirmedClosure = events.NewClosure(onTransactionConfirmed)
-	// onPledgeEventClosure = events.NewClosure(logPledgeEvent)
-	// onRevokeEventClosure = events.NewClosure(logRevokeEvent)
+	onPledgeEventClosure = events.NewClosure(logPledgeEvent)
+	onRevokeEventClosure = events.NewClosure(logRevokeEvent)
 
-	allowedPledgeNodes = make(map[mana.Type]AllowedPledge)
+	// mana calculation coefficients can be set from config
+	mana.SetCoefficients(ManaParameters.EmaCoefficient1, ManaParameters.EmaCoefficient2, ManaParameters.Decay)
+
+	pledgePolicies = make(map[mana.Type]*mana.PledgePolicy)
 	baseManaVectors = make(map[mana.Type]mana.BaseManaVector)
-	baseManaVectors[mana.AccessMana], _ = mana.NewBaseManaVector(mana.AccessMana)
-	baseManaVectors[mana.ConsensusMana], _ = mana.NewBaseManaVector(mana.ConsensusMana)
+	baseManaVectors[mana.AccessMana] = newBaseManaVector(mana.AccessMana, ManaParameters.AccessManaModel, ManaParameters.EmaCoefficient2)
+	baseManaVectors[mana.ConsensusMana] = newBaseManaVector(mana.ConsensusMana, ManaParameters.ConsensusManaModel, ManaParameters.EmaCoefficient1)
 
 	// configure storage for each vector type
 	storages = make(map[mana.Type]*objectstorage.ObjectStorage)
@@ -84,42 +91,83 @@ func configureManaPlugin(*node.Plugin) {
 		storages[mana.ResearchAccess] = osFactory.New(mana.PrefixAccessResearch, mana.FromObjectStorage)
 		storages[mana.ResearchConsensus] = osFactory.New(mana.PrefixConsensusResearch, mana.FromObjectStorage)
 	}
-	// consensusEventsLogStorage = osFactory.New(mana.PrefixEventStorage, mana.FromEventObjectStorage)
-	// consensusEventsLogsStorageSize.Store(getConsensusEventLogsStorageSize())
-	// manaLogger.Infof("read %d mana events from storage", consensusEventsLogsStorageSize.Load())
-	// consensusBaseManaPastVectorStorage = osFactory.New(mana.PrefixConsensusPastVector, mana.FromObjectStorage)
-	// consensusBaseManaPastVectorMetadataStorage = osFactory.New(mana.PrefixConsensusPastMetadata, mana.FromMetadataObjectStorage)
+	consensusEventsLogStorage = osFactory.New(mana.PrefixEventStorage, mana.FromEventObjectStorage)
+	pastConsensusHistory = newConsensusHistory(ManaParameters.ConsensusCheckpointInterval)
+	readConsensusEventLogsStorageStats()
+	manaLogger.Infof("read %d mana events from storage", consensusEventsLogsStorageSize.Load())
+	consensusBaseManaPastVectorStorage = osFactory.New(mana.PrefixConsensusPastVector, mana.FromObjectStorage)
+	consensusBaseManaPastVectorMetadataStorage = osFactory.New(mana.PrefixConsensusPastMetadata, mana.FromMetadataObjectStorage)
 
-	err := verifyPledgeNodes()
-	if err != nil {
-		manaLogger.Panic(err.Error())
-	}
+	pledgePolicies[mana.AccessMana] = newPledgePolicy(mana.AccessMana, withDeprecatedAllowList(mana.AccessMana,
+		ManaParameters.AccessPledgePolicy, ManaParameters.AllowedAccessFilterEnabled, ManaParameters.AllowedAccessPledge))
+	pledgePolicies[mana.ConsensusMana] = newPledgePolicy(mana.ConsensusMana, withDeprecatedAllowList(mana.ConsensusMana,
+		ManaParameters.ConsensusPledgePolicy, ManaParameters.AllowedConsensusFilterEnabled, ManaParameters.AllowedConsensusPledge))
 
 	// debuggingEnabled = ManaParameters.DebuggingEnabled
 
 	configureEvents()
 }
 
+// newBaseManaVector creates the base mana vector of the given type that uses the configured model.
+func newBaseManaVector(vectorType mana.Type, modelName string, emaCoefficient float64) mana.BaseManaVector {
+	model, err := mana.NewManaModel(modelName, mana.ModelParameters{
+		EMACoefficient:  emaCoefficient,
+		Decay:           ManaParameters.Decay,
+		LinearDecayRate: ManaParameters.LinearDecayRate,
+	})
+	if err != nil {
+		manaLogger.Panicf("invalid %s mana model: %s", vectorType.String(), err)
+	}
+	baseManaVector, err := mana.NewBaseManaVector(vectorType, model)
+	if err != nil {
+		manaLogger.Panic(err)
+	}
+	manaLogger.Infof("using %s model for %s mana", model.Name(), vectorType.String())
+	return baseManaVector
+}
+
 func configureEvents() {
 	// until we have the proper event...
 	Tangle().LedgerState.UTXODAG.Events().TransactionConfirmed.Attach(onTransactionConfirmedClosure)
-	// mana.Events().Pledged.Attach(onPledgeEventClosure)
-	// mana.Events().Revoked.Attach(onRevokeEventClosure)
-}
-
-//func logPledgeEvent(ev *mana.PledgedEvent) {
-//	if ev.ManaType == mana.ConsensusMana {
-//		consensusEventsLogStorage.Store(ev.ToPersistable()).Release()
-//		consensusEventsLogsStorageSize.Inc()
-//	}
-//}
-//
-//func logRevokeEvent(ev *mana.RevokedEvent) {
-//	if ev.ManaType == mana.ConsensusMana {
-//		consensusEventsLogStorage.Store(ev.ToPersistable()).Release()
-//		consensusEventsLogsStorageSize.Inc()
-//	}
-//}
+	mana.Events().Pledged.Attach(onPledgeEventClosure)
+	mana.Events().Revoked.Attach(onRevokeEventClosure)
+}
+
+func logPledgeEvent(ev *mana.PledgedEvent) {
+	if ev.ManaType == mana.ConsensusMana {
+		logConsensusEvent(ev.ToPersistable())
+	}
+}
+
+func logRevokeEvent(ev *mana.RevokedEvent) {
+	if ev.ManaType == mana.ConsensusMana {
+		logConsensusEvent(ev.ToPersistable())
+	}
+}
+
+func logConsensusEvent(persistableEvent *mana.PersistableEvent) {
+	consensusHistoryMutex.RLock()
+	defer consensusHistoryMutex.RUnlock()
+
+	ev, err := mana.FromPersistableEvent(persistableEvent)
+	if err != nil {
+		manaLogger.Errorf("error logging consensus event: %v", err)
+		return
+	}
+	consensusEventsLogStorage.Store(persistableEvent).Release()
+	consensusEventsLogsStorageSize.Inc()
+	pastConsensusHistory.add(ev)
+	updateLatestConsensusEventTime(persistableEvent.Time)
+}
+
+func updateLatestConsensusEventTime(eventTime time.Time) {
+	for {
+		latest := latestConsensusEventTime.Load()
+		if eventTime.UnixNano() <= latest || latestConsensusEventTime.CAS(latest, eventTime.UnixNano()) {
+			return
+		}
+	}
+}
 
 func onTransactionConfirmed(transactionID ledgerstate.TransactionID) {
 	Tangle().LedgerState.Transaction(transactionID).Consume(func(transaction *ledgerstate.Transaction) {
@@ -188,21 +236,17 @@ func onTransactionConfirmed(transactionID ledgerstate.TransactionID) {
 }
 
 func runManaPlugin(_ *node.Plugin) {
-	// mana calculation coefficients can be set from config
-	ema1 := ManaParameters.EmaCoefficient1
-	ema2 := ManaParameters.EmaCoefficient2
-	dec := ManaParameters.Decay
 	pruneInterval := ManaParameters.PruneConsensusEventLogsInterval
 	vectorsCleanUpInterval := ManaParameters.VectorsCleanupInterval
-	fmt.Printf("Prune interval: %v\n", pruneInterval)
-	mana.SetCoefficients(ema1, ema2, dec)
 	if err := daemon.BackgroundWorker("Mana", func(shutdownSignal <-chan struct{}) {
 		defer manaLogger.Infof("Stopping %s ... done", PluginName)
-		// ticker := time.NewTicker(pruneInterval)
-		// defer ticker.Stop()
+		ticker := time.NewTicker(pruneInterval)
+		defer ticker.Stop()
 		cleanupTicker := time.NewTicker(vectorsCleanUpInterval)
 		defer cleanupTicker.Stop()
-		if !readStoredManaVectors() {
+		if readStoredManaVectors() {
+			initConsensusHistory()
+		} else {
 			// read snapshot file
 			if Parameters.Snapshot.File != "" {
 				snapshot := &ledgerstate.Snapshot{}
@@ -222,14 +266,14 @@ func runManaPlugin(_ *node.Plugin) {
 			select {
 			case <-shutdownSignal:
 				manaLogger.Infof("Stopping %s ...", PluginName)
-				// mana.Events().Pledged.Detach(onPledgeEventClosure)
-				// mana.Events().Pledged.Detach(onRevokeEventClosure)
+				mana.Events().Pledged.Detach(onPledgeEventClosure)
+				mana.Events().Revoked.Detach(onRevokeEventClosure)
 				Tangle().LedgerState.UTXODAG.Events().TransactionConfirmed.Detach(onTransactionConfirmedClosure)
 				storeManaVectors()
 				shutdownStorages()
 				return
-			// case <-ticker.C:
-			// pruneConsensusEventLogsStorage()
+			case <-ticker.C:
+				pruneConsensusEventLogsStorage()
 			case <-cleanupTicker.C:
 				cleanupManaVectors()
 			}
@@ -275,9 +319,9 @@ func shutdownStorages() {
 	for vectorType := range baseManaVectors {
 		storages[vectorType].Shutdown()
 	}
-	// consensusEventsLogStorage.Shutdown()
-	// consensusBaseManaPastVectorStorage.Shutdown()
-	// consensusBaseManaPastVectorMetadataStorage.Shutdown()
+	consensusEventsLogStorage.Shutdown()
+	consensusBaseManaPastVectorStorage.Shutdown()
+	consensusBaseManaPastVectorMetadataStorage.Shutdown()
 }
 
 // GetHighestManaNodes returns the n highest type mana nodes in descending order.
@@ -303,10 +347,19 @@ func GetHighestManaNodesFraction(manaType mana.Type, p float64) ([]mana.Node, ti
 }
 
 // GetManaMap returns type mana perception of the node.
+// Consensus mana is rebuilt from the event log if the requested time lies before the youngest logged consensus event.
 func GetManaMap(manaType mana.Type, optionalUpdateTime ...time.Time) (mana.NodeMap, time.Time, error) {
 	if !QueryAllowed() {
 		return mana.NodeMap{}, time.Now(), ErrQueryNotAllowed
 	}
+	if manaType == mana.ConsensusMana && len(optionalUpdateTime) > 0 && optionalUpdateTime[0].UnixNano() < latestConsensusEventTime.Load() {
+		pastConsensusManaVector, _, err := GetPastConsensusManaVector(optionalUpdateTime[0])
+		if err != nil {
+			return mana.NodeMap{}, optionalUpdateTime[0], err
+		}
+		manaMap, _, err := pastConsensusManaVector.GetManaMap(optionalUpdateTime[0])
+		return manaMap, optionalUpdateTime[0], err
+	}
 	return baseManaVectors[manaType].GetManaMap(optionalUpdateTime...)
 }
 
@@ -324,7 +377,7 @@ func GetTotalMana(manaType mana.Type, optionalUpdateTime ...time.Time) (float64,
 	if !QueryAllowed() {
 		return 0, time.Now(), ErrQueryNotAllowed
 	}
-	manaMap, updateTime, err := baseManaVectors[manaType].GetManaMap(optionalUpdateTime...)
+	manaMap, updateTime, err := GetManaMap(manaType, optionalUpdateTime...)
 	if err != nil {
 		return 0, time.Now(), err
 	}
@@ -345,10 +398,18 @@ func GetAccessMana(nodeID identity.ID, optionalUpdateTime ...time.Time) (float64
 }
 
 // GetConsensusMana returns the consensus mana of the node specified.
+// It is rebuilt from the event log if the requested time lies before the youngest logged consensus event.
 func GetConsensusMana(nodeID identity.ID, optionalUpdateTime ...time.Time) (float64, time.Time, error) {
 	if !QueryAllowed() {
 		return 0, time.Now(), ErrQueryNotAllowed
 	}
+	if len(optionalUpdateTime) > 0 && optionalUpdateTime[0].UnixNano() < latestConsensusEventTime.Load() {
+		pastConsensusManaVector, _, err := GetPastConsensusManaVector(optionalUpdateTime[0])
+		if err != nil {
+			return 0, optionalUpdateTime[0], err
+		}
+		return pastConsensusManaVector.GetMana(nodeID, optionalUpdateTime[0])
+	}
 	return baseManaVectors[mana.ConsensusMana].GetMana(nodeID, optionalUpdateTime...)
 }
 
@@ -385,9 +446,42 @@ func OverrideMana(manaType mana.Type, nodeID identity.ID, bm *mana.AccessBaseMan
 	baseManaVectors[manaType].SetMana(nodeID, bm)
 }
 
-// GetAllowedPledgeNodes returns the list of nodes that type mana is allowed to be pledged to.
-func GetAllowedPledgeNodes(manaType mana.Type) AllowedPledge {
-	return allowedPledgeNodes[manaType]
+// GetPledgePolicy returns the policy that decides to which nodes type mana is allowed to be pledged to.
+func GetPledgePolicy(manaType mana.Type) *mana.PledgePolicy {
+	return pledgePolicies[manaType]
+}
+
+// ReserveManaPledge checks the access and consensus mana pledges of a transaction issued by the pledger against the
+// pledge policies and, if both are accepted, counts them towards the rate limit of the pledger. The returned cancel
+// function must be called if the transaction could not be issued, so that the pledges do not count.
+func ReserveManaPledge(accessPledgeID, consensusPledgeID identity.ID, pledger ledgerstate.Address) (cancel func(), err error) {
+	var pledgerKey string
+	if pledger != nil {
+		pledgerKey = pledger.Base58()
+	}
+	now := time.Now()
+	cancelAccess, err := pledgePolicies[mana.AccessMana].Reserve(accessPledgeID, pledgerKey, now)
+	if err != nil {
+		return nil, err
+	}
+	cancelConsensus, err := pledgePolicies[mana.ConsensusMana].Reserve(consensusPledgeID, pledgerKey, now)
+	if err != nil {
+		cancelAccess()
+		return nil, err
+	}
+	return func() {
+		cancelAccess()
+		cancelConsensus()
+	}, nil
+}
+
+// CheckLocalManaPledge checks the pledges of a transaction that the node issues to itself, e.g. to refresh delegated
+// mana. These are neither limited by the share of the total mana nor by the rate limit.
+func CheckLocalManaPledge(accessPledgeID, consensusPledgeID identity.ID) error {
+	if err := pledgePolicies[mana.AccessMana].CheckLocal(accessPledgeID); err != nil {
+		return err
+	}
+	return pledgePolicies[mana.ConsensusMana].CheckLocal(consensusPledgeID)
 }
 
 // GetOnlineNodes gets the list of currently known (and verified) peers in the network, and their respective mana values.
@@ -418,43 +512,64 @@ func GetOnlineNodes(manaType mana.Type) (onlineNodesMana []mana.Node, t time.Tim
 	return
 }
 
-func verifyPledgeNodes() error {
-	access := AllowedPledge{
-		IsFilterEnabled: ManaParameters.AllowedAccessFilterEnabled,
+// withDeprecatedAllowList translates the deprecated allow-list parameters of the given mana type into an allowList
+// pledge policy. It panics if they are combined with a pledge policy that is configured as well.
+func withDeprecatedAllowList(manaType mana.Type, config PledgePolicyParametersDefinition, filterEnabled bool, allowList []string) PledgePolicyParametersDefinition {
+	if !filterEnabled {
+		if len(allowList) > 0 {
+			manaLogger.Warnf("ignoring deprecated %s mana allow-list as its filter is disabled, use the %s mana pledge policy instead", manaType.String(), manaType.String())
+		}
+		return config
 	}
-	consensus := AllowedPledge{
-		IsFilterEnabled: ManaParameters.AllowedConsensusFilterEnabled,
+
+	if config.Mode != mana.AnyNodePledgeModeName || len(config.Nodes) > 0 {
+		manaLogger.Panicf("the deprecated %s mana allow-list cannot be combined with the %s mana pledge policy", manaType.String(), manaType.String())
 	}
+	manaLogger.Warnf("the %s mana allow-list parameters are deprecated, use the %s mana pledge policy with mode %s instead", manaType.String(), manaType.String(), mana.AllowListPledgeModeName)
+	config.Mode = mana.AllowListPledgeModeName
+	config.Nodes = allowList
+	return config
+}
 
-	access.Allowed = set.New(false)
+// newPledgePolicy creates the pledge policy of the given mana type from its configuration.
+func newPledgePolicy(manaType mana.Type, config PledgePolicyParametersDefinition) *mana.PledgePolicy {
+	mode, err := mana.PledgeModeFromString(config.Mode)
+	if err != nil {
+		manaLogger.Panicf("invalid %s mana pledge policy: %s", manaType.String(), err)
+	}
+	parameters := mana.PledgePolicyParameters{
+		Mode:            mode,
+		MinMana:         config.MinMana,
+		MaxShare:        config.MaxShare,
+		RateLimit:       config.RateLimit,
+		RateLimitWindow: config.RateLimitWindow,
+	}
 	// own ID is allowed by default
-	access.Allowed.Add(local.GetInstance().ID())
-	if access.IsFilterEnabled {
-		for _, pubKey := range ManaParameters.AllowedAccessPledge {
-			ID, err := mana.IDFromStr(pubKey)
-			if err != nil {
-				return err
-			}
-			access.Allowed.Add(ID)
+	if mode == mana.AllowListPledgeMode {
+		parameters.Nodes = append(parameters.Nodes, local.GetInstance().ID())
+	}
+	for _, pubKey := range config.Nodes {
+		ID, err := mana.IDFromStr(pubKey)
+		if err != nil {
+			manaLogger.Panicf("invalid node %s in %s mana pledge policy: %s", pubKey, manaType.String(), err)
 		}
+		parameters.Nodes = append(parameters.Nodes, ID)
 	}
 
-	consensus.Allowed = set.New(false)
-	// own ID is allowed by default
-	consensus.Allowed.Add(local.GetInstance().ID())
-	if consensus.IsFilterEnabled {
-		for _, pubKey := range ManaParameters.AllowedConsensusPledge {
-			ID, err := mana.IDFromStr(pubKey)
-			if err != nil {
-				return err
-			}
-			consensus.Allowed.Add(ID)
+	policy, err := mana.NewPledgePolicy(manaType, parameters, func(nodeID identity.ID) (float64, float64, error) {
+		nodeMana, _, err := baseManaVectors[manaType].GetMana(nodeID)
+		// nodes that are not in the base mana vector have no mana
+		if err != nil && !errors.Is(err, mana.ErrNodeNotFoundInBaseManaVector) {
+			return 0, 0, err
 		}
+		totalMana, _, err := GetTotalMana(manaType)
+		return nodeMana, totalMana, err
+	})
+	if err != nil {
+		manaLogger.Panicf("invalid %s mana pledge policy: %s", manaType.String(), err)
 	}
-
-	allowedPledgeNodes[mana.AccessMana] = access
-	allowedPledgeNodes[mana.ConsensusMana] = consensus
-	return nil
+	manaLogger.Infof("using %s pledge mode for %s mana", mode, manaType.String())
+	return policy
 }
 
 // PendingManaOnOutput predicts how much mana (bm2) will be pledged to a node if the output specified is spent.
@@ -485,279 +600,254 @@ func PendingManaOnOutput(outputID ledgerstate.OutputID) (float64, time.Time) {
 
 // GetPendingMana returns the mana pledged by spending a `value` output that sat for `n` duration.
 func GetPendingMana(value float64, n time.Duration) float64 {
-	return value * (1 - math.Pow(math.E, -mana.Decay*(n.Seconds())))
-}
-
-//// GetLoggedEvents gets the events logs for the node IDs and time frame specified. If none is specified, it returns the logs for all nodes.
-//func GetLoggedEvents(identityIDs []identity.ID, startTime time.Time, endTime time.Time) (map[identity.ID]*EventsLogs, error) {
-//	logs := make(map[identity.ID]*EventsLogs)
-//	lookup := make(map[identity.ID]bool)
-//	getAll := true
-//
-//	if len(identityIDs) > 0 {
-//		getAll = false
-//		for _, nodeID := range identityIDs {
-//			lookup[nodeID] = true
-//		}
-//	}
-//
-//	var err error
-//	consensusEventsLogStorage.ForEach(func(key []byte, cachedObject objectstorage.CachedObject) bool {
-//		cachedPe := &mana.CachedPersistableEvent{CachedObject: cachedObject}
-//		defer cachedPe.Release()
-//		pbm := cachedPe.Unwrap()
-//
-//		if !getAll {
-//			if !lookup[pbm.NodeID] {
-//				return true
-//			}
-//		}
-//
-//		if _, found := logs[pbm.NodeID]; !found {
-//			logs[pbm.NodeID] = &EventsLogs{}
-//		}
-//
-//		var ev mana.Event
-//		ev, err = mana.FromPersistableEvent(pbm)
-//		if err != nil {
-//			return false
-//		}
-//
-//		if ev.Timestamp().Before(startTime) || ev.Timestamp().After(endTime) {
-//			return true
-//		}
-//		switch ev.Type() {
-//		case mana.EventTypePledge:
-//			logs[pbm.NodeID].Pledge = append(logs[pbm.NodeID].Pledge, ev.(*mana.PledgedEvent))
-//		case mana.EventTypeRevoke:
-//			logs[pbm.NodeID].Revoke = append(logs[pbm.NodeID].Revoke, ev.(*mana.RevokedEvent))
-//		default:
-//			err = mana.ErrUnknownManaEvent
-//			return false
-//		}
-//		return true
-//	})
-//
-//	for ID := range logs {
-//		sort.Slice(logs[ID].Pledge, func(i, j int) bool {
-//			return logs[ID].Pledge[i].Time.Before(logs[ID].Pledge[j].Time)
-//		})
-//		sort.Slice(logs[ID].Revoke, func(i, j int) bool {
-//			return logs[ID].Revoke[i].Time.Before(logs[ID].Revoke[j].Time)
-//		})
-//	}
-//
-//	return logs, err
-//}
-//
-//// GetPastConsensusManaVectorMetadata gets the past consensus mana vector metadata.
-//func GetPastConsensusManaVectorMetadata() *mana.ConsensusBasePastManaVectorMetadata {
-//	cachedObj := consensusBaseManaPastVectorMetadataStorage.Load([]byte(mana.ConsensusBaseManaPastVectorMetadataStorageKey))
-//	cachedMetadata := &mana.CachedConsensusBasePastManaVectorMetadata{CachedObject: cachedObj}
-//	defer cachedMetadata.Release()
-//	return cachedMetadata.Unwrap()
-//}
-//
-//// GetPastConsensusManaVector builds a consensus base mana vector in the past.
-//func GetPastConsensusManaVector(t time.Time) (*mana.ConsensusBaseManaVector, []mana.Event, error) {
-//	baseManaVector, err := mana.NewBaseManaVector(mana.ConsensusMana)
-//	if err != nil {
-//		return nil, nil, err
-//	}
-//	cbmvPast := baseManaVector.(*mana.ConsensusBaseManaVector)
-//	cachedObj := consensusBaseManaPastVectorMetadataStorage.Load([]byte(mana.ConsensusBaseManaPastVectorMetadataStorageKey))
-//	cachedMetadata := &mana.CachedConsensusBasePastManaVectorMetadata{CachedObject: cachedObj}
-//	defer cachedMetadata.Release()
-//
-//	if cachedMetadata.Exists() {
-//		metadata := cachedMetadata.Unwrap()
-//		if t.After(metadata.Timestamp) {
-//			consensusBaseManaPastVectorStorage.ForEach(func(key []byte, cachedObject objectstorage.CachedObject) bool {
-//				cachedPbm := &mana.CachedPersistableBaseMana{CachedObject: cachedObject}
-//				defer cachedPbm.Release()
-//				p := cachedPbm.Unwrap()
-//				err = cbmvPast.FromPersistable(p)
-//				if err != nil {
-//					manaLogger.Errorf("error while restoring %s mana vector from storage: %w", mana.ConsensusMana.String(), err)
-//					baseManaVector, _ := mana.NewBaseManaVector(mana.ConsensusMana)
-//					cbmvPast = baseManaVector.(*mana.ConsensusBaseManaVector)
-//					return false
-//				}
-//				return true
-//			})
-//		}
-//	}
-//
-//	var eventLogs mana.EventSlice
-//	consensusEventsLogStorage.ForEach(func(key []byte, cachedObject objectstorage.CachedObject) bool {
-//		cachedPe := &mana.CachedPersistableEvent{CachedObject: cachedObject}
-//		defer cachedPe.Release()
-//		pe := cachedPe.Unwrap()
-//		if pe.Time.After(t) {
-//			return true
-//		}
-//
-//		// already consumed in stored base mana vector.
-//		if cachedMetadata.Exists() && cbmvPast.Size() > 0 {
-//			metadata := cachedMetadata.Unwrap()
-//			if pe.Time.Before(metadata.Timestamp) {
-//				return true
-//			}
-//		}
-//
-//		var ev mana.Event
-//		ev, err = mana.FromPersistableEvent(pe)
-//		if err != nil {
-//			return false
-//		}
-//		eventLogs = append(eventLogs, ev)
-//		return true
-//	})
-//	if err != nil {
-//		return nil, nil, err
-//	}
-//	eventLogs.Sort()
-//	err = cbmvPast.BuildPastBaseVector(eventLogs, t)
-//	if err != nil {
-//		return nil, nil, err
-//	}
-//
-//	err = cbmvPast.UpdateAll(t)
-//	if err != nil {
-//		return nil, nil, err
-//	}
-//
-//	return cbmvPast, eventLogs, nil
-//}
-//
-//func getConsensusEventLogsStorageSize() uint32 {
-//	var size uint32
-//	consensusEventsLogStorage.ForEachKeyOnly(func(key []byte) bool {
-//		size++
-//		return true
-//	}, objectstorage.WithIteratorSkipCache(true))
-//	return size
-//}
-//
-//func pruneConsensusEventLogsStorage() {
-//	if consensusEventsLogsStorageSize.Load() < maxConsensusEventsInStorage {
-//		return
-//	}
-//
-//	cachedObj := consensusBaseManaPastVectorMetadataStorage.Load([]byte(mana.ConsensusBaseManaPastVectorMetadataStorageKey))
-//	cachedMetadata := &mana.CachedConsensusBasePastManaVectorMetadata{CachedObject: cachedObj}
-//	defer cachedMetadata.Release()
-//
-//	bmv, err := mana.NewBaseManaVector(mana.ConsensusMana)
-//	if err != nil {
-//		manaLogger.Errorf("error creating consensus base mana vector: %v", err)
-//		return
-//	}
-//	cbmvPast := bmv.(*mana.ConsensusBaseManaVector)
-//	if cachedMetadata.Exists() {
-//		consensusBaseManaPastVectorStorage.ForEach(func(key []byte, cachedObject objectstorage.CachedObject) bool {
-//			cachedPbm := &mana.CachedPersistableBaseMana{CachedObject: cachedObject}
-//			pbm := cachedPbm.Unwrap()
-//			if pbm != nil {
-//				err = cbmvPast.FromPersistable(pbm)
-//				if err != nil {
-//					return false
-//				}
-//			}
-//			return true
-//		})
-//		if err != nil {
-//			manaLogger.Errorf("error reading stored consensus base mana vector: %v", err)
-//			return
-//		}
-//	}
-//
-//	var eventLogs mana.EventSlice
-//	consensusEventsLogStorage.ForEach(func(key []byte, cachedObject objectstorage.CachedObject) bool {
-//		cachedPe := &mana.CachedPersistableEvent{CachedObject: cachedObject}
-//		defer cachedPe.Release()
-//		pe := cachedPe.Unwrap()
-//		var ev mana.Event
-//		ev, err = mana.FromPersistableEvent(pe)
-//
-//		if cachedMetadata.Exists() {
-//			metadata := cachedMetadata.Unwrap()
-//			if ev.Timestamp().Before(metadata.Timestamp) {
-//				manaLogger.Errorf("consensus event storage contains event that is older, than the stored metadata timestamp %s: %s", metadata.Timestamp, ev.String())
-//				return true
-//			}
-//		}
-//
-//		if err != nil {
-//			return false
-//		}
-//		eventLogs = append(eventLogs, ev)
-//		return true
-//	})
-//	if err != nil {
-//		manaLogger.Infof("error reading persistable events: %v", err)
-//		return
-//	}
-//	eventLogs.Sort()
-//	// we always want (maxConsensusEventsInStorage - slidingEventsInterval) number of events left
-//	deleteWindow := len(eventLogs) - (maxConsensusEventsInStorage - slidingEventsInterval)
-//	storageSizeInt := int(consensusEventsLogsStorageSize.Load())
-//	if deleteWindow < 0 || deleteWindow > storageSizeInt {
-//		manaLogger.Errorf("invalid delete window %d for storage size %d, max storage size %d and sliding interval %d",
-//			deleteWindow, storageSizeInt, maxConsensusEventsInStorage, slidingEventsInterval)
-//		return
-//	}
-//	// Make sure to take related events. (we take deleteWindow oldest events)
-//	// Ensures that related events (same time) are not split between different intervals.
-//	prev := eventLogs[deleteWindow-1]
-//	var i int
-//	for i = deleteWindow; i < len(eventLogs); i++ {
-//		if !eventLogs[i].Timestamp().Equal(prev.Timestamp()) {
-//			break
-//		}
-//		prev = eventLogs[i]
-//	}
-//	toBePrunedEvents := eventLogs[:i]
-//	// TODO: later, when we have epochs, we have to make sure that `t` is before the epoch to be "finalized" next.
-//	// Otherwise, we won't be able to calculate the consensus mana for that epoch because we already pruned the events
-//	// leading up to it.
-//	t := toBePrunedEvents[len(toBePrunedEvents)-1].Timestamp()
-//
-//	err = cbmvPast.BuildPastBaseVector(toBePrunedEvents, t)
-//	if err != nil {
-//		manaLogger.Errorf("error building past consensus base mana vector: %w", err)
-//		return
-//	}
-//
-//	// store cbmv
-//	if err = consensusBaseManaPastVectorStorage.Prune(); err != nil {
-//		manaLogger.Errorf("error pruning consensus base mana vector storage: %w", err)
-//		return
-//	}
-//	for _, p := range cbmvPast.ToPersistables() {
-//		consensusBaseManaPastVectorStorage.Store(p).Release()
-//	}
-//
-//	// store the metadata
-//	metadata := &mana.ConsensusBasePastManaVectorMetadata{
-//		Timestamp: t,
-//	}
-//
-//	if err = consensusBaseManaPastVectorMetadataStorage.Prune(); err != nil {
-//		manaLogger.Errorf("error pruning consensus base mana vector metadata storage: %w", err)
-//		return
-//	}
-//	consensusBaseManaPastVectorMetadataStorage.Store(metadata).Release()
-//
-//	var entriesToDelete [][]byte
-//	for _, ev := range toBePrunedEvents {
-//		entriesToDelete = append(entriesToDelete, ev.ToPersistable().ObjectStorageKey())
-//	}
-//	manaLogger.Infof("deleting %d events from consensus event storage", len(entriesToDelete))
-//	consensusEventsLogStorage.DeleteEntriesFromStore(entriesToDelete)
-//	consensusEventsLogsStorageSize.Sub(uint32(len(entriesToDelete)))
-//	manaLogger.Infof("%d events remaining in consensus event storage", consensusEventsLogsStorageSize.Load())
-//}
+	return baseManaVectors[mana.AccessMana].Model().PendingMana(value, n)
+}
+
+// GetLoggedEvents gets the events logs for the node IDs and time frame specified. If none is specified, it returns the logs for all nodes.
+func GetLoggedEvents(identityIDs []identity.ID, startTime time.Time, endTime time.Time) (map[identity.ID]*EventsLogs, error) {
+	logs := make(map[identity.ID]*EventsLogs)
+	lookup := make(map[identity.ID]bool)
+	getAll := true
+
+	if len(identityIDs) > 0 {
+		getAll = false
+		for _, nodeID := range identityIDs {
+			lookup[nodeID] = true
+		}
+	}
+
+	var err error
+	consensusEventsLogStorage.ForEach(func(key []byte, cachedObject objectstorage.CachedObject) bool {
+		cachedPe := &mana.CachedPersistableEvent{CachedObject: cachedObject}
+		defer cachedPe.Release()
+		pbm := cachedPe.Unwrap()
+
+		if !getAll {
+			if !lookup[pbm.NodeID] {
+				return true
+			}
+		}
+
+		if _, found := logs[pbm.NodeID]; !found {
+			logs[pbm.NodeID] = &EventsLogs{}
+		}
+
+		var ev mana.Event
+		ev, err = mana.FromPersistableEvent(pbm)
+		if err != nil {
+			return false
+		}
+
+		if ev.Timestamp().Before(startTime) || ev.Timestamp().After(endTime) {
+			return true
+		}
+		switch ev.Type() {
+		case mana.EventTypePledge:
+			logs[pbm.NodeID].Pledge = append(logs[pbm.NodeID].Pledge, ev.(*mana.PledgedEvent))
+		case mana.EventTypeRevoke:
+			logs[pbm.NodeID].Revoke = append(logs[pbm.NodeID].Revoke, ev.(*mana.RevokedEvent))
+		default:
+			err = mana.ErrUnknownManaEvent
+			return false
+		}
+		return true
+	})
+
+	for ID := range logs {
+		sort.Slice(logs[ID].Pledge, func(i, j int) bool {
+			return logs[ID].Pledge[i].Time.Before(logs[ID].Pledge[j].Time)
+		})
+		sort.Slice(logs[ID].Revoke, func(i, j int) bool {
+			return logs[ID].Revoke[i].Time.Before(logs[ID].Revoke[j].Time)
+		})
+	}
+
+	return logs, err
+}
+
+// GetPastConsensusManaVectorMetadata gets the past consensus mana vector metadata.
+func GetPastConsensusManaVectorMetadata() *mana.ConsensusBasePastManaVectorMetadata {
+	cachedObj := consensusBaseManaPastVectorMetadataStorage.Load([]byte(mana.ConsensusBaseManaPastVectorMetadataStorageKey))
+	cachedMetadata := &mana.CachedConsensusBasePastManaVectorMetadata{CachedObject: cachedObj}
+	defer cachedMetadata.Release()
+	return cachedMetadata.Unwrap()
+}
+
+// GetPastConsensusManaVector builds a consensus base mana vector in the past from the closest cached checkpoint and
+// returns it together with the events that were applied on top of that checkpoint.
+// It returns ErrPastConsensusManaNotRetained if `t` lies before the checkpoint that the event log was compacted into.
+func GetPastConsensusManaVector(t time.Time) (*mana.ConsensusBaseManaVector, []mana.Event, error) {
+	consensusHistoryMutex.RLock()
+	defer consensusHistoryMutex.RUnlock()
+
+	cbmvPast, metadata, err := loadConsensusCheckpoint()
+	if err != nil {
+		return nil, nil, err
+	}
+	if metadata != nil && t.Before(metadata.Timestamp) {
+		return nil, nil, errors.Errorf("consensus mana at %s is older than the oldest checkpoint at %s: %w", t, metadata.Timestamp, ErrPastConsensusManaNotRetained)
+	}
+
+	// every logged event that is not part of the checkpoint yet is applied, even if it was issued before the
+	// checkpoint, because it got confirmed after the event log was compacted.
+	var checkpointTime time.Time
+	if metadata != nil {
+		checkpointTime = metadata.Timestamp
+	}
+	return pastConsensusHistory.vector(cbmvPast, checkpointTime, t)
+}
+
+// loadConsensusCheckpoint loads the consensus base mana vector that the event log was compacted into.
+func loadConsensusCheckpoint() (cbmvPast *mana.ConsensusBaseManaVector, metadata *mana.ConsensusBasePastManaVectorMetadata, err error) {
+	baseManaVector, err := mana.NewBaseManaVector(mana.ConsensusMana, baseManaVectors[mana.ConsensusMana].Model())
+	if err != nil {
+		return nil, nil, err
+	}
+	cbmvPast = baseManaVector.(*mana.ConsensusBaseManaVector)
+
+	if metadata = GetPastConsensusManaVectorMetadata(); metadata == nil {
+		return cbmvPast, nil, nil
+	}
+	consensusBaseManaPastVectorStorage.ForEach(func(key []byte, cachedObject objectstorage.CachedObject) bool {
+		cachedPbm := &mana.CachedPersistableBaseMana{CachedObject: cachedObject}
+		defer cachedPbm.Release()
+		err = cbmvPast.FromPersistable(cachedPbm.Unwrap())
+		return err == nil
+	})
+	if err != nil {
+		return nil, nil, errors.Errorf("error while restoring %s checkpoint from storage: %w", mana.ConsensusMana.String(), err)
+	}
+	return cbmvPast, metadata, nil
+}
+
+// storeConsensusCheckpoint replaces the stored checkpoint with the given vector taken at time `t`.
+func storeConsensusCheckpoint(cbmvPast *mana.ConsensusBaseManaVector, t time.Time) error {
+	if err := consensusBaseManaPastVectorStorage.Prune(); err != nil {
+		return errors.Errorf("error pruning consensus base mana vector storage: %w", err)
+	}
+	cbmvPast.RemoveZeroNodes()
+	for _, p := range cbmvPast.ToPersistables() {
+		consensusBaseManaPastVectorStorage.Store(p).Release()
+	}
+
+	if err := consensusBaseManaPastVectorMetadataStorage.Prune(); err != nil {
+		return errors.Errorf("error pruning consensus base mana vector metadata storage: %w", err)
+	}
+	consensusBaseManaPastVectorMetadataStorage.Store(&mana.ConsensusBasePastManaVectorMetadata{
+		Timestamp: t,
+	}).Release()
+	return nil
+}
+
+// initConsensusHistory checkpoints the restored consensus mana vector if there is no event log to rebuild it from,
+// e.g. because the database was created by a version that did not log consensus mana events.
+func initConsensusHistory() {
+	consensusHistoryMutex.Lock()
+	defer consensusHistoryMutex.Unlock()
+
+	if consensusEventsLogsStorageSize.Load() != 0 || GetPastConsensusManaVectorMetadata() != nil {
+		return
+	}
+
+	cbmvPast, _, err := loadConsensusCheckpoint()
+	if err != nil {
+		manaLogger.Errorf("error creating consensus base mana vector: %v", err)
+		return
+	}
+	for _, p := range baseManaVectors[mana.ConsensusMana].ToPersistables() {
+		if err = cbmvPast.FromPersistable(p); err != nil {
+			manaLogger.Errorf("error copying consensus base mana vector: %v", err)
+			return
+		}
+	}
+	if err = storeConsensusCheckpoint(cbmvPast, time.Now()); err != nil {
+		manaLogger.Error(err)
+	}
+}
+
+// readConsensusEventLogsStorageStats counts the logged consensus events, determines the youngest one and indexes them
+// for past consensus mana queries.
+func readConsensusEventLogsStorageStats() {
+	var events mana.EventSlice
+	consensusEventsLogStorage.ForEachKeyOnly(func(key []byte) bool {
+		consensusEventsLogsStorageSize.Inc()
+		// the key of a persistable event is its serialized form
+		storableObject, err := mana.FromEventObjectStorage(key, key)
+		if err != nil {
+			manaLogger.Errorf("error parsing stored consensus event: %v", err)
+			return true
+		}
+		pe := storableObject.(*mana.PersistableEvent)
+		ev, err := mana.FromPersistableEvent(pe)
+		if err != nil {
+			manaLogger.Errorf("error parsing stored consensus event: %v", err)
+			return true
+		}
+		events = append(events, ev)
+		updateLatestConsensusEventTime(pe.Time)
+		return true
+	}, objectstorage.WithIteratorSkipCache(true))
+	pastConsensusHistory.load(events)
+}
+
+// pruneConsensusEventLogsStorage compacts all logged consensus events that are older than the retention window into
+// the checkpoint, so that past consensus mana can be answered for any time within the window.
+func pruneConsensusEventLogsStorage() {
+	consensusHistoryMutex.Lock()
+	defer consensusHistoryMutex.Unlock()
+
+	retentionStart := time.Now().Add(-ManaParameters.ConsensusEventsRetention)
+	cbmvPast, _, err := loadConsensusCheckpoint()
+	if err != nil {
+		manaLogger.Errorf("error reading stored consensus base mana vector: %v", err)
+		return
+	}
+
+	var toBePrunedEvents mana.EventSlice
+	consensusEventsLogStorage.ForEach(func(key []byte, cachedObject objectstorage.CachedObject) bool {
+		cachedPe := &mana.CachedPersistableEvent{CachedObject: cachedObject}
+		defer cachedPe.Release()
+		pe := cachedPe.Unwrap()
+		if pe.Time.After(retentionStart) {
+			return true
+		}
+
+		var ev mana.Event
+		ev, err = mana.FromPersistableEvent(pe)
+		if err != nil {
+			return false
+		}
+		toBePrunedEvents = append(toBePrunedEvents, ev)
+		return true
+	})
+	if err != nil {
+		manaLogger.Errorf("error reading persistable events: %v", err)
+		return
+	}
+	if len(toBePrunedEvents) == 0 {
+		return
+	}
+	toBePrunedEvents.Sort()
+
+	if err = cbmvPast.BuildPastBaseVector(toBePrunedEvents, retentionStart); err != nil {
+		manaLogger.Errorf("error building past consensus base mana vector: %v", err)
+		return
+	}
+	if err = storeConsensusCheckpoint(cbmvPast, retentionStart); err != nil {
+		manaLogger.Error(err)
+		return
+	}
+
+	entriesToDelete := make([][]byte, 0, len(toBePrunedEvents))
+	for _, ev := range toBePrunedEvents {
+		entriesToDelete = append(entriesToDelete, ev.ToPersistable().ObjectStorageKey())
+	}
+	consensusEventsLogStorage.DeleteEntriesFromStore(entriesToDelete)
+	consensusEventsLogsStorageSize.Sub(uint32(len(entriesToDelete)))
+	pastConsensusHistory.prune(retentionStart)
+	manaLogger.Infof("compacted %d consensus events into checkpoint at %s, %d events remaining in consensus event storage",
+		len(entriesToDelete), retentionStart, consensusEventsLogsStorageSize.Load())
+}
 
 func cleanupManaVectors() {
 	vectorTypes := []mana.Type{mana.AccessMana, mana.ConsensusMana}
@@ -770,18 +860,12 @@ func cleanupManaVectors() {
 	}
 }
 
-// AllowedPledge represents the nodes that mana is allowed to be pledged to.
-type AllowedPledge struct {
-	IsFilterEnabled bool
-	Allowed         set.Set
+// EventsLogs represents the events logs.
+type EventsLogs struct {
+	Pledge []*mana.PledgedEvent `json:"pledge"`
+	Revoke []*mana.RevokedEvent `json:"revoke"`
 }
 
-//// EventsLogs represents the events logs.
-//type EventsLogs struct {
-//	Pledge []*mana.PledgedEvent `json:"pledge"`
-//	Revoke []*mana.RevokedEvent `json:"revoke"`
-//}
-
 // QueryAllowed returns if the mana plugin answers queries or not.
 func QueryAllowed() (allowed bool) {
 	// if debugging enabled, reply to the query
@@ -790,12 +874,16 @@ func QueryAllowed() (allowed bool) {
 	return true
 }
 
-// loadSnapshot loads the tx snapshot and the access mana snapshot, sorts it and loads it into the various mana versions
+// loadSnapshot loads the tx snapshot and the access and consensus mana snapshot, sorts it and loads it into the various
+// mana versions. The consensus mana is only derived from the tx snapshot if the snapshot doesn't contain it.
 func loadSnapshot(snapshot *ledgerstate.Snapshot) {
 	txSnapshotByNode := make(map[identity.ID]mana.SortedTxSnapshot)
 
 	// load txSnapshot into SnapshotInfoVec
 	for txID, record := range snapshot.Transactions {
+		if len(snapshot.ConsensusManaByNode) != 0 {
+			break
+		}
 		totalUnspentBalanceInTx := uint64(0)
 		for i, output := range record.Essence.Outputs() {
 			if !record.UnspentOutputs[i] {
@@ -834,6 +922,11 @@ func loadSnapshot(snapshot *ledgerstate.Snapshot) {
 				maxTimestamp = accessMana.Timestamp
 			}
 		}
+		for _, consensusMana := range snapshot.ConsensusManaByNode {
+			if consensusMana.Timestamp.After(maxTimestamp) {
+				maxTimestamp = consensusMana.Timestamp
+			}
+		}
 		addTime = time.Since(maxTimestamp)
 	}
 
@@ -851,6 +944,16 @@ func loadSnapshot(snapshot *ledgerstate.Snapshot) {
 		SnapshotByNode[nodeID] = snapshotNode
 	}
 
+	// load consensus mana
+	for nodeID, consensusMana := range snapshot.ConsensusManaByNode {
+		snapshotNode := SnapshotByNode[nodeID]
+		snapshotNode.ConsensusMana = &mana.ConsensusManaSnapshot{
+			BaseMana:  consensusMana.BaseMana,
+			Timestamp: consensusMana.Timestamp.Add(addTime),
+		}
+		SnapshotByNode[nodeID] = snapshotNode
+	}
+
 	baseManaVectors[mana.ConsensusMana].LoadSnapshot(SnapshotByNode)
 	baseManaVectors[mana.AccessMana].LoadSnapshot(SnapshotByNode)
 }
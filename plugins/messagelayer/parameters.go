@@ -6,11 +6,20 @@ import (
 	"github.com/iotaledger/hive.go/configuration"
 )
 
+// deprecatedFCoBKeys maps the config keys of removed FCoB parameters to the parameters that replaced them.
+var deprecatedFCoBKeys = map[string]string{
+	"messageLayer.fcob.quarantineTime": "messageLayer.fcob.likedThreshold",
+}
+
 // ParametersDefinition contains the definition of the parameters used by the messagelayer plugin.
 type ParametersDefinition struct {
 	// TangleWidth can be used to specify the number of tips the Tangle tries to maintain.
 	TangleWidth int `default:"0" usage:"the width of the Tangle"`
 
+	// Bech32HRP defines the human-readable part of bech32 encoded addresses which distinguishes the addresses of
+	// different networks.
+	Bech32HRP string `default:"atoi" usage:"the human-readable part of bech32 encoded addresses"`
+
 	// Snapshot contains snapshots related configuration parameters.
 	Snapshot struct {
 		// File is the path to the snapshot file.
@@ -21,8 +30,16 @@ type ParametersDefinition struct {
 
 	// FCOB contains parameters related to the transaction quarantine time before applying (if necessary) FPC.
 	FCOB struct {
-		// QuarantineTime determines the duration of the the first half of the quarantime time of the FCoB rule, in seconds.
-		QuarantineTime int `default:"2" usage:"the duration for the first half of the quarantine time of the FCoB rule in sec"`
+		// LikedThreshold determines the first half of the quarantine time of the FCoB rule.
+		LikedThreshold time.Duration `default:"2s" usage:"the first half of the quarantine time of the FCoB rule"`
+		// LocallyFinalizedThreshold determines the whole quarantine time of the FCoB rule.
+		LocallyFinalizedThreshold time.Duration `default:"4s" usage:"the whole quarantine time of the FCoB rule"`
+		// TimestampWindow defines the maximum difference between the timestamp and the arrival time of a liked message.
+		TimestampWindow time.Duration `default:"1m" usage:"the maximum difference between the timestamp and the arrival time of a liked message"`
+		// GratuitousNetworkDelay defines the time after which all messages are assumed to be delivered.
+		GratuitousNetworkDelay time.Duration `default:"15s" usage:"the time after which all messages are assumed to be delivered"`
+		// LevelOfKnowledgeStrategy defines how the arrival time of a message maps to the level of knowledge of its timestamp opinion.
+		LevelOfKnowledgeStrategy string `default:"gratuitousNetworkDelay" usage:"the rules to derive the level of knowledge of timestamp opinions (gratuitousNetworkDelay or binary)"`
 	}
 
 	// TangleTimeWindow defines the time window in which the node considers itself as synced according to TangleTime.
@@ -40,6 +57,9 @@ type FPCParametersDefinition struct {
 	// Listen defines if the FPC service should listen.
 	Listen bool `default:"true" usage:"if the FPC service should listen"`
 
+	// TLS defines if the FPC queries are sent over TLS using the node identity as certificate key.
+	TLS bool `default:"false" usage:"if the FPC service should use TLS with a certificate based on the node identity; must be the same for all nodes"`
+
 	// RoundInterval defines how long a round lasts (in seconds).
 	RoundInterval int64 `default:"10" usage:"FPC round interval [s]"`
 
@@ -57,6 +77,12 @@ type FPCParametersDefinition struct {
 
 	// DefaultRandomness defines default randomness used by FPC when no random is received from the dRNG.
 	DefaultRandomness float64 `default:"0.5" usage:"The default randomness used by FPC when no random is received from the dRNG"`
+
+	// SamplingStrategy defines how the nodes to query are selected each round.
+	SamplingStrategy string `default:"mana" usage:"the strategy to select the nodes to query (mana, manaWithoutReplacement, uniform or topK)"`
+
+	// SamplingTopK defines how many of the highest mana nodes are always queried by the topK sampling strategy.
+	SamplingTopK int `default:"10" usage:"the number of highest mana nodes that are always queried by the topK sampling strategy"`
 }
 
 // StatementParametersDefinition contains the definition of the parameters used by the FPC statements in the tangle.
@@ -78,6 +104,12 @@ type StatementParametersDefinition struct {
 
 	// DeleteAfter defines the time [in minutes] after which older statements are deleted from the registry.
 	DeleteAfter int `default:"5" usage:"the time in minutes after which older statements are deleted from the registry"`
+
+	// Retention defines the time [in minutes] for which the received statements are persisted.
+	Retention int `default:"60" usage:"the time in minutes for which the received statements are persisted"`
+
+	// CheckpointInterval defines after how many statements a statement with all opinions is issued.
+	CheckpointInterval int `default:"10" usage:"the number of statements after which a checkpoint statement with all opinions is issued"`
 }
 
 // ManaParametersDefinition contains the definition of the parameters used by the mana plugin.
@@ -88,19 +120,37 @@ type ManaParametersDefinition struct {
 	EmaCoefficient2 float64 `default:"0.0057762265" usage:"coefficient used for Effective Base Mana 1 (moving average) calculation"`
 	// Decay defines the decay coefficient used for Base Mana 2 calculation.
 	Decay float64 `default:"0.00003209" usage:"decay coefficient used for Base Mana 2 calculation"`
+	// AccessManaModel defines the model used for access mana calculation.
+	AccessManaModel string `default:"ema" usage:"model used for access mana calculation (ema, linear or none)"`
+	// ConsensusManaModel defines the model used for consensus mana calculation.
+	ConsensusManaModel string `default:"none" usage:"model used for consensus mana calculation (ema, linear or none)"`
+	// LinearDecayRate defines the amount of base mana lost per second when the linear model is used.
+	LinearDecayRate float64 `default:"1" usage:"amount of base mana lost per second when the linear model is used"`
+	// AccessPledgePolicy defines the rules for pledging access mana to a node.
+	AccessPledgePolicy PledgePolicyParametersDefinition
+	// ConsensusPledgePolicy defines the rules for pledging consensus mana to a node.
+	ConsensusPledgePolicy PledgePolicyParametersDefinition
 	// AllowedAccessPledge defines the list of nodes that access mana is allowed to be pledged to.
-	AllowedAccessPledge []string `usage:"list of nodes that access mana is allowed to be pledged to"`
+	// Deprecated: use AccessPledgePolicy with the allowList mode instead.
+	AllowedAccessPledge []string `usage:"deprecated: use mana.accessPledgePolicy.nodes with mode allowList instead"`
 	// AllowedAccessFilterEnabled defines if access mana pledge filter is enabled.
-	AllowedAccessFilterEnabled bool `default:"false" usage:"list of nodes that consensus mana is allowed to be pledge to"`
+	// Deprecated: use AccessPledgePolicy with the allowList mode instead.
+	AllowedAccessFilterEnabled bool `default:"false" usage:"deprecated: use mana.accessPledgePolicy.mode allowList instead"`
 	// AllowedConsensusPledge defines the list of nodes that consensus mana is allowed to be pledged to.
-	AllowedConsensusPledge []string `usage:"list of nodes that consensus mana is allowed to be pledge to"`
+	// Deprecated: use ConsensusPledgePolicy with the allowList mode instead.
+	AllowedConsensusPledge []string `usage:"deprecated: use mana.consensusPledgePolicy.nodes with mode allowList instead"`
 	// AllowedConsensusFilterEnabled defines if consensus mana pledge filter is enabled.
-	AllowedConsensusFilterEnabled bool `default:"false" usage:"if filtering on consensus mana pledge nodes is enabled"`
+	// Deprecated: use ConsensusPledgePolicy with the allowList mode instead.
+	AllowedConsensusFilterEnabled bool `default:"false" usage:"deprecated: use mana.consensusPledgePolicy.mode allowList instead"`
 	// EnableResearchVectors determines if research mana vector should be used or not. To use the Mana Research
 	// Grafana Dashboard, this should be set to true.
 	EnableResearchVectors bool `default:"false" usage:"enable mana research vectors"`
 	// PruneConsensusEventLogsInterval defines the interval to check and prune consensus event logs storage.
 	PruneConsensusEventLogsInterval time.Duration `default:"5m" usage:"interval to check and prune consensus event storage"`
+	// ConsensusEventsRetention defines for how long past consensus mana can be queried before the events are compacted.
+	ConsensusEventsRetention time.Duration `default:"24h" usage:"time window for which past consensus mana can be queried"`
+	// ConsensusCheckpointInterval defines the interval at which past consensus mana vectors are cached as checkpoints.
+	ConsensusCheckpointInterval time.Duration `default:"1h" usage:"interval at which past consensus mana vectors are cached as checkpoints"`
 	// VectorsCleanupInterval defines the interval to clean empty mana nodes from the base mana vectors.
 	VectorsCleanupInterval time.Duration `default:"30m" usage:"interval to cleanup empty mana nodes from the mana vectors"`
 	// DebuggingEnabled defines if the mana plugin responds to queries while not being in sync or not.
@@ -109,6 +159,22 @@ type ManaParametersDefinition struct {
 	SnapshotResetTime bool `default:"false" usage:"when loading snapshot reset to current time when true"`
 }
 
+// PledgePolicyParametersDefinition contains the definition of the rules of a mana pledge policy.
+type PledgePolicyParametersDefinition struct {
+	// Mode defines which nodes mana is allowed to be pledged to (any, allowList or denyList).
+	Mode string `default:"any" usage:"which nodes mana is allowed to be pledged to (any, allowList or denyList)"`
+	// Nodes defines the allow- or deny-list of nodes, depending on the mode.
+	Nodes []string `usage:"the allow- or deny-list of nodes (base58 public keys), depending on the mode"`
+	// MinMana defines the mana a node needs to have already to receive a pledge.
+	MinMana float64 `default:"0" usage:"the mana a node needs to have already to receive a pledge (0 to disable)"`
+	// MaxShare defines the maximum share of the total mana a node may own to receive a pledge.
+	MaxShare float64 `default:"0" usage:"the maximum share of the total mana a node may own to receive a pledge (0 to disable)"`
+	// RateLimit defines the maximum number of pledges per pledger address within the rate limit window.
+	RateLimit int `default:"0" usage:"the maximum number of pledges per pledger address within the rate limit window (0 to disable)"`
+	// RateLimitWindow defines the time window of the rate limit.
+	RateLimitWindow time.Duration `default:"1h" usage:"the time window of the pledge rate limit"`
+}
+
 // RateSetterParametersDefinition contains the definition of the parameters used by the Rate Setter.
 type RateSetterParametersDefinition struct {
 	// Initial defines the initial rate of rate setting.
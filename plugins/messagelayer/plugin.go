@@ -11,9 +11,11 @@ import (
 	"github.com/iotaledger/goshimmer/packages/shutdown"
 	"github.com/iotaledger/goshimmer/packages/tangle"
 	"github.com/iotaledger/goshimmer/plugins/autopeering/local"
+	"github.com/iotaledger/goshimmer/plugins/config"
 	"github.com/iotaledger/goshimmer/plugins/database"
 
 	"github.com/cockroachdb/errors"
+	"github.com/iotaledger/hive.go/configuration"
 	"github.com/iotaledger/hive.go/crypto/ed25519"
 	"github.com/iotaledger/hive.go/daemon"
 	"github.com/iotaledger/hive.go/events"
@@ -46,6 +48,19 @@ func Plugin() *node.Plugin {
 }
 
 func configure(plugin *node.Plugin) {
+	if err := ledgerstate.SetBech32HRP(Parameters.Bech32HRP); err != nil {
+		plugin.LogFatalf("failed to configure the address encoding: %s", err)
+	}
+	if err := checkDeprecatedFCoBKeys(config.Node()); err != nil {
+		plugin.LogFatalf("invalid FCoB parameters: %s", err)
+	}
+	consensusParameters, err := fcobParameters()
+	if err != nil {
+		plugin.LogFatalf("invalid FCoB parameters: %s", err)
+	}
+	plugin.LogInfof("FCoB quarantine time: %s / %s, timestamp window: %s, level of knowledge strategy: %s",
+		consensusParameters.LikedThreshold, consensusParameters.LocallyFinalizedThreshold, consensusParameters.TimestampWindow, consensusParameters.LevelOfKnowledgeStrategy.Name())
+
 	Tangle().Events.Error.Attach(events.NewClosure(func(err error) {
 		plugin.LogError(err)
 	}))
@@ -111,8 +126,6 @@ func configure(plugin *node.Plugin) {
 		plugin.LogInfof("read snapshot from %s", Parameters.Snapshot.File)
 	}
 
-	fcob.LikedThreshold = time.Duration(Parameters.FCOB.QuarantineTime) * time.Second
-	fcob.LocallyFinalizedThreshold = time.Duration(Parameters.FCOB.QuarantineTime+Parameters.FCOB.QuarantineTime) * time.Second
 
 	configureApprovalWeight()
 }
@@ -178,12 +191,46 @@ var (
 // ConsensusMechanism return the FcoB ConsensusMechanism used by the Tangle.
 func ConsensusMechanism() *fcob.ConsensusMechanism {
 	consensusMechanismOnce.Do(func() {
-		consensusMechanism = fcob.NewConsensusMechanism()
+		// invalid parameters are reported when the plugin is configured
+		parameters, err := fcobParameters()
+		if err != nil {
+			parameters = fcob.DefaultParameters()
+		}
+		consensusMechanism = fcob.NewConsensusMechanism(parameters)
 	})
 
 	return consensusMechanism
 }
 
+// fcobParameters returns the validated Parameters of the FCoB rule that are configured for the message layer.
+func fcobParameters() (parameters *fcob.Parameters, err error) {
+	parameters = &fcob.Parameters{
+		LikedThreshold:            Parameters.FCOB.LikedThreshold,
+		LocallyFinalizedThreshold: Parameters.FCOB.LocallyFinalizedThreshold,
+		TimestampWindow:           Parameters.FCOB.TimestampWindow,
+		GratuitousNetworkDelay:    Parameters.FCOB.GratuitousNetworkDelay,
+	}
+	if parameters.LevelOfKnowledgeStrategy, err = fcob.LevelOfKnowledgeStrategyFromString(Parameters.FCOB.LevelOfKnowledgeStrategy); err != nil {
+		return nil, err
+	}
+	if err = parameters.Validate(); err != nil {
+		return nil, err
+	}
+
+	return parameters, nil
+}
+
+// checkDeprecatedFCoBKeys returns an error if the given configuration still contains a removed FCoB parameter, which
+// would otherwise be ignored silently.
+func checkDeprecatedFCoBKeys(conf *configuration.Configuration) error {
+	for deprecatedKey, replacement := range deprecatedFCoBKeys {
+		if conf.Exists(deprecatedKey) {
+			return errors.Errorf("%s is no longer supported, configure %s as a duration, e.g. \"2s\", instead", deprecatedKey, replacement)
+		}
+	}
+	return nil
+}
+
 // endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
 
 // region Scheduler ///////////////////////////////////////////////////////////////////////////////////////////
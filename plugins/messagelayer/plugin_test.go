@@ -0,0 +1,21 @@
+package messagelayer
+
+import (
+	"testing"
+
+	"github.com/iotaledger/hive.go/configuration"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckDeprecatedFCoBKeys(t *testing.T) {
+	conf := configuration.New()
+	require.NoError(t, conf.Set("messageLayer.fcob.likedThreshold", "2s"))
+	assert.NoError(t, checkDeprecatedFCoBKeys(conf))
+
+	// the removed quarantine time is rejected instead of being ignored
+	require.NoError(t, conf.Set("messageLayer.fcob.quarantineTime", 2))
+	err := checkDeprecatedFCoBKeys(conf)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "messageLayer.fcob.likedThreshold")
+}
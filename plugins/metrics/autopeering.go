@@ -4,11 +4,14 @@ import (
 	"sync"
 	"time"
 
+	"github.com/cockroachdb/errors"
+	"github.com/iotaledger/hive.go/autopeering/peer"
 	"github.com/iotaledger/hive.go/autopeering/selection"
 	"github.com/iotaledger/hive.go/events"
 	"go.uber.org/atomic"
 
 	gossipPkg "github.com/iotaledger/goshimmer/packages/gossip"
+	gossipServer "github.com/iotaledger/goshimmer/packages/gossip/server"
 )
 
 var (
@@ -17,6 +20,7 @@ var (
 	neighborMutex               sync.RWMutex
 
 	neighborConnectionsCount    atomic.Uint64
+	incompatibleNeighborCount   atomic.Uint64
 	autopeeringConnectionsCount uint64
 	sumDistance                 uint64
 	minDistance                 = uint64(^uint32(0))
@@ -36,6 +40,12 @@ var (
 		neighborConnectionsCount.Inc()
 	})
 
+	onNeighborConnectionFailed = events.NewClosure(func(_ *peer.Peer, err error) {
+		if errors.Is(err, gossipServer.ErrIncompatibleVersion) {
+			incompatibleNeighborCount.Inc()
+		}
+	})
+
 	onAutopeeringSelection = events.NewClosure(func(ev *selection.PeeringEvent) {
 		distanceMutex.Lock()
 		defer distanceMutex.Unlock()
@@ -73,6 +83,12 @@ func NeighborConnectionsCount() uint64 {
 	return neighborConnectionsCount.Load()
 }
 
+// IncompatibleNeighborCount returns the number of neighbor connections that failed due to an incompatible gossip
+// protocol version.
+func IncompatibleNeighborCount() uint64 {
+	return incompatibleNeighborCount.Load()
+}
+
 // AutopeeringDistanceStats returns statistics of the autopeering distance function.
 func AutopeeringDistanceStats() (min, max uint64, avg float64) {
 	distanceMutex.RLock()
@@ -0,0 +1,44 @@
+package metrics
+
+import (
+	"go.uber.org/atomic"
+
+	"github.com/iotaledger/goshimmer/packages/drng"
+)
+
+var (
+	// drngMissingRoundsCount is the number of dRNG rounds that were never received.
+	drngMissingRoundsCount atomic.Uint64
+
+	// drngGapCount is the number of gaps in the received dRNG rounds (each gap can span multiple rounds).
+	drngGapCount atomic.Uint64
+
+	// drngChainMismatchCount is the number of beacons whose previous signature did not match the stored previous round.
+	drngChainMismatchCount atomic.Uint64
+)
+
+// DRNGMissingRounds returns the number of dRNG rounds that were never received since the start of the node.
+func DRNGMissingRounds() uint64 {
+	return drngMissingRoundsCount.Load()
+}
+
+// DRNGGaps returns the number of gaps in the received dRNG rounds since the start of the node.
+func DRNGGaps() uint64 {
+	return drngGapCount.Load()
+}
+
+// DRNGChainMismatches returns the number of beacons that did not match the previous round since the start of the node.
+func DRNGChainMismatches() uint64 {
+	return drngChainMismatchCount.Load()
+}
+
+//// logic broken into "process..."  functions to be able to write unit tests ////
+
+func processDRNGGap(gap *drng.GapEvent) {
+	drngGapCount.Inc()
+	drngMissingRoundsCount.Add(gap.MissingRounds())
+}
+
+func processDRNGChainMismatch(_ *drng.Beacon) {
+	drngChainMismatchCount.Inc()
+}
@@ -0,0 +1,23 @@
+package metrics
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/iotaledger/goshimmer/packages/drng"
+)
+
+func TestDRNGMissingRounds(t *testing.T) {
+	// initialized to 0
+	assert.Equal(t, uint64(0), DRNGMissingRounds())
+	assert.Equal(t, uint64(0), DRNGGaps())
+
+	processDRNGGap(&drng.GapEvent{InstanceID: 1, FirstRound: 5, LastRound: 5})
+	processDRNGGap(&drng.GapEvent{InstanceID: 1, FirstRound: 8, LastRound: 10})
+	assert.Equal(t, uint64(4), DRNGMissingRounds())
+	assert.Equal(t, uint64(2), DRNGGaps())
+
+	processDRNGChainMismatch(nil)
+	assert.Equal(t, uint64(1), DRNGChainMismatches())
+}
@@ -18,6 +18,7 @@ import (
 	"github.com/iotaledger/goshimmer/packages/vote"
 	"github.com/iotaledger/goshimmer/plugins/analysis/server"
 	"github.com/iotaledger/goshimmer/plugins/autopeering"
+	"github.com/iotaledger/goshimmer/plugins/drng"
 	"github.com/iotaledger/goshimmer/plugins/gossip"
 	"github.com/iotaledger/goshimmer/plugins/messagelayer"
 )
@@ -192,6 +193,12 @@ func registerLocalMetrics() {
 		processFailed(ev.Ctx)
 	}))
 
+	// dRNG beacons that do not continue the history of their instance
+	if !node.IsSkipped(drng.Plugin()) {
+		drng.Instance().Events.MissingRounds.Attach(events.NewClosure(processDRNGGap))
+		drng.Instance().Events.ChainMismatch.Attach(events.NewClosure(processDRNGChainMismatch))
+	}
+
 	//// Events coming from metrics package ////
 
 	metrics.Events().FPCInboundBytes.Attach(events.NewClosure(func(amountBytes uint64) {
@@ -215,6 +222,8 @@ func registerLocalMetrics() {
 
 	gossip.Manager().NeighborsEvents(gossippkg.NeighborsGroupAuto).NeighborRemoved.Attach(onNeighborRemoved)
 	gossip.Manager().NeighborsEvents(gossippkg.NeighborsGroupAuto).NeighborAdded.Attach(onNeighborAdded)
+	gossip.Manager().NeighborsEvents(gossippkg.NeighborsGroupAuto).ConnectionFailed.Attach(onNeighborConnectionFailed)
+	gossip.Manager().NeighborsEvents(gossippkg.NeighborsGroupManual).ConnectionFailed.Attach(onNeighborConnectionFailed)
 
 	autopeering.Selection().Events().IncomingPeering.Attach(onAutopeeringSelection)
 	autopeering.Selection().Events().OutgoingPeering.Attach(onAutopeeringSelection)
@@ -10,6 +10,7 @@ var (
 	neighborDropCount             prometheus.Gauge
 	avgNeighborConnectionLifeTime prometheus.Gauge
 	connectionsCount              prometheus.Gauge
+	incompatibleNeighborCount     prometheus.Gauge
 	minDistance                   prometheus.Gauge
 	maxDistance                   prometheus.Gauge
 	avgDistance                   prometheus.Gauge
@@ -31,6 +32,11 @@ func registerAutopeeringMetrics() {
 		Help: "Autopeering neighbor connections count.",
 	})
 
+	incompatibleNeighborCount = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "autopeering_neighbor_incompatible_count",
+		Help: "Autopeering neighbor connections rejected due to an incompatible gossip protocol version.",
+	})
+
 	minDistance = prometheus.NewGauge(prometheus.GaugeOpts{
 		Name: "autopeering_min_distance",
 		Help: "Autopeering minimum distance with all neighbors.",
@@ -49,6 +55,7 @@ func registerAutopeeringMetrics() {
 	registry.MustRegister(neighborDropCount)
 	registry.MustRegister(avgNeighborConnectionLifeTime)
 	registry.MustRegister(connectionsCount)
+	registry.MustRegister(incompatibleNeighborCount)
 	registry.MustRegister(minDistance)
 	registry.MustRegister(maxDistance)
 	registry.MustRegister(avgDistance)
@@ -60,6 +67,7 @@ func collectAutopeeringMetrics() {
 	neighborDropCount.Set(float64(metrics.NeighborDropCount()))
 	avgNeighborConnectionLifeTime.Set(metrics.AvgNeighborConnectionLifeTime())
 	connectionsCount.Set(float64(metrics.NeighborConnectionsCount()))
+	incompatibleNeighborCount.Set(float64(metrics.IncompatibleNeighborCount()))
 	min, max, avg := metrics.AutopeeringDistanceStats()
 	minDistance.Set(float64(min))
 	maxDistance.Set(float64(max))
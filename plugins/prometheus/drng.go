@@ -0,0 +1,40 @@
+package prometheus
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/iotaledger/goshimmer/plugins/metrics"
+)
+
+var (
+	drngMissingRounds   prometheus.Gauge
+	drngGaps            prometheus.Gauge
+	drngChainMismatches prometheus.Gauge
+)
+
+func registerDRNGMetrics() {
+	drngMissingRounds = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "drng_missing_rounds",
+		Help: "number of dRNG rounds that were never received since the start of the node",
+	})
+	drngGaps = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "drng_gaps",
+		Help: "number of gaps in the received dRNG rounds since the start of the node",
+	})
+	drngChainMismatches = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "drng_chain_mismatches",
+		Help: "number of dRNG beacons whose previous signature did not match the previous round",
+	})
+
+	registry.MustRegister(drngMissingRounds)
+	registry.MustRegister(drngGaps)
+	registry.MustRegister(drngChainMismatches)
+
+	addCollect(collectDRNGMetrics)
+}
+
+func collectDRNGMetrics() {
+	drngMissingRounds.Set(float64(metrics.DRNGMissingRounds()))
+	drngGaps.Set(float64(metrics.DRNGGaps()))
+	drngChainMismatches.Set(float64(metrics.DRNGChainMismatches()))
+}
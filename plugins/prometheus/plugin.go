@@ -16,6 +16,7 @@ import (
 
 	"github.com/iotaledger/goshimmer/packages/shutdown"
 	"github.com/iotaledger/goshimmer/plugins/autopeering"
+	"github.com/iotaledger/goshimmer/plugins/drng"
 	"github.com/iotaledger/goshimmer/plugins/metrics"
 )
 
@@ -60,6 +61,9 @@ func configure(plugin *node.Plugin) {
 			registerAutopeeringMetrics()
 		}
 		registerDBMetrics()
+		if !node.IsSkipped(drng.Plugin()) {
+			registerDRNGMetrics()
+		}
 		registerFPCMetrics()
 		registerInfoMetrics()
 		registerNetworkMetrics()
@@ -45,6 +45,8 @@ func onVoteRoundExecuted(roundStats *vote.RoundStats) {
 			Time:                 clock.SyncedTime(),
 			ConflictCreationTime: conflictContext.ConflictCreationTime,
 			Delta:                clock.Since(conflictContext.ConflictCreationTime).Nanoseconds(),
+			SamplingStrategy:     roundStats.SamplingStrategy,
+			SelectedManaShare:    roundStats.SampleComposition.SelectedManaShare,
 		}
 		if err := remotelog.RemoteLogger().Send(record); err != nil {
 			plugin.Logger().Errorw("Failed to send FPC conflict record on round executed event", "err", err)
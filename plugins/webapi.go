@@ -8,12 +8,14 @@ import (
 	"github.com/iotaledger/goshimmer/plugins/webapi/data"
 	"github.com/iotaledger/goshimmer/plugins/webapi/drng"
 	"github.com/iotaledger/goshimmer/plugins/webapi/faucet"
+	"github.com/iotaledger/goshimmer/plugins/webapi/fcob"
 	"github.com/iotaledger/goshimmer/plugins/webapi/healthz"
 	"github.com/iotaledger/goshimmer/plugins/webapi/info"
 	"github.com/iotaledger/goshimmer/plugins/webapi/ledgerstate"
 	"github.com/iotaledger/goshimmer/plugins/webapi/mana"
 	"github.com/iotaledger/goshimmer/plugins/webapi/message"
 	"github.com/iotaledger/goshimmer/plugins/webapi/snapshot"
+	"github.com/iotaledger/goshimmer/plugins/webapi/statement"
 	"github.com/iotaledger/goshimmer/plugins/webapi/tools"
 	"github.com/iotaledger/goshimmer/plugins/webapi/weightprovider"
 )
@@ -33,4 +35,6 @@ var WebAPI = node.Plugins(
 	ledgerstate.Plugin(),
 	snapshot.Plugin(),
 	weightprovider.Plugin(),
+	fcob.Plugin(),
+	statement.Plugin(),
 )
@@ -8,26 +8,35 @@ import (
 	"github.com/labstack/echo"
 	"github.com/mr-tron/base58"
 
+	drngpkg "github.com/iotaledger/goshimmer/packages/drng"
 	"github.com/iotaledger/goshimmer/packages/jsonmodels"
 	"github.com/iotaledger/goshimmer/plugins/drng"
 )
 
-// committeeHandler returns the current DRNG committee used.
+// committeeHandler returns the committees of all loaded DRNG instances.
 func committeeHandler(c echo.Context) error {
 	committees := []jsonmodels.Committee{}
-	for _, state := range drng.Instance().State {
-		committees = append(committees, jsonmodels.Committee{
-			InstanceID:    state.Committee().InstanceID,
-			Threshold:     state.Committee().Threshold,
-			Identities:    identitiesToString(state.Committee().Identities),
-			DistributedPK: hex.EncodeToString(state.Committee().DistributedPK),
-		})
+	for _, state := range drng.Instance().States() {
+		committees = append(committees, committeeToJSON(state.Committee()))
 	}
 	return c.JSON(http.StatusOK, jsonmodels.CommitteeResponse{
 		Committees: committees,
 	})
 }
 
+func committeeToJSON(committee drngpkg.Committee) jsonmodels.Committee {
+	result := jsonmodels.Committee{
+		InstanceID:    committee.InstanceID,
+		Threshold:     committee.Threshold,
+		Identities:    identitiesToString(committee.Identities),
+		DistributedPK: hex.EncodeToString(committee.DistributedPK),
+	}
+	if committee.RoundInterval != 0 {
+		result.RoundInterval = committee.RoundInterval.String()
+	}
+	return result
+}
+
 func identitiesToString(publicKeys []ed25519.PublicKey) []string {
 	identities := []string{}
 	for _, pk := range publicKeys {
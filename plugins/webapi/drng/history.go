@@ -0,0 +1,75 @@
+package drng
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/cockroachdb/errors"
+	"github.com/labstack/echo"
+
+	drngpkg "github.com/iotaledger/goshimmer/packages/drng"
+	"github.com/iotaledger/goshimmer/packages/jsonmodels"
+	"github.com/iotaledger/goshimmer/plugins/drng"
+)
+
+// historyHandler returns the persisted beacons of a DRNG instance. The range of rounds defaults to the latest
+// MaxHistoryRange stored rounds of the instance.
+func historyHandler(c echo.Context) error {
+	instanceID, err := strconv.ParseUint(c.QueryParam("instance"), 10, 32)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, jsonmodels.HistoryResponse{Error: errors.Errorf("invalid instance: %v", err).Error()})
+	}
+
+	history := drng.Instance().BeaconHistory()
+	if history == nil {
+		return c.JSON(http.StatusNotFound, jsonmodels.HistoryResponse{Error: "beacon history not available"})
+	}
+
+	latestRound, exists := history.LatestRound(uint32(instanceID))
+	to, err := uintQueryParam(c, "to", latestRound)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, jsonmodels.HistoryResponse{Error: err.Error()})
+	}
+	var defaultFrom uint64
+	if to >= drngpkg.MaxHistoryRange {
+		defaultFrom = to - drngpkg.MaxHistoryRange + 1
+	}
+	from, err := uintQueryParam(c, "from", defaultFrom)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, jsonmodels.HistoryResponse{Error: err.Error()})
+	}
+
+	response := jsonmodels.HistoryResponse{InstanceID: uint32(instanceID), Beacons: []jsonmodels.Beacon{}}
+	if !exists {
+		return c.JSON(http.StatusOK, response)
+	}
+
+	beacons, err := history.Beacons(uint32(instanceID), from, to)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, jsonmodels.HistoryResponse{Error: err.Error()})
+	}
+	for _, beacon := range beacons {
+		response.Beacons = append(response.Beacons, jsonmodels.Beacon{
+			Round:         beacon.Round(),
+			Timestamp:     beacon.Timestamp(),
+			MessageID:     beacon.MessageID().Base58(),
+			PrevSignature: beacon.PrevSignature(),
+			Signature:     beacon.Signature(),
+			Randomness:    beacon.Randomness(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
+
+func uintQueryParam(c echo.Context, name string, defaultValue uint64) (uint64, error) {
+	value := c.QueryParam(name)
+	if value == "" {
+		return defaultValue, nil
+	}
+	result, err := strconv.ParseUint(value, 10, 64)
+	if err != nil {
+		return 0, errors.Errorf("invalid %s: %w", name, err)
+	}
+	return result, nil
+}
@@ -0,0 +1,74 @@
+package drng
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/cockroachdb/errors"
+	"github.com/labstack/echo"
+
+	drngpkg "github.com/iotaledger/goshimmer/packages/drng"
+	"github.com/iotaledger/goshimmer/packages/jsonmodels"
+	"github.com/iotaledger/goshimmer/plugins/drng"
+	"github.com/iotaledger/goshimmer/plugins/webapi"
+)
+
+/*
+An example of the HTTP JSON request:
+{
+    "instanceID": 7,
+    "threshold": 3,
+    "identities": ["AheLpbhRs1XZsRF8t8VBwuyQh9mqPHXQvthV5rsHytDG", "FZ28bSTidszUBn8TTCAT9X1nVMwFNnoYBmZ1xfafez2z", "GT3UxryW4rA9RN9ojnMGmZgE2wP7psagQxgVdA4B9L1P"],
+    "roundInterval": "10s"
+}
+*/
+// loadInstanceHandler loads the DRNG instance of the given committee.
+func loadInstanceHandler(c echo.Context) error {
+	var request jsonmodels.Committee
+	if err := webapi.ParseJSONRequest(c, &request); err != nil {
+		return c.JSON(http.StatusBadRequest, jsonmodels.LoadInstanceResponse{Error: err.Error()})
+	}
+	instanceConfig, err := instanceConfigFromJSON(request)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, jsonmodels.LoadInstanceResponse{Error: err.Error()})
+	}
+
+	state, err := drng.LoadInstance(instanceConfig)
+	switch {
+	case errors.Is(err, drngpkg.ErrInstanceExists):
+		return c.JSON(http.StatusConflict, jsonmodels.LoadInstanceResponse{Error: err.Error()})
+	case err != nil:
+		return c.JSON(http.StatusBadRequest, jsonmodels.LoadInstanceResponse{Error: err.Error()})
+	}
+
+	committee := committeeToJSON(state.Committee())
+	return c.JSON(http.StatusOK, jsonmodels.LoadInstanceResponse{Committee: &committee})
+}
+
+// unloadInstanceHandler unloads the DRNG instance with the given instanceID.
+func unloadInstanceHandler(c echo.Context) error {
+	instanceID, err := strconv.ParseUint(c.Param("instanceID"), 10, 32)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, jsonmodels.NewErrorResponse(errors.Errorf("invalid instanceID: %w", err)))
+	}
+
+	if err := drng.Instance().UnloadInstance(uint32(instanceID)); err != nil {
+		if errors.Is(err, drngpkg.ErrInstanceNotFound) {
+			return c.JSON(http.StatusNotFound, jsonmodels.NewErrorResponse(err))
+		}
+		return c.JSON(http.StatusInternalServerError, jsonmodels.NewErrorResponse(err))
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+// instanceConfigFromJSON parses the committee of the request like an instance of the node configuration.
+func instanceConfigFromJSON(committee jsonmodels.Committee) (*drng.InstanceConfig, error) {
+	parameters := &drng.InstanceParameters{
+		InstanceID:        committee.InstanceID,
+		Threshold:         committee.Threshold,
+		RoundInterval:     committee.RoundInterval,
+		DistributedPubKey: committee.DistributedPK,
+		CommitteeMembers:  committee.Identities,
+	}
+	return parameters.InstanceConfig()
+}
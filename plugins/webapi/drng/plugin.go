@@ -29,4 +29,7 @@ func configure(_ *node.Plugin) {
 	webapi.Server().POST("drng/collectiveBeacon", collectiveBeaconHandler)
 	webapi.Server().GET("drng/info/committee", committeeHandler)
 	webapi.Server().GET("drng/info/randomness", randomnessHandler)
+	webapi.Server().GET("drng/history", historyHandler)
+	webapi.Server().POST("drng/admin/instances", loadInstanceHandler)
+	webapi.Server().DELETE("drng/admin/instances/:instanceID", unloadInstanceHandler)
 }
@@ -12,7 +12,7 @@ import (
 // randomnessHandler returns the current DRNG randomness used.
 func randomnessHandler(c echo.Context) error {
 	randomness := []jsonmodels.Randomness{}
-	for _, state := range drng.Instance().State {
+	for _, state := range drng.Instance().States() {
 		randomness = append(randomness,
 			jsonmodels.Randomness{
 				InstanceID: state.Committee().InstanceID,
@@ -47,7 +47,7 @@ func requestFunds(c echo.Context) error {
 	plugin.LogInfo("Received - address:", request.Address)
 	plugin.LogDebug(request)
 
-	addr, err := ledgerstate.AddressFromBase58EncodedString(request.Address)
+	addr, err := ledgerstate.AddressFromString(request.Address)
 	if err != nil {
 		return c.JSON(http.StatusBadRequest, jsonmodels.FaucetResponse{Error: "Invalid address"})
 	}
@@ -0,0 +1,137 @@
+package fcob
+
+import (
+	"net/http"
+	"strconv"
+	"sync"
+
+	"github.com/cockroachdb/errors"
+	"github.com/iotaledger/hive.go/node"
+	"github.com/labstack/echo"
+
+	"github.com/iotaledger/goshimmer/packages/consensus/fcob"
+	"github.com/iotaledger/goshimmer/packages/jsonmodels"
+	"github.com/iotaledger/goshimmer/packages/ledgerstate"
+	"github.com/iotaledger/goshimmer/packages/tangle"
+	"github.com/iotaledger/goshimmer/plugins/messagelayer"
+	"github.com/iotaledger/goshimmer/plugins/webapi"
+)
+
+// PluginName is the name of the web API FCoB endpoint plugin.
+const PluginName = "WebAPI FCoB Endpoint"
+
+// defaultAuditLimit is the maximum number of audits returned by the list endpoints if no limit is given.
+const defaultAuditLimit = 1000
+
+var (
+	// plugin is the plugin instance of the web API FCoB endpoint plugin.
+	plugin *node.Plugin
+	once   sync.Once
+)
+
+// Plugin gets the plugin instance.
+func Plugin() *node.Plugin {
+	once.Do(func() {
+		plugin = node.NewPlugin(PluginName, node.Enabled, configure)
+	})
+	return plugin
+}
+
+func configure(_ *node.Plugin) {
+	webapi.Server().GET("fcob/opinions", opinionAuditsHandler)
+	webapi.Server().GET("fcob/opinions/:transactionID", opinionAuditHandler)
+	webapi.Server().GET("fcob/timestampOpinions", timestampOpinionAuditsHandler)
+	webapi.Server().GET("fcob/timestampOpinions/:messageID", timestampOpinionAuditHandler)
+}
+
+// opinionAuditHandler returns the audit of the stored opinion of a transaction.
+func opinionAuditHandler(c echo.Context) error {
+	transactionID, err := ledgerstate.TransactionIDFromBase58(c.Param("transactionID"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, jsonmodels.OpinionAudit{Error: err.Error()})
+	}
+
+	audit, err := messagelayer.ConsensusMechanism().AuditOpinion(transactionID)
+	if err != nil {
+		if errors.Is(err, fcob.ErrOpinionNotFound) {
+			return c.JSON(http.StatusNotFound, jsonmodels.OpinionAudit{Error: err.Error()})
+		}
+		return c.JSON(http.StatusInternalServerError, jsonmodels.OpinionAudit{Error: err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, jsonmodels.NewOpinionAudit(audit))
+}
+
+// opinionAuditsHandler returns the audits of the stored opinions of transactions. Only inconsistent opinions are
+// returned unless the all query parameter is set.
+func opinionAuditsHandler(c echo.Context) error {
+	all, limit, err := listQueryParams(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, jsonmodels.OpinionAuditsResponse{Error: err.Error()})
+	}
+
+	response := jsonmodels.OpinionAuditsResponse{Audits: make([]*jsonmodels.OpinionAudit, 0)}
+	messagelayer.ConsensusMechanism().AuditOpinions(func(audit *fcob.OpinionAudit) bool {
+		if all || !audit.Consistent() {
+			response.Audits = append(response.Audits, jsonmodels.NewOpinionAudit(audit))
+		}
+		return len(response.Audits) < limit
+	})
+
+	return c.JSON(http.StatusOK, response)
+}
+
+// timestampOpinionAuditHandler returns the audit of the stored timestamp opinion of a message.
+func timestampOpinionAuditHandler(c echo.Context) error {
+	messageID, err := tangle.NewMessageID(c.Param("messageID"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, jsonmodels.TimestampOpinionAudit{Error: err.Error()})
+	}
+
+	audit, err := messagelayer.ConsensusMechanism().AuditTimestampOpinion(messageID)
+	if err != nil {
+		if errors.Is(err, fcob.ErrOpinionNotFound) {
+			return c.JSON(http.StatusNotFound, jsonmodels.TimestampOpinionAudit{Error: err.Error()})
+		}
+		return c.JSON(http.StatusInternalServerError, jsonmodels.TimestampOpinionAudit{Error: err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, jsonmodels.NewTimestampOpinionAudit(audit))
+}
+
+// timestampOpinionAuditsHandler returns the audits of the stored timestamp opinions of messages. Only inconsistent
+// opinions are returned unless the all query parameter is set.
+func timestampOpinionAuditsHandler(c echo.Context) error {
+	all, limit, err := listQueryParams(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, jsonmodels.TimestampOpinionAuditsResponse{Error: err.Error()})
+	}
+
+	response := jsonmodels.TimestampOpinionAuditsResponse{Audits: make([]*jsonmodels.TimestampOpinionAudit, 0)}
+	messagelayer.ConsensusMechanism().AuditTimestampOpinions(func(audit *fcob.TimestampOpinionAudit) bool {
+		if all || !audit.Consistent() {
+			response.Audits = append(response.Audits, jsonmodels.NewTimestampOpinionAudit(audit))
+		}
+		return len(response.Audits) < limit
+	})
+
+	return c.JSON(http.StatusOK, response)
+}
+
+// listQueryParams parses the all and limit query parameters of the list endpoints.
+func listQueryParams(c echo.Context) (all bool, limit int, err error) {
+	if value := c.QueryParam("all"); value != "" {
+		if all, err = strconv.ParseBool(value); err != nil {
+			return false, 0, errors.Errorf("invalid all: %w", err)
+		}
+	}
+
+	limit = defaultAuditLimit
+	if value := c.QueryParam("limit"); value != "" {
+		if limit, err = strconv.Atoi(value); err != nil || limit <= 0 {
+			return false, 0, errors.Errorf("invalid limit: %s", value)
+		}
+	}
+
+	return all, limit, nil
+}
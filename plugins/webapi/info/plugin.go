@@ -11,6 +11,7 @@ import (
 	"github.com/mr-tron/base58/base58"
 
 	"github.com/iotaledger/goshimmer/packages/jsonmodels"
+	"github.com/iotaledger/goshimmer/packages/ledgerstate"
 	"github.com/iotaledger/goshimmer/packages/mana"
 	"github.com/iotaledger/goshimmer/plugins/autopeering/discovery"
 	"github.com/iotaledger/goshimmer/plugins/autopeering/local"
@@ -124,9 +125,12 @@ func getInfo(c echo.Context) error {
 		nodeQueueSizes[nodeID.String()] = size
 	}
 
+	fcobParameters := messagelayer.ConsensusMechanism().Parameters()
+
 	return c.JSON(http.StatusOK, jsonmodels.InfoResponse{
 		Version:                 banner.AppVersion,
 		NetworkVersion:          discovery.NetworkVersion(),
+		Bech32HRP:               ledgerstate.Bech32HRP(),
 		TangleTime:              tangleTime,
 		IdentityID:              base58.Encode(local.GetInstance().Identity.ID().Bytes()),
 		IdentityIDShort:         local.GetInstance().Identity.ID().String(),
@@ -144,5 +148,12 @@ func getInfo(c echo.Context) error {
 			Rate:           messagelayer.Tangle().Scheduler.Rate().String(),
 			NodeQueueSizes: nodeQueueSizes,
 		},
+		FCOB: jsonmodels.FCOB{
+			LikedThreshold:            fcobParameters.LikedThreshold.String(),
+			LocallyFinalizedThreshold: fcobParameters.LocallyFinalizedThreshold.String(),
+			TimestampWindow:           fcobParameters.TimestampWindow.String(),
+			GratuitousNetworkDelay:    fcobParameters.GratuitousNetworkDelay.String(),
+			LevelOfKnowledgeStrategy:  fcobParameters.LevelOfKnowledgeStrategy.Name(),
+		},
 	})
 }
@@ -3,6 +3,7 @@ package ledgerstate
 import (
 	"fmt"
 	"net/http"
+	"strconv"
 	"sync"
 	"time"
 
@@ -17,7 +18,6 @@ import (
 	"github.com/iotaledger/goshimmer/packages/consensus/fcob"
 	"github.com/iotaledger/goshimmer/packages/jsonmodels"
 	"github.com/iotaledger/goshimmer/packages/ledgerstate"
-	"github.com/iotaledger/goshimmer/packages/mana"
 	"github.com/iotaledger/goshimmer/packages/shutdown"
 	"github.com/iotaledger/goshimmer/packages/tangle"
 	"github.com/iotaledger/goshimmer/plugins/messagelayer"
@@ -87,9 +87,14 @@ func run(*node.Plugin) {
 	webapi.Server().GET("ledgerstate/addresses/:address", GetAddress)
 	webapi.Server().GET("ledgerstate/addresses/:address/unspentOutputs", GetAddressUnspentOutputs)
 	webapi.Server().POST("ledgerstate/addresses/unspentOutputs", PostAddressUnspentOutputs)
+	webapi.Server().GET("ledgerstate/aliases/:aliasAddress/history", GetAliasHistory)
+	webapi.Server().GET("ledgerstate/aliases/:aliasAddress/states/:stateIndex", GetAliasState)
+	webapi.Server().GET("ledgerstate/aliases/:aliasAddress/states/:stateIndex/transaction", GetAliasStateTransaction)
 	webapi.Server().GET("ledgerstate/branches/:branchID", GetBranch)
 	webapi.Server().GET("ledgerstate/branches/:branchID/children", GetBranchChildren)
 	webapi.Server().GET("ledgerstate/branches/:branchID/conflicts", GetBranchConflicts)
+	webapi.Server().GET("ledgerstate/colors/:color", GetColorSupply)
+	webapi.Server().GET("ledgerstate/conflicts/:conflictID/timeline", GetConflictTimeline)
 	webapi.Server().GET("ledgerstate/outputs/:outputID", GetOutput)
 	webapi.Server().GET("ledgerstate/outputs/:outputID/consumers", GetOutputConsumers)
 	webapi.Server().GET("ledgerstate/outputs/:outputID/metadata", GetOutputMetadata)
@@ -125,7 +130,7 @@ func worker(shutdownSignal <-chan struct{}) {
 
 // GetAddress is the handler for the /ledgerstate/addresses/:address endpoint.
 func GetAddress(c echo.Context) error {
-	address, err := ledgerstate.AddressFromBase58EncodedString(c.Param("address"))
+	address, err := ledgerstate.AddressFromString(c.Param("address"))
 	if err != nil {
 		return c.JSON(http.StatusBadRequest, jsonmodels.NewErrorResponse(err))
 	}
@@ -142,7 +147,7 @@ func GetAddress(c echo.Context) error {
 
 // GetAddressUnspentOutputs is the handler for the /ledgerstate/addresses/:address/unspentOutputs endpoint.
 func GetAddressUnspentOutputs(c echo.Context) error {
-	address, err := ledgerstate.AddressFromBase58EncodedString(c.Param("address"))
+	address, err := ledgerstate.AddressFromString(c.Param("address"))
 	if err != nil {
 		return c.JSON(http.StatusBadRequest, jsonmodels.NewErrorResponse(err))
 	}
@@ -172,7 +177,7 @@ func PostAddressUnspentOutputs(c echo.Context) error {
 	addresses := make([]ledgerstate.Address, len(req.Addresses))
 	for i, addressString := range req.Addresses {
 		var err error
-		addresses[i], err = ledgerstate.AddressFromBase58EncodedString(addressString)
+		addresses[i], err = ledgerstate.AddressFromString(addressString)
 		if err != nil {
 			return c.JSON(http.StatusBadRequest, jsonmodels.NewErrorResponse(err))
 		}
@@ -230,6 +235,105 @@ func PostAddressUnspentOutputs(c echo.Context) error {
 
 // endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
 
+// region GetAliasHistory //////////////////////////////////////////////////////////////////////////////////////////////
+
+// GetAliasHistory is the handler for the /ledgerstate/aliases/:aliasAddress/history endpoint.
+func GetAliasHistory(c echo.Context) error {
+	aliasAddress, err := ledgerstate.AliasAddressFromString(c.Param("aliasAddress"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, jsonmodels.NewErrorResponse(err))
+	}
+
+	aliasStateRecords := messagelayer.Tangle().LedgerState.AliasStateRecords(aliasAddress)
+	if len(aliasStateRecords) == 0 {
+		return c.JSON(http.StatusNotFound, jsonmodels.NewErrorResponse(errors.Errorf("no states of alias with %s found", aliasAddress)))
+	}
+
+	response := &jsonmodels.GetAliasHistoryResponse{
+		AliasAddress: aliasAddress.Base58(),
+		States:       make([]*jsonmodels.AliasStateRecord, 0, len(aliasStateRecords)),
+	}
+	for _, aliasStateRecord := range aliasStateRecords {
+		response.States = append(response.States, jsonmodels.NewAliasStateRecord(aliasStateRecord, aliasStateInclusionState(aliasStateRecord)))
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region GetAliasState ////////////////////////////////////////////////////////////////////////////////////////////////
+
+// GetAliasState is the handler for the /ledgerstate/aliases/:aliasAddress/states/:stateIndex endpoint.
+func GetAliasState(c echo.Context) error {
+	aliasAddress, stateIndex, err := aliasStateFromContext(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, jsonmodels.NewErrorResponse(err))
+	}
+
+	aliasStateRecords := messagelayer.Tangle().LedgerState.AliasStateHistory.AliasStateRecordsByStateIndex(aliasAddress, stateIndex)
+	if len(aliasStateRecords) == 0 {
+		return c.JSON(http.StatusNotFound, jsonmodels.NewErrorResponse(errors.Errorf("no state with index %d of alias with %s found", stateIndex, aliasAddress)))
+	}
+
+	response := &jsonmodels.GetAliasStateResponse{
+		AliasAddress: aliasAddress.Base58(),
+		StateIndex:   stateIndex,
+		States:       make([]*jsonmodels.AliasState, 0, len(aliasStateRecords)),
+	}
+	for _, aliasStateRecord := range aliasStateRecords {
+		messagelayer.Tangle().LedgerState.CachedOutput(aliasStateRecord.OutputID()).Consume(func(output ledgerstate.Output) {
+			response.States = append(response.States, jsonmodels.NewAliasState(aliasStateRecord, aliasStateInclusionState(aliasStateRecord), output))
+		})
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region GetAliasStateTransaction /////////////////////////////////////////////////////////////////////////////////////
+
+// GetAliasStateTransaction is the handler for the /ledgerstate/aliases/:aliasAddress/states/:stateIndex/transaction
+// endpoint. It returns the Transaction that transitioned the alias into the given state (preferring a confirmed state
+// transition over pending ones or governance updates).
+func GetAliasStateTransaction(c echo.Context) error {
+	aliasAddress, stateIndex, err := aliasStateFromContext(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, jsonmodels.NewErrorResponse(err))
+	}
+
+	aliasStateRecords := messagelayer.Tangle().LedgerState.AliasStateHistory.AliasStateRecordsByStateIndex(aliasAddress, stateIndex)
+	if len(aliasStateRecords) == 0 {
+		return c.JSON(http.StatusNotFound, jsonmodels.NewErrorResponse(errors.Errorf("no state with index %d of alias with %s found", stateIndex, aliasAddress)))
+	}
+
+	transitionRecord := aliasStateRecords[0]
+	for _, aliasStateRecord := range aliasStateRecords {
+		if aliasStateRecord.GovernanceUpdate() {
+			continue
+		}
+		if transitionRecord.GovernanceUpdate() {
+			transitionRecord = aliasStateRecord
+		}
+		if aliasStateInclusionState(aliasStateRecord) == ledgerstate.Confirmed {
+			transitionRecord = aliasStateRecord
+			break
+		}
+	}
+
+	var tx *ledgerstate.Transaction
+	if !messagelayer.Tangle().LedgerState.Transaction(transitionRecord.TransactionID()).Consume(func(transaction *ledgerstate.Transaction) {
+		tx = transaction
+	}) {
+		return c.JSON(http.StatusNotFound, jsonmodels.NewErrorResponse(errors.Errorf("failed to load Transaction with %s", transitionRecord.TransactionID())))
+	}
+
+	return c.JSON(http.StatusOK, jsonmodels.NewTransaction(tx))
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
 // region GetBranch ////////////////////////////////////////////////////////////////////////////////////////////////////
 
 // GetBranch is the handler for the /ledgerstate/branch/:branchID endpoint.
@@ -300,6 +404,49 @@ func GetBranchConflicts(c echo.Context) (err error) {
 
 // endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
 
+// region GetColorSupply ///////////////////////////////////////////////////////////////////////////////////////////////
+
+// GetColorSupply is the handler for the /ledgerstate/colors/:color endpoint.
+func GetColorSupply(c echo.Context) (err error) {
+	color, err := ledgerstate.ColorFromBase58EncodedString(c.Param("color"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, jsonmodels.NewErrorResponse(err))
+	}
+
+	if !messagelayer.Tangle().LedgerState.ColorSupply(color).Consume(func(colorSupply *ledgerstate.ColorSupply) {
+		err = c.JSON(http.StatusOK, jsonmodels.NewColorSupply(colorSupply))
+	}) {
+		return c.JSON(http.StatusNotFound, jsonmodels.NewErrorResponse(errors.Errorf("failed to load ColorSupply of %s", color)))
+	}
+
+	return
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region GetConflictTimeline //////////////////////////////////////////////////////////////////////////////////////////
+
+// GetConflictTimeline is the handler for the /ledgerstate/conflicts/:conflictID/timeline endpoint.
+func GetConflictTimeline(c echo.Context) (err error) {
+	conflictID, err := ledgerstate.ConflictIDFromBase58(c.Param("conflictID"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, jsonmodels.NewErrorResponse(err))
+	}
+
+	if !messagelayer.Tangle().LedgerState.BranchDAG.Conflict(conflictID).Consume(func(*ledgerstate.Conflict) {}) {
+		return c.JSON(http.StatusNotFound, jsonmodels.NewErrorResponse(errors.Errorf("failed to load Conflict with %s", conflictID)))
+	}
+
+	branchIDs := make([]ledgerstate.BranchID, 0)
+	messagelayer.Tangle().LedgerState.BranchDAG.ConflictMembers(conflictID).Consume(func(conflictMember *ledgerstate.ConflictMember) {
+		branchIDs = append(branchIDs, conflictMember.BranchID())
+	})
+
+	return c.JSON(http.StatusOK, jsonmodels.NewGetConflictTimelineResponse(conflictID, branchIDs, messagelayer.Tangle().LedgerState.ConflictTimelineEntries(conflictID)))
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
 // region GetOutput ////////////////////////////////////////////////////////////////////////////////////////////////////
 
 // GetOutput is the handler for the /ledgerstate/outputs/:outputID endpoint.
@@ -463,6 +610,41 @@ func GetTransactionAttachments(c echo.Context) (err error) {
 
 // endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
 
+// region aliasStateFromContext ////////////////////////////////////////////////////////////////////////////////////////
+
+// aliasStateFromContext determines the AliasAddress and the state index from the aliasAddress and stateIndex parameters
+// in an echo.Context.
+func aliasStateFromContext(c echo.Context) (aliasAddress *ledgerstate.AliasAddress, stateIndex uint32, err error) {
+	if aliasAddress, err = ledgerstate.AliasAddressFromString(c.Param("aliasAddress")); err != nil {
+		return
+	}
+
+	parsedStateIndex, err := strconv.ParseUint(c.Param("stateIndex"), 10, 32)
+	if err != nil {
+		err = errors.Errorf("failed to parse state index %s: %w", c.Param("stateIndex"), err)
+		return
+	}
+	stateIndex = uint32(parsedStateIndex)
+
+	return
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region aliasStateInclusionState /////////////////////////////////////////////////////////////////////////////////////
+
+// aliasStateInclusionState returns the InclusionState of the Transaction that created the given AliasStateRecord.
+func aliasStateInclusionState(aliasStateRecord *ledgerstate.AliasStateRecord) (inclusionState ledgerstate.InclusionState) {
+	inclusionState, err := messagelayer.Tangle().LedgerState.TransactionInclusionState(aliasStateRecord.TransactionID())
+	if err != nil {
+		return ledgerstate.Pending
+	}
+
+	return inclusionState
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
 // region branchIDFromContext //////////////////////////////////////////////////////////////////////////////////////////
 
 // branchIDFromContext determines the BranchID from the branchID parameter in an echo.Context. It expects it to either
@@ -512,24 +694,6 @@ func PostTransaction(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, &jsonmodels.PostTransactionResponse{Error: err.Error()})
 	}
 
-	// validate allowed mana pledge nodes.
-	allowedAccessMana := messagelayer.GetAllowedPledgeNodes(mana.AccessMana)
-	if allowedAccessMana.IsFilterEnabled {
-		if !allowedAccessMana.Allowed.Has(tx.Essence().AccessPledgeID()) {
-			return c.JSON(http.StatusBadRequest, &jsonmodels.PostTransactionResponse{
-				Error: fmt.Errorf("not allowed to pledge access mana to %s: %w", tx.Essence().AccessPledgeID().String(), ErrNotAllowedToPledgeManaToNode).Error(),
-			})
-		}
-	}
-	allowedConsensusMana := messagelayer.GetAllowedPledgeNodes(mana.ConsensusMana)
-	if allowedConsensusMana.IsFilterEnabled {
-		if !allowedConsensusMana.Allowed.Has(tx.Essence().ConsensusPledgeID()) {
-			return c.JSON(http.StatusBadRequest, &jsonmodels.PostTransactionResponse{
-				Error: fmt.Errorf("not allowed to pledge consensus mana to %s: %w", tx.Essence().ConsensusPledgeID().String(), ErrNotAllowedToPledgeManaToNode).Error(),
-			})
-		}
-	}
-
 	// check transaction validity
 	if transactionErr := messagelayer.Tangle().LedgerState.CheckTransaction(tx); transactionErr != nil {
 		return c.JSON(http.StatusBadRequest, &jsonmodels.PostTransactionResponse{Error: transactionErr.Error()})
@@ -540,9 +704,19 @@ func PostTransaction(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, &jsonmodels.PostTransactionResponse{Error: fmt.Sprintf("transaction timestamp is older than MaxReattachmentTime (%s) and cannot be issued", tangle.MaxReattachmentTimeMin)})
 	}
 
+	// validate the mana pledges against the pledge policies, they only count towards the rate limit if the transaction
+	// is issued.
+	cancelPledge, err := messagelayer.ReserveManaPledge(tx.Essence().AccessPledgeID(), tx.Essence().ConsensusPledgeID(), pledgerAddress(tx))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, &jsonmodels.PostTransactionResponse{
+			Error: fmt.Errorf("%w: %s", ErrNotAllowedToPledgeManaToNode, err.Error()).Error(),
+		})
+	}
+
 	// if transaction is in the future we wait until the time arrives
 	if tx.Essence().Timestamp().After(clock.SyncedTime()) {
 		if tx.Essence().Timestamp().Sub(clock.SyncedTime()) > time.Minute {
+			cancelPledge()
 			return c.JSON(http.StatusBadRequest, &jsonmodels.PostTransactionResponse{Error: "transaction timestamp is in the future and cannot be issued; please readjust local clock"})
 		}
 		time.Sleep(tx.Essence().Timestamp().Sub(clock.SyncedTime()) + 1*time.Nanosecond)
@@ -557,9 +731,25 @@ func PostTransaction(c echo.Context) error {
 	if _, err := messagelayer.AwaitMessageToBeBooked(issueTransaction, tx.ID(), maxBookedAwaitTime); err != nil {
 		// if we failed to issue the transaction, we remove it
 		doubleSpendFilter.Remove(tx.ID())
+		cancelPledge()
 		return c.JSON(http.StatusBadRequest, jsonmodels.PostTransactionResponse{Error: err.Error()})
 	}
 	return c.JSON(http.StatusOK, &jsonmodels.PostTransactionResponse{TransactionID: tx.ID().Base58()})
 }
 
+// pledgerAddress returns the address that owns the first input of the transaction, or nil if it is unknown.
+func pledgerAddress(tx *ledgerstate.Transaction) (address ledgerstate.Address) {
+	if len(tx.Essence().Inputs()) == 0 {
+		return nil
+	}
+	utxoInput, ok := tx.Essence().Inputs()[0].(*ledgerstate.UTXOInput)
+	if !ok {
+		return nil
+	}
+	messagelayer.Tangle().LedgerState.CachedOutput(utxoInput.ReferencedOutputID()).Consume(func(output ledgerstate.Output) {
+		address = output.Address()
+	})
+	return address
+}
+
 // endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
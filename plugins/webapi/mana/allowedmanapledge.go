@@ -9,37 +9,56 @@ import (
 
 	"github.com/iotaledger/goshimmer/packages/jsonmodels"
 	"github.com/iotaledger/goshimmer/packages/mana"
+	"github.com/iotaledger/goshimmer/plugins/autopeering/local"
 	manaPlugin "github.com/iotaledger/goshimmer/plugins/messagelayer"
 )
 
 // Handler handles the request.
 func allowedManaPledgeHandler(c echo.Context) error {
-	access := manaPlugin.GetAllowedPledgeNodes(mana.AccessMana)
-	var accessNodes []string
-	access.Allowed.ForEach(func(element interface{}) {
-		accessNodes = append(accessNodes, base58.Encode(element.(identity.ID).Bytes()))
-	})
-	if len(accessNodes) == 0 {
+	access := allowedPledge(manaPlugin.GetPledgePolicy(mana.AccessMana))
+	if len(access.Allowed) == 0 {
 		return c.JSON(http.StatusNotFound, jsonmodels.AllowedManaPledgeResponse{Error: "No access mana pledge IDs are accepted"})
 	}
 
-	consensus := manaPlugin.GetAllowedPledgeNodes(mana.ConsensusMana)
-	var consensusNodes []string
-	consensus.Allowed.ForEach(func(element interface{}) {
-		consensusNodes = append(consensusNodes, base58.Encode(element.(identity.ID).Bytes()))
-	})
-	if len(consensusNodes) == 0 {
+	consensus := allowedPledge(manaPlugin.GetPledgePolicy(mana.ConsensusMana))
+	if len(consensus.Allowed) == 0 {
 		return c.JSON(http.StatusNotFound, jsonmodels.AllowedManaPledgeResponse{Error: "No consensus mana pledge IDs are accepted"})
 	}
 
 	return c.JSON(http.StatusOK, jsonmodels.AllowedManaPledgeResponse{
-		Access: jsonmodels.AllowedPledge{
-			IsFilterEnabled: access.IsFilterEnabled,
-			Allowed:         accessNodes,
-		},
-		Consensus: jsonmodels.AllowedPledge{
-			IsFilterEnabled: consensus.IsFilterEnabled,
-			Allowed:         consensusNodes,
-		},
+		Access:    access,
+		Consensus: consensus,
 	})
 }
+
+// allowedPledge converts the effective pledge policy to its json representation. The own node is listed first in the
+// allowed nodes, unless an allow-list is used.
+func allowedPledge(policy *mana.PledgePolicy) jsonmodels.AllowedPledge {
+	parameters := policy.Parameters()
+	res := jsonmodels.AllowedPledge{
+		IsFilterEnabled: parameters.Mode == mana.AllowListPledgeMode,
+		Mode:            parameters.Mode.String(),
+		MinMana:         parameters.MinMana,
+		MaxShare:        parameters.MaxShare,
+		RateLimit:       parameters.RateLimit,
+		RateLimitWindow: parameters.RateLimitWindow.String(),
+	}
+	switch parameters.Mode {
+	case mana.AllowListPledgeMode:
+		res.Allowed = encodeNodeIDs(parameters.Nodes)
+	case mana.DenyListPledgeMode:
+		res.Allowed = encodeNodeIDs([]identity.ID{local.GetInstance().ID()})
+		res.Denied = encodeNodeIDs(parameters.Nodes)
+	default:
+		res.Allowed = encodeNodeIDs([]identity.ID{local.GetInstance().ID()})
+	}
+	return res
+}
+
+func encodeNodeIDs(nodeIDs []identity.ID) []string {
+	encoded := make([]string, len(nodeIDs))
+	for i, nodeID := range nodeIDs {
+		encoded[i] = base58.Encode(nodeID.Bytes())
+	}
+	return encoded
+}
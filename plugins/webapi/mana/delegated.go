@@ -5,7 +5,9 @@ import (
 
 	"github.com/labstack/echo"
 
+	"github.com/iotaledger/goshimmer/packages/clock"
 	"github.com/iotaledger/goshimmer/packages/jsonmodels"
+	"github.com/iotaledger/goshimmer/packages/ledgerstate"
 	"github.com/iotaledger/goshimmer/plugins/manarefresher"
 )
 
@@ -46,3 +48,66 @@ type GetDelegatedOutputsResponse struct {
 }
 
 // endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region GetDelegations ///////////////////////////////////////////////////////////////////////////////////////////////
+
+// GetDelegations handles the GetDelegations requests.
+func GetDelegations(c echo.Context) error {
+	var delegator ledgerstate.Address
+	if delegatorString := c.QueryParam("delegator"); delegatorString != "" {
+		var err error
+		if delegator, err = ledgerstate.AddressFromString(delegatorString); err != nil {
+			return c.JSON(http.StatusBadRequest, &jsonmodels.GetDelegationsResponse{Error: err.Error()})
+		}
+	}
+	records, err := manarefresher.DelegationRecords(delegator)
+	if err != nil {
+		return c.JSON(http.StatusNotFound, &jsonmodels.GetDelegationsResponse{Error: err.Error()})
+	}
+	delegationsJSON := make([]*jsonmodels.DelegationRecord, len(records))
+	for i, record := range records {
+		delegationsJSON[i] = delegationRecordJSON(record)
+	}
+	return c.JSON(http.StatusOK, &jsonmodels.GetDelegationsResponse{Delegations: delegationsJSON})
+}
+
+// GetDelegation handles the GetDelegation requests.
+func GetDelegation(c echo.Context) error {
+	delegationID, err := ledgerstate.AliasAddressFromString(c.Param("delegationID"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, &jsonmodels.GetDelegationResponse{Error: err.Error()})
+	}
+	record, err := manarefresher.DelegationRecordByID(delegationID)
+	if err != nil {
+		return c.JSON(http.StatusNotFound, &jsonmodels.GetDelegationResponse{Error: err.Error()})
+	}
+	return c.JSON(http.StatusOK, &jsonmodels.GetDelegationResponse{Delegation: delegationRecordJSON(record)})
+}
+
+func delegationRecordJSON(record *manarefresher.DelegationRecord) *jsonmodels.DelegationRecord {
+	res := &jsonmodels.DelegationRecord{
+		DelegationID:         record.DelegationID.Base58(),
+		Delegator:            record.Delegator.Base58(),
+		OutputID:             record.OutputID.Base58(),
+		Amount:               record.Amount,
+		TimelockExpired:      record.TimelockExpired(clock.SyncedTime()),
+		FirstSeen:            record.FirstSeen.Unix(),
+		PledgedAccessMana:    record.PledgedAccessMana,
+		PledgedConsensusMana: record.PledgedConsensusMana,
+		Refreshes:            make([]*jsonmodels.DelegationRefresh, len(record.Refreshes)),
+	}
+	if !record.Timelock.IsZero() {
+		res.Timelock = record.Timelock.Unix()
+	}
+	for i, refresh := range record.Refreshes {
+		res.Refreshes[i] = &jsonmodels.DelegationRefresh{
+			Time:          refresh.Time.Unix(),
+			TransactionID: refresh.TransactionID.Base58(),
+			AccessMana:    refresh.AccessMana,
+			ConsensusMana: refresh.ConsensusMana,
+		}
+	}
+	return res
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
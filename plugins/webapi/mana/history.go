@@ -0,0 +1,76 @@
+package mana
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo"
+	"github.com/mr-tron/base58"
+
+	"github.com/iotaledger/goshimmer/packages/jsonmodels"
+	"github.com/iotaledger/goshimmer/packages/mana"
+	"github.com/iotaledger/goshimmer/packages/mana/timeseries"
+	"github.com/iotaledger/goshimmer/plugins/autopeering/local"
+	"github.com/iotaledger/goshimmer/plugins/manarecorder"
+)
+
+// getManaHistoryHandler handles the request.
+func getManaHistoryHandler(c echo.Context) error {
+	ID := local.GetInstance().ID()
+	if nodeID := c.QueryParam("nodeID"); nodeID != "" {
+		var err error
+		if ID, err = mana.IDFromStr(nodeID); err != nil {
+			return c.JSON(http.StatusBadRequest, jsonmodels.GetManaHistoryResponse{Error: err.Error()})
+		}
+	}
+	from, err := unixQueryParam(c, "from", time.Unix(0, 0))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, jsonmodels.GetManaHistoryResponse{Error: err.Error()})
+	}
+	to, err := unixQueryParam(c, "to", time.Now())
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, jsonmodels.GetManaHistoryResponse{Error: err.Error()})
+	}
+	if to.Before(from) {
+		return c.JSON(http.StatusBadRequest, jsonmodels.GetManaHistoryResponse{Error: "time interval mismatch. to cannot be before from"})
+	}
+
+	history, err := manarecorder.History(ID, from, to.Add(time.Second))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, jsonmodels.GetManaHistoryResponse{Error: err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, jsonmodels.GetManaHistoryResponse{
+		ShortNodeID: ID.String(),
+		NodeID:      base58.Encode(ID.Bytes()),
+		Access:      historyPoints(history[mana.AccessMana]),
+		Consensus:   historyPoints(history[mana.ConsensusMana]),
+		From:        from.Unix(),
+		To:          to.Unix(),
+	})
+}
+
+// unixQueryParam parses the query parameter with the given name as unix timestamp in seconds.
+func unixQueryParam(c echo.Context, name string, defaultValue time.Time) (time.Time, error) {
+	value := c.QueryParam(name)
+	if value == "" {
+		return defaultValue, nil
+	}
+	seconds, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.Unix(seconds, 0), nil
+}
+
+func historyPoints(points []timeseries.Point) []jsonmodels.ManaHistoryPoint {
+	res := make([]jsonmodels.ManaHistoryPoint, len(points))
+	for i, point := range points {
+		res[i] = jsonmodels.ManaHistoryPoint{
+			Mana:      point.Value,
+			Timestamp: point.Time.Unix(),
+		}
+	}
+	return res
+}
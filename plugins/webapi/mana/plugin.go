@@ -37,7 +37,10 @@ func configure(_ *node.Plugin) {
 	webapi.Server().GET("mana/allowedManaPledge", allowedManaPledgeHandler)
 	webapi.Server().GET("mana/delegated", GetDelegatedMana)
 	webapi.Server().GET("mana/delegated/outputs", GetDelegatedOutputs)
-	// webapi.Server().GET("/mana/consensus/past", getPastConsensusManaVectorHandler)
-	// webapi.Server().GET("/mana/consensus/logs", getEventLogsHandler)
-	// webapi.Server().GET("/mana/consensus/metadata", getPastConsensusVectorMetadataHandler)
+	webapi.Server().GET("mana/delegations", GetDelegations)
+	webapi.Server().GET("mana/delegations/:delegationID", GetDelegation)
+	webapi.Server().GET("mana/consensus/past", getPastConsensusManaVectorHandler)
+	webapi.Server().GET("mana/consensus/logs", getEventLogsHandler)
+	webapi.Server().GET("mana/consensus/metadata", getPastConsensusVectorMetadataHandler)
+	webapi.Server().GET("mana/history", getManaHistoryHandler)
 }
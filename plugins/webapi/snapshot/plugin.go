@@ -53,6 +53,12 @@ func DumpCurrentLedger(c echo.Context) (err error) {
 	}
 	snapshot.AccessManaByNode = aMana
 
+	cMana, err := snapshotConsensusMana()
+	if err != nil {
+		return err
+	}
+	snapshot.ConsensusManaByNode = cMana
+
 	f, err := os.OpenFile(snapshotFileName, os.O_RDWR|os.O_CREATE, 0666)
 	if err != nil {
 		plugin.LogErrorf("unable to create snapshot file %s", err)
@@ -76,6 +82,11 @@ func DumpCurrentLedger(c echo.Context) (err error) {
 	for nodeID, accessMana := range snapshot.AccessManaByNode {
 		plugin.LogInfo("          ", nodeID, accessMana.Value, accessMana.Timestamp)
 	}
+	plugin.LogInfo("     Number of snapshotted consensusManaEntries: ", len(snapshot.ConsensusManaByNode))
+	plugin.LogInfo("          nodeID, cMana, timestamp")
+	for nodeID, consensusMana := range snapshot.ConsensusManaByNode {
+		plugin.LogInfo("          ", nodeID, consensusMana.BaseMana, consensusMana.Timestamp)
+	}
 
 	plugin.LogInfof("Bytes written %d", n)
 	f.Close()
@@ -100,4 +111,22 @@ func snapshotAccessMana() (aManaSnapshot map[identity.ID]ledgerstate.AccessMana,
 	return
 }
 
+// snapshotConsensusMana returns snapshot of the current consensus mana. Consensus mana doesn't have a moving average,
+// so only the base mana and the time it was last updated are stored.
+func snapshotConsensusMana() (cManaSnapshot map[identity.ID]ledgerstate.ConsensusMana, err error) {
+	cManaSnapshot = make(map[identity.ID]ledgerstate.ConsensusMana)
+
+	m, t, err := messagelayer.GetManaMap(mana.ConsensusMana)
+	if err != nil {
+		return nil, err
+	}
+	for nodeID, cMana := range m {
+		cManaSnapshot[nodeID] = ledgerstate.ConsensusMana{
+			BaseMana:  cMana,
+			Timestamp: t,
+		}
+	}
+	return
+}
+
 // endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
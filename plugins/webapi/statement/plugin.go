@@ -0,0 +1,83 @@
+package statement
+
+import (
+	"net/http"
+	"sync"
+
+	"github.com/cockroachdb/errors"
+	"github.com/iotaledger/hive.go/node"
+	"github.com/labstack/echo"
+
+	"github.com/iotaledger/goshimmer/packages/jsonmodels"
+	"github.com/iotaledger/goshimmer/packages/ledgerstate"
+	"github.com/iotaledger/goshimmer/packages/mana"
+	"github.com/iotaledger/goshimmer/packages/tangle"
+	"github.com/iotaledger/goshimmer/plugins/messagelayer"
+	"github.com/iotaledger/goshimmer/plugins/webapi"
+)
+
+// PluginName is the name of the web API statement endpoint plugin.
+const PluginName = "WebAPI Statement Endpoint"
+
+var (
+	// plugin is the plugin instance of the web API statement endpoint plugin.
+	plugin *node.Plugin
+	once   sync.Once
+)
+
+// Plugin gets the plugin instance.
+func Plugin() *node.Plugin {
+	once.Do(func() {
+		plugin = node.NewPlugin(PluginName, node.Enabled, configure)
+	})
+	return plugin
+}
+
+func configure(_ *node.Plugin) {
+	webapi.Server().GET("statements/nodes/:nodeID", GetNodeStatements)
+	webapi.Server().GET("statements/conflicts/:transactionID", GetConflictOpinions)
+	webapi.Server().GET("statements/timestamps/:messageID", GetTimestampOpinions)
+}
+
+// GetNodeStatements is the handler for the /statements/nodes/:nodeID endpoint. It returns the persisted statements of
+// the node.
+func GetNodeStatements(c echo.Context) error {
+	nodeID, err := mana.IDFromStr(c.Param("nodeID"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, jsonmodels.NodeStatementsResponse{Error: err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, jsonmodels.NewNodeStatementsResponse(nodeID, messagelayer.Registry().NodeStatements(nodeID)))
+}
+
+// GetConflictOpinions is the handler for the /statements/conflicts/:transactionID endpoint. It returns the opinions
+// that the nodes stated about the conflict.
+func GetConflictOpinions(c echo.Context) error {
+	transactionID, err := ledgerstate.TransactionIDFromBase58(c.Param("transactionID"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, jsonmodels.NodesOpinionsResponse{Error: err.Error()})
+	}
+
+	nodesOpinions := messagelayer.Registry().ConflictOpinions(transactionID)
+	if len(nodesOpinions) == 0 {
+		return c.JSON(http.StatusNotFound, jsonmodels.NodesOpinionsResponse{Error: errors.Errorf("no statements about conflict %s", transactionID.Base58()).Error()})
+	}
+
+	return c.JSON(http.StatusOK, jsonmodels.NewNodesOpinionsResponse(transactionID.Base58(), nodesOpinions))
+}
+
+// GetTimestampOpinions is the handler for the /statements/timestamps/:messageID endpoint. It returns the opinions that
+// the nodes stated about the timestamp of the message.
+func GetTimestampOpinions(c echo.Context) error {
+	messageID, err := tangle.NewMessageID(c.Param("messageID"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, jsonmodels.NodesOpinionsResponse{Error: err.Error()})
+	}
+
+	nodesOpinions := messagelayer.Registry().TimestampOpinions(messageID)
+	if len(nodesOpinions) == 0 {
+		return c.JSON(http.StatusNotFound, jsonmodels.NodesOpinionsResponse{Error: errors.Errorf("no statements about timestamp of message %s", messageID.Base58()).Error()})
+	}
+
+	return c.JSON(http.StatusOK, jsonmodels.NewNodesOpinionsResponse(messageID.Base58(), nodesOpinions))
+}
@@ -55,12 +55,15 @@ func execAddressCommand(command *flag.FlagSet, cliWallet *wallet.Wallet) {
 
 	if *receivePtr {
 		fmt.Println()
-		fmt.Println("Latest Receive Address: " + cliWallet.ReceiveAddress().Address().Base58())
+		fmt.Println("Latest Receive Address: " + cliWallet.ReceiveAddress().Bech32())
+		fmt.Println("Legacy Base58 Encoding: " + cliWallet.ReceiveAddress().Base58())
 	}
 
 	if *newReceiveAddressPtr {
 		fmt.Println()
-		fmt.Println("New Receive Address: " + cliWallet.NewReceiveAddress().Address().Base58())
+		newReceiveAddress := cliWallet.NewReceiveAddress()
+		fmt.Println("New Receive Address: " + newReceiveAddress.Bech32())
+		fmt.Println("Legacy Base58 Encoding: " + newReceiveAddress.Base58())
 	}
 
 	if *listPtr {
@@ -72,11 +75,11 @@ func execAddressCommand(command *flag.FlagSet, cliWallet *wallet.Wallet) {
 		// print header
 		fmt.Println()
 		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\n", "INDEX", "ADDRESS", "SPENT")
-		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\n", "-----", "--------------------------------------------", "-----")
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\n", "-----", "---------------------------------------------------------------", "-----")
 
 		addressPrinted := false
 		for _, addr := range cliWallet.AddressManager().Addresses() {
-			_, _ = fmt.Fprintf(w, "%d\t%s\t%t\n", addr.Index, addr.Base58(), cliWallet.AddressManager().IsAddressSpent(addr.Index))
+			_, _ = fmt.Fprintf(w, "%d\t%s\t%t\n", addr.Index, addr.Bech32(), cliWallet.AddressManager().IsAddressSpent(addr.Index))
 
 			addressPrinted = true
 		}
@@ -95,11 +98,11 @@ func execAddressCommand(command *flag.FlagSet, cliWallet *wallet.Wallet) {
 		// print header
 		fmt.Println()
 		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\n", "INDEX", "ADDRESS", "SPENT")
-		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\n", "-------", "--------------------------------------------", "-------")
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\n", "-------", "---------------------------------------------------------------", "-------")
 
 		addressPrinted := false
 		for _, addr := range cliWallet.AddressManager().UnspentAddresses() {
-			_, _ = fmt.Fprintf(w, "%d\t%s\t%t\n", addr.Index, addr.String(), cliWallet.AddressManager().IsAddressSpent(addr.Index))
+			_, _ = fmt.Fprintf(w, "%d\t%s\t%t\n", addr.Index, addr.Bech32(), cliWallet.AddressManager().IsAddressSpent(addr.Index))
 
 			addressPrinted = true
 		}
@@ -118,11 +121,11 @@ func execAddressCommand(command *flag.FlagSet, cliWallet *wallet.Wallet) {
 		// print header
 		fmt.Println()
 		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\n", "INDEX", "ADDRESS", "SPENT")
-		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\n", "-------", "--------------------------------------------", "-------")
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\n", "-------", "---------------------------------------------------------------", "-------")
 
 		addressPrinted := false
 		for _, addr := range cliWallet.AddressManager().SpentAddresses() {
-			_, _ = fmt.Fprintf(w, "%d\t%s\t%t\n", addr.Index, addr.Base58(), cliWallet.AddressManager().IsAddressSpent(addr.Index))
+			_, _ = fmt.Fprintf(w, "%d\t%s\t%t\n", addr.Index, addr.Bech32(), cliWallet.AddressManager().IsAddressSpent(addr.Index))
 
 			addressPrinted = true
 		}
@@ -4,6 +4,7 @@ import (
 	"flag"
 	"fmt"
 	"os"
+	"strconv"
 	"text/tabwriter"
 
 	"github.com/iotaledger/goshimmer/client/wallet"
@@ -40,6 +41,15 @@ func execAssetInfoCommand(command *flag.FlagSet, cliWallet *wallet.Wallet) {
 		printUsage(command, fmt.Sprintf("failed to fetch asset info: %s", err.Error()))
 	}
 
+	// the ledger only knows the supply once the minting transaction is confirmed
+	minted, burned, circulating, holders := "unknown", "unknown", "unknown", "unknown"
+	if supply, supplyErr := cliWallet.AssetSupply(color); supplyErr == nil {
+		minted = strconv.FormatUint(supply.Minted, 10)
+		burned = strconv.FormatUint(supply.Burned, 10)
+		circulating = strconv.FormatUint(supply.Circulating, 10)
+		holders = strconv.FormatUint(supply.Holders, 10)
+	}
+
 	fmt.Println()
 	fmt.Println("Asset Info")
 	fmt.Println()
@@ -52,6 +62,10 @@ func execAssetInfoCommand(command *flag.FlagSet, cliWallet *wallet.Wallet) {
 	_, _ = fmt.Fprintf(w, "%s\t%s\n", "Symbol", asset.Symbol)
 	_, _ = fmt.Fprintf(w, "%s\t%s\n", "AssetID(color)", asset.Color.Base58())
 	_, _ = fmt.Fprintf(w, "%s\t%d\n", "Initial Supply", asset.Supply)
+	_, _ = fmt.Fprintf(w, "%s\t%s\n", "Minted Supply", minted)
+	_, _ = fmt.Fprintf(w, "%s\t%s\n", "Burned Supply", burned)
+	_, _ = fmt.Fprintf(w, "%s\t%s\n", "Circulating Supply", circulating)
+	_, _ = fmt.Fprintf(w, "%s\t%s\n", "Holders", holders)
 	_, _ = fmt.Fprintf(w, "%s\t%s\n", "Creating Transaction", asset.TransactionID.Base58())
 	_, _ = fmt.Fprintf(w, "%s\t%s\n", "Network", cliWallet.AssetRegistry().Network())
 
@@ -5,6 +5,7 @@ import (
 	"os"
 
 	"github.com/iotaledger/goshimmer/client"
+	"github.com/iotaledger/goshimmer/packages/ledgerstate"
 )
 
 // config type that defines the config structure
@@ -14,6 +15,7 @@ type configuration struct {
 	ReuseAddresses       bool             `json:"reuse_addresses"`
 	FaucetPowDifficulty  int              `json:"faucetPowDifficulty"`
 	AssetRegistryNetwork string           `json:"assetRegistryNetwork"`
+	Bech32HRP            string           `json:"bech32HRP,omitempty"`
 }
 
 // internal variable that holds the config
@@ -28,7 +30,8 @@ var configJSON = `{
 	},
 	"reuse_addresses": false,
 	"faucetPowDifficulty": 25,
-	"assetRegistryNetwork": "nectar"
+	"assetRegistryNetwork": "nectar",
+	"bech32HRP": "atoi"
 }`
 
 // load the config file
@@ -53,4 +56,11 @@ func loadConfig() {
 	if err = json.NewDecoder(file).Decode(&config); err != nil {
 		panic(err)
 	}
+
+	// configure the human-readable part of bech32 addresses (older config files don't contain it)
+	if config.Bech32HRP != "" {
+		if err = ledgerstate.SetBech32HRP(config.Bech32HRP); err != nil {
+			panic(err)
+		}
+	}
 }
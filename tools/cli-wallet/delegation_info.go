@@ -0,0 +1,109 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/iotaledger/goshimmer/client"
+	"github.com/iotaledger/goshimmer/client/wallet"
+	"github.com/iotaledger/goshimmer/packages/jsonmodels"
+	"github.com/iotaledger/goshimmer/packages/ledgerstate"
+)
+
+func execDelegationInfoCommand(command *flag.FlagSet, cliWallet *wallet.Wallet) {
+	command.Usage = func() {
+		printUsage(command)
+	}
+
+	helpPtr := command.Bool("help", false, "show this help screen")
+	delegationIDPtr := command.String("id", "", "optional delegation ID to show, all delegations of the wallet by default")
+	nodePtr := command.String("node", "", "optional web API of the node the funds are delegated to, the configured web API by default")
+
+	err := command.Parse(os.Args[2:])
+	if err != nil {
+		printUsage(command, err.Error())
+	}
+	if *helpPtr {
+		printUsage(command)
+	}
+
+	var delegationIDs []string
+	if *delegationIDPtr != "" {
+		delegationID, aErr := ledgerstate.AliasAddressFromString(*delegationIDPtr)
+		if aErr != nil {
+			printUsage(command, fmt.Sprintf("%s is not a valid IOTA alias address: %s", *delegationIDPtr, aErr.Error()))
+		}
+		delegationIDs = append(delegationIDs, delegationID.Base58())
+	} else {
+		confirmed, pending, bErr := cliWallet.DelegatedAliasBalance()
+		if bErr != nil {
+			printUsage(command, bErr.Error())
+		}
+		for delegationID := range confirmed {
+			delegationIDs = append(delegationIDs, delegationID.Base58())
+		}
+		for delegationID := range pending {
+			delegationIDs = append(delegationIDs, delegationID.Base58())
+		}
+	}
+	if len(delegationIDs) == 0 {
+		fmt.Println()
+		fmt.Println("The wallet has no delegated funds.")
+		return
+	}
+
+	// the delegations are queried from the node the funds are delegated to
+	webAPI := config.WebAPI
+	if *nodePtr != "" {
+		webAPI = *nodePtr
+	}
+	var options []client.Option
+	if config.BasicAuth.IsEnabled() {
+		options = append(options, client.WithBasicAuth(config.BasicAuth.Credentials()))
+	}
+	api := client.NewGoShimmerAPI(webAPI, options...)
+
+	for _, delegationID := range delegationIDs {
+		res, dErr := api.GetDelegation(delegationID)
+		if dErr != nil {
+			fmt.Println()
+			fmt.Printf("Delegation %s is not known to %s: %s\n", delegationID, webAPI, dErr.Error())
+			continue
+		}
+		printDelegationRecord(res.Delegation)
+	}
+}
+
+func printDelegationRecord(record *jsonmodels.DelegationRecord) {
+	timelock := "-"
+	if record.Timelock != 0 {
+		timelock = time.Unix(record.Timelock, 0).String()
+		if record.TimelockExpired {
+			timelock += " (expired, funds can be reclaimed)"
+		}
+	}
+
+	fmt.Println()
+	fmt.Println("Delegation Info")
+	fmt.Println()
+	// initialize tab writer
+	w := new(tabwriter.Writer)
+	w.Init(os.Stdout, 0, 8, 2, '\t', 0)
+	_, _ = fmt.Fprintf(w, "%s\t%s\n", "PROPERTY", "VALUE")
+	_, _ = fmt.Fprintf(w, "%s\t%s\n", "-----------------------", "--------------------------------------------")
+	_, _ = fmt.Fprintf(w, "%s\t%s\n", "Delegation ID", record.DelegationID)
+	_, _ = fmt.Fprintf(w, "%s\t%s\n", "Delegator", record.Delegator)
+	_, _ = fmt.Fprintf(w, "%s\t%d\n", "Amount", record.Amount)
+	_, _ = fmt.Fprintf(w, "%s\t%s\n", "Timelock", timelock)
+	_, _ = fmt.Fprintf(w, "%s\t%f\n", "Pledged Access Mana", record.PledgedAccessMana)
+	_, _ = fmt.Fprintf(w, "%s\t%f\n", "Pledged Consensus Mana", record.PledgedConsensusMana)
+	_, _ = fmt.Fprintf(w, "%s\t%d\n", "Refreshes", len(record.Refreshes))
+	for _, refresh := range record.Refreshes {
+		_, _ = fmt.Fprintf(w, "%s\t%s tx %s (access %f, consensus %f)\n", "", time.Unix(refresh.Time, 0).String(),
+			refresh.TransactionID, refresh.AccessMana, refresh.ConsensusMana)
+	}
+	_ = w.Flush()
+}
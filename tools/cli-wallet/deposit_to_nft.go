@@ -43,7 +43,7 @@ func execDepositToNFTCommand(command *flag.FlagSet, cliWallet *wallet.Wallet) {
 		printUsage(command, "color must be set")
 	}
 
-	aliasID, err := ledgerstate.AliasAddressFromBase58EncodedString(*nftIDPtr)
+	aliasID, err := ledgerstate.AliasAddressFromString(*nftIDPtr)
 	if err != nil {
 		printUsage(command, err.Error())
 	}
@@ -33,7 +33,7 @@ func execDestroyNFTCommand(command *flag.FlagSet, cliWallet *wallet.Wallet) {
 		printUsage(command, "an nft (alias) ID must be given for destroy")
 	}
 
-	aliasID, err := ledgerstate.AliasAddressFromBase58EncodedString(*nftIDPtr)
+	aliasID, err := ledgerstate.AliasAddressFromString(*nftIDPtr)
 	if err != nil {
 		printUsage(command, err.Error())
 	}
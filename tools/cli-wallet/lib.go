@@ -170,6 +170,8 @@ func printUsage(command *flag.FlagSet, optionalErrorMessage ...string) {
 		fmt.Println("        delegate funds to an address")
 		fmt.Println("  reclaim-delegated")
 		fmt.Println("        reclaim previously delegated funds")
+		fmt.Println("  delegation-info")
+		fmt.Println("        show the delegated funds and the mana they pledged to the node")
 		fmt.Println("  create-nft")
 		fmt.Println("        create an nft as an unforkable alias output")
 		fmt.Println("  transfer-nft")
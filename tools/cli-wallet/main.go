@@ -42,6 +42,7 @@ func main() {
 	assetInfoCommand := flag.NewFlagSet("asset-info", flag.ExitOnError)
 	delegateFundsCommand := flag.NewFlagSet("delegate-funds", flag.ExitOnError)
 	reclaimDelegatedFundsCommand := flag.NewFlagSet("reclaim-delegated", flag.ExitOnError)
+	delegationInfoCommand := flag.NewFlagSet("delegation-info", flag.ExitOnError)
 	createNFTCommand := flag.NewFlagSet("create-nft", flag.ExitOnError)
 	transferNFTCommand := flag.NewFlagSet("transfer-nft", flag.ExitOnError)
 	destroyNFTCommand := flag.NewFlagSet("destroy-nft", flag.ExitOnError)
@@ -75,6 +76,8 @@ func main() {
 		execDelegateFundsCommand(delegateFundsCommand, wallet)
 	case "reclaim-delegated":
 		execReclaimDelegatedFundsCommand(reclaimDelegatedFundsCommand, wallet)
+	case "delegation-info":
+		execDelegationInfoCommand(delegationInfoCommand, wallet)
 	case "create-nft":
 		execCreateNFTCommand(createNFTCommand, wallet)
 	case "transfer-nft":
@@ -35,13 +35,13 @@ func execReclaimDelegatedFundsCommand(command *flag.FlagSet, cliWallet *wallet.W
 
 	var toAddress ledgerstate.Address
 	if *toAddressPtr != "" {
-		toAddress, err = ledgerstate.AddressFromBase58EncodedString(*toAddressPtr)
+		toAddress, err = ledgerstate.AddressFromString(*toAddressPtr)
 		if err != nil {
 			printUsage(command, fmt.Sprintf("wrong optional toAddress provided: %s", err.Error()))
 		}
 	}
 
-	delegationID, err := ledgerstate.AliasAddressFromBase58EncodedString(*delegationIDPtr)
+	delegationID, err := ledgerstate.AliasAddressFromString(*delegationIDPtr)
 	if err != nil {
 		printUsage(command, fmt.Sprintf("%s is not a valid IOTA alias address: %s", *delegationIDPtr, err.Error()))
 	}
@@ -44,7 +44,7 @@ func execSendFundsCommand(command *flag.FlagSet, cliWallet *wallet.Wallet) {
 		printUsage(command, "color must be set")
 	}
 
-	destinationAddress, err := ledgerstate.AddressFromBase58EncodedString(*addressPtr)
+	destinationAddress, err := ledgerstate.AddressFromString(*addressPtr)
 	if err != nil {
 		printUsage(command, err.Error())
 		return
@@ -89,7 +89,7 @@ func execSendFundsCommand(command *flag.FlagSet, cliWallet *wallet.Wallet) {
 		if !(*fallbackAddressPtr != "" && *fallbackDeadlinePtr > 0) {
 			printUsage(command, "please provide both fallb-addr and fallb-deadline arguments for conditional sending")
 		}
-		fAddy, aErr := ledgerstate.AddressFromBase58EncodedString(*fallbackAddressPtr)
+		fAddy, aErr := ledgerstate.AddressFromString(*fallbackAddressPtr)
 		if aErr != nil {
 			printUsage(command, fmt.Sprintf("wrong fallback address: %s", aErr.Error()))
 		}
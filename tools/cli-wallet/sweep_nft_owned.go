@@ -35,7 +35,7 @@ func execSweepNFTOwnedFundsCommand(command *flag.FlagSet, cliWallet *wallet.Wall
 		printUsage(command, "an nft (alias) ID must be given for sweeping")
 	}
 
-	aliasID, err := ledgerstate.AliasAddressFromBase58EncodedString(*nftIDPtr)
+	aliasID, err := ledgerstate.AliasAddressFromString(*nftIDPtr)
 	if err != nil {
 		printUsage(command, err.Error())
 	}
@@ -82,7 +82,7 @@ func execSweepNFTOwnedNFTsCommand(command *flag.FlagSet, cliWallet *wallet.Walle
 		printUsage(command, "an nft (alias) ID must be given for sweeping")
 	}
 
-	aliasID, err := ledgerstate.AliasAddressFromBase58EncodedString(*nftIDPtr)
+	aliasID, err := ledgerstate.AliasAddressFromString(*nftIDPtr)
 	if err != nil {
 		printUsage(command, err.Error())
 	}
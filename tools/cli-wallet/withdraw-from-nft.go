@@ -45,7 +45,7 @@ func execWithdrawFromFTCommand(command *flag.FlagSet, cliWallet *wallet.Wallet)
 		printUsage(command, "color must be set")
 	}
 
-	aliasID, err := ledgerstate.AliasAddressFromBase58EncodedString(*nftIDPtr)
+	aliasID, err := ledgerstate.AliasAddressFromString(*nftIDPtr)
 	if err != nil {
 		printUsage(command, err.Error())
 	}
@@ -81,7 +81,7 @@ func execWithdrawFromFTCommand(command *flag.FlagSet, cliWallet *wallet.Wallet)
 	}
 
 	if *addressPtr != "" {
-		address, aErr := ledgerstate.AddressFromBase58EncodedString(*addressPtr)
+		address, aErr := ledgerstate.AddressFromString(*addressPtr)
 		if aErr != nil {
 			printUsage(command, aErr.Error())
 		}
@@ -0,0 +1,142 @@
+package main
+
+import (
+	"encoding/json"
+	"log"
+	"os"
+
+	flag "github.com/spf13/pflag"
+	"github.com/spf13/viper"
+
+	"github.com/iotaledger/goshimmer/packages/consensus/fcob"
+	"github.com/iotaledger/goshimmer/packages/database"
+	"github.com/iotaledger/goshimmer/packages/jsonmodels"
+	"github.com/iotaledger/goshimmer/packages/ledgerstate"
+	"github.com/iotaledger/goshimmer/packages/tangle"
+)
+
+const (
+	cfgDatabaseDirectory         = "database.directory"
+	cfgTransactionIDs            = "transactionIDs"
+	cfgMessageIDs                = "messageIDs"
+	cfgAll                       = "all"
+	cfgLikedThreshold            = "fcob.likedThreshold"
+	cfgLocallyFinalizedThreshold = "fcob.locallyFinalizedThreshold"
+	cfgTimestampWindow           = "fcob.timestampWindow"
+	cfgGratuitousNetworkDelay    = "fcob.gratuitousNetworkDelay"
+	cfgLevelOfKnowledgeStrategy  = "fcob.levelOfKnowledgeStrategy"
+)
+
+func init() {
+	flag.String(cfgDatabaseDirectory, "mainnetdb", "path to the database folder of the stopped node")
+	flag.StringSlice(cfgTransactionIDs, nil, "the base58 encoded IDs of the transactions whose opinions are audited")
+	flag.StringSlice(cfgMessageIDs, nil, "the base58 encoded IDs of the messages whose timestamp opinions are audited")
+	flag.Bool(cfgAll, false, "whether consistent opinions are printed as well when auditing all stored opinions")
+
+	// the FCoB parameters default to the ones of the node
+	defaultParameters := fcob.DefaultParameters()
+	flag.Duration(cfgLikedThreshold, defaultParameters.LikedThreshold, "the time after which a transaction without conflicts is liked")
+	flag.Duration(cfgLocallyFinalizedThreshold, defaultParameters.LocallyFinalizedThreshold, "the time after which a liked transaction without conflicts is locally finalized")
+	flag.Duration(cfgTimestampWindow, defaultParameters.TimestampWindow, "the maximum difference between the issuing and arrival time of a message with a liked timestamp")
+	flag.Duration(cfgGratuitousNetworkDelay, defaultParameters.GratuitousNetworkDelay, "the network delay used to derive the level of knowledge of a timestamp opinion")
+	flag.String(cfgLevelOfKnowledgeStrategy, defaultParameters.LevelOfKnowledgeStrategy.Name(), "the strategy to derive the level of knowledge of a timestamp opinion (gratuitousNetworkDelay or binary)")
+}
+
+func main() {
+	flag.Parse()
+	if err := viper.BindPFlags(flag.CommandLine); err != nil {
+		panic(err)
+	}
+
+	levelOfKnowledgeStrategy, err := fcob.LevelOfKnowledgeStrategyFromString(viper.GetString(cfgLevelOfKnowledgeStrategy))
+	if err != nil {
+		log.Fatal(err)
+	}
+	parameters := &fcob.Parameters{
+		LikedThreshold:            viper.GetDuration(cfgLikedThreshold),
+		LocallyFinalizedThreshold: viper.GetDuration(cfgLocallyFinalizedThreshold),
+		TimestampWindow:           viper.GetDuration(cfgTimestampWindow),
+		GratuitousNetworkDelay:    viper.GetDuration(cfgGratuitousNetworkDelay),
+		LevelOfKnowledgeStrategy:  levelOfKnowledgeStrategy,
+	}
+	if err = parameters.Validate(); err != nil {
+		log.Fatal(err)
+	}
+
+	if inconsistent := auditDatabase(parameters); inconsistent {
+		os.Exit(1)
+	}
+}
+
+// auditDatabase prints the audits of the stored opinions of the database and returns true if any of them is inconsistent.
+func auditDatabase(parameters *fcob.Parameters) (inconsistent bool) {
+	db, err := database.NewDB(viper.GetString(cfgDatabaseDirectory))
+	if err != nil {
+		log.Fatalf("failed to open the database: %s", err)
+	}
+	defer db.Close()
+
+	// the tangle is only used to look up the stored objects, so none of its components are set up
+	consensusMechanism := fcob.NewConsensusMechanism(parameters)
+	tangle.New(
+		tangle.Store(db.NewStore()),
+		tangle.CacheTimeProvider(database.NewCacheTimeProvider(0)),
+		tangle.Consensus(consensusMechanism),
+	)
+
+	output := json.NewEncoder(os.Stdout)
+	transactionIDs := viper.GetStringSlice(cfgTransactionIDs)
+	messageIDs := viper.GetStringSlice(cfgMessageIDs)
+	for _, transactionIDString := range transactionIDs {
+		transactionID, err := ledgerstate.TransactionIDFromBase58(transactionIDString)
+		if err != nil {
+			log.Fatal(err)
+		}
+		audit, err := consensusMechanism.AuditOpinion(transactionID)
+		if err != nil {
+			log.Print(err)
+			continue
+		}
+		inconsistent = inconsistent || !audit.Consistent()
+		encode(output, jsonmodels.NewOpinionAudit(audit))
+	}
+	for _, messageIDString := range messageIDs {
+		messageID, err := tangle.NewMessageID(messageIDString)
+		if err != nil {
+			log.Fatal(err)
+		}
+		audit, err := consensusMechanism.AuditTimestampOpinion(messageID)
+		if err != nil {
+			log.Print(err)
+			continue
+		}
+		inconsistent = inconsistent || !audit.Consistent()
+		encode(output, jsonmodels.NewTimestampOpinionAudit(audit))
+	}
+
+	if len(transactionIDs) == 0 && len(messageIDs) == 0 {
+		all := viper.GetBool(cfgAll)
+		consensusMechanism.AuditOpinions(func(audit *fcob.OpinionAudit) bool {
+			inconsistent = inconsistent || !audit.Consistent()
+			if all || !audit.Consistent() {
+				encode(output, jsonmodels.NewOpinionAudit(audit))
+			}
+			return true
+		})
+		consensusMechanism.AuditTimestampOpinions(func(audit *fcob.TimestampOpinionAudit) bool {
+			inconsistent = inconsistent || !audit.Consistent()
+			if all || !audit.Consistent() {
+				encode(output, jsonmodels.NewTimestampOpinionAudit(audit))
+			}
+			return true
+		})
+	}
+
+	return inconsistent
+}
+
+func encode(output *json.Encoder, audit interface{}) {
+	if err := output.Encode(audit); err != nil {
+		log.Fatal(err)
+	}
+}
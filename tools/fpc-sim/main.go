@@ -0,0 +1,153 @@
+package main
+
+import (
+	"encoding/csv"
+	"log"
+	"os"
+	"strconv"
+	"time"
+
+	flag "github.com/spf13/pflag"
+	"github.com/spf13/viper"
+
+	"github.com/iotaledger/goshimmer/packages/vote/fpc"
+	"github.com/iotaledger/goshimmer/packages/vote/fpc/simulation"
+)
+
+const (
+	cfgOutputFile              = "output"
+	cfgRuns                    = "runs"
+	cfgSeed                    = "seed"
+	cfgHonestNodes             = "honestNodes"
+	cfgAdversaryNodes          = "adversary.nodes"
+	cfgAdversaryManaShare      = "adversary.manaShare"
+	cfgAdversaryStrategy       = "adversary.strategy"
+	cfgManaDistribution        = "mana.distribution"
+	cfgZipfS                   = "mana.zipfS"
+	cfgInitialLikeProportion   = "initialLikeProportion"
+	cfgDropRate                = "network.dropRate"
+	cfgDelayRate               = "network.delayRate"
+	cfgRandomness              = "randomness.source"
+	cfgDefaultRandomness       = "randomness.default"
+	cfgRandomnessFailureRate   = "randomness.failureRate"
+	cfgQuerySampleSizes        = "fpc.querySampleSizes"
+	cfgTotalRoundsFinalization = "fpc.totalRoundsFinalization"
+	cfgMaxRoundsPerVoteContext = "fpc.maxRoundsPerVoteContext"
+	cfgSamplingStrategy        = "fpc.samplingStrategy"
+	cfgSamplingTopK            = "fpc.samplingTopK"
+)
+
+func init() {
+	flag.String(cfgOutputFile, "./fpc-sim.csv", "the CSV file the results of every parameter combination are written to")
+	flag.Int(cfgRuns, 100, "the number of votes simulated per parameter combination")
+	flag.Int64(cfgSeed, 0, "the seed of the random number generator (0 uses the current time)")
+	flag.Int(cfgHonestNodes, 100, "the number of honest nodes")
+	flag.Int(cfgAdversaryNodes, 0, "the number of adversary nodes")
+	flag.Float64(cfgAdversaryManaShare, 0.2, "the share of the total mana owned by the adversary nodes")
+	flag.String(cfgAdversaryStrategy, simulation.CautiousStrategyName, "the strategy of the adversary nodes (dislike, random, cautious or splitting)")
+	flag.String(cfgManaDistribution, simulation.UniformManaDistributionName, "the mana distribution of the honest nodes (uniform or zipf)")
+	flag.Float64(cfgZipfS, 0.9, "the exponent of the zipf mana distribution")
+	flag.Float64(cfgInitialLikeProportion, 0.5, "the proportion of honest nodes that initially like the conflict")
+	flag.Float64(cfgDropRate, 0, "the probability that a query is not answered")
+	flag.Float64(cfgDelayRate, 0, "the probability that a reply contains the opinion of the previous round")
+	flag.String(cfgRandomness, simulation.UniformRandomnessName, "the source of the random number of every round (uniform, fixed or drng)")
+	flag.Float64(cfgDefaultRandomness, 0.5, "the random number used by the fixed source and by the drng source if a beacon is missing")
+	flag.Float64(cfgRandomnessFailureRate, 0.1, "the probability that the drng source misses a beacon")
+
+	// the FPC parameters default to the ones of the node
+	defaultParameters := fpc.DefaultParameters()
+	flag.IntSlice(cfgQuerySampleSizes, []int{defaultParameters.QuerySampleSize}, "the query sample sizes to simulate")
+	flag.IntSlice(cfgTotalRoundsFinalization, []int{defaultParameters.TotalRoundsFinalization}, "the numbers of rounds with a constant opinion required for finalization to simulate")
+	flag.Int(cfgMaxRoundsPerVoteContext, defaultParameters.MaxRoundsPerVoteContext, "the number of rounds after which a vote fails")
+	flag.String(cfgSamplingStrategy, defaultParameters.SamplingStrategy.Name(), "the strategy to select the nodes to query (mana, manaWithoutReplacement, uniform or topK)")
+	flag.Int(cfgSamplingTopK, 10, "the number of highest mana nodes that are always queried by the topK sampling strategy")
+}
+
+func main() {
+	flag.Parse()
+	if err := viper.BindPFlags(flag.CommandLine); err != nil {
+		panic(err)
+	}
+
+	strategy, err := simulation.StrategyFromString(viper.GetString(cfgAdversaryStrategy))
+	if err != nil {
+		log.Fatal(err)
+	}
+	manaDistribution, err := simulation.ManaDistributionFromString(viper.GetString(cfgManaDistribution), viper.GetFloat64(cfgZipfS))
+	if err != nil {
+		log.Fatal(err)
+	}
+	randomness, err := simulation.RandomnessSourceFromString(viper.GetString(cfgRandomness), viper.GetFloat64(cfgDefaultRandomness), viper.GetFloat64(cfgRandomnessFailureRate))
+	if err != nil {
+		log.Fatal(err)
+	}
+	samplingStrategy, err := fpc.SamplingStrategyFromString(viper.GetString(cfgSamplingStrategy), viper.GetInt(cfgSamplingTopK))
+	if err != nil {
+		log.Fatal(err)
+	}
+	seed := viper.GetInt64(cfgSeed)
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+
+	f, err := os.Create(viper.GetString(cfgOutputFile))
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer f.Close()
+	output := csv.NewWriter(f)
+	defer output.Flush()
+	if err = output.Write([]string{"querySampleSize", "totalRoundsFinalization", "runs", "agreementFailureRate", "integrityFailureRate", "terminationFailureRate", "meanRounds", "p95Rounds"}); err != nil {
+		log.Fatal(err)
+	}
+
+	log.Printf("simulating %d honest and %d %s adversary nodes with %s sampling and seed %d...", viper.GetInt(cfgHonestNodes), viper.GetInt(cfgAdversaryNodes), strategy.Name(), samplingStrategy.Name(), seed)
+	for _, querySampleSize := range viper.GetIntSlice(cfgQuerySampleSizes) {
+		for _, totalRoundsFinalization := range viper.GetIntSlice(cfgTotalRoundsFinalization) {
+			fpcParameters := fpc.DefaultParameters()
+			fpcParameters.QuerySampleSize = querySampleSize
+			fpcParameters.TotalRoundsFinalization = totalRoundsFinalization
+			fpcParameters.MaxRoundsPerVoteContext = viper.GetInt(cfgMaxRoundsPerVoteContext)
+			fpcParameters.SamplingStrategy = samplingStrategy
+
+			sim, err := simulation.New(&simulation.Parameters{
+				HonestNodes:           viper.GetInt(cfgHonestNodes),
+				AdversaryNodes:        viper.GetInt(cfgAdversaryNodes),
+				AdversaryManaShare:    viper.GetFloat64(cfgAdversaryManaShare),
+				Strategy:              strategy,
+				ManaDistribution:      manaDistribution,
+				InitialLikeProportion: viper.GetFloat64(cfgInitialLikeProportion),
+				DropRate:              viper.GetFloat64(cfgDropRate),
+				DelayRate:             viper.GetFloat64(cfgDelayRate),
+				Randomness:            randomness,
+				FPC:                   fpcParameters,
+				Runs:                  viper.GetInt(cfgRuns),
+				Seed:                  seed,
+			})
+			if err != nil {
+				log.Fatal(err)
+			}
+			report, err := sim.Run()
+			if err != nil {
+				log.Fatal(err)
+			}
+
+			log.Printf("k=%d l=%d: agreement failures %.4f, integrity failures %.4f, termination failures %.4f, mean rounds %.2f",
+				querySampleSize, totalRoundsFinalization, report.AgreementFailureRate(), report.IntegrityFailureRate(),
+				report.TerminationFailureRate(), report.MeanRoundsToFinalization())
+			if err = output.Write([]string{
+				strconv.Itoa(querySampleSize),
+				strconv.Itoa(totalRoundsFinalization),
+				strconv.Itoa(report.Runs),
+				strconv.FormatFloat(report.AgreementFailureRate(), 'f', -1, 64),
+				strconv.FormatFloat(report.IntegrityFailureRate(), 'f', -1, 64),
+				strconv.FormatFloat(report.TerminationFailureRate(), 'f', -1, 64),
+				strconv.FormatFloat(report.MeanRoundsToFinalization(), 'f', -1, 64),
+				strconv.Itoa(report.RoundsToFinalizationQuantile(0.95)),
+			}); err != nil {
+				log.Fatal(err)
+			}
+		}
+	}
+	log.Printf("wrote results to %s", f.Name())
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/iotaledger/hive.go/crypto/ed25519"
+	"github.com/iotaledger/hive.go/identity"
+)
+
+// manaAllocation defines the mana the nodes receive in the genesis snapshot.
+type manaAllocation struct {
+	// GenesisPledgeID is the node the mana of the genesis output is pledged to.
+	GenesisPledgeID identity.ID
+	// Nodes contains the access and consensus mana of every node.
+	Nodes map[identity.ID]nodeAllocation
+}
+
+// nodeAllocation defines the mana of a single node.
+type nodeAllocation struct {
+	AccessMana    uint64 `json:"accessMana"`
+	ConsensusMana uint64 `json:"consensusMana"`
+}
+
+// manaAllocationFile is the JSON representation of the manaAllocation, the nodes are identified by their base58
+// encoded public keys.
+type manaAllocationFile struct {
+	GenesisPledgeID string                    `json:"genesisPledgeID"`
+	Nodes           map[string]nodeAllocation `json:"nodes"`
+}
+
+// readManaAllocation reads the mana allocation from the given JSON file.
+func readManaAllocation(fileName string) (*manaAllocation, error) {
+	content, err := os.ReadFile(fileName)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read mana allocation file: %w", err)
+	}
+	file := &manaAllocationFile{}
+	if err = json.Unmarshal(content, file); err != nil {
+		return nil, fmt.Errorf("unable to parse mana allocation file: %w", err)
+	}
+
+	allocation := &manaAllocation{
+		Nodes: make(map[identity.ID]nodeAllocation, len(file.Nodes)),
+	}
+	if allocation.GenesisPledgeID, err = nodeIDFromPublicKey(file.GenesisPledgeID); err != nil {
+		return nil, fmt.Errorf("invalid genesis pledge ID: %w", err)
+	}
+	for publicKey, nodeAllocation := range file.Nodes {
+		nodeID, err := nodeIDFromPublicKey(publicKey)
+		if err != nil {
+			return nil, fmt.Errorf("invalid node %s: %w", publicKey, err)
+		}
+		allocation.Nodes[nodeID] = nodeAllocation
+	}
+	if _, exists := allocation.Nodes[allocation.GenesisPledgeID]; !exists {
+		return nil, fmt.Errorf("genesis pledge ID %s is missing in the nodes", file.GenesisPledgeID)
+	}
+	return allocation, nil
+}
+
+// sortedNodeIDs returns the IDs of the allocated nodes in a deterministic order.
+func (m *manaAllocation) sortedNodeIDs() (nodeIDs []identity.ID) {
+	for nodeID := range m.Nodes {
+		nodeIDs = append(nodeIDs, nodeID)
+	}
+	sort.Slice(nodeIDs, func(i, j int) bool {
+		return bytes.Compare(nodeIDs[i].Bytes(), nodeIDs[j].Bytes()) < 0
+	})
+	return nodeIDs
+}
+
+func nodeIDFromPublicKey(publicKey string) (identity.ID, error) {
+	pubKey, err := ed25519.PublicKeyFromString(publicKey)
+	if err != nil {
+		return identity.ID{}, err
+	}
+	return identity.NewID(pubKey), nil
+}
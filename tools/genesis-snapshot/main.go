@@ -7,7 +7,6 @@ import (
 	"time"
 
 	"github.com/iotaledger/hive.go/bitmask"
-	"github.com/iotaledger/hive.go/crypto/ed25519"
 	"github.com/iotaledger/hive.go/identity"
 	"github.com/mr-tron/base58"
 	flag "github.com/spf13/pflag"
@@ -25,23 +24,18 @@ const (
 	cfgGenesisTokenAmount   = "token-amount"
 	cfgSnapshotFileName     = "snapshot-file"
 	cfgSnapshotGenesisSeed  = "seed"
+	cfgManaAllocationFile   = "mana-allocation-file"
 	defaultSnapshotFileName = "./snapshot.bin"
-
-	// In the docker network tokensToPledge is also pledged to the faucet
-	tokensToPledge   uint64 = 1000000000000000                               // we pledge the following amount to nodesToPledge
-	peerMasterPledge        = "EYsaGXnUVA9aTYL9FwYEvoQ8d1HCJveQVL7vogu6pqCP" // peer master pledge ID
+	defaultManaAllocation   = "./mana-allocation.json"
 )
 
-var nodesToPledge = []string{
-	"CHfU1NUf6ZvUKDQHTG2df53GR7CvuMFtyt7YymJ6DwS3", // Faucet
-}
-
 func init() {
-	flag.Uint64(cfgGenesisTokenAmount, 1000000000000000, "the amount of tokens to add to the genesis output") // we pledge this amount to peer master
+	flag.Uint64(cfgGenesisTokenAmount, 1000000000000000, "the amount of tokens to add to the genesis output") // we pledge this amount to the genesis pledge node
 	flag.String(cfgSnapshotFileName, defaultSnapshotFileName, "the name of the generated snapshot file")
 	// flag.String(cfgSnapshotGenesisSeed, "", "the genesis seed")
 	// Most recent seed when checking ../integration-tests/assets :
 	flag.String(cfgSnapshotGenesisSeed, "7R1itJx5hVuo9w9hjg5cwKFmek4HMSoBDgJZN8hKGxih", "the genesis seed")
+	flag.String(cfgManaAllocationFile, defaultManaAllocation, "the JSON file that defines the access and consensus mana of the nodes")
 }
 
 func main() {
@@ -77,86 +71,53 @@ func main() {
 		},
 	)
 
+	allocation, err := readManaAllocation(viper.GetString(cfgManaAllocationFile))
+	if err != nil {
+		log.Fatal(err)
+	}
+
 	// define maps for snapshot
 	transactionsMap := make(map[ledgerstate.TransactionID]ledgerstate.Record)
 	accessManaMap := make(map[identity.ID]ledgerstate.AccessMana)
+	consensusManaMap := make(map[identity.ID]ledgerstate.ConsensusMana)
+	genesisTime := time.Unix(tangle.DefaultGenesisTime, 0)
 
-	//////////////// prepare pledge to Peer master ////////////////////////////////////////////////////////////////
-	output := ledgerstate.NewSigLockedColoredOutput(
-		ledgerstate.NewColoredBalances(map[ledgerstate.Color]uint64{
-			ledgerstate.ColorIOTA: genesisTokenAmount,
-		}),
-		genesisSeed.Address(0).Address(),
-	)
+	//////////////// prepare pledge to genesis pledge node /////////////////////////////////////////////////////////////
+	tx := newSnapshotTransaction(allocation.GenesisPledgeID, 0, genesisTokenAmount, genesisSeed.Address(0).Address())
+	transactionsMap[tx.ID()] = newSnapshotRecord(tx)
 
-	pubKey, err := ed25519.PublicKeyFromString(peerMasterPledge)
-	if err != nil {
-		panic(err)
-	}
-	nodeID := identity.NewID(pubKey)
-	tx := ledgerstate.NewTransaction(ledgerstate.NewTransactionEssence(
-		0,
-		time.Unix(tangle.DefaultGenesisTime, 0),
-		nodeID,
-		nodeID,
-		ledgerstate.NewInputs(ledgerstate.NewUTXOInput(ledgerstate.NewOutputID(ledgerstate.GenesisTransactionID, 0))),
-		ledgerstate.NewOutputs(output),
-	), ledgerstate.UnlockBlocks{ledgerstate.NewReferenceUnlockBlock(0)})
-
-	txRecord := ledgerstate.Record{
-		Essence:        tx.Essence(),
-		UnlockBlocks:   tx.UnlockBlocks(),
-		UnspentOutputs: []bool{true},
-	}
-	transactionsMap[tx.ID()] = txRecord
-	accessManaRecord := ledgerstate.AccessMana{
-		Value:     float64(genesisTokenAmount),
-		Timestamp: time.Unix(tangle.DefaultGenesisTime, 0),
-	}
-	accessManaMap[nodeID] = accessManaRecord
-
-	//////////////// prepare pledge for nodesToPledge ////////////////////////////////////////////////////////////////
+	//////////////// prepare pledge for allocated nodes ////////////////////////////////////////////////////////////////
+	// the consensus mana that is not covered by the genesis output is backed by outputs of a random seed
 	randomSeed := seed.NewSeed()
-	output1 := ledgerstate.NewSigLockedColoredOutput(
-		ledgerstate.NewColoredBalances(map[ledgerstate.Color]uint64{
-			ledgerstate.ColorIOTA: tokensToPledge,
-		}),
-		randomSeed.Address(0).Address(),
-	)
-
-	// nodesToPledge is currently the faucet, and
-	for i, pk := range nodesToPledge {
-		pubKey, err = ed25519.PublicKeyFromString(pk)
-		if err != nil {
-			panic(err)
+	for i, nodeID := range allocation.sortedNodeIDs() {
+		nodeAllocation := allocation.Nodes[nodeID]
+		accessManaMap[nodeID] = ledgerstate.AccessMana{
+			Value:     float64(nodeAllocation.AccessMana),
+			Timestamp: genesisTime,
 		}
-		nodeID = identity.NewID(pubKey)
-
-		tx = ledgerstate.NewTransaction(ledgerstate.NewTransactionEssence(
-			0,
-			time.Unix(tangle.DefaultGenesisTime, 0),
-			nodeID,
-			nodeID,
-			ledgerstate.NewInputs(ledgerstate.NewUTXOInput(ledgerstate.NewOutputID(ledgerstate.GenesisTransactionID, uint16(i+1)))),
-			ledgerstate.NewOutputs(output1),
-		), ledgerstate.UnlockBlocks{ledgerstate.NewReferenceUnlockBlock(0)})
-
-		record := ledgerstate.Record{
-			Essence:        tx.Essence(),
-			UnlockBlocks:   tx.UnlockBlocks(),
-			UnspentOutputs: []bool{true},
+		consensusManaMap[nodeID] = ledgerstate.ConsensusMana{
+			BaseMana:  float64(nodeAllocation.ConsensusMana),
+			Timestamp: genesisTime,
 		}
-		transactionsMap[tx.ID()] = record
-		accessManaRecord := ledgerstate.AccessMana{
-			Value:     float64(tokensToPledge),
-			Timestamp: time.Unix(tangle.DefaultGenesisTime, 0),
+
+		backedAmount := nodeAllocation.ConsensusMana
+		if nodeID == allocation.GenesisPledgeID {
+			if backedAmount < genesisTokenAmount {
+				log.Fatalf("consensus mana of the genesis pledge node must be at least the genesis token amount %d", genesisTokenAmount)
+			}
+			backedAmount -= genesisTokenAmount
+		}
+		if backedAmount == 0 {
+			continue
 		}
-		accessManaMap[nodeID] = accessManaRecord
+		tx = newSnapshotTransaction(nodeID, uint16(i+1), backedAmount, randomSeed.Address(0).Address())
+		transactionsMap[tx.ID()] = newSnapshotRecord(tx)
 	}
 
 	newSnapshot := &ledgerstate.Snapshot{
-		AccessManaByNode: accessManaMap,
-		Transactions:     transactionsMap,
+		AccessManaByNode:    accessManaMap,
+		ConsensusManaByNode: consensusManaMap,
+		Transactions:        transactionsMap,
 	}
 
 	genesisWallet := wallet.New(wallet.Import(genesisSeed, 1, []bitmask.BitMask{}, wallet.NewAssetRegistry("test")), wallet.GenericConnector(mockedConnector))
@@ -206,6 +167,39 @@ func main() {
 		fmt.Println("===== key =", key)
 		fmt.Println(accessManaNode)
 	}
+	fmt.Printf("\n================= %d Snapshot Consensus Manas ===============\n", len(readSnapshot.ConsensusManaByNode))
+	for key, consensusManaNode := range readSnapshot.ConsensusManaByNode {
+		fmt.Println("===== key =", key)
+		fmt.Println(consensusManaNode)
+	}
+}
+
+// newSnapshotTransaction returns a transaction that spends the genesis output with the given index and pledges the
+// mana of its output to the given node.
+func newSnapshotTransaction(nodeID identity.ID, genesisOutputIndex uint16, amount uint64, address ledgerstate.Address) *ledgerstate.Transaction {
+	output := ledgerstate.NewSigLockedColoredOutput(
+		ledgerstate.NewColoredBalances(map[ledgerstate.Color]uint64{
+			ledgerstate.ColorIOTA: amount,
+		}),
+		address,
+	)
+
+	return ledgerstate.NewTransaction(ledgerstate.NewTransactionEssence(
+		0,
+		time.Unix(tangle.DefaultGenesisTime, 0),
+		nodeID,
+		nodeID,
+		ledgerstate.NewInputs(ledgerstate.NewUTXOInput(ledgerstate.NewOutputID(ledgerstate.GenesisTransactionID, genesisOutputIndex))),
+		ledgerstate.NewOutputs(output),
+	), ledgerstate.UnlockBlocks{ledgerstate.NewReferenceUnlockBlock(0)})
+}
+
+func newSnapshotRecord(tx *ledgerstate.Transaction) ledgerstate.Record {
+	return ledgerstate.Record{
+		Essence:        tx.Essence(),
+		UnlockBlocks:   tx.UnlockBlocks(),
+		UnspentOutputs: []bool{true},
+	}
 }
 
 type mockConnector struct {
@@ -12,6 +12,7 @@ import (
 	"github.com/iotaledger/goshimmer/plugins/autopeering/local"
 	"github.com/iotaledger/goshimmer/plugins/database"
 	"github.com/iotaledger/goshimmer/plugins/drng"
+	"github.com/iotaledger/goshimmer/plugins/drngmember"
 	"github.com/iotaledger/goshimmer/plugins/faucet"
 	"github.com/iotaledger/goshimmer/plugins/gossip"
 	"github.com/iotaledger/goshimmer/plugins/messagelayer"
@@ -44,6 +45,7 @@ type GoShimmer struct {
 	FPC
 	Activity
 	DRNG
+	DRNGMember
 }
 
 // NewGoShimmer creates a GoShimmer config initialized with default values.
@@ -143,6 +145,13 @@ type DRNG struct {
 	drng.ParametersDefinition
 }
 
+// DRNGMember defines the parameters of the DRNGMember plugin.
+type DRNGMember struct {
+	Enabled bool
+
+	drngmember.ParametersDefinition
+}
+
 // CreateIdentity returns an identity based on the config.
 // If a Seed is specified, it is used to derive the identity. Otherwise a new key pair is generated and Seed set accordingly.
 func (s *GoShimmer) CreateIdentity() (*identity.Identity, error) {
@@ -5,7 +5,6 @@ package framework
 
 import (
 	"context"
-	"encoding/hex"
 	"log"
 	"sync"
 	"time"
@@ -13,6 +12,8 @@ import (
 	"github.com/cockroachdb/errors"
 	"github.com/docker/docker/client"
 	"github.com/iotaledger/hive.go/crypto/ed25519"
+
+	drngplugin "github.com/iotaledger/goshimmer/plugins/drng"
 )
 
 var (
@@ -185,7 +186,7 @@ func (f *Framework) CreateDRNGNetwork(ctx context.Context, name string, numMembe
 	// create GoShimmer identities
 	pubKeys := make([]ed25519.PublicKey, numPeers)
 	privKeys := make([]ed25519.PrivateKey, numPeers)
-	var drngCommittee []string
+	var drngCommittee []ed25519.PublicKey
 	for i := 0; i < numPeers; i++ {
 		pubKeys[i], privKeys[i], err = ed25519.GenerateKey()
 		if err != nil {
@@ -193,16 +194,18 @@ func (f *Framework) CreateDRNGNetwork(ctx context.Context, name string, numMembe
 		}
 
 		if i < numMembers {
-			drngCommittee = append(drngCommittee, pubKeys[i].String())
+			drngCommittee = append(drngCommittee, pubKeys[i])
 		}
 	}
 
 	conf := PeerConfig()
 	conf.DRNG.Enabled = true
-	conf.DRNG.Custom.InstanceID = 111
-	conf.DRNG.Custom.Threshold = 3
-	conf.DRNG.Custom.DistributedPubKey = hex.EncodeToString(drng.distKey)
-	conf.DRNG.Custom.CommitteeMembers = drngCommittee
+	conf.DRNG.Instances = drngplugin.InstancesFlag(&drngplugin.InstanceConfig{
+		InstanceID:        DRNGInstanceID,
+		Threshold:         3,
+		DistributedPubKey: drng.distKey,
+		CommitteeMembers:  drngCommittee,
+	})
 
 	conf.MessageLayer.StartSynced = true
 
@@ -220,3 +223,55 @@ func (f *Framework) CreateDRNGNetwork(ctx context.Context, name string, numMembe
 	}
 	return drng, nil
 }
+
+// CreateNativeDRNGNetwork creates and returns a network that contains numPeers GoShimmer peers
+// out of which numMembers run the DRNGMember plugin and produce the randomness without any drand nodes.
+// It blocks until all peers are connected.
+func (f *Framework) CreateNativeDRNGNetwork(ctx context.Context, name string, numMembers int, numPeers int, threshold int) (*Network, error) {
+	network, err := NewNetwork(ctx, f.docker, name, f.tester)
+	if err != nil {
+		return nil, err
+	}
+
+	// create GoShimmer identities
+	privKeys := make([]ed25519.PrivateKey, numPeers)
+	var drngCommittee []ed25519.PublicKey
+	for i := 0; i < numPeers; i++ {
+		var pubKey ed25519.PublicKey
+		pubKey, privKeys[i], err = ed25519.GenerateKey()
+		if err != nil {
+			return nil, err
+		}
+
+		if i < numMembers {
+			drngCommittee = append(drngCommittee, pubKey)
+		}
+	}
+
+	conf := PeerConfig()
+	conf.DRNG.Enabled = true
+	conf.DRNG.Instances = drngplugin.InstancesFlag(&drngplugin.InstanceConfig{
+		InstanceID:       DRNGInstanceID,
+		Threshold:        uint8(threshold),
+		RoundInterval:    5 * time.Second,
+		CommitteeMembers: drngCommittee,
+	})
+	conf.DRNGMember.InstanceID = DRNGInstanceID
+
+	conf.MessageLayer.StartSynced = true
+
+	// create numPeers/GoShimmer nodes
+	for i := 0; i < numPeers; i++ {
+		conf.Seed = privKeys[i].Seed().Bytes()
+		conf.DRNGMember.Enabled = i < numMembers
+		if _, e := network.CreatePeer(ctx, conf); e != nil {
+			return nil, e
+		}
+	}
+
+	err = network.DoManualPeering(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "manual peering failed")
+	}
+	return network, nil
+}
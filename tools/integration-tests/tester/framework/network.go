@@ -305,8 +305,8 @@ func (n *Network) createEntryNode(ctx context.Context) error {
 	return nil
 }
 
-func (n *Network) createPeers(ctx context.Context, numPeers int, networkConfig CreateNetworkConfig) error {
-	// create a peer conf from the network conf
+// CreatePeerConfig returns the config of a standard peer in a network created with the given network config.
+func (n *Network) CreatePeerConfig(networkConfig CreateNetworkConfig) config.GoShimmer {
 	conf := PeerConfig()
 	if networkConfig.StartSynced {
 		conf.MessageLayer.StartSynced = true
@@ -325,6 +325,13 @@ func (n *Network) createPeers(ctx context.Context, numPeers int, networkConfig C
 		conf.FPC.Enabled = true
 	}
 
+	return conf
+}
+
+func (n *Network) createPeers(ctx context.Context, numPeers int, networkConfig CreateNetworkConfig) error {
+	// create a peer conf from the network conf
+	conf := n.CreatePeerConfig(networkConfig)
+
 	// the first peer is the master peer, it uses a special conf
 	masterConfig := conf
 	masterConfig.Seed = MasterSeed
@@ -25,6 +25,9 @@ const (
 
 	logsDir             = "/tmp/logs/"
 	dockerLogsPrefixLen = 8
+
+	// DRNGInstanceID is the instance ID of the dRNG committee of a DRNG network.
+	DRNGInstanceID = 111
 )
 
 var (
@@ -82,7 +85,8 @@ func PeerConfig() config.GoShimmer {
 	c.Autopeering.EntryNodes = nil
 
 	c.MessageLayer.Enabled = true
-	c.MessageLayer.FCOB.QuarantineTime = 2
+	c.MessageLayer.FCOB.LikedThreshold = 2 * time.Second
+	c.MessageLayer.FCOB.LocallyFinalizedThreshold = 4 * time.Second
 	c.MessageLayer.Snapshot.File = fmt.Sprintf("/assets/%s.bin", base58.Encode(GenesisSeed))
 	c.MessageLayer.Snapshot.GenesisNode = "" // use the default time based approach
 
@@ -104,6 +108,7 @@ func PeerConfig() config.GoShimmer {
 	c.BroadcastIntervalSec = 1 // increase frequency to speedup tests
 
 	c.DRNG.Enabled = false
+	c.DRNGMember.Enabled = false
 
 	return c
 }
@@ -122,6 +127,7 @@ func EntryNodeConfig() config.GoShimmer {
 	c.FPC.Enabled = false
 	c.Activity.Enabled = false
 	c.DRNG.Enabled = false
+	c.DRNGMember.Enabled = false
 
 	return c
 }
@@ -21,7 +21,7 @@ import (
 
 func TestConsensusConflicts(t *testing.T) {
 	const numberOfPeers = 6
-	FCoBQuarantineTime := time.Duration(framework.PeerConfig().FCOB.QuarantineTime) * time.Second
+	likedThreshold := framework.PeerConfig().FCOB.LikedThreshold
 
 	ctx, cancel := tests.Context(context.Background(), t)
 	defer cancel()
@@ -57,8 +57,8 @@ func TestConsensusConflicts(t *testing.T) {
 
 	// sleep the avg. network delay so both partitions confirm their own first seen transaction
 	log.Printf("waiting %v avg. network delay to make the transactions "+
-		"preferred in their corresponding partition", FCoBQuarantineTime)
-	time.Sleep(FCoBQuarantineTime)
+		"preferred in their corresponding partition", likedThreshold)
+	time.Sleep(likedThreshold)
 
 	// issue one transaction per peer to pledge mana to nodes
 	// leave one unspent output from splitting genesis transaction for further conflict creation
@@ -88,8 +88,8 @@ func TestConsensusConflicts(t *testing.T) {
 	}
 	// sleep 3* the avg. network delay so both partitions confirm their own pledging transaction
 	// and 1 avg delay more to make sure each node has mana
-	log.Printf("waiting 2 * %v avg. network delay + 5s to make the transactions confirmed", FCoBQuarantineTime)
-	time.Sleep(2*FCoBQuarantineTime + 5*time.Second)
+	log.Printf("waiting 2 * %v avg. network delay + 5s to make the transactions confirmed", likedThreshold)
+	time.Sleep(2*likedThreshold + 5*time.Second)
 
 	_, err = n.Peers()[0].GoShimmerAPI.GetAllMana()
 	require.NoError(t, err)
@@ -116,7 +116,7 @@ func TestConsensusConflicts(t *testing.T) {
 		conflictingTxIDs[i] = resp.TransactionID
 
 		// sleep to prefer the first one
-		time.Sleep(FCoBQuarantineTime)
+		time.Sleep(likedThreshold)
 	}
 
 	expStates := map[string]tests.ExpectedInclusionState{}
@@ -0,0 +1,67 @@
+package consensus
+
+import (
+	"context"
+	"log"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/goshimmer/packages/consensus/fcob"
+	"github.com/iotaledger/goshimmer/packages/vote/opinion"
+	"github.com/iotaledger/goshimmer/tools/integration-tests/tester/framework"
+	"github.com/iotaledger/goshimmer/tools/integration-tests/tester/tests"
+)
+
+// TestConsensusDisputedTimestamp adds a peer to the network that disputes the timestamps of all messages and checks
+// that it adopts the opinion of the other peers once FPC finalized the vote on the timestamp.
+func TestConsensusDisputedTimestamp(t *testing.T) {
+	const numberOfPeers = 3
+
+	ctx, cancel := tests.Context(context.Background(), t)
+	defer cancel()
+	networkConfig := framework.CreateNetworkConfig{
+		StartSynced: true,
+		Faucet:      true,
+		Autopeering: true,
+		FPC:         true,
+	}
+	n, err := f.CreateNetwork(ctx, t.Name(), numberOfPeers, networkConfig)
+	require.NoError(t, err)
+	defer tests.ShutdownNetwork(ctx, t, n)
+
+	// with such a narrow timestamp window every received message is slightly too old, but the difference is far below
+	// the gratuitous network delay, so the peer dislikes the timestamp with level of knowledge one and has to vote
+	disputingConfig := n.CreatePeerConfig(networkConfig)
+	disputingConfig.MessageLayer.FCOB.TimestampWindow = time.Nanosecond
+	disputingConfig.MessageLayer.FCOB.GratuitousNetworkDelay = 10 * time.Second
+	disputingPeer, err := n.CreatePeer(ctx, disputingConfig)
+	require.NoError(t, err)
+
+	err = n.WaitForAutopeering(ctx)
+	require.NoError(t, err)
+
+	messageID, _ := tests.SendDataMessage(t, n.Peers()[0], []byte("disputed timestamp"), 0)
+	log.Printf("issued message %s, waiting for the timestamp vote of %s", messageID, disputingPeer)
+
+	// the honest peers like the timestamp right away
+	for _, peer := range n.Peers()[:numberOfPeers] {
+		require.Eventuallyf(t, func() bool {
+			metadata, err := peer.GetMessageConsensusMetadata(messageID)
+			return err == nil && metadata.TimestampOpinionFormed &&
+				metadata.TimestampOpinion == opinion.Like.String() && metadata.TimestampLoK == fcob.Three.String()
+		}, tests.Timeout, tests.Tick, "peer %s did not like the timestamp of %s", peer, messageID)
+	}
+
+	// the disputing peer is outvoted and forms its timestamp opinion only after the vote was finalized
+	require.Eventuallyf(t, func() bool {
+		metadata, err := disputingPeer.GetMessageConsensusMetadata(messageID)
+		return err == nil && metadata.TimestampOpinionFormed &&
+			metadata.TimestampOpinion == opinion.Like.String() && metadata.TimestampLoK == fcob.Two.String()
+	}, tests.Timeout, tests.Tick, "FPC did not finalize the timestamp of %s on %s", messageID, disputingPeer)
+
+	messageMetadata, err := disputingPeer.GetMessageMetadata(messageID)
+	require.NoError(t, err)
+	require.True(t, messageMetadata.Eligible, "message %s is not eligible on %s", messageID, disputingPeer)
+}
@@ -49,11 +49,63 @@ func TestDRNG(t *testing.T) {
 	}
 }
 
+// TestNativeDRNG checks whether a committee of GoShimmer nodes running the DRNGMember plugin
+// produces randomness that is accepted by all nodes of the network.
+func TestNativeDRNG(t *testing.T) {
+	ctx, cancel := tests.Context(context.Background(), t)
+	defer cancel()
+	n, err := f.CreateNativeDRNGNetwork(ctx, t.Name(), 4, 5, 3)
+	require.NoError(t, err)
+	defer tests.ShutdownNetwork(ctx, t, n)
+
+	// wait for the distributed key generation to complete and the first beacon to be issued
+	log.Println("Waiting for randomness generation to be started...")
+	require.Eventually(t,
+		func() bool { return getRandomness(t, n.Peers()[0]).Round > 0 },
+		tests.Timeout, tests.Tick)
+	log.Println("Waiting for randomness generation to be started... done")
+
+	// eventually all peers should accept the beacons of later rounds
+	firstRound := getRandomness(t, n.Peers()[0]).Round
+	log.Printf("Waiting for all peers to receive a round after %d...", firstRound)
+	require.Eventually(t,
+		func() bool {
+			for _, peer := range n.Peers() {
+				if getRandomness(t, peer).Round <= firstRound {
+					return false
+				}
+			}
+			return true
+		},
+		time.Minute, tests.Tick)
+	log.Println("Waiting for all peers to receive a round... done")
+
+	// instances can be loaded and unloaded at runtime
+	peer := n.Peers()[len(n.Peers())-1]
+	committee, err := peer.GetCommittee()
+	require.NoError(t, err)
+	require.Len(t, committee.Committees, 1)
+
+	loaded := committee.Committees[0]
+	loaded.InstanceID = framework.DRNGInstanceID + 1
+	loaded.DistributedPK = ""
+	_, err = peer.LoadDRNGInstance(&loaded)
+	require.NoError(t, err)
+	_, err = peer.LoadDRNGInstance(&loaded)
+	require.Error(t, err)
+	require.NoError(t, peer.UnloadDRNGInstance(framework.DRNGInstanceID))
+
+	committee, err = peer.GetCommittee()
+	require.NoError(t, err)
+	require.Len(t, committee.Committees, 1)
+	require.Equal(t, loaded.InstanceID, committee.Committees[0].InstanceID)
+}
+
 func getRandomness(t *testing.T, node *framework.Node) jsonmodels.Randomness {
 	resp, err := node.GetRandomness()
 	require.NoError(t, err)
 
-	id := uint32(node.Config().DRNG.Custom.InstanceID)
+	id := uint32(framework.DRNGInstanceID)
 	for i := range resp.Randomness {
 		if resp.Randomness[i].InstanceID == id {
 			return resp.Randomness[i]
@@ -12,6 +12,7 @@ import (
 
 	"github.com/iotaledger/goshimmer/client"
 	"github.com/iotaledger/goshimmer/packages/ledgerstate"
+	"github.com/iotaledger/goshimmer/packages/mana"
 	"github.com/iotaledger/goshimmer/packages/tangle"
 	webapi "github.com/iotaledger/goshimmer/plugins/webapi/ledgerstate"
 	"github.com/iotaledger/goshimmer/tools/integration-tests/tester/framework"
@@ -81,10 +82,10 @@ func TestManaPledgeFilter(t *testing.T) {
 	faucetConfig.Faucet.PreparedOutputsCount = 3 // we require exactly three outputs
 	faucetConfig.Faucet.TokensPerRequest = tokensPerRequest
 	faucetConfig.Mana.Enabled = true
-	faucetConfig.Mana.AllowedAccessPledge = []string{accessPeerID}
-	faucetConfig.Mana.AllowedAccessFilterEnabled = true
-	faucetConfig.Mana.AllowedConsensusPledge = []string{consensusPeerID}
-	faucetConfig.Mana.AllowedConsensusFilterEnabled = true
+	faucetConfig.Mana.AccessPledgePolicy.Mode = mana.AllowListPledgeModeName
+	faucetConfig.Mana.AccessPledgePolicy.Nodes = []string{accessPeerID}
+	faucetConfig.Mana.ConsensusPledgePolicy.Mode = mana.AllowListPledgeModeName
+	faucetConfig.Mana.ConsensusPledgePolicy.Nodes = []string{consensusPeerID}
 
 	faucet, err := n.CreatePeer(ctx, faucetConfig)
 	require.NoError(t, err)
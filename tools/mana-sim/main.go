@@ -0,0 +1,236 @@
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/iotaledger/hive.go/identity"
+	"github.com/mr-tron/base58"
+	flag "github.com/spf13/pflag"
+	"github.com/spf13/viper"
+
+	"github.com/iotaledger/goshimmer/packages/mana"
+)
+
+const (
+	cfgEventFiles         = "events"
+	cfgOutputFile         = "output"
+	cfgStatsOutputFile    = "statsOutput"
+	cfgInterval           = "interval"
+	cfgTopN               = "topN"
+	cfgEmaCoefficient1    = "mana.emaCoefficient1"
+	cfgEmaCoefficient2    = "mana.emaCoefficient2"
+	cfgDecay              = "mana.decay"
+	cfgAccessManaModel    = "mana.accessManaModel"
+	cfgConsensusManaModel = "mana.consensusManaModel"
+	cfgLinearDecayRate    = "mana.linearDecayRate"
+)
+
+func init() {
+	flag.StringSlice(cfgEventFiles, nil, "the CSV files written by the manaeventlogger plugin")
+	flag.String(cfgOutputFile, "./mana.csv", "the CSV file the mana of every node is written to")
+	flag.String(cfgStatsOutputFile, "./mana-stats.csv", "the CSV file the Gini coefficient and top-N share are written to")
+	flag.Duration(cfgInterval, time.Minute, "the interval in which the mana is sampled")
+	flag.Int(cfgTopN, 10, "the number of nodes with the highest mana used for the top-N share")
+
+	// the mana parameters and their defaults are the same as the ones of the node
+	flag.Float64(cfgEmaCoefficient1, 0.00003209, "coefficient used for Effective Base Mana 1 (moving average) calculation")
+	flag.Float64(cfgEmaCoefficient2, 0.0057762265, "coefficient used for Effective Base Mana 2 (moving average) calculation")
+	flag.Float64(cfgDecay, 0.00003209, "decay coefficient used for Base Mana 2 calculation")
+	flag.String(cfgAccessManaModel, mana.EMAModelName, "model used for access mana calculation (ema, linear or none)")
+	flag.String(cfgConsensusManaModel, mana.NoDecayModelName, "model used for consensus mana calculation (ema, linear or none)")
+	flag.Float64(cfgLinearDecayRate, 1, "amount of base mana lost per second when the linear model is used")
+}
+
+func main() {
+	flag.Parse()
+	if err := viper.BindPFlags(flag.CommandLine); err != nil {
+		panic(err)
+	}
+
+	eventFiles := viper.GetStringSlice(cfgEventFiles)
+	if len(eventFiles) == 0 {
+		log.Fatal("At least one event file is required. Enter it via --events=...")
+	}
+	interval := viper.GetDuration(cfgInterval)
+	if interval <= 0 {
+		log.Fatal("The sampling interval must be greater than 0.")
+	}
+
+	accessManaVector := newBaseManaVector(mana.AccessMana, viper.GetString(cfgAccessManaModel), viper.GetFloat64(cfgEmaCoefficient2))
+	consensusManaVector := newBaseManaVector(mana.ConsensusMana, viper.GetString(cfgConsensusManaModel), viper.GetFloat64(cfgEmaCoefficient1))
+
+	log.Printf("reading mana events from %v...", eventFiles)
+	manaLog, err := readManaLog(eventFiles)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	output, err := newCSVFile(viper.GetString(cfgOutputFile), "timestamp", "manaType", "nodeID", "fullNodeID", "mana")
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer output.Close()
+	statsOutput, err := newCSVFile(viper.GetString(cfgStatsOutputFile), "timestamp", "manaType", "nodes", "totalMana", "gini", "topNShare")
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer statsOutput.Close()
+
+	sim := &simulation{
+		vectors:     []mana.BaseManaVector{accessManaVector, consensusManaVector},
+		output:      output,
+		statsOutput: statsOutput,
+		topN:        viper.GetInt(cfgTopN),
+	}
+	txInfos := manaLog.txInfos()
+	log.Printf("replaying %d transactions from %s to %s...", len(txInfos), manaLog.startTime, manaLog.endTime)
+	if err = sim.run(manaLog.snapshot(), txInfos, manaLog.startTime, manaLog.endTime, interval); err != nil {
+		log.Fatal(err)
+	}
+	log.Printf("wrote %d samples to %s and %s", sim.samples, output.Name(), statsOutput.Name())
+}
+
+func newBaseManaVector(vectorType mana.Type, modelName string, emaCoefficient float64) mana.BaseManaVector {
+	model, err := mana.NewManaModel(modelName, mana.ModelParameters{
+		EMACoefficient:  emaCoefficient,
+		Decay:           viper.GetFloat64(cfgDecay),
+		LinearDecayRate: viper.GetFloat64(cfgLinearDecayRate),
+	})
+	if err != nil {
+		log.Fatalf("invalid %s mana model: %s", vectorType.String(), err)
+	}
+	baseManaVector, err := mana.NewBaseManaVector(vectorType, model)
+	if err != nil {
+		log.Fatal(err)
+	}
+	log.Printf("using %s model for %s mana", model.Name(), vectorType.String())
+	return baseManaVector
+}
+
+// region simulation ///////////////////////////////////////////////////////////////////////////////////////////////////
+
+// simulation replays transactions through the base mana vectors and samples their mana.
+type simulation struct {
+	vectors     []mana.BaseManaVector
+	output      *csvFile
+	statsOutput *csvFile
+	topN        int
+	samples     int
+}
+
+// run loads the snapshot, books the transactions in order and samples the mana of all vectors every interval from
+// start until end.
+func (s *simulation) run(snapshot map[identity.ID]mana.SnapshotNode, txInfos []*mana.TxInfo, start, end time.Time, interval time.Duration) error {
+	for _, vector := range s.vectors {
+		vector.LoadSnapshot(snapshot)
+	}
+
+	sampleTime := start
+	for _, txInfo := range txInfos {
+		for ; sampleTime.Before(txInfo.TimeStamp); sampleTime = sampleTime.Add(interval) {
+			if err := s.sample(sampleTime); err != nil {
+				return err
+			}
+		}
+		for _, vector := range s.vectors {
+			vector.Book(txInfo)
+		}
+	}
+	for ; !sampleTime.After(end); sampleTime = sampleTime.Add(interval) {
+		if err := s.sample(sampleTime); err != nil {
+			return err
+		}
+	}
+
+	return s.flush()
+}
+
+func (s *simulation) sample(t time.Time) error {
+	timestamp := strconv.FormatInt(t.Unix(), 10)
+	for _, vector := range s.vectors {
+		manaMap, _, err := vector.GetManaMap(t)
+		if err != nil {
+			return fmt.Errorf("failed to get %s mana at %s: %w", vector.Type().String(), t, err)
+		}
+		manaType := vector.Type().String()
+		nodeIDs := make([]identity.ID, 0, len(manaMap))
+		for nodeID := range manaMap {
+			nodeIDs = append(nodeIDs, nodeID)
+		}
+		sort.Slice(nodeIDs, func(i, j int) bool {
+			return bytes.Compare(nodeIDs[i].Bytes(), nodeIDs[j].Bytes()) < 0
+		})
+		for _, nodeID := range nodeIDs {
+			if err = s.output.Write([]string{
+				timestamp,
+				manaType,
+				nodeID.String(),
+				base58.Encode(nodeID.Bytes()),
+				strconv.FormatFloat(manaMap[nodeID], 'g', -1, 64),
+			}); err != nil {
+				return err
+			}
+		}
+
+		values := distribution(manaMap)
+		if err = s.statsOutput.Write([]string{
+			timestamp,
+			manaType,
+			strconv.Itoa(len(values)),
+			strconv.FormatFloat(total(values), 'g', -1, 64),
+			strconv.FormatFloat(giniCoefficient(values), 'g', -1, 64),
+			strconv.FormatFloat(topNShare(values, s.topN), 'g', -1, 64),
+		}); err != nil {
+			return err
+		}
+	}
+	s.samples++
+	return nil
+}
+
+func (s *simulation) flush() error {
+	s.output.Flush()
+	if err := s.output.Error(); err != nil {
+		return err
+	}
+	s.statsOutput.Flush()
+	return s.statsOutput.Error()
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region csvFile //////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// csvFile is a CSV writer backed by a file.
+type csvFile struct {
+	*csv.Writer
+	*os.File
+}
+
+// newCSVFile creates the file with the given name and writes the header.
+func newCSVFile(fileName string, header ...string) (*csvFile, error) {
+	f, err := os.Create(fileName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create %s: %w", fileName, err)
+	}
+	c := &csvFile{Writer: csv.NewWriter(f), File: f}
+	if err = c.Writer.Write(header); err != nil {
+		_ = f.Close()
+		return nil, err
+	}
+	return c, nil
+}
+
+// Write writes a single record.
+func (c *csvFile) Write(record []string) error {
+	return c.Writer.Write(record)
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
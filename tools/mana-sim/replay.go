@@ -0,0 +1,215 @@
+package main
+
+import (
+	"encoding/csv"
+	"io"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/iotaledger/hive.go/identity"
+
+	"github.com/iotaledger/goshimmer/packages/ledgerstate"
+	"github.com/iotaledger/goshimmer/packages/mana"
+)
+
+// transactionRecord collects the logged mana events of a single transaction.
+type transactionRecord struct {
+	transactionID ledgerstate.TransactionID
+	timestamp     time.Time
+	pledgeIDs     map[mana.Type]identity.ID
+	inputs        []*mana.RevokedEvent
+	pledged       float64
+}
+
+// manaLog is a recorded sequence of mana events, grouped into the transactions that caused them.
+type manaLog struct {
+	transactions      map[ledgerstate.TransactionID]*transactionRecord
+	accessSnapshot    map[identity.ID]mana.AccessManaSnapshot
+	consensusSnapshot map[identity.ID]*mana.ConsensusManaSnapshot
+	startTime         time.Time
+	endTime           time.Time
+	transactionOrder  []ledgerstate.TransactionID
+}
+
+// readManaLog reads the mana events of the given CSV files that were written by the manaeventlogger plugin.
+func readManaLog(fileNames []string) (*manaLog, error) {
+	m := &manaLog{
+		transactions:      make(map[ledgerstate.TransactionID]*transactionRecord),
+		accessSnapshot:    make(map[identity.ID]mana.AccessManaSnapshot),
+		consensusSnapshot: make(map[identity.ID]*mana.ConsensusManaSnapshot),
+	}
+	for _, fileName := range fileNames {
+		if err := m.readFile(fileName); err != nil {
+			return nil, errors.Errorf("failed to read mana events from %s: %w", fileName, err)
+		}
+	}
+	if len(m.transactions) == 0 && len(m.consensusSnapshot) == 0 {
+		return nil, errors.New("no consensus mana events found")
+	}
+
+	sort.SliceStable(m.transactionOrder, func(i, j int) bool {
+		return m.transactions[m.transactionOrder[i]].timestamp.Before(m.transactions[m.transactionOrder[j]].timestamp)
+	})
+	return m, nil
+}
+
+func (m *manaLog) readFile(fileName string) error {
+	f, err := os.Open(fileName)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	reader := csv.NewReader(f)
+	header := (&mana.PersistableEvent{}).ToStringKeys()
+	for line := 1; ; line++ {
+		values, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if line == 1 && values[0] == header[0] {
+			continue
+		}
+
+		persistableEvent, err := mana.PersistableEventFromStringValues(values)
+		if err != nil {
+			return errors.Errorf("failed to parse line %d: %w", line, err)
+		}
+		event, err := mana.FromPersistableEvent(persistableEvent)
+		if err != nil {
+			return errors.Errorf("failed to parse line %d: %w", line, err)
+		}
+		m.addEvent(event)
+	}
+}
+
+func (m *manaLog) addEvent(event mana.Event) {
+	if m.startTime.IsZero() || event.Timestamp().Before(m.startTime) {
+		m.startTime = event.Timestamp()
+	}
+	if event.Timestamp().After(m.endTime) {
+		m.endTime = event.Timestamp()
+	}
+
+	switch ev := event.(type) {
+	case *mana.PledgedEvent:
+		// mana of the snapshot can be pledged without a transaction
+		if ev.TransactionID == ledgerstate.GenesisTransactionID {
+			switch ev.ManaType {
+			case mana.AccessMana:
+				m.accessSnapshot[ev.NodeID] = mana.AccessManaSnapshot{Value: ev.Amount, Timestamp: ev.Time}
+			case mana.ConsensusMana:
+				m.consensusSnapshot[ev.NodeID] = &mana.ConsensusManaSnapshot{BaseMana: ev.Amount, Timestamp: ev.Time}
+			}
+			return
+		}
+		if ev.ManaType != mana.AccessMana && ev.ManaType != mana.ConsensusMana {
+			return
+		}
+		transaction := m.transaction(ev.TransactionID)
+		transaction.timestamp = ev.Time
+		transaction.pledgeIDs[ev.ManaType] = ev.NodeID
+		if ev.ManaType == mana.ConsensusMana {
+			transaction.pledged = ev.Amount
+		}
+	case *mana.RevokedEvent:
+		// only consensus mana is revoked, it tells us which inputs were spent by the transaction
+		if ev.ManaType != mana.ConsensusMana {
+			return
+		}
+		transaction := m.transaction(ev.TransactionID)
+		transaction.timestamp = ev.Time
+		// the same input is logged by every node whose log is replayed
+		for _, input := range transaction.inputs {
+			if input.InputID == ev.InputID {
+				return
+			}
+		}
+		transaction.inputs = append(transaction.inputs, ev)
+	}
+}
+
+func (m *manaLog) transaction(transactionID ledgerstate.TransactionID) *transactionRecord {
+	transaction, exists := m.transactions[transactionID]
+	if !exists {
+		transaction = &transactionRecord{
+			transactionID: transactionID,
+			pledgeIDs:     make(map[mana.Type]identity.ID),
+		}
+		m.transactions[transactionID] = transaction
+		m.transactionOrder = append(m.transactionOrder, transactionID)
+	}
+	return transaction
+}
+
+// snapshot returns the initial mana state, i.e. the mana pledged without a transaction and the consensus mana of
+// transactions that did not spend any logged inputs.
+func (m *manaLog) snapshot() map[identity.ID]mana.SnapshotNode {
+	snapshot := make(map[identity.ID]mana.SnapshotNode)
+	for nodeID, accessMana := range m.accessSnapshot {
+		snapshot[nodeID] = mana.SnapshotNode{AccessMana: accessMana}
+	}
+	for nodeID, consensusMana := range m.consensusSnapshot {
+		snapshotNode := snapshot[nodeID]
+		snapshotNode.ConsensusMana = consensusMana
+		snapshot[nodeID] = snapshotNode
+	}
+	for _, transactionID := range m.transactionOrder {
+		transaction := m.transactions[transactionID]
+		if len(transaction.inputs) != 0 {
+			continue
+		}
+		nodeID := transaction.pledgeIDs[mana.ConsensusMana]
+		snapshotNode := snapshot[nodeID]
+		snapshotNode.SortedTxSnapshot = append(snapshotNode.SortedTxSnapshot, &mana.TxSnapshot{
+			Value:     transaction.pledged,
+			TxID:      transactionID,
+			Timestamp: transaction.timestamp,
+		})
+		snapshot[nodeID] = snapshotNode
+	}
+	return snapshot
+}
+
+// txInfos rebuilds the TxInfo of every logged transaction that spent inputs, ordered by their timestamp.
+// Inputs that were created before the recording started are treated as if they were created at its start.
+func (m *manaLog) txInfos() (txInfos []*mana.TxInfo) {
+	for _, transactionID := range m.transactionOrder {
+		transaction := m.transactions[transactionID]
+		if len(transaction.inputs) == 0 {
+			continue
+		}
+
+		txInfo := &mana.TxInfo{
+			TimeStamp:     transaction.timestamp,
+			TransactionID: transactionID,
+			PledgeID: map[mana.Type]identity.ID{
+				mana.AccessMana:    transaction.pledgeIDs[mana.AccessMana],
+				mana.ConsensusMana: transaction.pledgeIDs[mana.ConsensusMana],
+			},
+		}
+		for _, input := range transaction.inputs {
+			inputInfo := mana.InputInfo{
+				TimeStamp: m.startTime,
+				Amount:    input.Amount,
+				PledgeID: map[mana.Type]identity.ID{
+					mana.ConsensusMana: input.NodeID,
+				},
+				InputID: input.InputID,
+			}
+			if inputTransaction, exists := m.transactions[input.InputID.TransactionID()]; exists {
+				inputInfo.TimeStamp = inputTransaction.timestamp
+				inputInfo.PledgeID[mana.AccessMana] = inputTransaction.pledgeIDs[mana.AccessMana]
+			}
+			txInfo.TotalBalance += input.Amount
+			txInfo.InputInfos = append(txInfo.InputInfos, inputInfo)
+		}
+		txInfos = append(txInfos, txInfo)
+	}
+	return txInfos
+}
@@ -0,0 +1,105 @@
+package main
+
+import (
+	"encoding/csv"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/iotaledger/hive.go/identity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/goshimmer/packages/ledgerstate"
+	"github.com/iotaledger/goshimmer/packages/mana"
+)
+
+func TestReplay(t *testing.T) {
+	directory := t.TempDir()
+	nodeA, nodeB := identity.GenerateIdentity().ID(), identity.GenerateIdentity().ID()
+	start := time.Unix(time.Now().Unix(), 0)
+	transactionID := ledgerstate.TransactionID{1}
+	genesisOutputID := ledgerstate.NewOutputID(ledgerstate.GenesisTransactionID, 0)
+
+	events := []mana.Event{
+		&mana.PledgedEvent{NodeID: nodeA, Amount: 100, Time: start, ManaType: mana.AccessMana, TransactionID: ledgerstate.GenesisTransactionID},
+		&mana.PledgedEvent{NodeID: nodeA, Amount: 100, Time: start, ManaType: mana.ConsensusMana, TransactionID: ledgerstate.GenesisTransactionID},
+		&mana.RevokedEvent{NodeID: nodeA, Amount: 100, Time: start.Add(time.Minute), ManaType: mana.ConsensusMana, TransactionID: transactionID, InputID: genesisOutputID},
+		&mana.PledgedEvent{NodeID: nodeB, Amount: 100, Time: start.Add(time.Minute), ManaType: mana.AccessMana, TransactionID: transactionID},
+		&mana.PledgedEvent{NodeID: nodeB, Amount: 100, Time: start.Add(time.Minute), ManaType: mana.ConsensusMana, TransactionID: transactionID},
+	}
+	// the same events are logged by every node whose log is replayed
+	eventFiles := []string{writeEventLog(t, directory, "node1.csv", events), writeEventLog(t, directory, "node2.csv", events)}
+
+	manaLog, err := readManaLog(eventFiles)
+	require.NoError(t, err)
+	assert.Equal(t, start, manaLog.startTime)
+	assert.Equal(t, start.Add(time.Minute), manaLog.endTime)
+	assert.Equal(t, 100.0, manaLog.snapshot()[nodeA].ConsensusMana.BaseMana)
+
+	txInfos := manaLog.txInfos()
+	require.Len(t, txInfos, 1)
+	assert.Equal(t, transactionID, txInfos[0].TransactionID)
+	assert.Equal(t, 100.0, txInfos[0].TotalBalance)
+	require.Len(t, txInfos[0].InputInfos, 1)
+	assert.Equal(t, nodeA, txInfos[0].InputInfos[0].PledgeID[mana.ConsensusMana])
+	assert.Equal(t, nodeB, txInfos[0].PledgeID[mana.ConsensusMana])
+
+	consensusManaVector, err := mana.NewBaseManaVector(mana.ConsensusMana, mana.NewNoDecayModel())
+	require.NoError(t, err)
+	output, err := newCSVFile(filepath.Join(directory, "mana.csv"), "timestamp", "manaType", "nodeID", "fullNodeID", "mana")
+	require.NoError(t, err)
+	defer output.Close()
+	statsOutput, err := newCSVFile(filepath.Join(directory, "mana-stats.csv"), "timestamp", "manaType", "nodes", "totalMana", "gini", "topNShare")
+	require.NoError(t, err)
+	defer statsOutput.Close()
+
+	sim := &simulation{
+		vectors:     []mana.BaseManaVector{consensusManaVector},
+		output:      output,
+		statsOutput: statsOutput,
+		topN:        1,
+	}
+	require.NoError(t, sim.run(manaLog.snapshot(), txInfos, manaLog.startTime, manaLog.endTime, 30*time.Second))
+	assert.Equal(t, 3, sim.samples)
+
+	// the mana moves from nodeA to nodeB with the transaction in the last sample
+	manaRecords := readCSV(t, output.Name())
+	require.Len(t, manaRecords, 5)
+	assert.Equal(t, []string{"Consensus", nodeA.String(), "100"}, []string{manaRecords[1][1], manaRecords[1][2], manaRecords[1][4]})
+	lastSample := make(map[string]string)
+	for _, record := range manaRecords[3:] {
+		lastSample[record[2]] = record[4]
+	}
+	assert.Equal(t, map[string]string{nodeA.String(): "0", nodeB.String(): "100"}, lastSample)
+
+	statsRecords := readCSV(t, statsOutput.Name())
+	require.Len(t, statsRecords, 4)
+	assert.Equal(t, []string{"1", "100", "0", "1"}, statsRecords[3][2:])
+}
+
+func writeEventLog(t *testing.T, directory, fileName string, events []mana.Event) string {
+	f, err := os.Create(filepath.Join(directory, fileName))
+	require.NoError(t, err)
+	defer f.Close()
+
+	writer := csv.NewWriter(f)
+	require.NoError(t, writer.Write((&mana.PersistableEvent{}).ToStringKeys()))
+	for _, ev := range events {
+		require.NoError(t, writer.Write(ev.ToPersistable().ToStringValues()))
+	}
+	writer.Flush()
+	require.NoError(t, writer.Error())
+	return f.Name()
+}
+
+func readCSV(t *testing.T, fileName string) [][]string {
+	f, err := os.Open(fileName)
+	require.NoError(t, err)
+	defer f.Close()
+
+	records, err := csv.NewReader(f).ReadAll()
+	require.NoError(t, err)
+	return records
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"math"
+	"sort"
+
+	"github.com/iotaledger/goshimmer/packages/mana"
+)
+
+// distribution returns the positive mana values of the map in descending order.
+func distribution(manaMap mana.NodeMap) (values []float64) {
+	for _, value := range manaMap {
+		if value > 0 {
+			values = append(values, value)
+		}
+	}
+	sort.Sort(sort.Reverse(sort.Float64Slice(values)))
+	return values
+}
+
+// total returns the sum of the given values.
+func total(values []float64) (sum float64) {
+	for _, value := range values {
+		sum += value
+	}
+	return sum
+}
+
+// giniCoefficient returns the Gini coefficient of the values, which must be sorted in descending order.
+// It is 0 if all nodes hold the same amount of mana and approaches 1 if a single node holds all of it.
+func giniCoefficient(values []float64) float64 {
+	sum := total(values)
+	if len(values) == 0 || sum == 0 {
+		return 0
+	}
+	n := float64(len(values))
+	weighted := 0.0
+	for i, value := range values {
+		// rank in ascending order, starting at 1
+		weighted += (n - float64(i)) * value
+	}
+	return math.Max(0, 2*weighted/(n*sum)-(n+1)/n)
+}
+
+// topNShare returns the share of the total mana held by the n nodes with the highest mana. The values must be sorted
+// in descending order.
+func topNShare(values []float64, n int) float64 {
+	sum := total(values)
+	if sum == 0 {
+		return 0
+	}
+	if n > len(values) {
+		n = len(values)
+	}
+	return total(values[:n]) / sum
+}
@@ -0,0 +1,36 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/iotaledger/hive.go/identity"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/iotaledger/goshimmer/packages/mana"
+)
+
+func TestDistribution(t *testing.T) {
+	manaMap := mana.NodeMap{
+		identity.GenerateIdentity().ID(): 2,
+		identity.GenerateIdentity().ID(): 0,
+		identity.GenerateIdentity().ID(): 5,
+		identity.GenerateIdentity().ID(): 3,
+	}
+	assert.Equal(t, []float64{5, 3, 2}, distribution(manaMap))
+	assert.Empty(t, distribution(mana.NodeMap{}))
+}
+
+func TestGiniCoefficient(t *testing.T) {
+	assert.Equal(t, 0.0, giniCoefficient(nil))
+	assert.InDelta(t, 0.0, giniCoefficient([]float64{4, 4, 4, 4}), 1e-9)
+	assert.InDelta(t, 0.75, giniCoefficient([]float64{10, 0, 0, 0}), 1e-9)
+	assert.InDelta(t, 0.25, giniCoefficient([]float64{3, 1}), 1e-9)
+}
+
+func TestTopNShare(t *testing.T) {
+	values := []float64{5, 3, 2}
+	assert.InDelta(t, 0.5, topNShare(values, 1), 1e-9)
+	assert.InDelta(t, 0.8, topNShare(values, 2), 1e-9)
+	assert.InDelta(t, 1.0, topNShare(values, 10), 1e-9)
+	assert.Equal(t, 0.0, topNShare(nil, 1))
+}